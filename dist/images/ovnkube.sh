@@ -257,6 +257,8 @@ ovn_admin_network_policy_enable=${OVN_ADMIN_NETWORK_POLICY_ENABLE:=false}
 ovn_egressip_enable=${OVN_EGRESSIP_ENABLE:-false}
 #OVN_EGRESSIP_HEALTHCHECK_PORT - egress IP node check to use grpc on this port
 ovn_egress_ip_healthcheck_port=${OVN_EGRESSIP_HEALTHCHECK_PORT:-9107}
+#OVN_EGRESSIP_HEALTHCHECK_PROTOCOL - egress IP node check protocol on the healthcheck port, grpc or bfd
+ovn_egress_ip_healthcheck_protocol=${OVN_EGRESSIP_HEALTHCHECK_PROTOCOL:-}
 #OVN_EGRESSFIREWALL_ENABLE - enable egressFirewall for ovn-kubernetes
 ovn_egressfirewall_enable=${OVN_EGRESSFIREWALL_ENABLE:-false}
 #OVN_EGRESSQOS_ENABLE - enable egress QoS for ovn-kubernetes
@@ -1279,6 +1281,11 @@ ovn-master() {
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
   fi
 
+  egressip_healthcheck_protocol_flag=
+  if [[ -n "${ovn_egress_ip_healthcheck_protocol}" ]]; then
+      egressip_healthcheck_protocol_flag="--egressip-node-healthcheck-protocol=${ovn_egress_ip_healthcheck_protocol}"
+  fi
+
   egressfirewall_enabled_flag=
   if [[ ${ovn_egressfirewall_enable} == "true" ]]; then
 	  egressfirewall_enabled_flag="--enable-egress-firewall"
@@ -1410,6 +1417,7 @@ ovn-master() {
     ${egressfirewall_enabled_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
+    ${egressip_healthcheck_protocol_flag} \
     ${egressqos_enabled_flag} \
     ${egressservice_enabled_flag} \
     ${empty_lb_events_flag} \
@@ -1585,6 +1593,11 @@ ovnkube-controller() {
   if [[ -n "${ovn_egress_ip_healthcheck_port}" ]]; then
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
   fi
+
+  egressip_healthcheck_protocol_flag=
+  if [[ -n "${ovn_egress_ip_healthcheck_protocol}" ]]; then
+      egressip_healthcheck_protocol_flag="--egressip-node-healthcheck-protocol=${ovn_egress_ip_healthcheck_protocol}"
+  fi
   echo "egressip_healthcheck_port_flag=${egressip_healthcheck_port_flag}"
 
   egressfirewall_enabled_flag=
@@ -1741,6 +1754,7 @@ ovnkube-controller() {
     ${egressfirewall_enabled_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
+    ${egressip_healthcheck_protocol_flag} \
     ${egressqos_enabled_flag} \
     ${egressservice_enabled_flag} \
     ${empty_lb_events_flag} \
@@ -1930,6 +1944,11 @@ ovnkube-controller-with-node() {
   if [[ -n "${ovn_egress_ip_healthcheck_port}" ]]; then
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
   fi
+
+  egressip_healthcheck_protocol_flag=
+  if [[ -n "${ovn_egress_ip_healthcheck_protocol}" ]]; then
+      egressip_healthcheck_protocol_flag="--egressip-node-healthcheck-protocol=${ovn_egress_ip_healthcheck_protocol}"
+  fi
   echo "egressip_healthcheck_port_flag=${egressip_healthcheck_port_flag}"
 
   egressfirewall_enabled_flag=
@@ -2220,6 +2239,7 @@ ovnkube-controller-with-node() {
     ${egress_interface} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
+    ${egressip_healthcheck_protocol_flag} \
     ${egressqos_enabled_flag} \
     ${egressservice_enabled_flag} \
     ${empty_lb_events_flag} \
@@ -2315,6 +2335,11 @@ ovn-cluster-manager() {
   if [[ -n "${ovn_egress_ip_healthcheck_port}" ]]; then
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
   fi
+
+  egressip_healthcheck_protocol_flag=
+  if [[ -n "${ovn_egress_ip_healthcheck_protocol}" ]]; then
+      egressip_healthcheck_protocol_flag="--egressip-node-healthcheck-protocol=${ovn_egress_ip_healthcheck_protocol}"
+  fi
   echo "egressip_flags: ${egressip_enabled_flag}, ${egressip_healthcheck_port_flag}"
 
   egressservice_enabled_flag=
@@ -2481,6 +2506,7 @@ ovn-cluster-manager() {
     ${egressfirewall_enabled_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
+    ${egressip_healthcheck_protocol_flag} \
     ${egressqos_enabled_flag} \
     ${egressservice_enabled_flag} \
     ${empty_lb_events_flag} \
@@ -2648,6 +2674,11 @@ ovn-node() {
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
   fi
 
+  egressip_healthcheck_protocol_flag=
+  if [[ -n "${ovn_egress_ip_healthcheck_protocol}" ]]; then
+      egressip_healthcheck_protocol_flag="--egressip-node-healthcheck-protocol=${ovn_egress_ip_healthcheck_protocol}"
+  fi
+
   egressservice_enabled_flag=
   if [[ ${ovn_egressservice_enable} == "true" ]]; then
 	  egressservice_enabled_flag="--enable-egress-service"
@@ -2902,6 +2933,7 @@ ovn-node() {
         ${egress_interface} \
         ${egressip_enabled_flag} \
         ${egressip_healthcheck_port_flag} \
+        ${egressip_healthcheck_protocol_flag} \
         ${egressservice_enabled_flag} \
         ${enable_lflow_cache} \
         ${hybrid_overlay_flags} \
@@ -22,9 +22,11 @@ func main() {
 	c.Action = func(_ *cli.Context) error {
 		skel.PluginMainFuncs(
 			skel.CNIFuncs{
-				Add:   p.CmdAdd,
-				Check: p.CmdCheck,
-				Del:   p.CmdDel,
+				Add:    p.CmdAdd,
+				Check:  p.CmdCheck,
+				Del:    p.CmdDel,
+				GC:     p.CmdGC,
+				Status: p.CmdStatus,
 			},
 			version.All,
 			bv.BuildString("ovn-k8s-cni-overlay"))
@@ -0,0 +1,352 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"k8s.io/klog/v2"
+	kexec "k8s.io/utils/exec"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	ovsdbClientCommand    = "ovsdb-client"
+	ovsdbToolCommand      = "ovsdb-tool"
+	backupTimestampFormat = "20060102T150405Z"
+)
+
+// ovnDatabase describes an OVN database managed by ovnkube
+type ovnDatabase struct {
+	// dbName is the name of the database in its schema
+	dbName string
+	// socket is the unix socket the database server listens on
+	socket string
+	// schema is the schema file of the database
+	schema string
+	// dbFile is the default database file
+	dbFile string
+}
+
+var ovnDatabases = map[string]ovnDatabase{
+	"nb": {
+		dbName: "OVN_Northbound",
+		socket: "/var/run/ovn/ovnnb_db.sock",
+		schema: "/usr/share/ovn/ovn-nb.ovsschema",
+		dbFile: "/etc/ovn/ovnnb_db.db",
+	},
+	"sb": {
+		dbName: "OVN_Southbound",
+		socket: "/var/run/ovn/ovnsb_db.sock",
+		schema: "/usr/share/ovn/ovn-sb.ovsschema",
+		dbFile: "/etc/ovn/ovnsb_db.db",
+	},
+}
+
+func getOVNDatabase(name string) (ovnDatabase, error) {
+	db, ok := ovnDatabases[name]
+	if !ok {
+		return db, fmt.Errorf("unknown database %q, must be one of nb or sb", name)
+	}
+	return db, nil
+}
+
+// DBBackupCommand takes online backups of the OVN databases
+var DBBackupCommand = cli.Command{
+	Name:  "db-backup",
+	Usage: "Take online backups of the OVN NB/SB databases",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "databases",
+			Usage: "databases to back up, nb and/or sb",
+			Value: cli.NewStringSlice("nb", "sb"),
+		},
+		&cli.StringFlag{
+			Name:     "backup-dir",
+			Usage:    "directory the backups are written to",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:  "retention",
+			Usage: "number of backups to keep per database, older backups are deleted (0 keeps all the backups)",
+			Value: 7,
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "interval between two backups, when 0 a single backup is taken",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		backupDir := ctx.String("backup-dir")
+		retention := ctx.Int("retention")
+		interval := ctx.Duration("interval")
+		if retention < 0 {
+			return fmt.Errorf("invalid retention %d, must be 0 or more", retention)
+		}
+
+		var dbs []ovnDatabase
+		for _, name := range ctx.StringSlice("databases") {
+			db, err := getOVNDatabase(name)
+			if err != nil {
+				return err
+			}
+			dbs = append(dbs, db)
+		}
+		if err := os.MkdirAll(backupDir, 0o750); err != nil {
+			return fmt.Errorf("failed to create backup directory %s: %v", backupDir, err)
+		}
+
+		exec := kexec.New()
+		if err := util.SetSpecificExec(exec, ovsdbToolCommand); err != nil {
+			return err
+		}
+		ovsdbClientPath, err := exec.LookPath(ovsdbClientCommand)
+		if err != nil {
+			return err
+		}
+
+		backupAll := func() error {
+			var errorList []error
+			for _, db := range dbs {
+				backupFile, err := backupDB(exec, ovsdbClientPath, db, backupDir)
+				if err != nil {
+					errorList = append(errorList, err)
+					continue
+				}
+				klog.Infof("Backed up %s to %s", db.dbName, backupFile)
+				if err := pruneBackups(db, backupDir, retention); err != nil {
+					errorList = append(errorList, err)
+				}
+			}
+			return utilerrors.Join(errorList...)
+		}
+
+		if interval == 0 {
+			return backupAll()
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := backupAll(); err != nil {
+				klog.Errorf("Failed to back up the OVN databases: %v", err)
+			}
+			select {
+			case <-ctx.Context.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// DBRestoreCommand restores a backup into a stopped OVN database
+var DBRestoreCommand = cli.Command{
+	Name:  "db-restore",
+	Usage: "Restore a backup into a stopped OVN NB/SB database",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "database",
+			Usage:    "database to restore, nb or sb",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "backup",
+			Usage:    "backup file to restore",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "db-file",
+			Usage: "database file to restore the backup into (default the nb or sb database file under /etc/ovn)",
+		},
+		&cli.StringFlag{
+			Name: "raft-address",
+			Usage: "local Raft address (e.g. ssl:10.0.0.1:6643) of a clustered database, the backup is restored " +
+				"as a new single member cluster. Defaults to the local address of the clustered database being replaced",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "restore a backup whose schema version differs from the installed schema version",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		db, err := getOVNDatabase(ctx.String("database"))
+		if err != nil {
+			return err
+		}
+		backupFile := ctx.String("backup")
+		dbFile := ctx.String("db-file")
+		if dbFile == "" {
+			dbFile = db.dbFile
+		}
+
+		if err := util.SetSpecificExec(kexec.New(), ovsdbToolCommand); err != nil {
+			return err
+		}
+		return restoreDB(db, backupFile, dbFile, ctx.String("raft-address"), ctx.Bool("force"))
+	},
+}
+
+// backupDB takes an online backup of the database to a new file of the backup directory,
+// and returns the path of the backup file. The backup of a clustered database is a
+// standalone database.
+func backupDB(exec kexec.Interface, ovsdbClientPath string, db ovnDatabase, backupDir string) (string, error) {
+	backupFile := filepath.Join(backupDir, fmt.Sprintf("%s-%s.db", backupPrefix(db),
+		time.Now().UTC().Format(backupTimestampFormat)))
+	tmpFile := backupFile + ".tmp"
+
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup file %s: %v", tmpFile, err)
+	}
+	defer os.Remove(tmpFile)
+
+	// --no-leader-only allows backing up a clustered database from any of its members,
+	// the backup is a snapshot of the committed data of the member.
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command(ovsdbClientPath, "--no-leader-only", "backup", "unix:"+db.socket, db.dbName)
+	cmd.SetStdout(f)
+	cmd.SetStderr(stderr)
+	err = cmd.Run()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: stderr: %q, error: %v", db.dbName, stderr.String(), err)
+	}
+
+	// the database server may run a schema version other than the installed one, e.g. during
+	// an upgrade, the backup is still a valid snapshot of its data
+	if err := validateBackup(db, tmpFile, true); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpFile, backupFile); err != nil {
+		return "", fmt.Errorf("failed to rename backup file %s: %v", tmpFile, err)
+	}
+	return backupFile, nil
+}
+
+// validateBackup ensures the backup file is a readable database with the expected schema.
+// A backup of a different schema version than the installed schema is only valid when
+// allowVersionMismatch is set.
+func validateBackup(db ovnDatabase, backupFile string, allowVersionMismatch bool) error {
+	dbName, stderr, err := util.RunOVSDBTool("db-name", backupFile)
+	if err != nil {
+		return fmt.Errorf("invalid backup %s: stderr: %q, error: %v", backupFile, stderr, err)
+	}
+	if dbName != db.dbName {
+		return fmt.Errorf("invalid backup %s: database %s is not %s", backupFile, dbName, db.dbName)
+	}
+
+	dbVersion, stderr, err := util.RunOVSDBTool("db-version", backupFile)
+	if err != nil {
+		return fmt.Errorf("invalid backup %s: stderr: %q, error: %v", backupFile, stderr, err)
+	}
+	schemaVersion, stderr, err := util.RunOVSDBTool("schema-version", db.schema)
+	if err != nil {
+		return fmt.Errorf("failed to get the version of schema %s: stderr: %q, error: %v", db.schema, stderr, err)
+	}
+	if dbVersion == schemaVersion {
+		return nil
+	}
+	if !allowVersionMismatch {
+		return fmt.Errorf("invalid backup %s: schema version %s does not match the installed %s schema version %s",
+			backupFile, dbVersion, db.dbName, schemaVersion)
+	}
+	klog.Warningf("Backup %s has schema version %s, installed %s schema version is %s",
+		backupFile, dbVersion, db.dbName, schemaVersion)
+	return nil
+}
+
+// pruneBackups deletes the oldest backups of the database from the backup directory to
+// only keep retention backups
+func pruneBackups(db ovnDatabase, backupDir string, retention int) error {
+	if retention == 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(filepath.Join(backupDir, backupPrefix(db)+"-*.db"))
+	if err != nil {
+		return err
+	}
+	if len(backups) <= retention {
+		return nil
+	}
+
+	// the backup timestamps sort in chronological order
+	sort.Strings(backups)
+	var errorList []error
+	for _, backup := range backups[:len(backups)-retention] {
+		if err := os.Remove(backup); err != nil {
+			errorList = append(errorList, fmt.Errorf("failed to delete backup %s: %v", backup, err))
+			continue
+		}
+		klog.Infof("Deleted backup %s", backup)
+	}
+	return utilerrors.Join(errorList...)
+}
+
+// restoreDB replaces the database file with the backup. The database server must be stopped.
+// When the database is clustered, or a Raft address is given, the backup is restored as a new
+// cluster which the other members must join after their database file is deleted. A backup of
+// another schema version than the installed one is only restored when force is set.
+func restoreDB(db ovnDatabase, backupFile, dbFile, raftAddress string, force bool) error {
+	if conn, err := net.DialTimeout("unix", db.socket, 5*time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s database server is running on %s, it must be stopped to restore a backup",
+			db.dbName, db.socket)
+	}
+
+	if err := validateBackup(db, backupFile, force); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dbFile); err == nil {
+		if _, _, err := util.RunOVSDBTool("db-is-clustered", dbFile); err == nil && raftAddress == "" {
+			address, stderr, err := util.RunOVSDBTool("db-local-address", dbFile)
+			if err != nil {
+				return fmt.Errorf("failed to get the Raft address of %s: stderr: %q, error: %v", dbFile, stderr, err)
+			}
+			raftAddress = address
+		}
+		oldDBFile := fmt.Sprintf("%s.%s.bak", dbFile, time.Now().UTC().Format(backupTimestampFormat))
+		if err := os.Rename(dbFile, oldDBFile); err != nil {
+			return fmt.Errorf("failed to move database %s away: %v", dbFile, err)
+		}
+		klog.Infof("Moved the database being replaced to %s", oldDBFile)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat database %s: %v", dbFile, err)
+	}
+
+	if raftAddress == "" {
+		if _, stderr, err := util.RunOVSDBTool("compact", backupFile, dbFile); err != nil {
+			return fmt.Errorf("failed to restore %s into %s: stderr: %q, error: %v", backupFile, dbFile, stderr, err)
+		}
+		klog.Infof("Restored %s into standalone database %s", backupFile, dbFile)
+		return nil
+	}
+
+	if _, stderr, err := util.RunOVSDBTool("create-cluster", dbFile, backupFile, raftAddress); err != nil {
+		return fmt.Errorf("failed to restore %s into %s: stderr: %q, error: %v", backupFile, dbFile, stderr, err)
+	}
+	cid, _, err := util.RunOVSDBTool("db-cid", dbFile)
+	if err != nil {
+		klog.Warningf("Failed to get the cluster ID of %s: %v", dbFile, err)
+	}
+	klog.Infof("Restored %s into clustered database %s with cluster ID %s on %s, the database file of the other "+
+		"cluster members must be deleted for them to join the cluster", backupFile, dbFile, cid, raftAddress)
+	return nil
+}
+
+// backupPrefix returns the prefix of the backup files of the database, e.g. ovnnb_db
+func backupPrefix(db ovnDatabase) string {
+	return strings.TrimSuffix(filepath.Base(db.dbFile), filepath.Ext(db.dbFile))
+}
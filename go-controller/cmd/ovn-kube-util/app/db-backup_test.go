@@ -0,0 +1,316 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	testSchema        = "/usr/share/ovn/ovn-nb.ovsschema"
+	testSchemaVersion = "7.3.0"
+)
+
+func newTestOVNDatabase(dir string) ovnDatabase {
+	return ovnDatabase{
+		dbName: "OVN_Northbound",
+		socket: filepath.Join(dir, "ovnnb_db.sock"),
+		schema: testSchema,
+		dbFile: filepath.Join(dir, "ovnnb_db.db"),
+	}
+}
+
+// validateBackupCmds returns the commands run by validateBackup for a backup of the given database
+// name and schema version.
+func validateBackupCmds(backupFile, dbName, dbVersion string) []*ovntest.ExpectedCmd {
+	return []*ovntest.ExpectedCmd{
+		{Cmd: "ovsdb-tool db-name " + backupFile, Output: dbName},
+		{Cmd: "ovsdb-tool db-version " + backupFile, Output: dbVersion},
+		{Cmd: "ovsdb-tool schema-version " + testSchema, Output: testSchemaVersion},
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, names ...string) {
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o640); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	nbBackups := []string{
+		"ovnnb_db-20240101T000000Z.db",
+		"ovnnb_db-20240102T000000Z.db",
+		"ovnnb_db-20240103T000000Z.db",
+		"ovnnb_db-20240104T000000Z.db",
+	}
+	otherFiles := []string{
+		"ovnsb_db-20240101T000000Z.db",
+		"ovnsb_db-20240102T000000Z.db",
+		"ovnnb_db-20240105T000000Z.db.tmp",
+		"ovnnb_db.db",
+	}
+
+	tests := []struct {
+		desc              string
+		retention         int
+		expectedNBBackups []string
+	}{
+		{
+			desc:              "retention 0 keeps all the backups",
+			retention:         0,
+			expectedNBBackups: nbBackups,
+		},
+		{
+			desc:              "older backups are deleted",
+			retention:         2,
+			expectedNBBackups: nbBackups[2:],
+		},
+		{
+			desc:              "retention of the number of backups",
+			retention:         len(nbBackups),
+			expectedNBBackups: nbBackups,
+		},
+		{
+			desc:              "retention above the number of backups",
+			retention:         10,
+			expectedNBBackups: nbBackups,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			dir := t.TempDir()
+			writeTestFiles(t, dir, nbBackups...)
+			writeTestFiles(t, dir, otherFiles...)
+
+			g.Expect(pruneBackups(newTestOVNDatabase(dir), dir, tc.retention)).To(gomega.Succeed())
+
+			entries, err := os.ReadDir(dir)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			var files []string
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			// the backups of the other databases and the other files are left untouched
+			g.Expect(files).To(gomega.ConsistOf(append(append([]string{}, tc.expectedNBBackups...), otherFiles...)))
+		})
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	const backupFile = "/var/lib/ovn/backups/ovnnb_db-20240101T000000Z.db"
+
+	tests := []struct {
+		desc                 string
+		cmds                 []*ovntest.ExpectedCmd
+		allowVersionMismatch bool
+		expectError          bool
+	}{
+		{
+			desc: "valid backup",
+			cmds: validateBackupCmds(backupFile, "OVN_Northbound", testSchemaVersion),
+		},
+		{
+			desc: "unreadable backup",
+			cmds: []*ovntest.ExpectedCmd{
+				{Cmd: "ovsdb-tool db-name " + backupFile, Stderr: "ovsdb-tool: syntax error", Err: fmt.Errorf("exit status 1")},
+			},
+			expectError: true,
+		},
+		{
+			desc: "backup of another database",
+			cmds: []*ovntest.ExpectedCmd{
+				{Cmd: "ovsdb-tool db-name " + backupFile, Output: "OVN_Southbound"},
+			},
+			expectError: true,
+		},
+		{
+			desc:        "backup of another schema version",
+			cmds:        validateBackupCmds(backupFile, "OVN_Northbound", "7.2.0"),
+			expectError: true,
+		},
+		{
+			desc:                 "backup of another schema version allowed",
+			cmds:                 validateBackupCmds(backupFile, "OVN_Northbound", "7.2.0"),
+			allowVersionMismatch: true,
+		},
+		{
+			desc: "installed schema not readable",
+			cmds: []*ovntest.ExpectedCmd{
+				{Cmd: "ovsdb-tool db-name " + backupFile, Output: "OVN_Northbound"},
+				{Cmd: "ovsdb-tool db-version " + backupFile, Output: testSchemaVersion},
+				{Cmd: "ovsdb-tool schema-version " + testSchema, Err: fmt.Errorf("exit status 1")},
+			},
+			allowVersionMismatch: true,
+			expectError:          true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmds(tc.cmds)
+			g.Expect(util.SetSpecificExec(fexec, ovsdbToolCommand)).To(gomega.Succeed())
+
+			err := validateBackup(newTestOVNDatabase(t.TempDir()), backupFile, tc.allowVersionMismatch)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			g.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
+		})
+	}
+}
+
+func TestRestoreDB(t *testing.T) {
+	const raftAddress = "ssl:10.0.0.1:6643"
+
+	tests := []struct {
+		desc          string
+		dbExists      bool
+		serverRunning bool
+		raftAddress   string
+		force         bool
+		// cmds returns the commands expected after the backup validation
+		cmds             func(backupFile, dbFile string) []*ovntest.ExpectedCmd
+		backupVersion    string
+		expectError      bool
+		expectDBReplaced bool
+	}{
+		{
+			desc: "standalone database without database file",
+			cmds: func(backupFile, dbFile string) []*ovntest.ExpectedCmd {
+				return []*ovntest.ExpectedCmd{
+					{Cmd: fmt.Sprintf("ovsdb-tool compact %s %s", backupFile, dbFile)},
+				}
+			},
+		},
+		{
+			desc:     "standalone database replacing the database file",
+			dbExists: true,
+			cmds: func(backupFile, dbFile string) []*ovntest.ExpectedCmd {
+				return []*ovntest.ExpectedCmd{
+					{Cmd: "ovsdb-tool db-is-clustered " + dbFile, Err: fmt.Errorf("exit status 2")},
+					{Cmd: fmt.Sprintf("ovsdb-tool compact %s %s", backupFile, dbFile)},
+				}
+			},
+			expectDBReplaced: true,
+		},
+		{
+			desc:     "clustered database on the Raft address of the replaced database file",
+			dbExists: true,
+			cmds: func(backupFile, dbFile string) []*ovntest.ExpectedCmd {
+				return []*ovntest.ExpectedCmd{
+					{Cmd: "ovsdb-tool db-is-clustered " + dbFile},
+					{Cmd: "ovsdb-tool db-local-address " + dbFile, Output: raftAddress},
+					{Cmd: fmt.Sprintf("ovsdb-tool create-cluster %s %s %s", dbFile, backupFile, raftAddress)},
+					{Cmd: "ovsdb-tool db-cid " + dbFile, Output: "e2f0f6a5-0f4b-4c2b-9e5f-6f8e4b2b0c1d"},
+				}
+			},
+			expectDBReplaced: true,
+		},
+		{
+			desc:        "clustered database on the given Raft address",
+			raftAddress: raftAddress,
+			cmds: func(backupFile, dbFile string) []*ovntest.ExpectedCmd {
+				return []*ovntest.ExpectedCmd{
+					{Cmd: fmt.Sprintf("ovsdb-tool create-cluster %s %s %s", dbFile, backupFile, raftAddress)},
+					{Cmd: "ovsdb-tool db-cid " + dbFile, Output: "e2f0f6a5-0f4b-4c2b-9e5f-6f8e4b2b0c1d"},
+				}
+			},
+		},
+		{
+			desc:          "backup of another schema version",
+			dbExists:      true,
+			backupVersion: "7.2.0",
+			expectError:   true,
+		},
+		{
+			desc:          "backup of another schema version with force",
+			dbExists:      true,
+			force:         true,
+			backupVersion: "7.2.0",
+			cmds: func(backupFile, dbFile string) []*ovntest.ExpectedCmd {
+				return []*ovntest.ExpectedCmd{
+					{Cmd: "ovsdb-tool db-is-clustered " + dbFile, Err: fmt.Errorf("exit status 2")},
+					{Cmd: fmt.Sprintf("ovsdb-tool compact %s %s", backupFile, dbFile)},
+				}
+			},
+			expectDBReplaced: true,
+		},
+		{
+			desc:          "running database server",
+			dbExists:      true,
+			serverRunning: true,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			dir := t.TempDir()
+			db := newTestOVNDatabase(dir)
+			backupFile := filepath.Join(dir, "ovnnb_db-20240101T000000Z.db")
+			writeTestFiles(t, dir, filepath.Base(backupFile))
+			if tc.dbExists {
+				writeTestFiles(t, dir, filepath.Base(db.dbFile))
+			}
+			if tc.serverRunning {
+				listener, err := net.Listen("unix", db.socket)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				defer listener.Close()
+			}
+
+			fexec := ovntest.NewFakeExec()
+			if !tc.serverRunning {
+				backupVersion := tc.backupVersion
+				if backupVersion == "" {
+					backupVersion = testSchemaVersion
+				}
+				fexec.AddFakeCmds(validateBackupCmds(backupFile, db.dbName, backupVersion))
+			}
+			if tc.cmds != nil {
+				fexec.AddFakeCmds(tc.cmds(backupFile, db.dbFile))
+			}
+			g.Expect(util.SetSpecificExec(fexec, ovsdbToolCommand)).To(gomega.Succeed())
+
+			err := restoreDB(db, backupFile, db.dbFile, tc.raftAddress, tc.force)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			g.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
+
+			// the replaced database file is moved away, and kept otherwise
+			oldDBFiles, err := filepath.Glob(db.dbFile + ".*.bak")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			if tc.expectDBReplaced {
+				g.Expect(oldDBFiles).To(gomega.HaveLen(1))
+				g.Expect(db.dbFile).NotTo(gomega.BeAnExistingFile())
+			} else {
+				g.Expect(oldDBFiles).To(gomega.BeEmpty())
+			}
+			if tc.dbExists && !tc.expectDBReplaced {
+				g.Expect(db.dbFile).To(gomega.BeAnExistingFile())
+			}
+			g.Expect(backupFile).To(gomega.BeAnExistingFile())
+		})
+	}
+}
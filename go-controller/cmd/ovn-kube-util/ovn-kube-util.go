@@ -31,6 +31,8 @@ func main() {
 		&app.BridgesToNicCommand,
 		&app.ReadinessProbeCommand,
 		&app.OvsExporterCommand,
+		&app.DBBackupCommand,
+		&app.DBRestoreCommand,
 	}
 
 	c.Before = func(ctx *cli.Context) error {
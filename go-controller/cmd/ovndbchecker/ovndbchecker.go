@@ -54,9 +54,23 @@ func getFlagsByCategory() map[string][]cli.Flag {
 	m["K8s-related Options"] = config.K8sFlags
 	m["OVN Northbound DB Options"] = config.OvnNBFlags
 	m["OVN Southbound DB Options"] = config.OvnSBFlags
+	m["DB Checker Options"] = dbCheckerFlags
 	return m
 }
 
+var dbCheckerFlags = []cli.Flag{
+	&cli.DurationFlag{
+		Name: "raft-quorum-loss-recovery-threshold",
+		Usage: "When set, recover a Raft cluster whose majority of members has been unreachable for longer than this " +
+			"duration: a surviving member is converted into a new single member cluster and the other members join it. " +
+			"Disabled by default.",
+	},
+	&cli.BoolFlag{
+		Name:  "raft-recovery-dry-run",
+		Usage: "Only report the Raft cluster recovery steps with events, without changing the databases.",
+	},
+}
+
 // borrowed from cli packages' printHelpCustom()
 func printOvnDBCheckHelp(out io.Writer, templ string, data interface{}, customFunc map[string]interface{}) {
 	funcMap := template.FuncMap{
@@ -83,7 +97,7 @@ func main() {
 	c.Usage = "run ovn db checker to ensure raft membership and db health"
 	c.Version = config.Version
 	c.CustomAppHelpTemplate = CustomDBCheckAppHelpTemplate
-	c.Flags = config.GetFlags(nil)
+	c.Flags = append(config.GetFlags(nil), dbCheckerFlags...)
 
 	c.Action = func(c *cli.Context) error {
 		return runOvnKubeDBChecker(c)
@@ -184,6 +198,11 @@ func runOvnKubeDBChecker(ctx *cli.Context) error {
 	stopChan := make(chan struct{})
 	go ovndbmanager.RunDBChecker(
 		&kube.Kube{KClient: ovnClientset.KubeClient},
+		util.EventRecorder(ovnClientset.KubeClient),
+		ovndbmanager.RaftRecoveryConfig{
+			QuorumLossThreshold: ctx.Duration("raft-quorum-loss-recovery-threshold"),
+			DryRun:              ctx.Bool("raft-recovery-dry-run"),
+		},
 		stopChan)
 	// run until cancelled
 	<-ctx.Context.Done()
@@ -9,6 +9,8 @@ import (
 	"syscall"
 
 	observ "github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/exporter"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
 )
 
 func main() {
@@ -28,12 +30,47 @@ func main() {
 	logCookie := flag.Bool("log-cookie", false, "Print raw sample cookie with psample group_id.")
 	printPacket := flag.Bool("print-full-packet", false, "Print full received packet. When false, only src and dst ips are printed with every sample.")
 	addOVSCollector := flag.Bool("add-ovs-collector", false, "Add ovs collector to enable sampling. Use with caution. Make sure no one else is using observability.")
+	collectorSetID := flag.Int("collector-set-id", observability.DefaultObservabilityCollectorSetID, "Collector set ID of the ovs collector added with -add-ovs-collector, e.g. the collectorSetID of an ObservabilityConfig.")
 	outputFile := flag.String("output-file", "", "Output file to write the samples to.")
 	filterSrcIP := flag.String("filter-src-ip", "", "Filter in only packets from a given source ip.")
 	filterDstIP := flag.String("filter-dst-ip", "", "Filter in only packets to a given destination ip.")
+	metricsAddress := flag.String("metrics-bind-address", "", "The IP address and port to serve the sample metrics on, e.g. \":9312\". Metrics are disabled when empty.")
+	exportJSON := flag.String("export-json", "", "Export the decoded samples as JSON lines to a file, or to stdout with \"-\".")
+	exportOTLP := flag.String("export-otlp-endpoint", "", "Export the decoded samples as OpenTelemetry logs to an OTLP/gRPC endpoint, e.g. \"localhost:4317\".")
+	otlpInsecure := flag.Bool("export-otlp-insecure", false, "Connect to the OTLP/gRPC endpoint without TLS.")
+	exportIPFIX := flag.String("export-ipfix-collector", "", "Export the decoded samples to an IPFIX collector over UDP, e.g. \"10.0.0.1:4739\".")
+	ipfixEnterpriseNumber := flag.Uint("ipfix-enterprise-number", 0, "Private enterprise number of the pod and ACL IPFIX Information Elements. Required with -export-ipfix-collector.")
+	ipfixObservationDomainID := flag.Uint("ipfix-observation-domain-id", 0, "IPFIX observation domain ID.")
 	flag.Parse()
 
-	reader := observ.NewSampleReader(*enableDecoder, *logCookie, *printPacket, *addOVSCollector, *filterSrcIP, *filterDstIP, *outputFile)
+	reader := observ.NewSampleReader(*enableDecoder, *logCookie, *printPacket, *addOVSCollector, *filterSrcIP, *filterDstIP, *outputFile, *metricsAddress)
+	reader.SetCollectorSetID(*collectorSetID)
+	var exporters []exporter.Exporter
+	if *exportJSON != "" {
+		e, err := exporter.NewJSONLinesExporter(*exportJSON)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		exporters = append(exporters, e)
+	}
+	if *exportOTLP != "" {
+		e, err := exporter.NewOTLPExporter(*exportOTLP, *otlpInsecure)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		exporters = append(exporters, e)
+	}
+	if *exportIPFIX != "" {
+		e, err := exporter.NewIPFIXExporter(*exportIPFIX, uint32(*ipfixEnterpriseNumber), uint32(*ipfixObservationDomainID))
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		exporters = append(exporters, e)
+	}
+	reader.SetExporters(exporters...)
 	err := reader.ReadSamples(ctx)
 	if err != nil {
 		fmt.Println(err.Error())
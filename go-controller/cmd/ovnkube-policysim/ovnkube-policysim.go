@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/policysim"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	expectAllow = "allow"
+	expectDeny  = "deny"
+)
+
+// exit codes
+const (
+	exitOK = iota
+	exitError
+	exitUnexpectedVerdict
+)
+
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	// use a dedicated flag set, the default one is polluted by the test helpers linked in through
+	// the in-memory OVN databases
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var snapshots fileList
+	flags.Var(&snapshots, "snapshot", "YAML or JSON manifests with the pods, namespaces, nodes, NetworkPolicies, AdminNetworkPolicies "+
+		"and BaselineAdminNetworkPolicies to simulate; can be repeated")
+	src := flags.String("src", "", "source of the flow: a pod as namespace/name or an IP address")
+	dst := flags.String("dst", "", "destination of the flow: a pod as namespace/name or an IP address")
+	protocol := flags.String("protocol", "tcp", "transport protocol: tcp, udp or sctp")
+	srcPort := flags.Int("src-port", 0, "source port")
+	dstPort := flags.Int("dst-port", 0, "destination port")
+	addressFamily := flags.String("addr-family", "", "address family (ip4 or ip6); defaults to ip4 when both endpoints have an IPv4 address")
+	output := flags.String("output", outputText, "output format of the verdict: text or json")
+	expect := flags.String("expect", "", "expected verdict, allow or deny; when set the command exits with code 2 if the verdict differs")
+	loglevel := flags.Int("loglevel", 0, "loglevel: klog level")
+	_ = flags.Parse(os.Args[1:])
+
+	if len(snapshots) == 0 || *src == "" || *dst == "" {
+		fmt.Fprintln(os.Stderr, "-snapshot, -src and -dst are required")
+		flags.Usage()
+		return exitError
+	}
+	flow := policysim.Flow{
+		Source:          *src,
+		Destination:     *dst,
+		Protocol:        *protocol,
+		SourcePort:      *srcPort,
+		DestinationPort: *dstPort,
+	}
+	switch *addressFamily {
+	case "":
+	case "ip4":
+		flow.IPFamily = 4
+	case "ip6":
+		flow.IPFamily = 6
+	default:
+		fmt.Fprintf(os.Stderr, "invalid address family %q\n", *addressFamily)
+		return exitError
+	}
+	if *output != outputText && *output != outputJSON {
+		fmt.Fprintf(os.Stderr, "invalid output format %q\n", *output)
+		return exitError
+	}
+	if *expect != "" && *expect != expectAllow && *expect != expectDeny {
+		fmt.Fprintf(os.Stderr, "invalid expected verdict %q\n", *expect)
+		return exitError
+	}
+
+	snapshot, err := policysim.LoadSnapshotFiles(snapshots...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load snapshot: %v\n", err)
+		return exitError
+	}
+	sim, err := policysim.New(snapshot, policysim.Options{LogLevel: *loglevel})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to translate snapshot: %v\n", err)
+		return exitError
+	}
+	verdict, err := sim.Evaluate(flow)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to evaluate flow: %v\n", err)
+		return exitError
+	}
+
+	if *output == outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(verdict); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode verdict: %v\n", err)
+			return exitError
+		}
+	} else {
+		printVerdict(os.Stdout, verdict)
+	}
+
+	if *expect != "" && verdict.Allowed != (*expect == expectAllow) {
+		fmt.Fprintf(os.Stderr, "Unexpected verdict: expected %s\n", *expect)
+		return exitUnexpectedVerdict
+	}
+	return exitOK
+}
+
+func endpointString(e policysim.Endpoint, port int) string {
+	s := e.IP
+	if port != 0 {
+		s = fmt.Sprintf("%s:%d", s, port)
+	}
+	if e.Pod != "" {
+		s = fmt.Sprintf("%s (%s)", s, e.Pod)
+	}
+	return s
+}
+
+func aclString(acl *policysim.ACL) string {
+	return fmt.Sprintf("%s by %s %s, tier %d (%s), priority %d", acl.Action, acl.OwnerType, acl.Owner,
+		acl.Tier, policysim.TierName(acl.Tier), acl.Priority)
+}
+
+func printVerdict(w io.Writer, v *policysim.Verdict) {
+	fmt.Fprintf(w, "Flow: %s %s -> %s\n", v.Protocol, endpointString(v.Source, v.SourcePort), endpointString(v.Destination, v.DestinationPort))
+	for _, stage := range v.Stages {
+		name := stage.Stage
+		if stage.AfterLB {
+			name += " (after load balancing)"
+		}
+		fmt.Fprintf(w, "\n%s on port %s:\n", name, stage.LogicalPort)
+		for _, acl := range stage.Passed {
+			fmt.Fprintf(w, "  passed:  %s\n           match: %s\n", aclString(acl), acl.Match)
+		}
+		if stage.ACL == nil {
+			fmt.Fprintln(w, "  allowed: no ACL matched")
+			continue
+		}
+		result := "allowed"
+		if !stage.Allowed {
+			result = "denied"
+		}
+		fmt.Fprintf(w, "  %s: %s\n           match: %s\n", result, aclString(stage.ACL), stage.ACL.Match)
+	}
+	for _, warning := range v.Warnings {
+		fmt.Fprintf(w, "\nWarning: %s\n", warning)
+	}
+	verdict := "ALLOW"
+	if !v.Allowed {
+		verdict = "DENY"
+	}
+	fmt.Fprintf(w, "\nVerdict: %s", verdict)
+	if deciding := v.Deciding(); deciding != nil {
+		fmt.Fprintf(w, " (%s, %s)", deciding.Stage, aclString(deciding.ACL))
+	}
+	fmt.Fprintln(w)
+}
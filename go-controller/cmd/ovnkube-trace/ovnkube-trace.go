@@ -115,6 +115,9 @@ type PodInfo struct {
 	SslCertKeys          string // ssl cert keys string to access ovn nbdb/sbdb
 	NbCommand            string // contains subset of nb command string to execute on ovn nbdb
 	SbCommand            string // contains subset of sb command string to execute on ovn sbdb
+
+	// UDN is the user defined network the pod is traced on, nil for the default network.
+	UDN *UDNInfo
 }
 
 // String returns a JSON representation of the SvcInfo object, or "" on failure.
@@ -147,8 +150,12 @@ func (si *SvcInfo) FullyQualifiedPodName() string {
 	return si.PodInfo.FullyQualifiedPodName()
 }
 
-// FullyQualifiedPodName returns the full name of the pod, <namespace>_<pod>.
+// FullyQualifiedPodName returns the full name of the pod, <namespace>_<pod>, prefixed with the network
+// prefix on user defined networks. This is also the name of the pod's logical switch port.
 func (pi *PodInfo) FullyQualifiedPodName() string {
+	if pi.UDN != nil {
+		return util.GetUserDefinedNetworkLogicalPortName(pi.PodNamespace, pi.PodName, pi.UDN.NADName)
+	}
 	return fmt.Sprintf("%s_%s", pi.PodNamespace, pi.PodName)
 }
 
@@ -165,7 +172,7 @@ func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, n
 
 	scheme := runtime.NewScheme()
 	if err := corev1.AddToScheme(scheme); err != nil {
-		klog.Exitf("Error adding to scheme: %v", err)
+		return "", "", fmt.Errorf("error adding to scheme: %v", err)
 	}
 	parameterCodec := runtime.NewParameterCodec(scheme)
 
@@ -345,7 +352,7 @@ func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, rest
 }
 
 // getSvcInfo builds the SvcInfo object for this service. PodName/PodNamespace/PodIP are for the first valid endpoint pod that can be found for this service.
-func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string) (svcInfo *SvcInfo, err error) {
+func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily, network string) (svcInfo *SvcInfo, err error) {
 	// Get service with the name supplied by svcName
 	svc, err := coreclient.Services(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
 	if err != nil {
@@ -379,7 +386,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	}
 	klog.V(5).Infof("==> Got EndpointSlices %v for service %s in namespace %s\n", es, svcName, namespace)
 
-	err = extractEndpointSliceInfo(coreclient, restconfig, es.Items, svcInfo, ovnNamespace, addressFamily)
+	err = extractEndpointSliceInfo(coreclient, restconfig, es.Items, svcInfo, ovnNamespace, addressFamily, network)
 	if err != nil {
 		return nil, err
 	}
@@ -390,7 +397,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 // extractEndpointSliceInfo copies information from the endpoint slices into the SvcInfo object.
 // Modifies the svcInfo object the pointer of which is passed to it.
 // slice is *discoveryv1.EndpointSlice slices is
-func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, slices []discoveryv1.EndpointSlice, svcInfo *SvcInfo, ovnNamespace, addressFamily string) error {
+func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, slices []discoveryv1.EndpointSlice, svcInfo *SvcInfo, ovnNamespace, addressFamily, network string) error {
 
 	for _, slice := range slices {
 		klog.V(5).Infof("==> Trying to extract information for service %s in namespace %s from slice %v",
@@ -434,9 +441,9 @@ func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig
 			}
 
 			// Get info needed for the src Pod
-			svcPodInfo, err := getPodInfo(coreclient, restconfig, endpoint.TargetRef.Name, ovnNamespace, endpoint.TargetRef.Namespace, addressFamily)
+			svcPodInfo, err := getPodInfo(coreclient, restconfig, endpoint.TargetRef.Name, ovnNamespace, endpoint.TargetRef.Namespace, addressFamily, network)
 			if err != nil {
-				klog.Exitf("Failed to get information from pod %s: %v", endpoint.TargetRef.Name, err)
+				return fmt.Errorf("failed to get information from pod %s: %w", endpoint.TargetRef.Name, err)
 			}
 			klog.V(5).Infof("svcPodInfo is %s\n", svcPodInfo)
 
@@ -453,7 +460,8 @@ func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig
 }
 
 // getPodInfo returns a pointer to a fully populated PodInfo struct, or error on failure.
-func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string) (podInfo *PodInfo, err error) {
+// network selects the user defined network the pod is traced on, see getPodUDNInfo.
+func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily, network string) (podInfo *PodInfo, err error) {
 	// Create a PodInfo object with the base information already added, such as
 	// IP, PodName, ContainerName, NodeName, HostNetwork, Namespace, PrimaryInterfaceName
 	pod, err := coreclient.Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -478,6 +486,27 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	}
 	podInfo.NodeName = pod.Spec.NodeName
 
+	// Get the user defined network the pod is traced on, if any.
+	if pod.Spec.HostNetwork {
+		if network != "" && network != types.DefaultNetworkName {
+			return nil, fmt.Errorf("host networked pod %s in namespace %s cannot be traced on network %s", podName, namespace, network)
+		}
+	} else {
+		podInfo.UDN, err = getPodUDNInfo(restconfig, pod, network)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if podInfo.UDN != nil {
+		node, err := coreclient.Nodes().Get(context.TODO(), podInfo.NodeName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if err := setPodUDNInfo(podInfo, pod, node); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get the pod's ovnkubePod.
 	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
 	if err != nil {
@@ -493,7 +522,7 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 
 	podInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
 	if err != nil {
-		klog.Exitf("Failed to get database URIs: %v\n", err)
+		return nil, fmt.Errorf("failed to get database URIs: %w", err)
 	}
 
 	// Get the pod's MAC address.
@@ -507,7 +536,7 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		}
 		localOutput = strings.ReplaceAll(localOutput, "\n", "")
 		podInfo.MAC = strings.ReplaceAll(localOutput, "\"", "")
-	} else {
+	} else if podInfo.UDN == nil {
 		podInfo.MAC, err = getPodMAC(pod)
 		if err != nil {
 			klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
@@ -515,29 +544,33 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		}
 	}
 
-	// Find rtos MAC (this is the pod's first hop router).
-	podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToSwitchPrefix)
-	if err != nil {
-		return nil, err
+	// Find rtos MAC (this is the pod's first hop router). Pods on localnet networks have no first hop router.
+	if portName := podInfo.routerToSwitchPortName(); portName != "" {
+		podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, portName)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
-	if podInfo.IsInterConnect {
-		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToTransitSwitchPrefix)
+	if portName := podInfo.routerToTransitSwitchPortName(); podInfo.IsInterConnect && portName != "" {
+		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, portName)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Set information specific to ovn-k8s-mp0. This info is required for routingViaHost gateway mode traffic to an external IP
-	// destination.
-	podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
-	portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
-	localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
-	if err != nil {
-		return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+	// Set information specific to the management port of the pod's network, e.g. ovn-k8s-mp0. This info is required for
+	// routingViaHost gateway mode traffic to an external IP destination.
+	podInfo.OvnK8sMp0PortName = podInfo.mgmtPortName()
+	if podInfo.OvnK8sMp0PortName != "" {
+		portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
+		localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
+		if err != nil {
+			return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+		}
+		podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 	}
-	podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 
 	// Set information specific to host networked pods or non-host networked pods.
 	if podInfo.HostNetwork {
@@ -564,8 +597,8 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	return podInfo, err
 }
 
-func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portPrefix string) (string, error) {
-	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portPrefix + podInfo.NodeName
+func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portName string) (string, error) {
+	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portName
 	ipOutput, ipError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, tspCmd, "")
 	if err != nil {
 		return "", fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, ipError, ipOutput, podInfo)
@@ -706,63 +739,79 @@ func getDatabaseURIs(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 }
 
 // printSuccessOrFailure will print a success or failure message. If searchString is set, then we expect to find a match for the
-// regexp given in searchString.
-func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) {
+// regexp given in searchString. It returns an error if the command failed or if its output didn't match.
+// Unless the output format is text, the result is recorded as a trace step instead and printed by printTraceResult.
+func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) error {
 	if err != nil {
-		klog.Exitf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
+		if outputFormat != outputText {
+			step := newTraceStep(commandDescription, src, dst, commandStdout, searchString, false)
+			step.Error = fmt.Sprintf("%v: %s", err, commandStderr)
+			step.Reason = step.Error
+			recordTraceStep(step)
+		}
+		return fmt.Errorf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
 	}
 	klog.V(2).Infof("%s Output:\n%s%s%s\n", commandDescription, italic, commandStdout, reset)
 
+	match := true
 	if searchString != "" {
-		match, err := regexp.MatchString(searchString, commandStdout)
+		match, err = regexp.MatchString(searchString, commandStdout)
 		if err != nil {
-			klog.Exitf("Unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
+			return fmt.Errorf("unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
 		}
-		if match {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
+	}
+
+	if outputFormat != outputText {
+		recordTraceStep(newTraceStep(commandDescription, src, dst, commandStdout, searchString, match))
+	} else if match {
+		// Write the result to stdout.
+		fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
+		if searchString != "" {
 			// Log further info on log level 1.
 			klog.V(1).Infof("%sSearch string matched:\n%s%s\n", green, searchString, reset)
-		} else {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
-			// Log further info on log level 1.
-			klog.V(1).Infof("%sSearch string not matched:\n%s%s\n", red, searchString, reset)
-			os.Exit(-1)
 		}
 	} else {
 		// Write the result to stdout.
-		fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
+		fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
+		// Log further info on log level 1.
+		klog.V(1).Infof("%sSearch string not matched:\n%s%s\n", red, searchString, reset)
+	}
+	if !match {
+		return fmt.Errorf("%s indicates failure from %s to %s", commandDescription, src, dst)
 	}
+	return nil
 }
 
 // runOvnTraceToService runs an ovntrace from src pod to dst service. If dstSvcInfo == nil, then skip all steps.
-func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort string) {
+func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort string) error {
 	var inport string
 	inport = srcPodInfo.FullyQualifiedPodName()
 	if srcPodInfo.HostNetwork {
 		inport = srcPodInfo.K8sNodeNamePort
 	}
+	if srcPodInfo.topology() == types.LocalnetTopology {
+		return fmt.Errorf("tracing to a service is not supported on localnet network %s", srcPodInfo.UDN.NetworkName)
+	}
 	svcL3Ver := dstSvcInfo.getL3Ver()
 	if srcPodInfo.IPVer != svcL3Ver {
-		klog.Exitf("Pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
+		return fmt.Errorf("pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
 			srcPodInfo.IP, dstSvcInfo.ClusterIP)
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888' --lb-dst %[12]s:%[13]s`,
-		srcPodInfo.SbCommand,  // 1
-		srcPodInfo.NodeName,   // 2
-		inport,                // 3
-		srcPodInfo.MAC,        // 4
-		srcPodInfo.RtosMAC,    // 5
-		srcPodInfo.IPVer,      // 6
-		srcPodInfo.IP,         // 7
-		svcL3Ver,              // 8
-		dstSvcInfo.ClusterIP,  // 9
-		protocol,              // 10
-		dstPort,               // 11
-		dstSvcInfo.PodInfo.IP, // 12
-		dstSvcInfo.PodPort,    // 13
+		srcPodInfo.SbCommand,    // 1
+		srcPodInfo.switchName(), // 2
+		inport,                  // 3
+		srcPodInfo.MAC,          // 4
+		srcPodInfo.RtosMAC,      // 5
+		srcPodInfo.IPVer,        // 6
+		srcPodInfo.IP,           // 7
+		svcL3Ver,                // 8
+		dstSvcInfo.ClusterIP,    // 9
+		protocol,                // 10
+		dstPort,                 // 11
+		dstSvcInfo.PodInfo.IP,   // 12
+		dstSvcInfo.PodPort,      // 13
 	)
 	klog.V(4).Infof("ovn-trace command from src to service clusterIP is %s", cmd)
 
@@ -771,32 +820,36 @@ func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *res
 	if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstSvcInfo.PodInfo) {
 		successString = fmt.Sprintf(`output to "%s"`, dstSvcInfo.FullyQualifiedPodName())
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstSvcInfo.PodInfo.NodeName)
+		successString = remotePodOutport(srcPodInfo, dstSvcInfo.PodInfo)
 	}
 	direction := "source pod to service clusterIP"
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
-
+	if err := printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
 }
 
 // runOvnTraceToIP runs an ovntrace from src pod to dst IP address (should be external to the cluster).
-// Returns the node that the trace will exit on.
-func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string) {
+// Returns the node and the bridge that the trace will exit on.
+func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string, error) {
 	if srcPodInfo.HostNetwork {
-		klog.Exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
+		return "", "", fmt.Errorf("pod cannot be on Host Network when tracing to an IP address; use ping")
+	}
+	if srcPodInfo.topology() == types.LocalnetTopology {
+		return "", "", fmt.Errorf("tracing to an IP address is not supported on localnet network %s", srcPodInfo.UDN.NetworkName)
 	}
 
 	l3ver := getIPVer(parsedDstIP)
 
 	if srcPodInfo.IPVer != l3ver {
-		klog.Exitf("Pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
+		return "", "", fmt.Errorf("pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
 			srcPodInfo.IP, parsedDstIP)
 	}
 
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
 		srcPodInfo.SbCommand,               // 1
-		srcPodInfo.NodeName,                // 2
+		srcPodInfo.switchName(),            // 2
 		srcPodInfo.FullyQualifiedPodName(), // 3
 		srcPodInfo.MAC,                     // 4
 		srcPodInfo.RtosMAC,                 // 5
@@ -813,10 +866,15 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	// a) if this is routingViaHost gateway mode, output to "k8s-<nodename>"
 	// b) for routingViaHost gateway egressip and routingViaOVN gateway mode, go out of <bridge name>_<node name>
 	// c) when interconnect enabled and egressip available for the pod, then go out of tstor-<egress-node> with type "remote".
-	successString := fmt.Sprintf(`output to "(.*)_(.*)", type "localnet"|output to "k8s-%s"|remote`, srcPodInfo.NodeName)
+	// On user defined networks, the node names are prefixed with the network prefix.
+	networkPrefix := regexp.QuoteMeta(srcPodInfo.networkScopedName(""))
+	successString := fmt.Sprintf(`output to "(.*)_%s(.*)", type "localnet"|output to "%s"|remote`,
+		networkPrefix, regexp.QuoteMeta(types.K8sPrefix+srcPodInfo.networkScopedName(srcPodInfo.NodeName)))
 	// Run the command and check if succesString was found.
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	if err := printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return "", "", err
+	}
 
 	// Print some additional information about the node where this request leaves from as well
 	// as the SNAT IP address.
@@ -830,39 +888,39 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 		// We should never hit this (printSuccessOrFailure checks the same already above).
 		if len(subMatches) < 3 {
-			klog.Exitf("Could not determine the output port for this trace command, subMatches: %q\n", subMatches)
+			return "", "", fmt.Errorf("could not determine the output port for this trace command, subMatches: %q", subMatches)
 		}
 		node := subMatches[len(subMatches)-1]
 		bridgeName := subMatches[len(subMatches)-2]
 		klog.V(1).Infof("%sout on node %s via Logical_Switch_Port %s with SNAT %s%s\n", green, node, bridgeName, snat, reset)
 
-		return string(node), string(bridgeName)
+		return string(node), string(bridgeName), nil
 	}
 
 	// Try to find egress node name when ovnSrcDstOut contains "output to tstor-<egress-node>"".
-	nodeNameRegex := `output to "tstor-(.*)",`
+	nodeNameRegex := fmt.Sprintf(`output to "%s(.*)",`, regexp.QuoteMeta(srcPodInfo.networkScopedName(types.TransitSwitchToRouterPrefix)))
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) > 1 {
 		node := subMatches[len(subMatches)-1]
 		klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-		return string(node), ""
+		return string(node), "", nil
 	}
 
 	klog.V(5).Infof("Could not find SNAT for this trace command, this must be routingViaHost gateway mode without EgressIP.")
-	nodeNameRegex = `output to "k8s-(.*)",`
+	nodeNameRegex = fmt.Sprintf(`output to "%s%s(.*)",`, types.K8sPrefix, networkPrefix)
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) < 2 {
-		klog.Exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
+		return "", "", fmt.Errorf("could not determine node name / bridge name of egress node in runOvnTraceToIP()")
 	}
 	node := subMatches[len(subMatches)-1]
 	klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-	return string(node), ""
+	return string(node), "", nil
 }
 
 // runOvnTraceToPod runs an ovntrace from src pod to dst pod.
-func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
+func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) error {
 	var inport string
 	inport = srcPodInfo.FullyQualifiedPodName()
 	if srcPodInfo.HostNetwork {
@@ -870,17 +928,17 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand, // 1
-		srcPodInfo.NodeName,  // 2
-		inport,               // 3
-		srcPodInfo.MAC,       // 4
-		srcPodInfo.RtosMAC,   // 5
-		srcPodInfo.IPVer,     // 6
-		srcPodInfo.IP,        // 7
-		dstPodInfo.IPVer,     // 8
-		dstPodInfo.IP,        // 9
-		protocol,             // 10
-		dstPort,              // 11
+		srcPodInfo.SbCommand,                     // 1
+		srcPodInfo.switchName(),                  // 2
+		inport,                                   // 3
+		srcPodInfo.MAC,                           // 4
+		podTrafficDstMAC(srcPodInfo, dstPodInfo), // 5
+		srcPodInfo.IPVer,                         // 6
+		srcPodInfo.IP,                            // 7
+		dstPodInfo.IPVer,                         // 8
+		dstPodInfo.IP,                            // 9
+		protocol,                                 // 10
+		dstPort,                                  // 11
 	)
 	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
 
@@ -897,34 +955,37 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 	} else if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
 		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstPodInfo.NodeName)
+		successString = remotePodOutport(srcPodInfo, dstPodInfo)
 	}
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
+	if err := printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
 }
 
-func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
+func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) error {
 	if dstPodInfo.HostNetwork || !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		return
+		return nil
 	}
+	inport, dstMAC := remotePodInport(srcPodInfo, dstPodInfo)
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s `+
 		`'inport=="%[2]s" && eth.src==%[3]s && eth.dst==%[4]s && %[5]s.src==%[6]s && %[7]s.dst==%[8]s && ip.ttl==64 && %[9]s.dst==%[10]s && %[9]s.src==52888'`,
 		dstPodInfo.SbCommand, // 1
-		types.TransitSwitchToRouterPrefix+srcPodInfo.NodeName, // 2
-		srcPodInfo.MAC,      // 3
-		dstPodInfo.RtotsMAC, // 4
-		srcPodInfo.IPVer,    // 5
-		srcPodInfo.IP,       // 6
-		dstPodInfo.IPVer,    // 7
-		dstPodInfo.IP,       // 8
-		protocol,            // 9
-		dstPort,             // 10
+		inport,               // 2
+		srcPodInfo.MAC,       // 3
+		dstMAC,               // 4
+		srcPodInfo.IPVer,     // 5
+		srcPodInfo.IP,        // 6
+		dstPodInfo.IPVer,     // 7
+		dstPodInfo.IP,        // 8
+		protocol,             // 9
+		dstPort,              // 10
 	)
 	klog.V(4).Infof("ovn-trace command on destination pod node is %s", cmd)
 	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, dstPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	return printSuccessOrFailure("ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
@@ -933,21 +994,21 @@ func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
 }
 
 // runOfprotoTraceToPod runs an ofproto/trace command from the src to the destination pod.
-func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) string {
+func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(dstPodInfo.IP))
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[9]s, dl_src=%[3]s, dl_dst=%[4]s, %[10]s=%[5]s, %[11]s=%[6]s, nw_ttl=64, %[7]s_dst=%[8]s, %[7]s_src=12345"`,
-		srcPodInfo.VethName, // 1
-		protocol,            // 2
-		srcPodInfo.MAC,      // 3
-		srcPodInfo.RtosMAC,  // 4
-		srcPodInfo.IP,       // 5
-		dstPodInfo.IP,       // 6
-		protocol,            // 7
-		dstPort,             // 8
-		protocolSelector,    // 9
-		nwSrc,               // 10
-		nwDst,               // 11
+		srcPodInfo.VethName,                      // 1
+		protocol,                                 // 2
+		srcPodInfo.MAC,                           // 3
+		podTrafficDstMAC(srcPodInfo, dstPodInfo), // 4
+		srcPodInfo.IP,                            // 5
+		dstPodInfo.IP,                            // 6
+		protocol,                                 // 7
+		dstPort,                                  // 8
+		protocolSelector,                         // 9
+		nwSrc,                                    // 10
+		nwDst,                                    // 11
 	)
 	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
 
@@ -967,21 +1028,27 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		} else {
 			successString = fmt.Sprintf(`output:%s\n\nFinal flow:`, srcPodInfo.OvnK8sMp0OfportNum)
 		}
+	} else if srcPodInfo.topology() == types.LocalnetTopology {
+		klog.V(5).Infof("Pods are on node: %s and node %s, connected through the physical network", srcPodInfo.NodeName, dstPodInfo.NodeName)
+		// Traffic leaves br-int through the patch port to the bridge the localnet network is mapped to.
+		successString = `bridge\("`
 	} else {
 		klog.V(5).Infof("Pods are on node: %s and node %s", srcPodInfo.NodeName, dstPodInfo.NodeName)
 		successString = "-> output to kernel tunnel"
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString)
+	if err := printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString); err != nil {
+		return "", err
+	}
 
-	return appSrcDstOut
+	return appSrcDstOut, nil
 }
 
 // runOfprotoTraceToIP runs an ofproto/trace command from the src to the destination pod.
 // egressNodeName is the exit node, as determined by an ovn-trace command that was run earlier.
 // egressBridgeName is the name of the exit bridge (for EgressIPs, EgressGW and also for routingViaOVN mode).
 // If egressBridgeName == "", then this is routingViaHost Gateway mode without an EgressIP / EgressGW.
-func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) string {
+func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, dstIP)
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[8]s, dl_src=%[3]s, dl_dst=%[4]s, %[9]s=%[5]s, %[10]s=%[6]s, nw_ttl=64, %[2]s_dst=%[7]s, %[2]s_src=12345"`,
@@ -1015,9 +1082,11 @@ func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest
 		}
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString)
+	if err := printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString); err != nil {
+		return "", err
+	}
 
-	return appSrcDstOut
+	return appSrcDstOut, nil
 }
 
 // getOfprotoIPFamilyArgs generates the protocol parameter name and the src and dst parameter names.
@@ -1079,10 +1148,9 @@ func verifyDependency(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 	return trueFalse, depVerifyErr, nil
 }
 
-// runOvnDetrace runs an ovn-detrace command for the given input.
-// Returns error if dependencies are not met (allows for graceful handling of those issues).
-func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
-	dstName string, appSrcDstOut, ovnNamespace string) error {
+// checkOvnDetrace returns an error if ovn-detrace can't be run from the ovnkube pod of the given pod, i.e. if the NBDB is
+// not reachable or if its dependencies are not met (allows for graceful handling of those issues).
+func checkOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, ovnNamespace string) error {
 	// If NBDB connectivity is not available do not run ovn-detrace.
 	if _, stdErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, fmt.Sprintf("ovn-nbctl %s get-connection", srcPodInfo.NbCommand), ""); err != nil {
 		return fmt.Errorf("nbdb is not available %q", stdErr)
@@ -1091,6 +1159,12 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	if err := installOvnDetraceDependencies(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
 		return fmt.Errorf("dependencies check failed: %q", err)
 	}
+	return nil
+}
+
+// runOvnDetrace runs an ovn-detrace command for the given input, checkOvnDetrace must succeed first.
+func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
+	dstName string, appSrcDstOut, ovnNamespace string) error {
 
 	cmd := fmt.Sprintf(`ovn-detrace --ovnnb=%[1]s --ovnsb=%[2]s %[3]s --ovsdb=unix:/var/run/openvswitch/db.sock`,
 		srcPodInfo.NbURI,       // 1
@@ -1100,17 +1174,15 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	klog.V(4).Infof("ovn-detrace command from %s is %s", direction, cmd)
 
 	dtraceSrcDstOut, dtraceSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, appSrcDstOut)
-	printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
-
-	return nil
+	return printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
 }
 
 // displayNodeInfo shows a summary about nodes in this cluster.
-func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
+func displayNodeInfo(coreclient *corev1client.CoreV1Client) error {
 	// List all Nodes.
 	nodes, err := coreclient.Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		return fmt.Errorf("unexpected error: %w", err)
 	}
 
 	masters := make(map[string]string)
@@ -1148,6 +1220,7 @@ func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
 	if len(masters) < 3 {
 		klog.V(5).Infof("Cluster does not have 3 masters, found %d", len(masters))
 	}
+	return nil
 }
 
 func getDesiredPodIP(pod *corev1.Pod, addressFamily string) (string, error) {
@@ -1178,6 +1251,134 @@ func setLogLevel(loglevel string) {
 	klog.V(1).Infof("Log level set to: %s", loglevel)
 }
 
+// traceOptions are the source and destination of a trace, as given on the command line.
+type traceOptions struct {
+	srcNamespace   string
+	srcPodName     string
+	dstNamespace   string
+	dstPodName     string
+	dstSvcName     string
+	dstIP          net.IP
+	dstPort        string
+	protocol       string
+	addressFamily  string
+	network        string
+	skipOvnDetrace bool
+}
+
+// runTrace runs the trace commands from the source pod to the destination pod, service or IP address, in that
+// order, and returns an error as soon as one of them fails.
+func runTrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, opts *traceOptions) error {
+	// Get info needed for the src Pod
+	srcPodInfo, err := getPodInfo(coreclient, restconfig, opts.srcPodName, ovnNamespace, opts.srcNamespace, opts.addressFamily, opts.network)
+	if err != nil {
+		return fmt.Errorf("failed to get information from pod %s: %w", opts.srcPodName, err)
+	}
+	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
+	traceResult.Source = newTracePod(srcPodInfo)
+	traceResult.Protocol = opts.protocol
+	traceResult.DstPort = opts.dstPort
+
+	// 1) Either run a trace from source pod to destination IP and return ...
+	if opts.dstIP != nil {
+		klog.V(5).Infof("Running a trace to an IP address")
+		traceResult.DestinationIP = opts.dstIP.String()
+		egressNodeName, egressBridgeName, err := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, opts.dstIP, ovnNamespace, opts.protocol, opts.dstPort)
+		if err != nil {
+			return err
+		}
+		appSrcDstOut, err := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, opts.dstIP, ovnNamespace, opts.protocol, opts.dstPort, egressNodeName, egressBridgeName)
+		if err != nil {
+			return err
+		}
+		if opts.skipOvnDetrace {
+			return nil
+		}
+		if err := checkOvnDetrace(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
+			klog.Infof("Skipped ovn-detrace due to: %q", err)
+			return nil
+		}
+		return runOvnDetrace(coreclient, restconfig, "pod to external IP", srcPodInfo, opts.dstIP.String(), appSrcDstOut, ovnNamespace)
+	}
+
+	// 2) ... or run a trace to destination service / destination pod.
+	// Get destination service information if a destination service name was provided.
+	klog.V(5).Infof("Running a trace to a cluster local svc or to another pod")
+	dstPodName := opts.dstPodName
+	var dstSvcInfo *SvcInfo
+	if opts.dstSvcName != "" {
+		// Get dst service
+		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, opts.dstSvcName, ovnNamespace, opts.dstNamespace, opts.addressFamily, opts.network)
+		if err != nil {
+			return fmt.Errorf("failed to get information from service %s: %w", opts.dstSvcName, err)
+		}
+		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
+		// Set dst pod name, we'll use this to run through pod-pod tests as if use supplied this pod
+		dstPodName = dstSvcInfo.PodInfo.PodName
+		klog.V(1).Infof("Using pod %s in service %s to test against", dstSvcInfo.PodInfo.PodName, opts.dstSvcName)
+	}
+
+	// Now get info needed for the dst Pod
+	dstPodInfo, err := getPodInfo(coreclient, restconfig, dstPodName, ovnNamespace, opts.dstNamespace, opts.addressFamily, opts.network)
+	if err != nil {
+		return fmt.Errorf("failed to get information from pod %s: %w", dstPodName, err)
+	}
+	klog.V(5).Infof("dstPodInfo is %s\n", dstPodInfo)
+	traceResult.Destination = newTracePod(dstPodInfo)
+	traceResult.Service = newTraceSvc(dstSvcInfo)
+
+	// At least one pod must not be on the Host Network
+	if srcPodInfo.HostNetwork && dstPodInfo.HostNetwork {
+		return fmt.Errorf("both pods cannot be on Host Network; use ping")
+	}
+
+	// Both pods must be traced on the same network.
+	if srcNetwork, dstNetwork := srcPodInfo.networkName(), dstPodInfo.networkName(); srcNetwork != dstNetwork {
+		return fmt.Errorf("source pod is on network %s and destination pod is on network %s; tracing across networks is not supported",
+			srcNetwork, dstNetwork)
+	}
+
+	// ovn-trace commands
+	if dstSvcInfo != nil {
+		if err := runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, opts.protocol, opts.dstPort); err != nil {
+			return err
+		}
+	}
+	if err := runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, opts.protocol, opts.dstPort); err != nil {
+		return err
+	}
+	if err := runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, opts.protocol, opts.dstPort); err != nil {
+		return err
+	}
+
+	// ovs-appctl ofproto/trace commands
+	appSrcDstOut, err := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, opts.protocol, opts.dstPort)
+	if err != nil {
+		return err
+	}
+	appDstSrcOut, err := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, opts.protocol, opts.dstPort)
+	if err != nil {
+		return err
+	}
+
+	// ovn-detrace commands below
+	if opts.skipOvnDetrace {
+		return nil
+	}
+	if err := checkOvnDetrace(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
+		klog.Infof("Skipped ovn-detrace due to: %q", err)
+		return nil
+	}
+	if err := runOvnDetrace(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo.PodName, appSrcDstOut, ovnNamespace); err != nil {
+		return err
+	}
+	if err := checkOvnDetrace(coreclient, restconfig, dstPodInfo, ovnNamespace); err != nil {
+		klog.Infof("Skipped ovn-detrace due to: %q", err)
+		return nil
+	}
+	return runOvnDetrace(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo.PodName, appDstSrcOut, ovnNamespace)
+}
+
 func main() {
 	var protocol string
 	var parsedDstIP net.IP
@@ -1197,13 +1398,21 @@ func main() {
 	udp := flag.Bool("udp", false, "use udp transport protocol")
 	addressFamily := flag.String("addr-family", ip4, "Address family (ip4 or ip6) to be used for tracing")
 	skipOvnDetrace := flag.Bool("skip-detrace", false, "skip ovn-detrace command")
+	network := flag.String("network", "", "network to trace on, either the name of a user defined network or of its NAD in the pods' namespace; "+
+		"defaults to the primary user defined network of the pods, if any, or the default network")
 	dumpVRFTableIDs := flag.Bool("dump-udn-vrf-table-ids", false, "Dump the VRF table ID per node for all the user defined networks")
+	flag.StringVar(&outputFormat, "output", outputText, "output format of the trace results: text, json (structured result) or dot (graphviz graph of the logical datapaths traversed)")
 	loglevel := flag.String("loglevel", "0", "loglevel: klog level")
 	flag.Parse()
 
 	// Set the application's log level.
 	setLogLevel(*loglevel)
 
+	// Verify the output format first, the errors below are reported in that format.
+	if outputFormat != outputText && outputFormat != outputJSON && outputFormat != outputDot {
+		klog.Exitf("Usage: -output must be one of %s, %s or %s", outputText, outputJSON, outputDot)
+	}
+
 	// Get the ClientConfig.
 	// This might work better?  https://godoc.org/sigs.k8s.io/controller-runtime/pkg/client/config
 	// When supplied the kubeconfig supplied via cli takes precedence
@@ -1212,7 +1421,7 @@ func main() {
 		// use the current context in kubeconfig
 		restconfig, err = clientcmd.BuildConfigFromFlags("", *cliConfig)
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			exitTraceFailed(fmt.Errorf("unexpected error: %w", err))
 		}
 	} else {
 		// Instantiate loader for kubeconfig file.
@@ -1225,20 +1434,20 @@ func main() {
 		// the client objects we create.
 		restconfig, err = kubeconfig.ClientConfig()
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			exitTraceFailed(fmt.Errorf("unexpected error: %w", err))
 		}
 	}
 
 	// Create a Kubernetes core/v1 client.
 	coreclient, err := corev1client.NewForConfig(restconfig)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		exitTraceFailed(fmt.Errorf("unexpected error: %w", err))
 	}
 
 	// Get the namespace that OVN pods reside in.
 	ovnNamespace, err := getOvnNamespace(coreclient, *cfgNamespace)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		exitTraceFailed(fmt.Errorf("unexpected error: %w", err))
 	}
 
 	klog.V(5).Infof("OVN-Kubernetes namespace is %s", ovnNamespace)
@@ -1290,82 +1499,28 @@ func main() {
 
 	// Show some information about the nodes in this cluster - only if log level 5 or higher.
 	if lvl, err := strconv.Atoi(*loglevel); err == nil && lvl >= 5 {
-		displayNodeInfo(coreclient)
-	}
-
-	// Get info needed for the src Pod
-	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily)
-	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *srcPodName, err)
-	}
-	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
-
-	// 1) Either run a trace from source pod to destination IP and return ...
-	if parsedDstIP != nil {
-		klog.V(5).Infof("Running a trace to an IP address")
-		egressNodeName, egressBridgeName := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
-		appSrcDstOut := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
-		if *skipOvnDetrace {
-			return
-		}
-		err = runOvnDetrace(coreclient, restconfig, "pod to external IP", srcPodInfo, parsedDstIP.String(), appSrcDstOut, ovnNamespace)
-		if err != nil {
-			klog.Infof("Skipped ovn-detrace due to: %q", err)
+		if err := displayNodeInfo(coreclient); err != nil {
+			exitTraceFailed(err)
 		}
-		return
-	}
-
-	// 2) ... or run a trace to destination service / destination pod.
-	// Get destination service information if a destination service name was provided.
-	klog.V(5).Infof("Running a trace to a cluster local svc or to another pod")
-	var dstSvcInfo *SvcInfo
-	if *dstSvcName != "" {
-		// Get dst service
-		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily)
-		if err != nil {
-			klog.Exitf("Failed to get information from service %s: %v", *dstSvcName, err)
-		}
-		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
-		// Set dst pod name, we'll use this to run through pod-pod tests as if use supplied this pod
-		*dstPodName = dstSvcInfo.PodInfo.PodName
-		klog.V(1).Infof("Using pod %s in service %s to test against", dstSvcInfo.PodInfo.PodName, *dstSvcName)
-	}
-
-	// Now get info needed for the dst Pod
-	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily)
-	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *dstPodName, err)
-	}
-	klog.V(5).Infof("dstPodInfo is %s\n", dstPodInfo)
-
-	// At least one pod must not be on the Host Network
-	if srcPodInfo.HostNetwork && dstPodInfo.HostNetwork {
-		klog.Exitf("Both pods cannot be on Host Network; use ping")
-	}
-
-	// ovn-trace commands
-	if dstSvcInfo != nil {
-		runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, protocol, *dstPort)
 	}
-	runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
 
-	// ovs-appctl ofproto/trace commands
-	appSrcDstOut := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	appDstSrcOut := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
-
-	// ovn-detrace commands below
-	if *skipOvnDetrace {
-		return
-	}
-	err = runOvnDetrace(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo.PodName, appSrcDstOut, ovnNamespace)
-	if err != nil {
-		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+	traceErr := runTrace(coreclient, restconfig, ovnNamespace, &traceOptions{
+		srcNamespace:   *srcNamespace,
+		srcPodName:     *srcPodName,
+		dstNamespace:   *dstNamespace,
+		dstPodName:     *dstPodName,
+		dstSvcName:     *dstSvcName,
+		dstIP:          parsedDstIP,
+		dstPort:        *dstPort,
+		protocol:       protocol,
+		addressFamily:  *addressFamily,
+		network:        *network,
+		skipOvnDetrace: *skipOvnDetrace,
+	})
+	if traceErr != nil {
+		exitTraceFailed(traceErr)
 	}
-	err = runOvnDetrace(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo.PodName, appDstSrcOut, ovnNamespace)
-	if err != nil {
-		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+	if err := printTraceResult(); err != nil {
+		klog.Exit(err)
 	}
 }
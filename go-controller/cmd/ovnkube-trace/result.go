@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Output formats.
+	outputText = "text"
+	outputJSON = "json"
+	outputDot  = "dot"
+
+	// Tools run by ovnkube-trace.
+	toolOvnTrace     = "ovn-trace"
+	toolOfprotoTrace = "ofproto-trace"
+	toolOvnDetrace   = "ovn-detrace"
+
+	// Kinds of logical flow hits reported for ovn-trace steps.
+	hitACL = "acl"
+	hitLB  = "lb"
+	hitNAT = "nat"
+
+	// Verdicts.
+	verdictAllow = "allow"
+	verdictDrop  = "drop"
+)
+
+var (
+	// outputFormat is the format used to report the trace results, one of text, json or dot.
+	outputFormat = outputText
+	// traceResult accumulates the result of every trace command when outputFormat is not text.
+	traceResult = &TraceResult{}
+
+	// ingress(dp="ovn-worker", inport="default_client", outport="stor-ovn-worker")
+	ovnTraceDatapathRegex = regexp.MustCompile(`^(ingress|egress)\(dp="([^"]*)"(?:, inport="([^"]*)")?(?:, outport="([^"]*)")?\)`)
+	// 9. ls_in_acl_eval (northd.c:6764): ip4 && tcp.dst == 80, priority 2000, uuid 3eec76bb
+	ovnTraceFlowRegex = regexp.MustCompile(`^\s*(\d+)\. (\S+) \([^)]*\): (.*), priority (\d+), uuid ([0-9a-f]+)$`)
+	// Datapath actions: drop
+	ofprotoDatapathActionsRegex = regexp.MustCompile(`(?m)^Datapath actions: (.*)$`)
+	// ct_lb_mark(backends=10.244.1.3:8080);
+	ovnTraceLBRegex = regexp.MustCompile(`ct_lb(_mark)?\(backends=`)
+	// ct_snat(172.18.0.3); ct_dnat_in_czone(10.244.1.3);
+	ovnTraceNATRegex = regexp.MustCompile(`ct_(s|d)nat(_in_czone)?\(.+\)`)
+)
+
+// TraceResult is the structured result of an ovnkube-trace run.
+type TraceResult struct {
+	Source        *TracePod   `json:"source,omitempty"`
+	Destination   *TracePod   `json:"destination,omitempty"`
+	Service       *TraceSvc   `json:"service,omitempty"`
+	DestinationIP string      `json:"destinationIP,omitempty"`
+	Protocol      string      `json:"protocol,omitempty"`
+	DstPort       string      `json:"dstPort,omitempty"`
+	Steps         []TraceStep `json:"steps"`
+	// Success is true if every trace step succeeded.
+	Success bool `json:"success"`
+	// Reason explains the final verdict, i.e. why the traffic was dropped.
+	Reason string `json:"reason,omitempty"`
+	// Error is the error that stopped the trace, if any, e.g. a pod that could not be found.
+	Error string `json:"error,omitempty"`
+}
+
+// TracePod describes a pod taking part in the trace.
+type TracePod struct {
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	NodeName             string `json:"nodeName"`
+	IP                   string `json:"ip"`
+	MAC                  string `json:"mac,omitempty"`
+	HostNetwork          bool   `json:"hostNetwork,omitempty"`
+	InterConnectZoneName string `json:"interConnectZone,omitempty"`
+	Network              string `json:"network,omitempty"`
+}
+
+// TraceSvc describes the destination service of the trace.
+type TraceSvc struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	ClusterIP string    `json:"clusterIP"`
+	Endpoint  *TracePod `json:"endpoint,omitempty"`
+	PodPort   string    `json:"podPort,omitempty"`
+}
+
+// TraceStep is the result of a single trace command.
+type TraceStep struct {
+	Description string `json:"description"`
+	Tool        string `json:"tool"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	// Expected is the regular expression that the command output must match for the step to succeed.
+	Expected string `json:"expected,omitempty"`
+	Success  bool   `json:"success"`
+	// Verdict is either allow or drop.
+	Verdict string `json:"verdict"`
+	// Reason explains why the step failed.
+	Reason string `json:"reason,omitempty"`
+	// Hops lists, in order, the logical datapath pipelines traversed by an ovn-trace step.
+	Hops []TraceHop `json:"hops,omitempty"`
+	// Hits lists the ACL, load balancer and NAT logical flows hit by an ovn-trace step.
+	Hits []TraceHit `json:"hits,omitempty"`
+	// DatapathActions are the final datapath actions of an ofproto/trace step.
+	DatapathActions string `json:"datapathActions,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// TraceHop is a logical datapath pipeline traversed by the packet.
+type TraceHop struct {
+	Pipeline string `json:"pipeline"`
+	Datapath string `json:"datapath"`
+	Inport   string `json:"inport,omitempty"`
+	Outport  string `json:"outport,omitempty"`
+}
+
+// TraceHit is a logical flow of interest hit by the packet.
+type TraceHit struct {
+	Kind     string   `json:"kind"`
+	Datapath string   `json:"datapath"`
+	Pipeline string   `json:"pipeline"`
+	Table    int      `json:"table"`
+	Stage    string   `json:"stage"`
+	Match    string   `json:"match"`
+	Priority int      `json:"priority"`
+	UUID     string   `json:"uuid"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+// newTracePod summarizes the given PodInfo for the trace result.
+func newTracePod(pi *PodInfo) *TracePod {
+	if pi == nil {
+		return nil
+	}
+	return &TracePod{
+		Name:                 pi.PodName,
+		Namespace:            pi.PodNamespace,
+		NodeName:             pi.NodeName,
+		IP:                   pi.IP,
+		MAC:                  pi.MAC,
+		HostNetwork:          pi.HostNetwork,
+		InterConnectZoneName: pi.InterConnectZoneName,
+		Network:              pi.networkName(),
+	}
+}
+
+// newTraceSvc summarizes the given SvcInfo for the trace result.
+func newTraceSvc(si *SvcInfo) *TraceSvc {
+	if si == nil {
+		return nil
+	}
+	return &TraceSvc{
+		Name:      si.SvcName,
+		Namespace: si.SvcNamespace,
+		ClusterIP: si.ClusterIP,
+		Endpoint:  newTracePod(si.PodInfo),
+		PodPort:   si.PodPort,
+	}
+}
+
+// getTraceTool returns the tool that ran the command with the given description.
+func getTraceTool(commandDescription string) string {
+	switch {
+	case strings.HasPrefix(commandDescription, "ovs-appctl ofproto/trace"):
+		return toolOfprotoTrace
+	case strings.HasPrefix(commandDescription, "ovn-detrace"):
+		return toolOvnDetrace
+	default:
+		return toolOvnTrace
+	}
+}
+
+// newTraceStep builds the TraceStep of a command out of its output.
+func newTraceStep(commandDescription, src, dst, commandStdout, searchString string, success bool) TraceStep {
+	step := TraceStep{
+		Description: commandDescription,
+		Tool:        getTraceTool(commandDescription),
+		Source:      src,
+		Destination: dst,
+		Expected:    searchString,
+		Success:     success,
+		Verdict:     verdictAllow,
+	}
+	switch step.Tool {
+	case toolOvnTrace:
+		step.Hops, step.Hits = parseOvnTrace(commandStdout)
+	case toolOfprotoTrace:
+		if subMatches := ofprotoDatapathActionsRegex.FindStringSubmatch(commandStdout); len(subMatches) > 1 {
+			step.DatapathActions = strings.TrimSpace(subMatches[1])
+		}
+	}
+	if !success {
+		step.Verdict = verdictDrop
+		step.Reason = getTraceStepFailureReason(&step)
+	}
+	return step
+}
+
+// parseOvnTrace parses the detailed output of ovn-trace and returns the datapath pipelines traversed
+// by the packet as well as the ACL, load balancer and NAT logical flows it hit.
+func parseOvnTrace(output string) ([]TraceHop, []TraceHit) {
+	var hops []TraceHop
+	var hits []TraceHit
+	var hop *TraceHop
+	var flow *TraceHit
+
+	// addFlow keeps the last parsed flow if it is of interest.
+	addFlow := func() {
+		if flow == nil {
+			return
+		}
+		if kind := getTraceHitKind(flow.Stage, flow.Actions); kind != "" {
+			flow.Kind = kind
+			hits = append(hits, *flow)
+		}
+		flow = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if subMatches := ovnTraceDatapathRegex.FindStringSubmatch(line); subMatches != nil {
+			addFlow()
+			hops = append(hops, TraceHop{
+				Pipeline: subMatches[1],
+				Datapath: subMatches[2],
+				Inport:   subMatches[3],
+				Outport:  subMatches[4],
+			})
+			hop = &hops[len(hops)-1]
+			continue
+		}
+		if subMatches := ovnTraceFlowRegex.FindStringSubmatch(line); subMatches != nil {
+			addFlow()
+			if hop == nil {
+				continue
+			}
+			table, _ := strconv.Atoi(subMatches[1])
+			priority, _ := strconv.Atoi(subMatches[4])
+			flow = &TraceHit{
+				Datapath: hop.Datapath,
+				Pipeline: hop.Pipeline,
+				Table:    table,
+				Stage:    subMatches[2],
+				Match:    subMatches[3],
+				Priority: priority,
+				UUID:     subMatches[5],
+			}
+			continue
+		}
+		// The actions of a flow are the indented lines that follow it.
+		if flow != nil {
+			if action := strings.TrimSpace(line); action != "" && strings.HasPrefix(line, " ") {
+				flow.Actions = append(flow.Actions, action)
+			} else {
+				addFlow()
+			}
+		}
+	}
+	addFlow()
+
+	return hops, hits
+}
+
+// getTraceHitKind returns the kind of a logical flow, or "" if the flow is not of interest.
+func getTraceHitKind(stage string, actions []string) string {
+	for _, action := range actions {
+		if ovnTraceLBRegex.MatchString(action) {
+			return hitLB
+		}
+		if ovnTraceNATRegex.MatchString(action) {
+			return hitNAT
+		}
+	}
+	// Only ACL evaluation stages carry the ACLs configured in the NB database,
+	// skip the hint, action and pre-ACL stages.
+	if strings.Contains(stage, "acl_eval") || (strings.HasSuffix(stage, "_acl") && !strings.HasSuffix(stage, "_pre_acl")) {
+		return hitACL
+	}
+	return ""
+}
+
+// getTraceStepFailureReason returns a human readable reason for the failure of a step.
+func getTraceStepFailureReason(step *TraceStep) string {
+	if step.Error != "" {
+		return step.Error
+	}
+	// Look for the last flow that dropped or rejected the packet.
+	for i := len(step.Hits) - 1; i >= 0; i-- {
+		hit := step.Hits[i]
+		for _, action := range hit.Actions {
+			if strings.HasPrefix(action, "drop") || strings.HasPrefix(action, "reject") {
+				return fmt.Sprintf("%s %s in %s pipeline of %s (priority %d, uuid %s): %s",
+					hit.Kind, action, hit.Pipeline, hit.Datapath, hit.Priority, hit.UUID, hit.Match)
+			}
+		}
+	}
+	if step.DatapathActions == verdictDrop {
+		return "datapath actions: drop"
+	}
+	if step.Expected != "" {
+		return fmt.Sprintf("output did not match %q", step.Expected)
+	}
+	return "trace failed"
+}
+
+// recordTraceStep adds a step to the trace result.
+func recordTraceStep(step TraceStep) {
+	traceResult.Steps = append(traceResult.Steps, step)
+}
+
+// printTraceResult prints the trace result in the configured output format to stdout and
+// returns an error if it could not be printed or if any trace step failed. It is a no-op
+// for the text output format, as every step is then printed as it completes.
+func printTraceResult() error {
+	if outputFormat == outputText {
+		return nil
+	}
+	if err := writeTraceResult(os.Stdout, traceResult, outputFormat); err != nil {
+		return err
+	}
+	if !traceResult.Success {
+		return fmt.Errorf("trace failed: %s", traceResult.Reason)
+	}
+	return nil
+}
+
+// exitTraceFailed reports err as the error that stopped the trace and exits with a non-zero
+// exit code. Unless the output format is text, the failed trace result is printed first, so
+// that consumers of the structured result get one as well.
+func exitTraceFailed(err error) {
+	if outputFormat != outputText {
+		traceResult.Error = err.Error()
+		if writeErr := writeTraceResult(os.Stdout, traceResult, outputFormat); writeErr != nil {
+			klog.Error(writeErr)
+		}
+	}
+	klog.Exit(err)
+}
+
+// writeTraceResult sets the final verdict of the trace result, from the first failed step or
+// else from the error that stopped the trace, and writes it to w in the given output format,
+// either json or dot.
+func writeTraceResult(w io.Writer, result *TraceResult, format string) error {
+	result.Success = true
+	result.Reason = ""
+	for _, step := range result.Steps {
+		if !step.Success {
+			result.Success = false
+			result.Reason = fmt.Sprintf("%s: %s", step.Description, step.Reason)
+			break
+		}
+	}
+	if result.Success && result.Error != "" {
+		result.Success = false
+		result.Reason = result.Error
+	}
+
+	switch format {
+	case outputJSON:
+		b, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal trace result: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, string(b)); err != nil {
+			return fmt.Errorf("failed to write trace result: %w", err)
+		}
+	case outputDot:
+		if _, err := fmt.Fprint(w, traceResultToDot(result)); err != nil {
+			return fmt.Errorf("failed to write trace result: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	return nil
+}
+
+// traceResultToDot renders the datapath hops of every ovn-trace step as a graphviz digraph,
+// one cluster per step, with the ACL, load balancer and NAT hits of every hop as node labels.
+func traceResultToDot(result *TraceResult) string {
+	var sb strings.Builder
+	sb.WriteString("digraph ovnkube_trace {\n")
+	sb.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+	for i, step := range result.Steps {
+		if len(step.Hops) == 0 {
+			continue
+		}
+		color := "green"
+		if !step.Success {
+			color = "red"
+		}
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&sb, "    label=%q;\n    color=%s;\n", step.Description, color)
+		for j, hop := range step.Hops {
+			label := fmt.Sprintf("%s\\n%s", hop.Datapath, hop.Pipeline)
+			for _, hit := range step.Hits {
+				if hit.Datapath == hop.Datapath && hit.Pipeline == hop.Pipeline {
+					label += fmt.Sprintf("\\n%s %s (%d)", hit.Kind, hit.Stage, hit.Priority)
+				}
+			}
+			fmt.Fprintf(&sb, "    s%d_h%d [label=\"%s\"];\n", i, j, strings.ReplaceAll(label, `"`, `\"`))
+			if j > 0 {
+				port := hop.Inport
+				if hop.Pipeline == "egress" {
+					port = hop.Outport
+				}
+				fmt.Fprintf(&sb, "    s%d_h%d -> s%d_h%d [label=%q];\n", i, j-1, i, j, port)
+			}
+		}
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
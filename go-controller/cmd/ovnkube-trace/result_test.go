@@ -0,0 +1,473 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// ovnTraceToServiceOutput is the output of ovn-trace --detailed for a pod reaching a service backed by a pod
+// on another node, trimmed to the stages of interest.
+const ovnTraceToServiceOutput = `# tcp,reg14=0x3,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:03,dl_dst=0a:58:0a:f4:01:01,nw_src=10.244.1.3,nw_dst=10.96.0.10,nw_tos=0,nw_ecn=0,nw_ttl=64,nw_frag=no,tp_src=52888,tp_dst=80,tcp_flags=0
+
+ingress(dp="ovn-worker", inport="default_client")
+-------------------------------------------------
+ 0. ls_in_check_port_sec (northd.c:8691): 1, priority 50, uuid 6ba83f5f
+    reg0[15] = check_in_port_sec();
+    next;
+ 4. ls_in_pre_acl (northd.c:5997): ip, priority 100, uuid 2a7bd14c
+    reg0[0] = 1;
+    next;
+ 8. ls_in_acl_hint (northd.c:6153): ct.new && !ct.est, priority 7, uuid 7c1d4e2a
+    reg0[7] = 1;
+    next;
+ 9. ls_in_acl_eval (northd.c:6764): ip4 && tcp.dst == 80, priority 2000, uuid 3eec76bb
+    reg8[16] = 1;
+    next;
+ 13. ls_in_lb (northd.c:7373): ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80, priority 120, uuid 1d2e3f4a
+    reg0[1] = 0;
+    ct_lb_mark(backends=10.244.2.3:8080);
+
+ct_lb_mark /* default (use --ct to customize) */
+------------------------------------------------
+ 14. ls_in_after_lb (northd.c:7445): 1, priority 0, uuid 4f5e6d7c
+    next;
+ 27. ls_in_l2_lkup (northd.c:9390): eth.dst == 0a:58:0a:f4:01:01, priority 50, uuid 8a9b0c1d
+    outport = "stor-ovn-worker";
+    output;
+
+egress(dp="ovn-worker", inport="default_client", outport="stor-ovn-worker")
+---------------------------------------------------------------------------
+ 0. ls_out_pre_acl (northd.c:5925): ip && outport == "stor-ovn-worker", priority 110, uuid 2e3f4a5b
+    next;
+ 9. ls_out_check_port_sec (northd.c:5861): 1, priority 0, uuid 9d8c7b6a
+    reg0[15] = check_out_port_sec();
+    next;
+
+ingress(dp="ovn_cluster_router", inport="rtos-ovn-worker")
+----------------------------------------------------------
+ 0. lr_in_admission (northd.c:11855): eth.dst == 0a:58:0a:f4:01:01 && inport == "rtos-ovn-worker", priority 50, uuid 5c6d7e8f
+    xreg0[0..47] = 0a:58:0a:f4:01:01;
+    next;
+ 7. lr_in_dnat (northd.c:10879): ct.est && ip4.dst == 10.244.2.3, priority 110, uuid 0e1f2a3b
+    ct_dnat_in_czone(10.244.2.3);
+`
+
+// ovnTraceDroppedOutput is the output of ovn-trace --detailed for a packet dropped by a network policy.
+const ovnTraceDroppedOutput = `# tcp,reg14=0x4,vlan_tci=0x0000,dl_src=0a:58:0a:f4:02:03,dl_dst=0a:58:0a:f4:02:04,nw_src=10.244.2.3,nw_dst=10.244.2.4,nw_tos=0,nw_ecn=0,nw_ttl=64,nw_frag=no,tp_src=52888,tp_dst=8080,tcp_flags=0
+
+ingress(dp="ovn-worker2", inport="default_server")
+-------------------------------------------------
+ 27. ls_in_l2_lkup (northd.c:9390): eth.dst == 0a:58:0a:f4:02:04, priority 50, uuid 8a9b0c1d
+    outport = "default_client";
+    output;
+
+egress(dp="ovn-worker2", inport="default_server", outport="default_client")
+--------------------------------------------------------------------------
+ 4. ls_out_acl_eval (northd.c:6800): ip4.dst == 10.244.2.4 && outport == @a12345, priority 1000, uuid 5a6b7c8d
+    drop;
+`
+
+func TestParseOvnTrace(t *testing.T) {
+	tests := []struct {
+		desc         string
+		output       string
+		expectedHops []TraceHop
+		expectedHits []TraceHit
+	}{
+		{
+			desc:   "trace to a service",
+			output: ovnTraceToServiceOutput,
+			expectedHops: []TraceHop{
+				{Pipeline: "ingress", Datapath: "ovn-worker", Inport: "default_client"},
+				{Pipeline: "egress", Datapath: "ovn-worker", Inport: "default_client", Outport: "stor-ovn-worker"},
+				{Pipeline: "ingress", Datapath: "ovn_cluster_router", Inport: "rtos-ovn-worker"},
+			},
+			expectedHits: []TraceHit{
+				{
+					Kind:     hitACL,
+					Datapath: "ovn-worker",
+					Pipeline: "ingress",
+					Table:    9,
+					Stage:    "ls_in_acl_eval",
+					Match:    "ip4 && tcp.dst == 80",
+					Priority: 2000,
+					UUID:     "3eec76bb",
+					Actions:  []string{"reg8[16] = 1;", "next;"},
+				},
+				{
+					Kind:     hitLB,
+					Datapath: "ovn-worker",
+					Pipeline: "ingress",
+					Table:    13,
+					Stage:    "ls_in_lb",
+					Match:    "ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80",
+					Priority: 120,
+					UUID:     "1d2e3f4a",
+					Actions:  []string{"reg0[1] = 0;", "ct_lb_mark(backends=10.244.2.3:8080);"},
+				},
+				{
+					Kind:     hitNAT,
+					Datapath: "ovn_cluster_router",
+					Pipeline: "ingress",
+					Table:    7,
+					Stage:    "lr_in_dnat",
+					Match:    "ct.est && ip4.dst == 10.244.2.3",
+					Priority: 110,
+					UUID:     "0e1f2a3b",
+					Actions:  []string{"ct_dnat_in_czone(10.244.2.3);"},
+				},
+			},
+		},
+		{
+			desc:   "trace dropped by an ACL",
+			output: ovnTraceDroppedOutput,
+			expectedHops: []TraceHop{
+				{Pipeline: "ingress", Datapath: "ovn-worker2", Inport: "default_server"},
+				{Pipeline: "egress", Datapath: "ovn-worker2", Inport: "default_server", Outport: "default_client"},
+			},
+			expectedHits: []TraceHit{
+				{
+					Kind:     hitACL,
+					Datapath: "ovn-worker2",
+					Pipeline: "egress",
+					Table:    4,
+					Stage:    "ls_out_acl_eval",
+					Match:    "ip4.dst == 10.244.2.4 && outport == @a12345",
+					Priority: 1000,
+					UUID:     "5a6b7c8d",
+					Actions:  []string{"drop;"},
+				},
+			},
+		},
+		{
+			desc:   "flows before the first datapath are ignored",
+			output: " 9. ls_in_acl_eval (northd.c:6764): ip4, priority 2000, uuid 3eec76bb\n    drop;\n",
+		},
+		{
+			desc: "empty output",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			hops, hits := parseOvnTrace(tc.output)
+			g.Expect(hops).To(gomega.Equal(tc.expectedHops))
+			g.Expect(hits).To(gomega.Equal(tc.expectedHits))
+		})
+	}
+}
+
+func TestGetTraceHitKind(t *testing.T) {
+	tests := []struct {
+		desc         string
+		stage        string
+		actions      []string
+		expectedKind string
+	}{
+		{
+			desc:         "load balancer",
+			stage:        "ls_in_lb",
+			actions:      []string{"reg0[1] = 0;", "ct_lb_mark(backends=10.244.2.3:8080);"},
+			expectedKind: hitLB,
+		},
+		{
+			desc:         "legacy load balancer action",
+			stage:        "lr_in_dnat",
+			actions:      []string{"ct_lb(backends=10.244.2.3:8080);"},
+			expectedKind: hitLB,
+		},
+		{
+			desc:         "SNAT",
+			stage:        "lr_out_snat",
+			actions:      []string{"ct_snat(172.18.0.3);"},
+			expectedKind: hitNAT,
+		},
+		{
+			desc:         "DNAT in the zone",
+			stage:        "lr_in_dnat",
+			actions:      []string{"ct_dnat_in_czone(10.244.2.3);"},
+			expectedKind: hitNAT,
+		},
+		{
+			desc:         "ACL evaluation stage",
+			stage:        "ls_out_acl_eval",
+			actions:      []string{"drop;"},
+			expectedKind: hitACL,
+		},
+		{
+			desc:         "legacy ACL stage",
+			stage:        "ls_in_acl",
+			actions:      []string{"next;"},
+			expectedKind: hitACL,
+		},
+		{
+			desc:    "pre-ACL stage",
+			stage:   "ls_in_pre_acl",
+			actions: []string{"reg0[0] = 1;", "next;"},
+		},
+		{
+			desc:    "ACL hint stage",
+			stage:   "ls_in_acl_hint",
+			actions: []string{"reg0[7] = 1;", "next;"},
+		},
+		{
+			desc:    "ACL action stage",
+			stage:   "ls_in_acl_action",
+			actions: []string{"next;"},
+		},
+		{
+			desc:    "stage of no interest",
+			stage:   "ls_in_l2_lkup",
+			actions: []string{`outport = "stor-ovn-worker";`, "output;"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			g.Expect(getTraceHitKind(tc.stage, tc.actions)).To(gomega.Equal(tc.expectedKind))
+		})
+	}
+}
+
+func TestTraceResultToDot(t *testing.T) {
+	serviceHops, serviceHits := parseOvnTrace(ovnTraceToServiceOutput)
+	droppedHops, droppedHits := parseOvnTrace(ovnTraceDroppedOutput)
+
+	tests := []struct {
+		desc        string
+		result      *TraceResult
+		expectedDot string
+	}{
+		{
+			desc:   "no steps",
+			result: &TraceResult{},
+			expectedDot: `digraph ovnkube_trace {
+  rankdir=LR;
+  node [shape=box];
+}
+`,
+		},
+		{
+			desc: "steps without hops are skipped",
+			result: &TraceResult{
+				Steps: []TraceStep{
+					{Description: `ovs-appctl ofproto/trace source pod to destination pod`, Tool: toolOfprotoTrace, Success: true},
+				},
+			},
+			expectedDot: `digraph ovnkube_trace {
+  rankdir=LR;
+  node [shape=box];
+}
+`,
+		},
+		{
+			desc: "successful and failed ovn-trace steps",
+			result: &TraceResult{
+				Steps: []TraceStep{
+					{Description: `ovn-trace source pod to service clusterIP`, Tool: toolOvnTrace, Success: true, Hops: serviceHops, Hits: serviceHits},
+					{Description: `ovs-appctl ofproto/trace source pod to service clusterIP`, Tool: toolOfprotoTrace, Success: true},
+					{Description: `ovn-trace "server" to "client"`, Tool: toolOvnTrace, Success: false, Hops: droppedHops, Hits: droppedHits},
+				},
+			},
+			expectedDot: `digraph ovnkube_trace {
+  rankdir=LR;
+  node [shape=box];
+  subgraph cluster_0 {
+    label="ovn-trace source pod to service clusterIP";
+    color=green;
+    s0_h0 [label="ovn-worker\ningress\nacl ls_in_acl_eval (2000)\nlb ls_in_lb (120)"];
+    s0_h1 [label="ovn-worker\negress"];
+    s0_h0 -> s0_h1 [label="stor-ovn-worker"];
+    s0_h2 [label="ovn_cluster_router\ningress\nnat lr_in_dnat (110)"];
+    s0_h1 -> s0_h2 [label="rtos-ovn-worker"];
+  }
+  subgraph cluster_2 {
+    label="ovn-trace \"server\" to \"client\"";
+    color=red;
+    s2_h0 [label="ovn-worker2\ningress"];
+    s2_h1 [label="ovn-worker2\negress\nacl ls_out_acl_eval (1000)"];
+    s2_h0 -> s2_h1 [label="default_client"];
+  }
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			g.Expect(traceResultToDot(tc.result)).To(gomega.Equal(tc.expectedDot))
+		})
+	}
+}
+
+func TestWriteTraceResult(t *testing.T) {
+	serviceHops, serviceHits := parseOvnTrace(ovnTraceToServiceOutput)
+	successfulStep := TraceStep{
+		Description: "ovn-trace source pod to destination pod",
+		Tool:        toolOvnTrace,
+		Success:     true,
+		Verdict:     verdictAllow,
+		Hops:        serviceHops,
+		Hits:        serviceHits,
+	}
+	failedStep := newTraceStep("ovn-trace destination pod to source pod", "server", "client", ovnTraceDroppedOutput, `output to "default_client"`, false)
+
+	tests := []struct {
+		desc            string
+		steps           []TraceStep
+		traceError      string
+		format          string
+		expectedSuccess bool
+		expectedReason  string
+		expectError     bool
+	}{
+		{
+			desc:            "successful trace in json",
+			steps:           []TraceStep{successfulStep},
+			format:          outputJSON,
+			expectedSuccess: true,
+		},
+		{
+			desc:           "failed trace in json reports the first failed step",
+			steps:          []TraceStep{successfulStep, failedStep},
+			format:         outputJSON,
+			expectedReason: "ovn-trace destination pod to source pod: acl drop; in egress pipeline of ovn-worker2 (priority 1000, uuid 5a6b7c8d): ip4.dst == 10.244.2.4 && outport == @a12345",
+		},
+		{
+			desc:           "trace stopped by an error in json",
+			steps:          []TraceStep{successfulStep},
+			traceError:     "failed to get information from pod server: pods \"server\" not found",
+			format:         outputJSON,
+			expectedReason: "failed to get information from pod server: pods \"server\" not found",
+		},
+		{
+			desc:           "failed step takes precedence over the error that stopped the trace",
+			steps:          []TraceStep{successfulStep, failedStep},
+			traceError:     "ovn-trace destination pod to source pod indicates failure from server to client",
+			format:         outputJSON,
+			expectedReason: "ovn-trace destination pod to source pod: acl drop; in egress pipeline of ovn-worker2 (priority 1000, uuid 5a6b7c8d): ip4.dst == 10.244.2.4 && outport == @a12345",
+		},
+		{
+			desc:            "successful trace in dot",
+			steps:           []TraceStep{successfulStep},
+			format:          outputDot,
+			expectedSuccess: true,
+		},
+		{
+			desc:        "unsupported output format",
+			steps:       []TraceStep{successfulStep},
+			format:      outputText,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			result := &TraceResult{Steps: tc.steps, Error: tc.traceError}
+			var buf bytes.Buffer
+			err := writeTraceResult(&buf, result, tc.format)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Success).To(gomega.Equal(tc.expectedSuccess))
+			g.Expect(result.Reason).To(gomega.Equal(tc.expectedReason))
+			switch tc.format {
+			case outputJSON:
+				written := &TraceResult{}
+				g.Expect(json.Unmarshal(buf.Bytes(), written)).To(gomega.Succeed())
+				g.Expect(written).To(gomega.Equal(result))
+			case outputDot:
+				g.Expect(buf.String()).To(gomega.Equal(traceResultToDot(result)))
+			}
+		})
+	}
+}
+
+func TestPrintSuccessOrFailure(t *testing.T) {
+	tests := []struct {
+		desc            string
+		format          string
+		stdout          string
+		err             error
+		searchString    string
+		expectError     bool
+		expectedSuccess bool
+	}{
+		{
+			desc:            "matching output",
+			format:          outputJSON,
+			stdout:          ovnTraceToServiceOutput,
+			searchString:    `outport = "stor-ovn-worker"`,
+			expectedSuccess: true,
+		},
+		{
+			desc:         "output not matching",
+			format:       outputJSON,
+			stdout:       ovnTraceDroppedOutput,
+			searchString: `output to "default_client"`,
+			expectError:  true,
+		},
+		{
+			desc:         "command failure",
+			format:       outputJSON,
+			err:          fmt.Errorf("command terminated with exit code 1"),
+			searchString: `output to "default_server"`,
+			expectError:  true,
+		},
+		{
+			desc:         "output not matching in text",
+			format:       outputText,
+			stdout:       ovnTraceDroppedOutput,
+			searchString: `output to "default_client"`,
+			expectError:  true,
+		},
+		{
+			desc:         "invalid search string",
+			format:       outputText,
+			stdout:       ovnTraceToServiceOutput,
+			searchString: `output to "(`,
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			savedOutputFormat, savedTraceResult := outputFormat, traceResult
+			defer func() {
+				outputFormat, traceResult = savedOutputFormat, savedTraceResult
+			}()
+			outputFormat = tc.format
+			traceResult = &TraceResult{}
+
+			err := printSuccessOrFailure("ovn-trace source pod to destination pod", "client", "server", tc.stdout, "", tc.err, tc.searchString)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			if tc.format == outputText {
+				g.Expect(traceResult.Steps).To(gomega.BeEmpty())
+				return
+			}
+			// the step is recorded for the trace result, failed or not
+			g.Expect(traceResult.Steps).To(gomega.HaveLen(1))
+			g.Expect(traceResult.Steps[0].Success).To(gomega.Equal(tc.expectedSuccess))
+		})
+	}
+}
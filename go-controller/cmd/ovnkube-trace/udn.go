@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"strconv"
 
+	nadclient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/typed/k8s.cni.cncf.io/v1"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
 	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -89,3 +92,214 @@ func findUDNNetworks(node *corev1.Node) (map[string]string, error) {
 	delete(networks, types.DefaultNetworkName)
 	return networks, nil
 }
+
+// UDNInfo contains information about the user defined network a pod is traced on.
+type UDNInfo struct {
+	NetworkName string // name of the network, e.g. cluster_udn_blue or ns1_blue
+	NADName     string // <namespace>/<name> of the NAD attaching the pod to the network
+	Topology    string // layer3, layer2 or localnet
+	NetworkID   string // network ID as found in the k8s.ovn.org/network-ids node annotation
+	SwitchName  string // network scoped logical switch the pod is attached to
+	netInfo     util.NetInfo
+}
+
+// getPodUDNInfo returns the user defined network that the pod must be traced on, or nil if the pod
+// must be traced on the default network.
+// If network is empty, the pod's primary user defined network is used, if it has one. Otherwise, network may
+// either be the name of the network or the name of the NAD in the pod's namespace.
+func getPodUDNInfo(restconfig *rest.Config, pod *corev1.Pod, network string) (*UDNInfo, error) {
+	client, err := nadclient.NewForConfig(restconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network attachment definition client: %w", err)
+	}
+	return findPodUDNInfo(client, pod, network)
+}
+
+// findPodUDNInfo looks up the user defined network that the pod must be traced on in the NADs of the pod's
+// namespace, see getPodUDNInfo.
+func findPodUDNInfo(client nadclient.NetworkAttachmentDefinitionsGetter, pod *corev1.Pod, network string) (*UDNInfo, error) {
+	if network == types.DefaultNetworkName {
+		return nil, nil
+	}
+	podNetworks, err := util.UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the pod networks annotation of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	nads, err := client.NetworkAttachmentDefinitions(pod.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network attachment definitions in namespace %s: %w", pod.Namespace, err)
+	}
+	for i := range nads.Items {
+		nad := &nads.Items[i]
+		nadName := util.GetNADName(nad.Namespace, nad.Name)
+		podNetwork, ok := podNetworks[nadName]
+		if !ok {
+			continue
+		}
+		if network == "" && podNetwork.Role != types.NetworkRolePrimary {
+			continue
+		}
+		netInfo, err := util.ParseNADInfo(nad)
+		if err != nil {
+			klog.V(5).Infof("Skipping network attachment definition %s: %v", nadName, err)
+			continue
+		}
+		if network != "" && network != netInfo.GetNetworkName() && network != nad.Name && network != nadName {
+			continue
+		}
+		klog.V(5).Infof("Pod %s/%s is traced on network %s (NAD %s, topology %s)", pod.Namespace, pod.Name,
+			netInfo.GetNetworkName(), nadName, netInfo.TopologyType())
+		return &UDNInfo{
+			NetworkName: netInfo.GetNetworkName(),
+			NADName:     nadName,
+			Topology:    netInfo.TopologyType(),
+			netInfo:     netInfo,
+		}, nil
+	}
+	if network != "" {
+		return nil, fmt.Errorf("pod %s/%s is not attached to network %s", pod.Namespace, pod.Name, network)
+	}
+	return nil, nil
+}
+
+// setPodUDNInfo fills in the network specific information of a pod attached to a user defined network:
+// the pod's IP and MAC address on that network and the names of the network scoped entities on the pod's node.
+func setPodUDNInfo(podInfo *PodInfo, pod *corev1.Pod, node *corev1.Node) error {
+	udnInfo := podInfo.UDN
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, udnInfo.NADName)
+	if err != nil {
+		return fmt.Errorf("failed to get the pod annotation of pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, udnInfo.NADName, err)
+	}
+	podInfo.IP = ""
+	for _, ip := range podAnnotation.IPs {
+		if getIPVer(ip.IP) == podInfo.IPVer {
+			podInfo.IP = ip.IP.String()
+			break
+		}
+	}
+	if podInfo.IP == "" {
+		return fmt.Errorf("could not find desired pod ip address for the given address family on network %s", udnInfo.NetworkName)
+	}
+	podInfo.MAC = podAnnotation.MAC.String()
+	udnInfo.SwitchName = udnInfo.netInfo.GetNetworkScopedSwitchName(podInfo.NodeName)
+	if udnInfo.Topology == types.LocalnetTopology {
+		udnInfo.SwitchName = udnInfo.netInfo.GetNetworkScopedName(types.OVNLocalnetSwitch)
+		return nil
+	}
+	networks, err := findUDNNetworks(node)
+	if err != nil {
+		return err
+	}
+	udnInfo.NetworkID = networks[udnInfo.NetworkName]
+	if udnInfo.NetworkID == "" {
+		return fmt.Errorf("could not find network ID of network %s on node %s", udnInfo.NetworkName, node.Name)
+	}
+	return nil
+}
+
+// networkScopedName returns the name of the OVN entity on the network the pod is traced on.
+func (pi *PodInfo) networkScopedName(name string) string {
+	if pi.UDN == nil {
+		return name
+	}
+	return pi.UDN.netInfo.GetNetworkScopedName(name)
+}
+
+// topology returns the topology of the network the pod is traced on.
+func (pi *PodInfo) topology() string {
+	if pi.UDN == nil {
+		return types.Layer3Topology
+	}
+	return pi.UDN.Topology
+}
+
+// switchName returns the name of the logical switch the pod is attached to, which is the datapath that
+// ovn-trace starts from.
+func (pi *PodInfo) switchName() string {
+	if pi.UDN == nil {
+		return pi.NodeName
+	}
+	return pi.UDN.SwitchName
+}
+
+// routerToSwitchPortName returns the name of the pod's first hop router port, or "" if there is none.
+func (pi *PodInfo) routerToSwitchPortName() string {
+	switch pi.topology() {
+	case types.Layer2Topology:
+		return types.TransitRouterToSwitchPrefix + pi.switchName()
+	case types.LocalnetTopology:
+		return ""
+	default:
+		return types.RouterToSwitchPrefix + pi.switchName()
+	}
+}
+
+// routerToTransitSwitchPortName returns the name of the pod's cluster router port to the transit switch, or
+// "" if the network has no transit switch.
+func (pi *PodInfo) routerToTransitSwitchPortName() string {
+	if pi.topology() != types.Layer3Topology {
+		return ""
+	}
+	return pi.networkScopedName(types.RouterToTransitSwitchPrefix + pi.NodeName)
+}
+
+// mgmtPortName returns the name of the OVS interface of the node's management port on the network
+// the pod is traced on, or "" if there is none.
+func (pi *PodInfo) mgmtPortName() string {
+	if pi.UDN == nil {
+		return types.K8sMgmtIntfName
+	}
+	if pi.UDN.Topology == types.LocalnetTopology {
+		return ""
+	}
+	networkID, _ := strconv.Atoi(pi.UDN.NetworkID)
+	return util.GetNetworkScopedK8sMgmtHostIntfName(uint(networkID))
+}
+
+// isL2Adjacent returns true if pods on the network reach each other without going through a router.
+func (pi *PodInfo) isL2Adjacent() bool {
+	return pi.topology() == types.Layer2Topology || pi.topology() == types.LocalnetTopology
+}
+
+// podTrafficDstMAC returns the destination MAC address of traffic sent from the src pod to the dst pod.
+func podTrafficDstMAC(srcPodInfo, dstPodInfo *PodInfo) string {
+	if srcPodInfo.isL2Adjacent() && !dstPodInfo.HostNetwork {
+		return dstPodInfo.MAC
+	}
+	return srcPodInfo.RtosMAC
+}
+
+// remotePodOutport returns a regular expression matching the logical port that the ovn-trace of traffic from the
+// src pod to a dst pod in another zone leaves the src zone through.
+func remotePodOutport(srcPodInfo, dstPodInfo *PodInfo) string {
+	switch srcPodInfo.topology() {
+	case types.Layer2Topology:
+		// Remote pods have a remote port on the network's switch in every zone.
+		return fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+	case types.LocalnetTopology:
+		return fmt.Sprintf(`output to "%s", type "localnet"`, srcPodInfo.networkScopedName(types.OVNLocalnetPort))
+	default:
+		return fmt.Sprintf(`output to "%s"`, srcPodInfo.networkScopedName(types.TransitSwitchToRouterPrefix+dstPodInfo.NodeName))
+	}
+}
+
+// remotePodInport returns the logical port and destination MAC address of traffic from the src pod entering the
+// zone of a dst pod in another zone.
+func remotePodInport(srcPodInfo, dstPodInfo *PodInfo) (string, string) {
+	switch srcPodInfo.topology() {
+	case types.Layer2Topology:
+		return srcPodInfo.FullyQualifiedPodName(), dstPodInfo.MAC
+	case types.LocalnetTopology:
+		return srcPodInfo.networkScopedName(types.OVNLocalnetPort), dstPodInfo.MAC
+	default:
+		return srcPodInfo.networkScopedName(types.TransitSwitchToRouterPrefix + srcPodInfo.NodeName), dstPodInfo.RtotsMAC
+	}
+}
+
+// networkName returns the name of the network the pod is traced on.
+func (pi *PodInfo) networkName() string {
+	if pi.UDN == nil {
+		return types.DefaultNetworkName
+	}
+	return pi.UDN.NetworkName
+}
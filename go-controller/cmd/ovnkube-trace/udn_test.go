@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	udnTestNamespace = "ns1"
+	udnTestNodeName  = "node1"
+)
+
+func newUDNTestPod(t *testing.T, networks map[string]*util.PodAnnotation) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod1",
+			Namespace:   udnTestNamespace,
+			Annotations: map[string]string{},
+		},
+	}
+	for nadName, podAnnotation := range networks {
+		annotations, err := util.MarshalPodAnnotation(pod.Annotations, podAnnotation, nadName)
+		if err != nil {
+			t.Fatalf("failed to marshal the pod annotation of NAD %s: %v", nadName, err)
+		}
+		pod.Annotations = annotations
+	}
+	return pod
+}
+
+func newUDNTestPodAnnotation(role string, ips ...string) *util.PodAnnotation {
+	podAnnotation := &util.PodAnnotation{Role: role}
+	for _, ip := range ips {
+		ipNet := ovntest.MustParseIPNet(ip)
+		podAnnotation.IPs = append(podAnnotation.IPs, ipNet)
+		podAnnotation.MAC = util.IPAddrToHWAddr(ipNet.IP)
+	}
+	return podAnnotation
+}
+
+func newUDNTestNode(networkIDs string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: udnTestNodeName,
+		},
+	}
+	if networkIDs != "" {
+		node.Annotations = map[string]string{"k8s.ovn.org/network-ids": networkIDs}
+	}
+	return node
+}
+
+func newUDNTestInfo(t *testing.T, networkName, nadName, topology, subnets, role string) *UDNInfo {
+	namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+	if err != nil {
+		t.Fatalf("failed to parse NAD name %s: %v", nadName, err)
+	}
+	netInfo, err := util.ParseNADInfo(ovntest.GenerateNAD(networkName, name, namespace, topology, subnets, role))
+	if err != nil {
+		t.Fatalf("failed to parse NAD %s: %v", nadName, err)
+	}
+	return &UDNInfo{
+		NetworkName: netInfo.GetNetworkName(),
+		NADName:     nadName,
+		Topology:    netInfo.TopologyType(),
+		netInfo:     netInfo,
+	}
+}
+
+func TestFindUDNNetworks(t *testing.T) {
+	tests := []struct {
+		desc             string
+		networkIDs       string
+		expectedNetworks map[string]string
+		expectError      bool
+	}{
+		{
+			desc: "node without network IDs",
+		},
+		{
+			desc:             "default network is skipped",
+			networkIDs:       `{"default":"0","tenantblue":"2","ns1_red":"3"}`,
+			expectedNetworks: map[string]string{"tenantblue": "2", "ns1_red": "3"},
+		},
+		{
+			desc:             "only the default network",
+			networkIDs:       `{"default":"0"}`,
+			expectedNetworks: map[string]string{},
+		},
+		{
+			desc:        "invalid network IDs",
+			networkIDs:  `{"default":`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			networks, err := findUDNNetworks(newUDNTestNode(tc.networkIDs))
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(networks).To(gomega.Equal(tc.expectedNetworks))
+		})
+	}
+}
+
+func TestFindPodUDNInfo(t *testing.T) {
+	config.PrepareTestConfig()
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+
+	primaryNAD := ovntest.GenerateNAD("tenantblue", "blue", udnTestNamespace, types.Layer3Topology, "10.10.0.0/16/24", types.NetworkRolePrimary)
+	secondaryNAD := ovntest.GenerateNAD("tenantred", "red", udnTestNamespace, types.Layer2Topology, "10.100.0.0/16", types.NetworkRoleSecondary)
+	unattachedNAD := ovntest.GenerateNAD("tenantgreen", "green", udnTestNamespace, types.Layer2Topology, "10.200.0.0/16", types.NetworkRoleSecondary)
+	otherNamespaceNAD := ovntest.GenerateNAD("tenantblue", "blue", "ns2", types.Layer3Topology, "10.10.0.0/16/24", types.NetworkRolePrimary)
+
+	podWithPrimaryUDN := map[string]*util.PodAnnotation{
+		types.DefaultNetworkName: newUDNTestPodAnnotation(types.NetworkRoleInfrastructure, "10.244.0.5/24"),
+		"ns1/blue":               newUDNTestPodAnnotation(types.NetworkRolePrimary, "10.10.1.5/24"),
+		"ns1/red":                newUDNTestPodAnnotation(types.NetworkRoleSecondary, "10.100.0.5/16"),
+	}
+	podWithoutPrimaryUDN := map[string]*util.PodAnnotation{
+		types.DefaultNetworkName: newUDNTestPodAnnotation(types.NetworkRolePrimary, "10.244.0.5/24"),
+		"ns1/red":                newUDNTestPodAnnotation(types.NetworkRoleSecondary, "10.100.0.5/16"),
+	}
+
+	tests := []struct {
+		desc            string
+		podNetworks     map[string]*util.PodAnnotation
+		network         string
+		expectedNetwork string
+		expectedNAD     string
+		expectedTopo    string
+		expectError     bool
+	}{
+		{
+			desc:            "primary user defined network by default",
+			podNetworks:     podWithPrimaryUDN,
+			expectedNetwork: "tenantblue",
+			expectedNAD:     "ns1/blue",
+			expectedTopo:    types.Layer3Topology,
+		},
+		{
+			desc:        "default network requested explicitly",
+			podNetworks: podWithPrimaryUDN,
+			network:     types.DefaultNetworkName,
+		},
+		{
+			desc:        "pod without primary user defined network",
+			podNetworks: podWithoutPrimaryUDN,
+		},
+		{
+			desc:            "secondary network by network name",
+			podNetworks:     podWithoutPrimaryUDN,
+			network:         "tenantred",
+			expectedNetwork: "tenantred",
+			expectedNAD:     "ns1/red",
+			expectedTopo:    types.Layer2Topology,
+		},
+		{
+			desc:            "secondary network by NAD name",
+			podNetworks:     podWithPrimaryUDN,
+			network:         "red",
+			expectedNetwork: "tenantred",
+			expectedNAD:     "ns1/red",
+			expectedTopo:    types.Layer2Topology,
+		},
+		{
+			desc:            "secondary network by namespaced NAD name",
+			podNetworks:     podWithPrimaryUDN,
+			network:         "ns1/red",
+			expectedNetwork: "tenantred",
+			expectedNAD:     "ns1/red",
+			expectedTopo:    types.Layer2Topology,
+		},
+		{
+			desc:        "network the pod is not attached to",
+			podNetworks: podWithPrimaryUDN,
+			network:     "tenantgreen",
+			expectError: true,
+		},
+		{
+			desc:        "unknown network",
+			podNetworks: podWithPrimaryUDN,
+			network:     "tenantyellow",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			client := nadfake.NewSimpleClientset()
+			for _, nad := range []*nadapi.NetworkAttachmentDefinition{primaryNAD, secondaryNAD, unattachedNAD, otherNamespaceNAD} {
+				_, err := client.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Create(context.TODO(), nad, metav1.CreateOptions{})
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			udnInfo, err := findPodUDNInfo(client.K8sCniCncfIoV1(), newUDNTestPod(t, tc.podNetworks), tc.network)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			if tc.expectedNetwork == "" {
+				g.Expect(udnInfo).To(gomega.BeNil())
+				return
+			}
+			g.Expect(udnInfo).NotTo(gomega.BeNil())
+			g.Expect(udnInfo.NetworkName).To(gomega.Equal(tc.expectedNetwork))
+			g.Expect(udnInfo.NADName).To(gomega.Equal(tc.expectedNAD))
+			g.Expect(udnInfo.Topology).To(gomega.Equal(tc.expectedTopo))
+		})
+	}
+}
+
+func TestSetPodUDNInfo(t *testing.T) {
+	config.PrepareTestConfig()
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+	config.IPv4Mode = true
+	config.IPv6Mode = true
+
+	tests := []struct {
+		desc           string
+		networkName    string
+		topology       string
+		subnets        string
+		role           string
+		podIPs         []string
+		ipVer          string
+		networkIDs     string
+		expectedIP     string
+		expectedSwitch string
+		expectedID     string
+		expectError    bool
+	}{
+		{
+			desc:           "layer3 network",
+			networkName:    "tenantblue",
+			topology:       types.Layer3Topology,
+			subnets:        "10.10.0.0/16/24",
+			role:           types.NetworkRolePrimary,
+			podIPs:         []string{"10.10.1.5/24"},
+			ipVer:          ip4,
+			networkIDs:     `{"default":"0","tenantblue":"2"}`,
+			expectedIP:     "10.10.1.5",
+			expectedSwitch: "tenantblue_node1",
+			expectedID:     "2",
+		},
+		{
+			desc:           "layer2 network in dual stack picks the address of the traced family",
+			networkName:    "tenantblue",
+			topology:       types.Layer2Topology,
+			subnets:        "10.10.0.0/16,fd00:10:10::/64",
+			role:           types.NetworkRolePrimary,
+			podIPs:         []string{"10.10.1.5/16", "fd00:10:10::5/64"},
+			ipVer:          ip6,
+			networkIDs:     `{"default":"0","tenantblue":"2"}`,
+			expectedIP:     "fd00:10:10::5",
+			expectedSwitch: "tenantblue_ovn_layer2_switch",
+			expectedID:     "2",
+		},
+		{
+			desc:           "localnet network has no network ID",
+			networkName:    "tenantred",
+			topology:       types.LocalnetTopology,
+			subnets:        "10.100.0.0/16",
+			role:           types.NetworkRoleSecondary,
+			podIPs:         []string{"10.100.0.5/16"},
+			ipVer:          ip4,
+			expectedIP:     "10.100.0.5",
+			expectedSwitch: "tenantred_ovn_localnet_switch",
+		},
+		{
+			desc:        "no address of the traced family",
+			networkName: "tenantblue",
+			topology:    types.Layer3Topology,
+			subnets:     "10.10.0.0/16/24",
+			role:        types.NetworkRolePrimary,
+			podIPs:      []string{"10.10.1.5/24"},
+			ipVer:       ip6,
+			networkIDs:  `{"default":"0","tenantblue":"2"}`,
+			expectError: true,
+		},
+		{
+			desc:        "network ID missing on the node",
+			networkName: "tenantblue",
+			topology:    types.Layer3Topology,
+			subnets:     "10.10.0.0/16/24",
+			role:        types.NetworkRolePrimary,
+			podIPs:      []string{"10.10.1.5/24"},
+			ipVer:       ip4,
+			networkIDs:  `{"default":"0"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			const nadName = "ns1/nad1"
+			podAnnotation := newUDNTestPodAnnotation(tc.role, tc.podIPs...)
+			pod := newUDNTestPod(t, map[string]*util.PodAnnotation{nadName: podAnnotation})
+			podInfo := &PodInfo{
+				NodeInfo: NodeInfo{NodeName: udnTestNodeName},
+				IPVer:    tc.ipVer,
+				UDN:      newUDNTestInfo(t, tc.networkName, nadName, tc.topology, tc.subnets, tc.role),
+			}
+
+			err := setPodUDNInfo(podInfo, pod, newUDNTestNode(tc.networkIDs))
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(podInfo.IP).To(gomega.Equal(tc.expectedIP))
+			g.Expect(podInfo.MAC).To(gomega.Equal(podAnnotation.MAC.String()))
+			g.Expect(podInfo.UDN.SwitchName).To(gomega.Equal(tc.expectedSwitch))
+			g.Expect(podInfo.UDN.NetworkID).To(gomega.Equal(tc.expectedID))
+		})
+	}
+}
+
+func TestPodInfoNetworkScopedNames(t *testing.T) {
+	config.PrepareTestConfig()
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+
+	tests := []struct {
+		desc                      string
+		udn                       *UDNInfo
+		expectedSwitch            string
+		expectedRouterToSwitch    string
+		expectedRouterToTransit   string
+		expectedMgmtPort          string
+		expectedRemotePodOutport  string
+		expectedRemotePodInport   string
+		expectedRemotePodInMAC    string
+		expectedPodTrafficDstMAC  string
+		expectedLogicalSwitchPort string
+	}{
+		{
+			desc:                      "default network",
+			expectedSwitch:            "node1",
+			expectedRouterToSwitch:    "rtos-node1",
+			expectedRouterToTransit:   "rtots-node1",
+			expectedMgmtPort:          "ovn-k8s-mp0",
+			expectedRemotePodOutport:  `output to "tstor-node2"`,
+			expectedRemotePodInport:   "tstor-node1",
+			expectedRemotePodInMAC:    "0a:58:64:58:00:03",
+			expectedPodTrafficDstMAC:  "0a:58:0a:f4:00:01",
+			expectedLogicalSwitchPort: "ns1_pod1",
+		},
+		{
+			desc: "layer3 user defined network",
+			udn: &UDNInfo{
+				NetworkName: "tenantblue",
+				NADName:     "ns1/blue",
+				Topology:    types.Layer3Topology,
+				NetworkID:   "2",
+				SwitchName:  "tenantblue_node1",
+			},
+			expectedSwitch:            "tenantblue_node1",
+			expectedRouterToSwitch:    "rtos-tenantblue_node1",
+			expectedRouterToTransit:   "tenantblue_rtots-node1",
+			expectedMgmtPort:          "ovn-k8s-mp2",
+			expectedRemotePodOutport:  `output to "tenantblue_tstor-node2"`,
+			expectedRemotePodInport:   "tenantblue_tstor-node1",
+			expectedRemotePodInMAC:    "0a:58:64:58:00:03",
+			expectedPodTrafficDstMAC:  "0a:58:0a:f4:00:01",
+			expectedLogicalSwitchPort: "ns1.blue_ns1_pod1",
+		},
+		{
+			desc: "layer2 user defined network",
+			udn: &UDNInfo{
+				NetworkName: "tenantblue",
+				NADName:     "ns1/blue",
+				Topology:    types.Layer2Topology,
+				NetworkID:   "2",
+				SwitchName:  "tenantblue_ovn_layer2_switch",
+			},
+			expectedSwitch:            "tenantblue_ovn_layer2_switch",
+			expectedRouterToSwitch:    "trtos-tenantblue_ovn_layer2_switch",
+			expectedMgmtPort:          "ovn-k8s-mp2",
+			expectedRemotePodOutport:  `output to "ns1.blue_ns1_pod2"`,
+			expectedRemotePodInport:   "ns1.blue_ns1_pod1",
+			expectedRemotePodInMAC:    "0a:58:0a:f4:01:05",
+			expectedPodTrafficDstMAC:  "0a:58:0a:f4:01:05",
+			expectedLogicalSwitchPort: "ns1.blue_ns1_pod1",
+		},
+		{
+			desc: "localnet user defined network",
+			udn: &UDNInfo{
+				NetworkName: "tenantred",
+				NADName:     "ns1/red",
+				Topology:    types.LocalnetTopology,
+				SwitchName:  "tenantred_ovn_localnet_switch",
+			},
+			expectedSwitch:            "tenantred_ovn_localnet_switch",
+			expectedRemotePodOutport:  `output to "tenantred_ovn_localnet_port", type "localnet"`,
+			expectedRemotePodInport:   "tenantred_ovn_localnet_port",
+			expectedRemotePodInMAC:    "0a:58:0a:f4:01:05",
+			expectedPodTrafficDstMAC:  "0a:58:0a:f4:01:05",
+			expectedLogicalSwitchPort: "ns1.red_ns1_pod1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var dstUDN *UDNInfo
+			if tc.udn != nil {
+				subnets := "10.10.0.0/16/24"
+				if tc.udn.Topology != types.Layer3Topology {
+					subnets = "10.10.0.0/16"
+				}
+				role := types.NetworkRolePrimary
+				if tc.udn.Topology == types.LocalnetTopology {
+					role = types.NetworkRoleSecondary
+				}
+				tc.udn.netInfo = newUDNTestInfo(t, tc.udn.NetworkName, tc.udn.NADName, tc.udn.Topology, subnets, role).netInfo
+				dstUDN = &UDNInfo{}
+				*dstUDN = *tc.udn
+				dstUDN.SwitchName = tc.udn.netInfo.GetNetworkScopedSwitchName("node2")
+			}
+			srcPodInfo := &PodInfo{
+				NodeInfo:     NodeInfo{NodeName: "node1"},
+				PodName:      "pod1",
+				PodNamespace: udnTestNamespace,
+				MAC:          util.IPAddrToHWAddr(net.ParseIP("10.244.1.4")).String(),
+				RtosMAC:      "0a:58:0a:f4:00:01",
+				RtotsMAC:     "0a:58:64:58:00:02",
+				UDN:          tc.udn,
+			}
+			dstPodInfo := &PodInfo{
+				NodeInfo:     NodeInfo{NodeName: "node2"},
+				PodName:      "pod2",
+				PodNamespace: udnTestNamespace,
+				MAC:          util.IPAddrToHWAddr(net.ParseIP("10.244.1.5")).String(),
+				RtosMAC:      "0a:58:0a:f4:01:01",
+				RtotsMAC:     "0a:58:64:58:00:03",
+				UDN:          dstUDN,
+			}
+
+			g.Expect(srcPodInfo.switchName()).To(gomega.Equal(tc.expectedSwitch))
+			g.Expect(srcPodInfo.routerToSwitchPortName()).To(gomega.Equal(tc.expectedRouterToSwitch))
+			g.Expect(srcPodInfo.routerToTransitSwitchPortName()).To(gomega.Equal(tc.expectedRouterToTransit))
+			g.Expect(srcPodInfo.mgmtPortName()).To(gomega.Equal(tc.expectedMgmtPort))
+			g.Expect(srcPodInfo.FullyQualifiedPodName()).To(gomega.Equal(tc.expectedLogicalSwitchPort))
+			g.Expect(remotePodOutport(srcPodInfo, dstPodInfo)).To(gomega.Equal(tc.expectedRemotePodOutport))
+			inport, inMAC := remotePodInport(srcPodInfo, dstPodInfo)
+			g.Expect(inport).To(gomega.Equal(tc.expectedRemotePodInport))
+			g.Expect(inMAC).To(gomega.Equal(tc.expectedRemotePodInMAC))
+			g.Expect(podTrafficDstMAC(srcPodInfo, dstPodInfo)).To(gomega.Equal(tc.expectedPodTrafficDstMAC))
+		})
+	}
+}
@@ -131,6 +131,8 @@ echo "Copying egressService CRD"
 cp _output/crds/k8s.ovn.org_egressservices.yaml ../dist/templates/k8s.ovn.org_egressservices.yaml.j2
 echo "Copying networkQoS CRD"
 cp _output/crds/k8s.ovn.org_networkqoses.yaml ../dist/templates/k8s.ovn.org_networkqoses.yaml.j2
+echo "Copying observabilityConfig CRD"
+cp _output/crds/k8s.ovn.org_observabilityconfigs.yaml ../dist/templates/k8s.ovn.org_observabilityconfigs.yaml.j2
 echo "Copying userdefinednetworks CRD"
 cp _output/crds/k8s.ovn.org_userdefinednetworks.yaml ../dist/templates/k8s.ovn.org_userdefinednetworks.yaml.j2
 echo "Copying clusteruserdefinednetworks CRD"
@@ -0,0 +1,112 @@
+// Package exporter exports the network events decoded from OVS samples to external sinks.
+package exporter
+
+import (
+	"net"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+)
+
+const (
+	// Constants are duplicated to minimize dependencies, see also github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb
+	aclActionAllow          = "allow"
+	aclActionAllowRelated   = "allow-related"
+	aclActionAllowStateless = "allow-stateless"
+	aclActionDrop           = "drop"
+	aclActionReject         = "reject"
+)
+
+// Actors of a FlowEvent that is not an ACLEvent, the actors of the ACLEvents are the owner types of the ACLs
+const (
+	actorEgressFirewall = "EgressFirewall"
+	actorUDNIsolation   = "UDNIsolation"
+)
+
+// Verdicts of a FlowEvent
+const (
+	VerdictAllow = "allow"
+	VerdictDeny  = "deny"
+)
+
+// FlowEvent is a network event decoded from an OVS sample, with the packet that was sampled and the
+// pod interface it was received on.
+type FlowEvent struct {
+	Time     time.Time `json:"time"`
+	SrcIP    net.IP    `json:"srcIP,omitempty"`
+	DstIP    net.IP    `json:"dstIP,omitempty"`
+	Protocol uint8     `json:"protocol,omitempty"`
+	SrcPort  uint16    `json:"srcPort,omitempty"`
+	DstPort  uint16    `json:"dstPort,omitempty"`
+	// Interface is the OVS interface the sampled packet was received on
+	Interface string `json:"interface,omitempty"`
+	// PodNamespace and PodName identify the pod of Interface
+	PodNamespace string `json:"podNamespace,omitempty"`
+	PodName      string `json:"podName,omitempty"`
+	// UDN is the (C)UDN of Interface, namespace/name for UDNs and name for CUDNs,
+	// empty for the default network
+	UDN string `json:"udn,omitempty"`
+
+	// Verdict is the allow or deny decision of the policy that sampled the packet, if any
+	Verdict string `json:"verdict,omitempty"`
+	// Action, Actor, PolicyName, PolicyNamespace, Direction and RuleIndex describe the ACL that sampled the packet
+	Action          string `json:"action,omitempty"`
+	Actor           string `json:"actor,omitempty"`
+	PolicyName      string `json:"policyName,omitempty"`
+	PolicyNamespace string `json:"policyNamespace,omitempty"`
+	Direction       string `json:"direction,omitempty"`
+	RuleIndex       string `json:"ruleIndex,omitempty"`
+	// Message is the human-readable description of the network event
+	Message string `json:"message"`
+}
+
+// NewFlowEvent returns the FlowEvent of a decoded network event
+func NewFlowEvent(t time.Time, event model.NetworkEvent) *FlowEvent {
+	flowEvent := &FlowEvent{
+		Time:    t,
+		Message: event.String(),
+	}
+	switch e := event.(type) {
+	case *model.ACLEvent:
+		flowEvent.Action = e.Action
+		flowEvent.Verdict = getVerdict(e.Action)
+		flowEvent.Actor = e.Actor
+		flowEvent.PolicyName = e.Name
+		flowEvent.PolicyNamespace = e.Namespace
+		flowEvent.Direction = e.Direction
+		flowEvent.RuleIndex = e.RuleIndex
+	case *model.EgressFirewallEvent:
+		flowEvent.Action = e.Action
+		flowEvent.Verdict = getVerdict(e.Action)
+		flowEvent.Actor = actorEgressFirewall
+		flowEvent.PolicyNamespace = e.Namespace
+		flowEvent.Direction = "Egress"
+		flowEvent.RuleIndex = e.RuleIndex
+	case *model.UDNIsolationEvent:
+		flowEvent.Action = e.Action
+		flowEvent.Verdict = getVerdict(e.Action)
+		flowEvent.Actor = actorUDNIsolation
+		flowEvent.PolicyName = e.Name
+		flowEvent.Direction = e.Direction
+	}
+	return flowEvent
+}
+
+func getVerdict(action string) string {
+	switch action {
+	case aclActionAllow, aclActionAllowRelated, aclActionAllowStateless:
+		return VerdictAllow
+	case aclActionDrop, aclActionReject:
+		return VerdictDeny
+	default:
+		return ""
+	}
+}
+
+// Exporter exports FlowEvents to a sink
+type Exporter interface {
+	// Export exports a FlowEvent, it must not block on the sink
+	Export(event *FlowEvent) error
+	// Close flushes the FlowEvents not exported yet and releases the sink
+	Close() error
+}
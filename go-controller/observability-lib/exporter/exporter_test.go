@@ -0,0 +1,71 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+)
+
+func newTestFlowEvent() *FlowEvent {
+	event := NewFlowEvent(time.Unix(1700000000, 0), &model.ACLEvent{
+		Action:    aclActionDrop,
+		Actor:     "NetworkPolicy",
+		Name:      "deny-all",
+		Namespace: "foo",
+		Direction: "Ingress",
+	})
+	event.SrcIP = net.ParseIP("10.128.0.5")
+	event.DstIP = net.ParseIP("10.128.1.6")
+	event.Protocol = 6
+	event.SrcPort = 34567
+	event.DstPort = 8080
+	event.Interface = "a1b2c3d4e5f6g7h"
+	event.PodNamespace = "foo"
+	event.PodName = "client"
+	event.UDN = "foo/udn"
+	return event
+}
+
+func TestNewFlowEvent(t *testing.T) {
+	event := newTestFlowEvent()
+	assert.Equal(t, VerdictDeny, event.Verdict)
+	assert.Equal(t, aclActionDrop, event.Action)
+	assert.Equal(t, "deny-all", event.PolicyName)
+	assert.Equal(t, "foo", event.PolicyNamespace)
+	assert.Equal(t, "Dropped by network policy deny-all in namespace foo, direction Ingress", event.Message)
+
+	event = NewFlowEvent(time.Now(), &model.ACLEvent{Action: aclActionAllowRelated, Actor: "NetpolNode"})
+	assert.Equal(t, VerdictAllow, event.Verdict)
+
+	event = NewFlowEvent(time.Now(), &model.EgressFirewallEvent{Action: aclActionDrop, Namespace: "foo", RuleIndex: "2"})
+	assert.Equal(t, VerdictDeny, event.Verdict)
+	assert.Equal(t, actorEgressFirewall, event.Actor)
+	assert.Equal(t, "foo", event.PolicyNamespace)
+	assert.Equal(t, "2", event.RuleIndex)
+}
+
+func TestJSONLinesExporter(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONLinesExporter(&buf, nil)
+	require.NoError(t, e.Export(newTestFlowEvent()))
+	require.NoError(t, e.Export(newTestFlowEvent()))
+	require.NoError(t, e.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	decoded := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "10.128.0.5", decoded["srcIP"])
+	assert.Equal(t, "client", decoded["podName"])
+	assert.Equal(t, "foo/udn", decoded["udn"])
+	assert.Equal(t, VerdictDeny, decoded["verdict"])
+	assert.Equal(t, float64(8080), decoded["dstPort"])
+}
@@ -0,0 +1,234 @@
+package exporter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// IPFIX protocol, see RFC 7011
+const (
+	ipfixVersion             = 10
+	ipfixMessageHeaderLength = 16
+	ipfixTemplateSetID       = 2
+	ipfixVariableLength      = 65535
+	ipfixEnterpriseBit       = 0x8000
+	// ipfixTemplateRefresh is the interval the templates are sent again at, collectors may have lost them over UDP
+	ipfixTemplateRefresh = time.Minute
+
+	ipfixTemplateIDIPv4 = 256
+	ipfixTemplateIDIPv6 = 257
+)
+
+// IANA Information Elements
+const (
+	ieProtocolIdentifier          = 4
+	ieSourceTransportPort         = 7
+	ieSourceIPv4Address           = 8
+	ieDestinationTransportPort    = 11
+	ieDestinationIPv4Address      = 12
+	ieSourceIPv6Address           = 27
+	ieDestinationIPv6Address      = 28
+	ieInterfaceName               = 82
+	ieFirewallEvent               = 233
+	ieObservationTimeMilliseconds = 323
+)
+
+// Enterprise-specific Information Elements, in the enterprise number given to NewIPFIXExporter. All of
+// them are strings.
+const (
+	iePodNamespace = iota + 1
+	iePodName
+	ieUDN
+	ieACLAction
+	ieACLActor
+	ieACLName
+	ieACLNamespace
+	ieACLDirection
+	ieACLRuleIndex
+)
+
+// firewallEvent values
+const (
+	firewallEventIgnored = 0
+	firewallEventCreated = 1
+	firewallEventDenied  = 3
+)
+
+// ipfixField is a field specifier of an IPFIX template
+type ipfixField struct {
+	id         uint16
+	length     uint16
+	enterprise bool
+}
+
+var ipfixCommonFields = []ipfixField{
+	{id: ieObservationTimeMilliseconds, length: 8},
+	{id: ieProtocolIdentifier, length: 1},
+	{id: ieSourceTransportPort, length: 2},
+	{id: ieDestinationTransportPort, length: 2},
+	{id: ieFirewallEvent, length: 1},
+	{id: ieInterfaceName, length: ipfixVariableLength},
+	{id: iePodNamespace, length: ipfixVariableLength, enterprise: true},
+	{id: iePodName, length: ipfixVariableLength, enterprise: true},
+	{id: ieUDN, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLAction, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLActor, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLName, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLNamespace, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLDirection, length: ipfixVariableLength, enterprise: true},
+	{id: ieACLRuleIndex, length: ipfixVariableLength, enterprise: true},
+}
+
+var ipfixTemplates = map[uint16][]ipfixField{
+	ipfixTemplateIDIPv4: append([]ipfixField{
+		{id: ieSourceIPv4Address, length: net.IPv4len},
+		{id: ieDestinationIPv4Address, length: net.IPv4len},
+	}, ipfixCommonFields...),
+	ipfixTemplateIDIPv6: append([]ipfixField{
+		{id: ieSourceIPv6Address, length: net.IPv6len},
+		{id: ieDestinationIPv6Address, length: net.IPv6len},
+	}, ipfixCommonFields...),
+}
+
+// ipfixExporter exports a data record per FlowEvent to an IPFIX collector over UDP
+type ipfixExporter struct {
+	lock                sync.Mutex
+	conn                net.Conn
+	enterpriseNumber    uint32
+	observationDomainID uint32
+	sequenceNumber      uint32
+	templatesSent       time.Time
+}
+
+// NewIPFIXExporter returns an Exporter sending the FlowEvents to the IPFIX collector over UDP. The pod and
+// ACL Information Elements are enterprise-specific, in the given private enterprise number.
+func NewIPFIXExporter(collector string, enterpriseNumber, observationDomainID uint32) (Exporter, error) {
+	if enterpriseNumber == 0 {
+		return nil, fmt.Errorf("an IPFIX enterprise number is required")
+	}
+	conn, err := net.Dial("udp", collector)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to IPFIX collector: %w", err)
+	}
+	return &ipfixExporter{
+		conn:                conn,
+		enterpriseNumber:    enterpriseNumber,
+		observationDomainID: observationDomainID,
+	}, nil
+}
+
+func (e *ipfixExporter) Export(event *FlowEvent) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var sets []byte
+	now := time.Now()
+	sendTemplates := now.Sub(e.templatesSent) >= ipfixTemplateRefresh
+	if sendTemplates {
+		sets = e.appendTemplateSet(sets)
+	}
+	sets, err := e.appendDataSet(sets, event)
+	if err != nil {
+		return err
+	}
+
+	message := make([]byte, ipfixMessageHeaderLength, ipfixMessageHeaderLength+len(sets))
+	binary.BigEndian.PutUint16(message[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(message[2:], uint16(ipfixMessageHeaderLength+len(sets)))
+	binary.BigEndian.PutUint32(message[4:], uint32(now.Unix()))
+	binary.BigEndian.PutUint32(message[8:], e.sequenceNumber)
+	binary.BigEndian.PutUint32(message[12:], e.observationDomainID)
+	message = append(message, sets...)
+	if _, err := e.conn.Write(message); err != nil {
+		return fmt.Errorf("error sending IPFIX message: %w", err)
+	}
+	if sendTemplates {
+		e.templatesSent = now
+	}
+	// the sequence number counts the data records
+	e.sequenceNumber++
+	return nil
+}
+
+func (e *ipfixExporter) Close() error {
+	return e.conn.Close()
+}
+
+// appendTemplateSet appends a template set with all the templates
+func (e *ipfixExporter) appendTemplateSet(b []byte) []byte {
+	start := len(b)
+	b = binary.BigEndian.AppendUint16(b, ipfixTemplateSetID)
+	b = binary.BigEndian.AppendUint16(b, 0)
+	for _, templateID := range []uint16{ipfixTemplateIDIPv4, ipfixTemplateIDIPv6} {
+		fields := ipfixTemplates[templateID]
+		b = binary.BigEndian.AppendUint16(b, templateID)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(fields)))
+		for _, field := range fields {
+			if field.enterprise {
+				b = binary.BigEndian.AppendUint16(b, field.id|ipfixEnterpriseBit)
+				b = binary.BigEndian.AppendUint16(b, field.length)
+				b = binary.BigEndian.AppendUint32(b, e.enterpriseNumber)
+				continue
+			}
+			b = binary.BigEndian.AppendUint16(b, field.id)
+			b = binary.BigEndian.AppendUint16(b, field.length)
+		}
+	}
+	binary.BigEndian.PutUint16(b[start+2:], uint16(len(b)-start))
+	return b
+}
+
+// appendDataSet appends a data set with the data record of the FlowEvent
+func (e *ipfixExporter) appendDataSet(b []byte, event *FlowEvent) ([]byte, error) {
+	templateID := uint16(ipfixTemplateIDIPv4)
+	srcIP, dstIP := event.SrcIP.To4(), event.DstIP.To4()
+	if srcIP == nil || dstIP == nil {
+		templateID = ipfixTemplateIDIPv6
+		srcIP, dstIP = event.SrcIP.To16(), event.DstIP.To16()
+		if srcIP == nil || dstIP == nil {
+			return nil, fmt.Errorf("can't export event without source and destination IPs over IPFIX")
+		}
+	}
+
+	start := len(b)
+	b = binary.BigEndian.AppendUint16(b, templateID)
+	b = binary.BigEndian.AppendUint16(b, 0)
+	b = append(b, srcIP...)
+	b = append(b, dstIP...)
+	b = binary.BigEndian.AppendUint64(b, uint64(event.Time.UnixMilli()))
+	b = append(b, event.Protocol)
+	b = binary.BigEndian.AppendUint16(b, event.SrcPort)
+	b = binary.BigEndian.AppendUint16(b, event.DstPort)
+	b = append(b, getFirewallEvent(event.Verdict))
+	for _, s := range []string{event.Interface, event.PodNamespace, event.PodName, event.UDN, event.Action, event.Actor,
+		event.PolicyName, event.PolicyNamespace, event.Direction, event.RuleIndex} {
+		b = appendIPFIXString(b, s)
+	}
+	binary.BigEndian.PutUint16(b[start+2:], uint16(len(b)-start))
+	return b, nil
+}
+
+// appendIPFIXString appends a variable-length string field, see RFC 7011 section 7
+func appendIPFIXString(b []byte, s string) []byte {
+	if len(s) < 255 {
+		b = append(b, uint8(len(s)))
+	} else {
+		b = append(b, 255)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	}
+	return append(b, s...)
+}
+
+func getFirewallEvent(verdict string) uint8 {
+	switch verdict {
+	case VerdictAllow:
+		return firewallEventCreated
+	case VerdictDeny:
+		return firewallEventDenied
+	default:
+		return firewallEventIgnored
+	}
+}
@@ -0,0 +1,107 @@
+package exporter
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEnterpriseNumber = 12345
+
+// ipfixTestSet is a set of a received IPFIX message
+type ipfixTestSet struct {
+	id   uint16
+	data []byte
+}
+
+func receiveIPFIXMessage(t *testing.T, conn net.PacketConn) (header []byte, sets []ipfixTestSet) {
+	t.Helper()
+	buf := make([]byte, 65535)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	message := buf[:n]
+	require.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(message[0:]))
+	require.Equal(t, uint16(n), binary.BigEndian.Uint16(message[2:]))
+
+	for b := message[ipfixMessageHeaderLength:]; len(b) > 0; {
+		length := binary.BigEndian.Uint16(b[2:])
+		sets = append(sets, ipfixTestSet{id: binary.BigEndian.Uint16(b[0:]), data: b[4:length]})
+		b = b[length:]
+	}
+	return message[:ipfixMessageHeaderLength], sets
+}
+
+func consumeIPFIXString(t *testing.T, b []byte) (string, []byte) {
+	t.Helper()
+	length := int(b[0])
+	b = b[1:]
+	if length == 255 {
+		length = int(binary.BigEndian.Uint16(b))
+		b = b[2:]
+	}
+	return string(b[:length]), b[length:]
+}
+
+func TestIPFIXExporter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	e, err := NewIPFIXExporter(conn.LocalAddr().String(), testEnterpriseNumber, 7)
+	require.NoError(t, err)
+	defer e.Close()
+
+	event := newTestFlowEvent()
+	require.NoError(t, e.Export(event))
+
+	header, sets := receiveIPFIXMessage(t, conn)
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(header[8:]), "sequence number")
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(header[12:]), "observation domain ID")
+	require.Len(t, sets, 2)
+
+	// the first message has the templates
+	templates := sets[0]
+	assert.Equal(t, uint16(ipfixTemplateSetID), templates.id)
+	assert.Equal(t, uint16(ipfixTemplateIDIPv4), binary.BigEndian.Uint16(templates.data[0:]))
+	assert.Equal(t, uint16(len(ipfixTemplates[ipfixTemplateIDIPv4])), binary.BigEndian.Uint16(templates.data[2:]))
+
+	data := sets[1]
+	assert.Equal(t, uint16(ipfixTemplateIDIPv4), data.id)
+	b := data.data
+	assert.Equal(t, net.IP(b[0:4]).String(), "10.128.0.5")
+	assert.Equal(t, net.IP(b[4:8]).String(), "10.128.1.6")
+	assert.Equal(t, uint64(event.Time.UnixMilli()), binary.BigEndian.Uint64(b[8:]))
+	assert.Equal(t, uint8(6), b[16])
+	assert.Equal(t, uint16(34567), binary.BigEndian.Uint16(b[17:]))
+	assert.Equal(t, uint16(8080), binary.BigEndian.Uint16(b[19:]))
+	assert.Equal(t, uint8(firewallEventDenied), b[21])
+	b = b[22:]
+	var strs []string
+	for len(b) > 0 {
+		var s string
+		s, b = consumeIPFIXString(t, b)
+		strs = append(strs, s)
+	}
+	assert.Equal(t, []string{"a1b2c3d4e5f6g7h", "foo", "client", "foo/udn", aclActionDrop, "NetworkPolicy", "deny-all",
+		"foo", "Ingress", ""}, strs)
+
+	// the next messages only have the data record
+	event.SrcIP = net.ParseIP("fd00::5")
+	event.DstIP = net.ParseIP("fd00::6")
+	require.NoError(t, e.Export(event))
+	header, sets = receiveIPFIXMessage(t, conn)
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(header[8:]), "sequence number")
+	require.Len(t, sets, 1)
+	assert.Equal(t, uint16(ipfixTemplateIDIPv6), sets[0].id)
+	assert.Equal(t, net.IP(sets[0].data[0:16]).String(), "fd00::5")
+}
+
+func TestIPFIXExporterRequiresEnterpriseNumber(t *testing.T) {
+	_, err := NewIPFIXExporter("127.0.0.1:4739", 0, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// jsonLinesExporter writes a JSON document per FlowEvent, one per line
+type jsonLinesExporter struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewJSONLinesExporter returns an Exporter writing the FlowEvents as JSON lines to the given file,
+// or to stdout when the file is "-". The file is appended to if it exists.
+func NewJSONLinesExporter(file string) (Exporter, error) {
+	if file == "-" {
+		return newJSONLinesExporter(os.Stdout, nil), nil
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening JSON lines file: %w", err)
+	}
+	return newJSONLinesExporter(f, f), nil
+}
+
+func newJSONLinesExporter(w io.Writer, closer io.Closer) *jsonLinesExporter {
+	return &jsonLinesExporter{
+		encoder: json.NewEncoder(w),
+		closer:  closer,
+	}
+}
+
+func (e *jsonLinesExporter) Export(event *FlowEvent) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err := e.encoder.Encode(event); err != nil {
+		return fmt.Errorf("error writing JSON line: %w", err)
+	}
+	return nil
+}
+
+func (e *jsonLinesExporter) Close() error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
@@ -0,0 +1,332 @@
+package exporter
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// otlpLogsExportMethod is the gRPC method of the OTLP logs service
+	otlpLogsExportMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+	otlpServiceName      = "ovnkube-observ"
+	otlpMaxBatchSize     = 512
+	otlpQueueSize        = 8 * otlpMaxBatchSize
+	otlpFlushInterval    = time.Second
+	otlpExportTimeout    = 10 * time.Second
+	// otlpSeverityNumberInfo is the INFO SeverityNumber of the OTLP logs data model
+	otlpSeverityNumberInfo = 9
+)
+
+// otlpExporter exports the FlowEvents as OpenTelemetry log records over OTLP/gRPC. The FlowEvents are
+// queued and exported in batches.
+type otlpExporter struct {
+	conn     *grpc.ClientConn
+	resource []byte
+	events   chan *FlowEvent
+	done     chan struct{}
+}
+
+// NewOTLPExporter returns an Exporter sending the FlowEvents as log records to the OTLP/gRPC endpoint, e.g.
+// an OpenTelemetry collector listening on localhost:4317. TLS is used unless insecureConn is true.
+func NewOTLPExporter(endpoint string, insecureConn bool) (Exporter, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if insecureConn {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP client: %w", err)
+	}
+
+	resourceAttributes := []otlpAttribute{{key: "service.name", stringValue: otlpServiceName}}
+	if hostname, err := os.Hostname(); err == nil {
+		resourceAttributes = append(resourceAttributes, otlpAttribute{key: "host.name", stringValue: hostname})
+	}
+	e := &otlpExporter{
+		conn:     conn,
+		resource: marshalOTLPResource(resourceAttributes),
+		events:   make(chan *FlowEvent, otlpQueueSize),
+		done:     make(chan struct{}),
+	}
+	go e.run()
+	return e, nil
+}
+
+func (e *otlpExporter) Export(event *FlowEvent) error {
+	select {
+	case e.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("OTLP export queue is full, dropping event")
+	}
+}
+
+func (e *otlpExporter) Close() error {
+	close(e.events)
+	<-e.done
+	return e.conn.Close()
+}
+
+// run exports the queued FlowEvents when a batch is full, at least every otlpFlushInterval
+func (e *otlpExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*FlowEvent, 0, otlpMaxBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			fmt.Printf("ERROR: failed to export %d events over OTLP: %v\n", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case event, ok := <-e.events:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) == otlpMaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (e *otlpExporter) export(events []*FlowEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	var response []byte
+	err := e.conn.Invoke(ctx, otlpLogsExportMethod, marshalOTLPExportLogsRequest(e.resource, events), &response,
+		grpc.ForceCodec(rawProtoCodec{}))
+	if err != nil {
+		return err
+	}
+	rejected, message, err := unmarshalOTLPExportLogsResponse(response)
+	if err != nil {
+		return err
+	}
+	if rejected > 0 {
+		return fmt.Errorf("%d events rejected: %s", rejected, message)
+	}
+	return nil
+}
+
+// rawProtoCodec passes the already marshalled protobuf messages through to gRPC, so that the OTLP
+// messages don't need generated code.
+type rawProtoCodec struct{}
+
+func (rawProtoCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (rawProtoCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawProtoCodec) Name() string {
+	return "proto"
+}
+
+// otlpAttribute is a string or an int attribute of an OTLP resource or log record
+type otlpAttribute struct {
+	key         string
+	stringValue string
+	intValue    int64
+	isInt       bool
+}
+
+// getOTLPAttributes returns the log record attributes of a FlowEvent, using the OpenTelemetry semantic
+// conventions where they apply. Empty attributes are omitted.
+func getOTLPAttributes(event *FlowEvent) []otlpAttribute {
+	var attributes []otlpAttribute
+	addString := func(key, value string) {
+		if value != "" {
+			attributes = append(attributes, otlpAttribute{key: key, stringValue: value})
+		}
+	}
+	addInt := func(key string, value int64) {
+		if value != 0 {
+			attributes = append(attributes, otlpAttribute{key: key, intValue: value, isInt: true})
+		}
+	}
+	if event.SrcIP != nil {
+		addString("source.address", event.SrcIP.String())
+	}
+	addInt("source.port", int64(event.SrcPort))
+	if event.DstIP != nil {
+		addString("destination.address", event.DstIP.String())
+	}
+	addInt("destination.port", int64(event.DstPort))
+	if event.Protocol != 0 {
+		addString("network.transport", strings.ToLower(layers.IPProtocol(event.Protocol).String()))
+	}
+	addString("k8s.namespace.name", event.PodNamespace)
+	addString("k8s.pod.name", event.PodName)
+	addString("ovn.udn", event.UDN)
+	addString("ovn.interface", event.Interface)
+	addString("ovn.verdict", event.Verdict)
+	addString("ovn.acl.action", event.Action)
+	addString("ovn.acl.actor", event.Actor)
+	addString("ovn.acl.name", event.PolicyName)
+	addString("ovn.acl.namespace", event.PolicyNamespace)
+	addString("ovn.acl.direction", event.Direction)
+	addString("ovn.acl.rule_index", event.RuleIndex)
+	return attributes
+}
+
+// The following functions marshal the messages of the OTLP logs protocol, see
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/collector/logs/v1/logs_service.proto
+
+func appendOTLPMessage(b []byte, num protowire.Number, message []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, message)
+}
+
+func appendOTLPString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// appendOTLPAttribute appends a KeyValue message
+func appendOTLPAttribute(b []byte, num protowire.Number, attribute otlpAttribute) []byte {
+	// AnyValue
+	var value []byte
+	if attribute.isInt {
+		value = protowire.AppendTag(value, 3, protowire.VarintType)
+		value = protowire.AppendVarint(value, uint64(attribute.intValue))
+	} else {
+		value = appendOTLPString(value, 1, attribute.stringValue)
+	}
+	// KeyValue
+	var keyValue []byte
+	keyValue = appendOTLPString(keyValue, 1, attribute.key)
+	keyValue = appendOTLPMessage(keyValue, 2, value)
+	return appendOTLPMessage(b, num, keyValue)
+}
+
+// marshalOTLPResource marshals a Resource message
+func marshalOTLPResource(attributes []otlpAttribute) []byte {
+	var resource []byte
+	for _, attribute := range attributes {
+		resource = appendOTLPAttribute(resource, 1, attribute)
+	}
+	return resource
+}
+
+// marshalOTLPLogRecord marshals the LogRecord message of a FlowEvent
+func marshalOTLPLogRecord(event *FlowEvent, observed time.Time) []byte {
+	var record []byte
+	record = protowire.AppendTag(record, 1, protowire.Fixed64Type)
+	record = protowire.AppendFixed64(record, uint64(event.Time.UnixNano()))
+	record = protowire.AppendTag(record, 2, protowire.VarintType)
+	record = protowire.AppendVarint(record, otlpSeverityNumberInfo)
+	record = appendOTLPString(record, 3, "INFO")
+	record = appendOTLPMessage(record, 5, appendOTLPString(nil, 1, event.Message))
+	for _, attribute := range getOTLPAttributes(event) {
+		record = appendOTLPAttribute(record, 6, attribute)
+	}
+	record = protowire.AppendTag(record, 11, protowire.Fixed64Type)
+	record = protowire.AppendFixed64(record, uint64(observed.UnixNano()))
+	return record
+}
+
+// marshalOTLPExportLogsRequest marshals an ExportLogsServiceRequest message with a single ResourceLogs
+func marshalOTLPExportLogsRequest(resource []byte, events []*FlowEvent) []byte {
+	observed := time.Now()
+	// ScopeLogs
+	scopeLogs := appendOTLPMessage(nil, 1, appendOTLPString(nil, 1, otlpServiceName))
+	for _, event := range events {
+		scopeLogs = appendOTLPMessage(scopeLogs, 2, marshalOTLPLogRecord(event, observed))
+	}
+	// ResourceLogs
+	resourceLogs := appendOTLPMessage(nil, 1, resource)
+	resourceLogs = appendOTLPMessage(resourceLogs, 2, scopeLogs)
+	// ExportLogsServiceRequest
+	return appendOTLPMessage(nil, 1, resourceLogs)
+}
+
+// unmarshalOTLPExportLogsResponse returns the rejected log records and the error message of the partial
+// success of an ExportLogsServiceResponse message
+func unmarshalOTLPExportLogsResponse(b []byte) (int64, string, error) {
+	var rejected int64
+	var message string
+	var partialSuccess []byte
+	if err := consumeOTLPFields(b, func(num protowire.Number, typ protowire.Type, field []byte) (int, error) {
+		if num == 1 && typ == protowire.BytesType {
+			var n int
+			partialSuccess, n = protowire.ConsumeBytes(field)
+			return n, protowire.ParseError(n)
+		}
+		return -1, nil
+	}); err != nil {
+		return 0, "", fmt.Errorf("invalid OTLP logs export response: %w", err)
+	}
+	if err := consumeOTLPFields(partialSuccess, func(num protowire.Number, typ protowire.Type, field []byte) (int, error) {
+		var n int
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(field)
+			rejected = int64(v)
+		case num == 2 && typ == protowire.BytesType:
+			message, n = protowire.ConsumeString(field)
+		default:
+			return -1, nil
+		}
+		return n, protowire.ParseError(n)
+	}); err != nil {
+		return 0, "", fmt.Errorf("invalid OTLP logs export partial success: %w", err)
+	}
+	return rejected, message, nil
+}
+
+// consumeOTLPFields calls consume for each field of a protobuf message. consume returns the length of the
+// field value it consumed, or -1 to skip the field.
+func consumeOTLPFields(b []byte, consume func(num protowire.Number, typ protowire.Type, field []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := consume(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+		}
+		b = b[n:]
+	}
+	return nil
+}
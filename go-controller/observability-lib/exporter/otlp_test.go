@@ -0,0 +1,152 @@
+package exporter
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// otlpTestCollector is an OTLP/gRPC logs collector recording the export requests
+type otlpTestCollector struct {
+	server   *grpc.Server
+	address  string
+	requests chan []byte
+	response []byte
+}
+
+func newOTLPTestCollector(t *testing.T, response []byte) *otlpTestCollector {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	c := &otlpTestCollector{
+		address:  lis.Addr().String(),
+		requests: make(chan []byte, 10),
+		response: response,
+	}
+	c.server = grpc.NewServer(grpc.ForceServerCodec(rawProtoCodec{}),
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			assert.Equal(t, otlpLogsExportMethod, method)
+			var request []byte
+			if err := stream.RecvMsg(&request); err != nil {
+				return err
+			}
+			c.requests <- request
+			return stream.SendMsg(c.response)
+		}))
+	go func() {
+		_ = c.server.Serve(lis)
+	}()
+	t.Cleanup(c.server.Stop)
+	return c
+}
+
+// decodeOTLPMessages returns the values of the length-delimited fields of a protobuf message by field number
+func decodeOTLPMessages(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	t.Helper()
+	fields := map[protowire.Number][][]byte{}
+	require.NoError(t, consumeOTLPFields(b, func(num protowire.Number, typ protowire.Type, field []byte) (int, error) {
+		if typ != protowire.BytesType {
+			return -1, nil
+		}
+		value, n := protowire.ConsumeBytes(field)
+		fields[num] = append(fields[num], value)
+		return n, protowire.ParseError(n)
+	}))
+	return fields
+}
+
+// decodeOTLPAttributes returns the string and int attributes of KeyValue messages
+func decodeOTLPAttributes(t *testing.T, keyValues [][]byte) map[string]interface{} {
+	t.Helper()
+	attributes := map[string]interface{}{}
+	for _, keyValue := range keyValues {
+		fields := decodeOTLPMessages(t, keyValue)
+		key := string(fields[1][0])
+		require.NoError(t, consumeOTLPFields(fields[2][0], func(num protowire.Number, typ protowire.Type, field []byte) (int, error) {
+			switch typ {
+			case protowire.BytesType:
+				value, n := protowire.ConsumeString(field)
+				attributes[key] = value
+				return n, protowire.ParseError(n)
+			case protowire.VarintType:
+				value, n := protowire.ConsumeVarint(field)
+				attributes[key] = int64(value)
+				return n, protowire.ParseError(n)
+			}
+			return -1, nil
+		}))
+	}
+	return attributes
+}
+
+func TestOTLPExporter(t *testing.T) {
+	collector := newOTLPTestCollector(t, nil)
+	e, err := NewOTLPExporter(collector.address, true)
+	require.NoError(t, err)
+
+	event := newTestFlowEvent()
+	require.NoError(t, e.Export(event))
+	require.NoError(t, e.Export(event))
+	// Close flushes the queued events
+	require.NoError(t, e.Close())
+
+	var request []byte
+	select {
+	case request = <-collector.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the OTLP collector didn't receive any request")
+	}
+
+	resourceLogs := decodeOTLPMessages(t, request)[1]
+	require.Len(t, resourceLogs, 1)
+	resourceLogsFields := decodeOTLPMessages(t, resourceLogs[0])
+	resourceAttributes := decodeOTLPAttributes(t, decodeOTLPMessages(t, resourceLogsFields[1][0])[1])
+	assert.Equal(t, otlpServiceName, resourceAttributes["service.name"])
+
+	scopeLogs := decodeOTLPMessages(t, resourceLogsFields[2][0])
+	logRecords := scopeLogs[2]
+	require.Len(t, logRecords, 2)
+
+	logRecord := decodeOTLPMessages(t, logRecords[0])
+	body := decodeOTLPMessages(t, logRecord[5][0])
+	assert.Equal(t, event.Message, string(body[1][0]))
+	attributes := decodeOTLPAttributes(t, logRecord[6])
+	assert.Equal(t, map[string]interface{}{
+		"source.address":      "10.128.0.5",
+		"source.port":         int64(34567),
+		"destination.address": "10.128.1.6",
+		"destination.port":    int64(8080),
+		"network.transport":   "tcp",
+		"k8s.namespace.name":  "foo",
+		"k8s.pod.name":        "client",
+		"ovn.udn":             "foo/udn",
+		"ovn.interface":       "a1b2c3d4e5f6g7h",
+		"ovn.verdict":         VerdictDeny,
+		"ovn.acl.action":      aclActionDrop,
+		"ovn.acl.actor":       "NetworkPolicy",
+		"ovn.acl.name":        "deny-all",
+		"ovn.acl.namespace":   "foo",
+		"ovn.acl.direction":   "Ingress",
+	}, attributes)
+}
+
+func TestOTLPExporterPartialSuccess(t *testing.T) {
+	var partialSuccess []byte
+	partialSuccess = protowire.AppendTag(partialSuccess, 1, protowire.VarintType)
+	partialSuccess = protowire.AppendVarint(partialSuccess, 1)
+	partialSuccess = appendOTLPString(partialSuccess, 2, "invalid record")
+	collector := newOTLPTestCollector(t, appendOTLPMessage(nil, 1, partialSuccess))
+
+	e, err := NewOTLPExporter(collector.address, true)
+	require.NoError(t, err)
+	defer e.Close()
+
+	err = e.(*otlpExporter).export([]*FlowEvent{newTestFlowEvent()})
+	assert.ErrorContains(t, err, "1 events rejected: invalid record")
+}
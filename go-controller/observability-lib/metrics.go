@@ -0,0 +1,64 @@
+package observability_lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+// metricEgressFirewallRuleHits counts the decoded samples of egress firewall ACLs. When the sampling probability
+// of egress firewalls is lower than 100%, the number of hits of a rule is the count divided by the probability.
+var metricEgressFirewallRuleHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemObserv,
+	Name:      "egress_firewall_rule_hits_total",
+	Help:      "The number of sampled packets matching an egress firewall rule, by namespace, rule index and action"},
+	[]string{
+		"namespace",
+		"rule_index",
+		"action",
+	},
+)
+
+// recordNetworkEventMetrics updates the metrics for a decoded network event
+func recordNetworkEventMetrics(event model.NetworkEvent) {
+	egressFirewallEvent, ok := event.(*model.EgressFirewallEvent)
+	if !ok || egressFirewallEvent.RuleIndex == "" {
+		return
+	}
+	metricEgressFirewallRuleHits.WithLabelValues(egressFirewallEvent.Namespace, egressFirewallEvent.RuleIndex,
+		egressFirewallEvent.Action).Inc()
+}
+
+// startMetricsServer registers the metrics and serves them on bindAddress until the context is done
+func startMetricsServer(ctx context.Context, bindAddress string) error {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(metricEgressFirewallRuleHits); err != nil {
+		return fmt.Errorf("failed to register metrics: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: bindAddress, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Metrics server exited with error: %v\n", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			fmt.Printf("Error stopping metrics server: %v\n", err)
+		}
+	}()
+	return nil
+}
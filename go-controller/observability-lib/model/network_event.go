@@ -39,20 +39,25 @@ type ACLEvent struct {
 	Name      string
 	Namespace string
 	Direction string
+	// RuleIndex is the index of the egress firewall rule, only set for egress firewall events
+	RuleIndex string
 }
 
-func (e *ACLEvent) String() string {
-	var action string
-	switch e.Action {
+func getActionString(aclAction string) string {
+	switch aclAction {
 	case aclActionAllow, aclActionAllowRelated, aclActionAllowStateless:
-		action = "Allowed"
+		return "Allowed"
 	case aclActionDrop:
-		action = "Dropped"
+		return "Dropped"
 	case aclActionPass:
-		action = "Delegated to network policy"
+		return "Delegated to network policy"
 	default:
-		action = "Action " + e.Action
+		return "Action " + aclAction
 	}
+}
+
+func (e *ACLEvent) String() string {
+	action := getActionString(e.Action)
 	var msg string
 	switch e.Actor {
 	case adminNetworkPolicyOwnerType:
@@ -74,9 +79,46 @@ func (e *ACLEvent) String() string {
 	case netpolNamespaceOwnerType:
 		msg = fmt.Sprintf("network policies isolation in namespace %s, direction %s", e.Namespace, e.Direction)
 	case egressFirewallOwnerType:
-		msg = fmt.Sprintf("egress firewall in namespace %s", e.Namespace)
+		if e.RuleIndex != "" {
+			msg = fmt.Sprintf("egress firewall rule %s in namespace %s", e.RuleIndex, e.Namespace)
+		} else {
+			msg = fmt.Sprintf("egress firewall in namespace %s", e.Namespace)
+		}
 	case udnIsolationOwnerType:
 		msg = fmt.Sprintf("UDN isolation of type %s", e.Name)
 	}
 	return fmt.Sprintf("%s by %s", action, msg)
 }
+
+// EgressFirewallEvent is the verdict of an egress firewall rule
+type EgressFirewallEvent struct {
+	NetworkEvent
+	Action    string
+	Namespace string
+	// RuleIndex is the index of the egress firewall rule, empty for the default deny of the egress firewall
+	RuleIndex string
+}
+
+func (e *EgressFirewallEvent) String() string {
+	if e.RuleIndex != "" {
+		return fmt.Sprintf("%s by egress firewall rule %s in namespace %s", getActionString(e.Action), e.RuleIndex, e.Namespace)
+	}
+	return fmt.Sprintf("%s by egress firewall in namespace %s", getActionString(e.Action), e.Namespace)
+}
+
+// UDNIsolationEvent is the verdict of the isolation between a primary (C)UDN and the default network
+type UDNIsolationEvent struct {
+	NetworkEvent
+	Action string
+	// Name is the name of the isolation ACL, e.g. DenyPrimaryUDN or OpenPort-<namespace>/<pod>
+	Name      string
+	Direction string
+}
+
+func (e *UDNIsolationEvent) String() string {
+	msg := fmt.Sprintf("%s by UDN isolation of type %s", getActionString(e.Action), e.Name)
+	if e.Direction != "" {
+		msg += ", direction " + e.Direction
+	}
+	return msg
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"github.com/google/gopacket"
@@ -19,7 +20,10 @@ import (
 	"github.com/vishvananda/netlink/nl"
 	"golang.org/x/sys/unix"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/exporter"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
 )
 
 const (
@@ -52,22 +56,28 @@ type SampleReader struct {
 	logCookie       bool
 	printFullPacket bool
 	addOVSCollector bool
+	collectorSetID  int
 	srcIP, dstIP    string
 	outputFile      string
+	metricsAddress  string
 
-	decoder   *sampledecoder.SampleDecoder
-	cookieStr []string
+	decoder       *sampledecoder.SampleDecoder
+	cookieStr     []string
+	exporters     []exporter.Exporter
+	podInterfaces *podInterfaces
 }
 
-func NewSampleReader(enableDecoder, logCookie, printFullPacket, addOVSCollector bool, srcIP, dstIP, outputFile string) *SampleReader {
+func NewSampleReader(enableDecoder, logCookie, printFullPacket, addOVSCollector bool, srcIP, dstIP, outputFile, metricsAddress string) *SampleReader {
 	r := &SampleReader{
 		enableDecoder:   enableDecoder,
 		logCookie:       logCookie,
 		printFullPacket: printFullPacket,
 		addOVSCollector: addOVSCollector,
+		collectorSetID:  observability.DefaultObservabilityCollectorSetID,
 		srcIP:           srcIP,
 		dstIP:           dstIP,
 		outputFile:      outputFile,
+		metricsAddress:  metricsAddress,
 	}
 	if logCookie {
 		r.cookieStr = make([]string, 2)
@@ -75,17 +85,45 @@ func NewSampleReader(enableDecoder, logCookie, printFullPacket, addOVSCollector
 	return r
 }
 
+// SetExporters sets the exporters the decoded samples are exported with, enriched with the pod of the interface
+// they were received on. The exporters are closed when ReadSamples returns.
+func (r *SampleReader) SetExporters(exporters ...exporter.Exporter) {
+	r.exporters = exporters
+}
+
+// SetCollectorSetID sets the collector set of the OVS collector added with addOVSCollector, it must match the
+// collectorSetID of the ObservabilityConfig the samples are read for.
+func (r *SampleReader) SetCollectorSetID(collectorSetID int) {
+	r.collectorSetID = collectorSetID
+}
+
 func (r *SampleReader) ReadSamples(ctx context.Context) error {
+	defer func() {
+		for _, e := range r.exporters {
+			if err := e.Close(); err != nil {
+				fmt.Printf("Error closing exporter: %v\n", err)
+			}
+		}
+	}()
+	if len(r.exporters) > 0 && !r.enableDecoder {
+		return fmt.Errorf("exporters require samples enrichment to be enabled")
+	}
 	if r.enableDecoder {
 		var err error
 		// currently only local nbdb connection is supported.
 		nbdbSocketPath := "/var/run/ovn/ovnnb_db.sock"
 		if r.addOVSCollector {
-			r.decoder, err = sampledecoder.NewSampleDecoderWithDefaultCollector(ctx, nbdbSocketPath, "ovnk-debug", 123)
+			r.decoder, err = sampledecoder.NewSampleDecoderWithCollector(ctx, nbdbSocketPath, r.collectorSetID, "ovnk-debug", 123)
 			if err != nil {
 				return fmt.Errorf("error creating decoder: %w", err)
 			}
 			defer r.decoder.Shutdown()
+		} else if len(r.exporters) > 0 {
+			// exporters need the OVS interfaces to find the pods of the samples
+			r.decoder, err = sampledecoder.NewSampleDecoderWithOVSDB(ctx, nbdbSocketPath)
+			if err != nil {
+				return fmt.Errorf("error creating decoder: %w", err)
+			}
 		} else {
 			r.decoder, err = sampledecoder.NewSampleDecoder(ctx, nbdbSocketPath)
 			if err != nil {
@@ -93,6 +131,17 @@ func (r *SampleReader) ReadSamples(ctx context.Context) error {
 			}
 		}
 	}
+	if len(r.exporters) > 0 {
+		r.podInterfaces = newPodInterfaces(r.decoder)
+	}
+	if r.metricsAddress != "" {
+		if !r.enableDecoder {
+			return fmt.Errorf("metrics require samples enrichment to be enabled")
+		}
+		if err := startMetricsServer(ctx, r.metricsAddress); err != nil {
+			return err
+		}
+	}
 	var writer io.Writer
 	if r.outputFile != "" {
 		file, err := os.Create(r.outputFile)
@@ -180,8 +229,18 @@ var hostEndian = getHostEndian()
 func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(a ...any)) error {
 	for _, msg := range msgs {
 		var packetStr, sampleStr string
+		var event model.NetworkEvent
+		var packet gopacket.Packet
+		var ifindex uint16
+		sampleTime := time.Now()
 		data := msg.Data[nl.SizeofGenlmsg:]
 		for attr := range nl.ParseAttributes(data) {
+			if attr.Type == PSAMPLE_ATTR_IIFINDEX && len(attr.Value) == 2 {
+				ifindex = hostEndian.Uint16(attr.Value)
+			}
+			if attr.Type == PSAMPLE_ATTR_TIMESTAMP && len(attr.Value) == 8 {
+				sampleTime = time.Unix(0, int64(hostEndian.Uint64(attr.Value)))
+			}
 			if r.logCookie && attr.Type == PSAMPLE_ATTR_SAMPLE_GROUP {
 				if uint64(len(attr.Value)) == 4 {
 					g := uint32(0)
@@ -210,12 +269,13 @@ func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(
 							sampleStr = fmt.Sprintf("decoding failed: %v", err)
 						} else {
 							sampleStr = fmt.Sprintf("OVN-K message: %s", decoded.String())
+							event = decoded
 						}
 					}
 				}
 			}
 			if attr.Type == PSAMPLE_ATTR_DATA {
-				packet := gopacket.NewPacket(attr.Value, layers.LayerTypeEthernet, gopacket.Lazy)
+				packet = gopacket.NewPacket(attr.Value, layers.LayerTypeEthernet, gopacket.Lazy)
 				networkLayer := packet.NetworkLayer().NetworkFlow()
 				if r.printFullPacket {
 					packetStr = packet.String()
@@ -237,7 +297,53 @@ func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(
 		if r.decoder != nil {
 			printlnFunc(sampleStr)
 		}
+		if event != nil {
+			recordNetworkEventMetrics(event)
+			if len(r.exporters) > 0 {
+				r.exportNetworkEvent(sampleTime, event, packet, uint32(ifindex), printlnFunc)
+			}
+		}
 		printlnFunc(packetStr)
 	}
 	return nil
 }
+
+// exportNetworkEvent exports the network event with the sampled packet and the pod of the interface it was
+// received on
+func (r *SampleReader) exportNetworkEvent(sampleTime time.Time, event model.NetworkEvent, packet gopacket.Packet,
+	ifindex uint32, printlnFunc func(a ...any)) {
+	flowEvent := exporter.NewFlowEvent(sampleTime, event)
+	if packet != nil {
+		setFlowEventPacket(flowEvent, packet)
+	}
+	if ifindex != 0 {
+		if err := r.podInterfaces.enrich(flowEvent, ifindex); err != nil {
+			printlnFunc("ERROR: failed to find the pod of the sample:", err)
+		}
+	}
+	for _, e := range r.exporters {
+		if err := e.Export(flowEvent); err != nil {
+			printlnFunc("ERROR: export failed:", err)
+		}
+	}
+}
+
+// setFlowEventPacket sets the addresses, protocol and ports of the sampled packet
+func setFlowEventPacket(flowEvent *exporter.FlowEvent, packet gopacket.Packet) {
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		flowEvent.SrcIP, flowEvent.DstIP = ip.SrcIP, ip.DstIP
+		flowEvent.Protocol = uint8(ip.Protocol)
+	case *layers.IPv6:
+		flowEvent.SrcIP, flowEvent.DstIP = ip.SrcIP, ip.DstIP
+		flowEvent.Protocol = uint8(ip.NextHeader)
+	}
+	switch transport := packet.TransportLayer().(type) {
+	case *layers.TCP:
+		flowEvent.SrcPort, flowEvent.DstPort = uint16(transport.SrcPort), uint16(transport.DstPort)
+	case *layers.UDP:
+		flowEvent.SrcPort, flowEvent.DstPort = uint16(transport.SrcPort), uint16(transport.DstPort)
+	case *layers.SCTP:
+		flowEvent.SrcPort, flowEvent.DstPort = uint16(transport.SrcPort), uint16(transport.DstPort)
+	}
+}
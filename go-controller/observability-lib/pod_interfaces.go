@@ -0,0 +1,76 @@
+package observability_lib
+
+import (
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/exporter"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+)
+
+// podInterfacesMinSyncInterval limits how often the pod interfaces are listed when an unknown interface is sampled
+const podInterfacesMinSyncInterval = 5 * time.Second
+
+// podInterfaces caches the pods and (C)UDNs of the pod interfaces, and the names of the sampled interfaces
+type podInterfaces struct {
+	decoder  *sampledecoder.SampleDecoder
+	lastSync time.Time
+	names    map[uint32]string
+	pods     map[string]types.NamespacedName
+	udns     map[string]string
+}
+
+func newPodInterfaces(decoder *sampledecoder.SampleDecoder) *podInterfaces {
+	return &podInterfaces{
+		decoder: decoder,
+		names:   map[uint32]string{},
+	}
+}
+
+// enrich sets the interface, pod and UDN of the event from the index of the interface the sampled packet
+// was received on
+func (p *podInterfaces) enrich(event *exporter.FlowEvent, ifindex uint32) error {
+	name, found := p.names[ifindex]
+	if !found || p.pods[name] == (types.NamespacedName{}) {
+		// interfaces are added with the pods and their indexes may be reused, sync again
+		if err := p.sync(); err != nil {
+			return err
+		}
+		name = p.names[ifindex]
+		if name == "" {
+			if link, err := netlink.LinkByIndex(int(ifindex)); err == nil {
+				name = link.Attrs().Name
+				p.names[ifindex] = name
+			}
+		}
+	}
+	event.Interface = name
+	if pod, ok := p.pods[name]; ok {
+		event.PodNamespace = pod.Namespace
+		event.PodName = pod.Name
+		event.UDN = p.udns[name]
+	}
+	return nil
+}
+
+func (p *podInterfaces) sync() error {
+	if time.Since(p.lastSync) < podInterfacesMinSyncInterval {
+		return nil
+	}
+	p.lastSync = time.Now()
+	pods, err := p.decoder.GetInterfacePods()
+	if err != nil {
+		return err
+	}
+	udns, err := p.decoder.GetInterfaceUDNs()
+	if err != nil {
+		return err
+	}
+	p.pods = pods
+	p.udns = udns
+	p.names = map[uint32]string{}
+	return nil
+}
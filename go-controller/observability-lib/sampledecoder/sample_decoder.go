@@ -9,6 +9,8 @@ import (
 
 	"github.com/ovn-kubernetes/libovsdb/client"
 
+	"k8s.io/apimachinery/pkg/types"
+
 	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/ovsdb"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
@@ -70,6 +72,14 @@ func getLocalOVSDBClient(ctx context.Context) (client.Client, error) {
 // If the default collector already exists with a different owner or different groupID an error will be returned.
 // Shutdown should be called to clean up the collector from the OVSDB.
 func NewSampleDecoderWithDefaultCollector(ctx context.Context, nbdbSocketPath string, ownerName string, groupID int) (*SampleDecoder, error) {
+	return NewSampleDecoderWithCollector(ctx, nbdbSocketPath, observability.DefaultObservabilityCollectorSetID, ownerName, groupID)
+}
+
+// NewSampleDecoderWithCollector creates a new SampleDecoder, initializes the OVSDB client and adds a collector for the
+// collectorSetID, e.g. the collectorSetID of an ObservabilityConfig.
+// If the collector already exists with a different owner or different groupID an error will be returned.
+// Shutdown should be called to clean up the collector from the OVSDB.
+func NewSampleDecoderWithCollector(ctx context.Context, nbdbSocketPath string, collectorSetID int, ownerName string, groupID int) (*SampleDecoder, error) {
 	nbClient, err := getLocalNBClient(ctx, nbdbSocketPath)
 	if err != nil {
 		return nil, err
@@ -82,11 +92,11 @@ func NewSampleDecoderWithDefaultCollector(ctx context.Context, nbdbSocketPath st
 		nbClient:    nbClient,
 		ovsdbClient: ovsdbClient,
 	}
-	err = decoder.AddCollector(observability.DefaultObservabilityCollectorSetID, groupID, ownerName)
+	err = decoder.AddCollector(collectorSetID, groupID, ownerName)
 	if err != nil {
 		return nil, err
 	}
-	decoder.cleanupCollectors = append(decoder.cleanupCollectors, observability.DefaultObservabilityCollectorSetID)
+	decoder.cleanupCollectors = append(decoder.cleanupCollectors, collectorSetID)
 	return decoder, nil
 }
 
@@ -101,6 +111,23 @@ func NewSampleDecoder(ctx context.Context, nbdbSocketPath string) (*SampleDecode
 	}, nil
 }
 
+// NewSampleDecoderWithOVSDB creates a new SampleDecoder and initializes both the NBDB and the OVSDB clients.
+// The OVSDB client is required to find the pods of the OVS interfaces, see GetInterfaceUDNs and GetInterfacePods.
+func NewSampleDecoderWithOVSDB(ctx context.Context, nbdbSocketPath string) (*SampleDecoder, error) {
+	nbClient, err := getLocalNBClient(ctx, nbdbSocketPath)
+	if err != nil {
+		return nil, err
+	}
+	ovsdbClient, err := getLocalOVSDBClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SampleDecoder{
+		nbClient:    nbClient,
+		ovsdbClient: ovsdbClient,
+	}, nil
+}
+
 func (d *SampleDecoder) Shutdown() {
 	for _, collectorID := range d.cleanupCollectors {
 		err := d.DeleteCollector(collectorID)
@@ -122,6 +149,19 @@ func findACLBySample(nbClient client.Client, acl *nbdb.ACL) ([]*nbdb.ACL, error)
 }
 
 func (d *SampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.NetworkEvent, error) {
+	acl, err := d.findSampledACL(obsDomainID, obsPointID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := newACLNetworkEvent(acl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ACL network event: %w", err)
+	}
+	return event, nil
+}
+
+// findSampledACL returns the ACL sampled with the given observation IDs
+func (d *SampleDecoder) findSampledACL(obsDomainID, obsPointID uint32) (*nbdb.ACL, error) {
 	// Find sample using obsPointID
 	sample, err := libovsdbops.FindSample(d.nbClient, int(obsPointID))
 	if err != nil || sample == nil {
@@ -132,39 +172,43 @@ func (d *SampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.N
 	// we need to make sure the other one will not match.
 	// nil is a valid index value, therefore we have to use non-existing UUID.
 	wrongUUID := "wrongUUID"
-	var dbObj interface{}
+	var acls []*nbdb.ACL
 	switch getObservAppID(obsDomainID) {
 	case observability.ACLNewTrafficSamplingID:
-		acls, err := findACLBySample(d.nbClient, &nbdb.ACL{SampleNew: &sample.UUID, SampleEst: &wrongUUID})
-		if err != nil {
-			return nil, fmt.Errorf("find acl for sample failed: %w", err)
-		}
-		if len(acls) != 1 {
-			return nil, fmt.Errorf("expected 1 ACL, got %d", len(acls))
-		}
-		dbObj = acls[0]
+		acls, err = findACLBySample(d.nbClient, &nbdb.ACL{SampleNew: &sample.UUID, SampleEst: &wrongUUID})
 	case observability.ACLEstTrafficSamplingID:
-		acls, err := findACLBySample(d.nbClient, &nbdb.ACL{SampleNew: &wrongUUID, SampleEst: &sample.UUID})
-		if err != nil {
-			return nil, fmt.Errorf("find acl for sample failed: %w", err)
-		}
-		if len(acls) != 1 {
-			return nil, fmt.Errorf("expected 1 ACL, got %d", len(acls))
-		}
-		dbObj = acls[0]
+		acls, err = findACLBySample(d.nbClient, &nbdb.ACL{SampleNew: &wrongUUID, SampleEst: &sample.UUID})
 	default:
 		return nil, fmt.Errorf("unknown app ID: %d", getObservAppID(obsDomainID))
 	}
-	var event model.NetworkEvent
-	switch o := dbObj.(type) {
-	case *nbdb.ACL:
-		event, err = newACLEvent(o)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build ACL network event: %w", err)
-		}
+	if err != nil {
+		return nil, fmt.Errorf("find acl for sample failed: %w", err)
 	}
-	if event == nil {
-		return nil, fmt.Errorf("failed to build network event for db object %v", dbObj)
+	if len(acls) != 1 {
+		return nil, fmt.Errorf("expected 1 ACL, got %d", len(acls))
+	}
+	return acls[0], nil
+}
+
+// newACLNetworkEvent returns the network event of an ACL, depending on the feature that owns the ACL
+func newACLNetworkEvent(o *nbdb.ACL) (model.NetworkEvent, error) {
+	switch o.ExternalIDs[libovsdbops.OwnerTypeKey.String()] {
+	case libovsdbops.EgressFirewallOwnerType:
+		return &model.EgressFirewallEvent{
+			Action:    o.Action,
+			Namespace: o.ExternalIDs[libovsdbops.ObjectNameKey.String()],
+			RuleIndex: o.ExternalIDs[libovsdbops.RuleIndex.String()],
+		}, nil
+	case libovsdbops.UDNIsolationOwnerType:
+		return &model.UDNIsolationEvent{
+			Action:    o.Action,
+			Name:      o.ExternalIDs[libovsdbops.ObjectNameKey.String()],
+			Direction: o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()],
+		}, nil
+	}
+	event, err := newACLEvent(o)
+	if err != nil {
+		return nil, err
 	}
 	return event, nil
 }
@@ -194,11 +238,6 @@ func newACLEvent(o *nbdb.ACL) (*model.ACLEvent, error) {
 		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
 	case libovsdbops.MulticastClusterOwnerType:
 		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
-	case libovsdbops.EgressFirewallOwnerType:
-		event.Namespace = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
-		event.Direction = "Egress"
-	case libovsdbops.UDNIsolationOwnerType:
-		event.Name = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
 	case libovsdbops.NetpolNodeOwnerType:
 		event.Direction = "Ingress"
 	}
@@ -311,16 +350,11 @@ func networkNameToUDNNamespacedName(networkName string) string {
 // UDN namespace+name are joined by "/", CUDN will just have a name.
 func (d *SampleDecoder) GetInterfaceUDNs() (map[string]string, error) {
 	res := map[string]string{}
-	ifaces := []*ovsdb.Interface{}
-	err := d.ovsdbClient.List(context.Background(), &ifaces)
+	ifaces, err := d.listPodInterfaces()
 	if err != nil {
-		return nil, fmt.Errorf("failed listing interfaces: %w", err)
+		return nil, err
 	}
 	for _, iface := range ifaces {
-		if iface.ExternalIDs["iface-id-ver"] == "" || iface.ExternalIDs["iface-id"] == "" {
-			// not a pod interface
-			continue
-		}
 		if iface.ExternalIDs["k8s.ovn.org/network"] == "" {
 			res[iface.Name] = ""
 			continue
@@ -329,3 +363,37 @@ func (d *SampleDecoder) GetInterfaceUDNs() (map[string]string, error) {
 	}
 	return res, nil
 }
+
+// GetInterfacePods returns a map of all pod interface names to their pod namespaced names.
+func (d *SampleDecoder) GetInterfacePods() (map[string]types.NamespacedName, error) {
+	res := map[string]types.NamespacedName{}
+	ifaces, err := d.listPodInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range ifaces {
+		// iface-id is namespace_pod, prefixed with the network name for (C)UDNs.
+		// Neither namespace nor pod names can have underscores.
+		parts := strings.Split(iface.ExternalIDs["iface-id"], "_")
+		if len(parts) < 2 {
+			continue
+		}
+		res[iface.Name] = types.NamespacedName{Namespace: parts[len(parts)-2], Name: parts[len(parts)-1]}
+	}
+	return res, nil
+}
+
+// listPodInterfaces returns the OVS interfaces of the pods
+func (d *SampleDecoder) listPodInterfaces() ([]*ovsdb.Interface, error) {
+	if d.ovsdbClient == nil {
+		return nil, fmt.Errorf("OVSDB client is not initialized")
+	}
+	ifaces := []*ovsdb.Interface{}
+	err := d.ovsdbClient.WhereCache(func(item *ovsdb.Interface) bool {
+		return item.ExternalIDs["iface-id-ver"] != "" && item.ExternalIDs["iface-id"] != ""
+	}).List(context.Background(), &ifaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing interfaces: %w", err)
+	}
+	return ifaces, nil
+}
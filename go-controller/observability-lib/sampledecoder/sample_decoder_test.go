@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -46,6 +47,18 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	assert.Equal(t, "Allowed by admin network policy foo, direction Ingress", event.String())
 
 	event, err = newACLEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionAllow,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String(): libovsdbops.NetpolNodeOwnerType,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Allowed by default allow from local node policy, direction Ingress", event.String())
+	assert.Equal(t, "Ingress", event.Direction)
+}
+
+func TestNewACLNetworkEvent(t *testing.T) {
+	event, err := newACLNetworkEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
@@ -54,15 +67,47 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Equal(t, "Allowed by egress firewall in namespace foo", event.String())
-	assert.Equal(t, "Egress", event.Direction)
 
-	event, err = newACLEvent(&nbdb.ACL{
+	event, err = newACLNetworkEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionDrop,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
+			libovsdbops.ObjectNameKey.String(): "foo",
+			libovsdbops.RuleIndex.String():     "2",
+		},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &model.EgressFirewallEvent{}, event)
+	assert.Equal(t, "Dropped by egress firewall rule 2 in namespace foo", event.String())
+	assert.Equal(t, "2", event.(*model.EgressFirewallEvent).RuleIndex)
+
+	event, err = newACLNetworkEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionDrop,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.UDNIsolationOwnerType,
+			libovsdbops.ObjectNameKey.String():      "DenyPrimaryUDN",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLEgress),
+		},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &model.UDNIsolationEvent{}, event)
+	assert.Equal(t, "Dropped by UDN isolation of type DenyPrimaryUDN, direction Egress", event.String())
+
+	event, err = newACLNetworkEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String(): libovsdbops.NetpolNodeOwnerType,
 		},
 	})
 	require.NoError(t, err)
-	assert.Equal(t, "Allowed by default allow from local node policy, direction Ingress", event.String())
-	assert.Equal(t, "Ingress", event.Direction)
+	require.IsType(t, &model.ACLEvent{}, event)
+
+	_, err = newACLNetworkEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionAllow,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.NetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String(): "foo",
+		},
+	})
+	assert.Error(t, err)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressfirewallclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned"
 	egressfirewalllister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -32,6 +33,7 @@ import (
 type Controller struct {
 	lock             sync.Mutex
 	ocpNetworkClient ocpnetworkclientset.Interface
+	efClient         egressfirewallclientset.Interface
 
 	// controller for egress firewall
 	efController controller.Controller
@@ -41,6 +43,9 @@ type Controller struct {
 	dnsController controller.Controller
 	// Lister for dns name resolver
 	dnsLister ocpnetworklisterv1alpha1.DNSNameResolverLister
+	// controller updating the status of the egress firewalls with the
+	// resolution of their DNS names
+	efStatusController controller.Reconciler
 
 	resInfo *resolverInfo
 }
@@ -50,6 +55,7 @@ type Controller struct {
 func NewController(ovnClient *util.OVNClusterManagerClientset, wf *factory.WatchFactory) *Controller {
 	c := &Controller{
 		ocpNetworkClient: ovnClient.OCPNetworkClient,
+		efClient:         ovnClient.EgressFirewallClient,
 		resInfo:          newResolverInfo(ovnClient.OCPNetworkClient),
 	}
 	c.initControllers(wf)
@@ -82,6 +88,13 @@ func (c *Controller) initControllers(watchFactory *factory.WatchFactory) {
 		Threadiness:    1,
 	}
 	c.dnsController = controller.NewController[ocpnetworkapiv1alpha1.DNSNameResolver]("cm-dns-controller", dnsConfig)
+
+	efStatusConfig := &controller.ReconcilerConfig{
+		RateLimiter: workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
+		Reconcile:   c.reconcileEgressFirewallStatus,
+		Threadiness: 1,
+	}
+	c.efStatusController = controller.NewReconciler("cm-ef-status-controller", efStatusConfig)
 }
 
 // efNeedsUpdate returns true if an egress firewall object is either added
@@ -97,18 +110,19 @@ func efNeedsUpdate(oldObj, newObj *egressfirewall.EgressFirewall) bool {
 // dnsNeedsUpdate returns true if a dns name resolver object is either added
 // or deleted. The spec of a dns name resolver object is immutable. If the
 // status of a dns name resolver is updated, then dnsNeedsUpdate returns
-// false.
+// true so that the status of the egress firewalls using the DNS name is
+// updated.
 func dnsNeedsUpdate(oldObj, newObj *ocpnetworkapiv1alpha1.DNSNameResolver) bool {
 	if oldObj == nil && newObj != nil || oldObj != nil && newObj == nil {
 		return true
 	}
-	return false
+	return !reflect.DeepEqual(oldObj.Status, newObj.Status)
 }
 
 // Start initializes the handlers for EgressFirewall and DNSNameResolver
 // by watching the corresponding resource types.
 func (c *Controller) Start() error {
-	if err := controller.StartWithInitialSync(c.syncDNSNames, c.efController, c.dnsController); err != nil {
+	if err := controller.StartWithInitialSync(c.syncDNSNames, c.efController, c.dnsController, c.efStatusController); err != nil {
 		return fmt.Errorf("unable to start egress firewall and dns name resolver controllers %w", err)
 	}
 	return nil
@@ -117,7 +131,7 @@ func (c *Controller) Start() error {
 // Stop gracefully stops the controller. The handlers for EgressFirewall
 // and DNSNameResolver are removed.
 func (c *Controller) Stop() {
-	controller.Stop(c.efController, c.dnsController)
+	controller.Stop(c.efController, c.dnsController, c.efStatusController)
 }
 
 // syncDNSNames syncs the existing EgressFirewall and DNSNameResolver objects
@@ -181,7 +195,11 @@ func (c *Controller) reconcileEgressFirewall(key string) error {
 	// newly added and create the corresponding DNSNameResolver objects. Also
 	// check the DNS names which are deleted and delete the corresponding
 	// DNSNameResolver objects.
-	return c.resInfo.ModifyDNSNamesForNamespace(util.GetDNSNames(ef), namespace)
+	err = c.resInfo.ModifyDNSNamesForNamespace(util.GetDNSNames(ef), namespace)
+
+	// Update the status with the resolution of the current DNS names.
+	c.efStatusController.Reconcile(key)
+	return err
 }
 
 // reconcileDNSNameResolver reconciles a DNSNameResolver object. If an object
@@ -210,8 +228,10 @@ func (c *Controller) reconcileDNSNameResolver(key string) error {
 				return nil
 			}
 
-			// Recreate the DNSNameResolver object.
+			// Recreate the DNSNameResolver object. The addresses of the deleted
+			// object are gone until the recreated object is resolved again.
 			klog.Warningf("Recreating deleted dns name resolver object %s for dns name %s", name, dnsName)
+			c.reconcileEgressFirewallStatusForDNSName(dnsName)
 			return createDNSNameResolver(c.ocpNetworkClient, name, dnsName)
 
 		}
@@ -228,6 +248,10 @@ func (c *Controller) reconcileDNSNameResolver(key string) error {
 		klog.Warningf("Deleting additional dns name resolver object %s for dns name %s", resolverObj.Name, dnsName)
 		return deleteDNSNameResolver(c.ocpNetworkClient, resolverObj.Name)
 	}
+
+	// The addresses of the DNS name may have changed, update the status of
+	// the egress firewalls using it.
+	c.reconcileEgressFirewallStatusForDNSName(dnsName)
 	return nil
 }
 
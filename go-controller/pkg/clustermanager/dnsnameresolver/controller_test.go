@@ -178,6 +178,65 @@ var _ = ginkgo.Describe("Cluster manager DNS Name Resolver Controller operations
 			ginkgo.By("checking if the corresponding dns name resolver object got created")
 			checkDNSNameResolverExists(dnsName)
 		})
+		ginkgo.It("correctly report the names resolved by a wildcard dns name in the egress firewall status", func() {
+			var err error
+			dnsName := "*.example.com"
+			namespace := "namespace1"
+			egressFirewall := buildEgressFirewall("default", namespace, dnsName)
+			ginkgo.By("starting the cluster manager with the egress firewall object")
+			start(egressFirewall)
+
+			ginkgo.By("checking if the dns names are reported without resolved names")
+			getDNSNameStatus := func() []egressfirewallapi.EgressFirewallDNSNameStatus {
+				ef, err := fakeClient.EgressFirewallClient.K8sV1().EgressFirewalls(namespace).
+					Get(context.TODO(), egressFirewall.Name, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				return ef.Status.DNSNames
+			}
+			gomega.Eventually(getDNSNameStatus).WithTimeout(5 * time.Second).Should(gomega.Equal(
+				[]egressfirewallapi.EgressFirewallDNSNameStatus{{DNSName: "*.example.com."}}))
+
+			ginkgo.By("resolving subdomains of the wildcard dns name")
+			dnsNameResolver := checkDNSNameResolverExists(dnsName).DeepCopy()
+			now := metav1.Now()
+			expired := metav1.NewTime(now.Add(-time.Hour))
+			dnsNameResolver.Status.ResolvedNames = []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+				{
+					DNSName: "www.example.com.",
+					ResolvedAddresses: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{
+						{IP: "1.1.1.1", TTLSeconds: 300, LastLookupTime: &now},
+						{IP: "1.1.1.2", TTLSeconds: 300, LastLookupTime: &now},
+					},
+				},
+				{
+					DNSName: "api.example.com.",
+					ResolvedAddresses: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{
+						{IP: "2.2.2.2", TTLSeconds: 300, LastLookupTime: &now},
+					},
+				},
+				{
+					DNSName: "old.example.com.",
+					ResolvedAddresses: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{
+						{IP: "3.3.3.3", TTLSeconds: 300, LastLookupTime: &expired},
+					},
+				},
+			}
+			_, err = fakeClient.OCPNetworkClient.NetworkV1alpha1().DNSNameResolvers(config.Kubernetes.OVNConfigNamespace).
+				UpdateStatus(context.TODO(), dnsNameResolver, metav1.UpdateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("checking if the resolved subdomains which have not expired are reported")
+			gomega.Eventually(getDNSNameStatus).WithTimeout(5 * time.Second).Should(gomega.Equal(
+				[]egressfirewallapi.EgressFirewallDNSNameStatus{
+					{
+						DNSName: "*.example.com.",
+						ResolvedNames: []egressfirewallapi.EgressFirewallResolvedName{
+							{Name: "api.example.com.", IPCount: 1},
+							{Name: "www.example.com.", IPCount: 2},
+						},
+					},
+				}))
+		})
 		ginkgo.It("correctly delete a dns name resolver", func() {
 			var err error
 			dnsName := "www.example.com"
@@ -0,0 +1,115 @@
+package dnsnameresolver
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressfirewallapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/applyconfiguration/egressfirewall/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// egressFirewallStatusFieldManager is the field manager owning the
+// .status.dnsNames field of the EgressFirewall objects.
+const egressFirewallStatusFieldManager = "ovnkube-cluster-manager-dns-name-resolver"
+
+// reconcileEgressFirewallStatusForDNSName queues the status update of the
+// EgressFirewall objects using the DNS name.
+func (c *Controller) reconcileEgressFirewallStatusForDNSName(dnsName string) {
+	for _, namespace := range c.resInfo.GetNamespacesForDNSName(dnsName) {
+		efs, err := c.efLister.EgressFirewalls(namespace).List(labels.Everything())
+		if err != nil {
+			klog.Errorf("Failed to list egress firewalls in namespace %s: %v", namespace, err)
+			continue
+		}
+		for _, ef := range efs {
+			c.efStatusController.Reconcile(ef.Namespace + "/" + ef.Name)
+		}
+	}
+}
+
+// reconcileEgressFirewallStatus updates the .status.dnsNames field of an
+// EgressFirewall object with the names each of its DNS names currently
+// resolves, along with the number of IP addresses of each name.
+func (c *Controller) reconcileEgressFirewallStatus(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.Errorf("reconcileEgressFirewallStatus failed to split meta namespace cache key %s for egress firewall: %v", key, err)
+		return nil
+	}
+	ef, err := c.efLister.EgressFirewalls(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	dnsNames, nextExpiry, err := c.getEgressFirewallDNSNameStatus(ef, time.Now())
+	if err != nil {
+		return err
+	}
+	// Reconcile the object again when the next subdomain address expires.
+	if !nextExpiry.IsZero() {
+		c.efStatusController.ReconcileAfter(key, time.Until(nextExpiry))
+	}
+	if reflect.DeepEqual(dnsNames, ef.Status.DNSNames) {
+		return nil
+	}
+
+	applyStatus := egressfirewallapply.EgressFirewallStatus()
+	for _, dnsName := range dnsNames {
+		applyDNSName := egressfirewallapply.EgressFirewallDNSNameStatus().WithDNSName(dnsName.DNSName)
+		for _, resolvedName := range dnsName.ResolvedNames {
+			applyDNSName.WithResolvedNames(egressfirewallapply.EgressFirewallResolvedName().
+				WithName(resolvedName.Name).
+				WithIPCount(resolvedName.IPCount))
+		}
+		applyStatus.WithDNSNames(applyDNSName)
+	}
+	applyObj := egressfirewallapply.EgressFirewall(ef.Name, ef.Namespace).WithStatus(applyStatus)
+	_, err = c.efClient.K8sV1().EgressFirewalls(ef.Namespace).ApplyStatus(context.TODO(), applyObj,
+		metav1.ApplyOptions{FieldManager: egressFirewallStatusFieldManager, Force: true})
+	return err
+}
+
+// getEgressFirewallDNSNameStatus returns the DNS name status of an EgressFirewall
+// object, sorted by DNS name and resolved name, along with the time at which the
+// next subdomain address expires.
+func (c *Controller) getEgressFirewallDNSNameStatus(ef *egressfirewall.EgressFirewall, now time.Time) (
+	[]egressfirewall.EgressFirewallDNSNameStatus, time.Time, error) {
+	var dnsNames []egressfirewall.EgressFirewallDNSNameStatus
+	var nextExpiry time.Time
+	for _, dnsName := range sets.List(sets.New(util.GetDNSNames(ef)...)) {
+		dnsNameStatus := egressfirewall.EgressFirewallDNSNameStatus{DNSName: dnsName}
+		if resolverName, found := c.resInfo.GetResolverForDNSName(dnsName); found {
+			resolver, err := c.dnsLister.DNSNameResolvers(config.Kubernetes.OVNConfigNamespace).Get(resolverName)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, time.Time{}, err
+			}
+			if err == nil {
+				addresses, expiry := util.GetDNSNameResolverAddresses(resolver, now)
+				for _, resolvedName := range sets.List(sets.KeySet(addresses)) {
+					dnsNameStatus.ResolvedNames = append(dnsNameStatus.ResolvedNames, egressfirewall.EgressFirewallResolvedName{
+						Name:    resolvedName,
+						IPCount: int32(len(addresses[resolvedName])),
+					})
+				}
+				if !expiry.IsZero() && (nextExpiry.IsZero() || expiry.Before(nextExpiry)) {
+					nextExpiry = expiry
+				}
+			}
+		}
+		dnsNames = append(dnsNames, dnsNameStatus)
+	}
+	return dnsNames, nextExpiry, nil
+}
@@ -328,3 +328,28 @@ func (resInfo *resolverInfo) IsDNSNameMatchingResolverName(dnsName, resolverName
 	}
 	return true
 }
+
+// GetResolverForDNSName returns the name of the DNSNameResolver object
+// corresponding to the DNS name.
+func (resInfo *resolverInfo) GetResolverForDNSName(dnsName string) (string, bool) {
+	resInfo.lock.Lock()
+	defer resInfo.lock.Unlock()
+
+	objDetails, exists := resInfo.dnsNameToResolverDetails[dnsName]
+	if !exists || objDetails.objName == "" {
+		return "", false
+	}
+	return objDetails.objName, true
+}
+
+// GetNamespacesForDNSName returns the namespaces where the DNS name is used.
+func (resInfo *resolverInfo) GetNamespacesForDNSName(dnsName string) []string {
+	resInfo.lock.Lock()
+	defer resInfo.lock.Unlock()
+
+	objDetails, exists := resInfo.dnsNameToResolverDetails[dnsName]
+	if !exists {
+		return nil
+	}
+	return sets.List(objDetails.namespaces)
+}
@@ -8,7 +8,7 @@ import (
 	"net"
 	"os"
 	"reflect"
-	"sort"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
@@ -45,7 +45,12 @@ const (
 
 type egressIPHealthcheckClientAllocator struct{}
 
-func (hccAlloc *egressIPHealthcheckClientAllocator) allocate(nodeName string) healthcheck.EgressIPHealthClient {
+func (hccAlloc *egressIPHealthcheckClientAllocator) allocate(nodeName string, notify func()) healthcheck.EgressIPHealthClient {
+	if config.OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol == config.EgressIPNodeHealthCheckProtocolBFD {
+		return healthcheck.NewEgressIPBFDClient(nodeName,
+			time.Duration(config.OVNKubernetesFeature.EgressIPBFDInterval)*time.Millisecond,
+			config.OVNKubernetesFeature.EgressIPBFDMultiplier, notify)
+	}
 	return healthcheck.NewEgressIPHealthClient(nodeName)
 }
 
@@ -62,6 +67,19 @@ func isReachableViaGRPC(mgmtIPs []net.IP, healthClient healthcheck.EgressIPHealt
 	return healthClient.Probe(dialCtx)
 }
 
+func isReachableViaBFD(mgmtIPs []net.IP, healthClient healthcheck.EgressIPHealthClient, healthCheckPort, totalTimeout int) bool {
+	if !healthClient.IsConnected() {
+		// BFD sessions are not started. Start them and wait for them to come up for the total timeout, they
+		// keep running afterwards even if they didn't come up.
+		dialCtx, dialCancel := context.WithTimeout(context.Background(), time.Duration(totalTimeout)*time.Second)
+		defer dialCancel()
+		return healthClient.Connect(dialCtx, mgmtIPs, healthCheckPort)
+	}
+
+	// BFD sessions are running and detect the failures on their own, the probe returns their current state.
+	return healthClient.Probe(context.Background())
+}
+
 type egressIPDialer interface {
 	dial(ip net.IP, timeout time.Duration) bool
 }
@@ -71,7 +89,9 @@ type egressIPDial struct{}
 var dialer egressIPDialer = &egressIPDial{}
 
 type healthcheckClientAllocator interface {
-	allocate(nodeName string) healthcheck.EgressIPHealthClient
+	// allocate returns the health client of a node, notify may be called by the client when the node
+	// reachability changes to check it again without waiting for the next reachability check interval
+	allocate(nodeName string, notify func()) healthcheck.EgressIPHealthClient
 }
 
 // Blantant copy from: https://github.com/openshift/sdn/blob/master/pkg/network/common/egressip.go#L499-L505
@@ -101,9 +121,13 @@ var hccAllocator healthcheckClientAllocator = &egressIPHealthcheckClientAllocato
 
 // egressNode is a cache helper used for egress IP assignment, representing an egress node
 type egressNode struct {
-	egressIPConfig     *util.ParsedNodeEgressIPConfiguration
-	mgmtIPs            []net.IP
-	allocations        map[string]string
+	egressIPConfig *util.ParsedNodeEgressIPConfiguration
+	placement      util.EgressIPPlacement
+	mgmtIPs        []net.IP
+	allocations    map[string]string
+	// moving holds the allocated egress IPs which are being moved to another
+	// node to follow the placement strategy
+	moving             sets.Set[string]
 	healthClient       healthcheck.EgressIPHealthClient
 	isReady            bool
 	isReachable        bool
@@ -376,6 +400,8 @@ type egressIPClusterController struct {
 	// nodeAllocator is a cache of egress IP centric data needed to when both route
 	// health-checking and tracking allocations made
 	nodeAllocator nodeAllocator
+	// placement decides which egress node hosts an egress IP
+	placement     egressIPPlacementStrategy
 	markAllocator id.Allocator
 	// watchFactory watching k8s objects
 	watchFactory *factory.WatchFactory
@@ -383,8 +409,12 @@ type egressIPClusterController struct {
 	egressIPTotalTimeout int
 	// reachability check interval
 	reachabilityCheckInterval time.Duration
+	// reachabilityCheckCh requests a reachability check before the next reachability check interval
+	reachabilityCheckCh chan struct{}
 	// EgressIP Node reachability gRPC port (0 means it should use dial instead)
 	egressIPNodeHealthCheckPort int
+	// EgressIP Node reachability protocol used on egressIPNodeHealthCheckPort
+	egressIPNodeHealthCheckProtocol string
 	// retry framework for Egress nodes
 	retryEgressNodes *objretry.RetryFramework
 	// retry framework for egress IP
@@ -415,12 +445,15 @@ func newEgressIPController(ovnClient *util.OVNClusterManagerClientset, wf *facto
 		pendingCloudPrivateIPConfigsMutex: &sync.Mutex{},
 		pendingCloudPrivateIPConfigsOps:   make(map[string]map[string]*cloudPrivateIPConfigOp),
 		nodeAllocator:                     nodeAllocator{&sync.Mutex{}, make(map[string]*egressNode)},
+		placement:                         newEgressIPPlacementStrategy(config.OVNKubernetesFeature.EgressIPPlacementStrategy),
 		markAllocator:                     markAllocator,
 		watchFactory:                      wf,
 		recorder:                          recorder,
 		egressIPTotalTimeout:              config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout,
 		reachabilityCheckInterval:         egressIPReachabilityCheckInterval,
+		reachabilityCheckCh:               make(chan struct{}, 1),
 		egressIPNodeHealthCheckPort:       config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
+		egressIPNodeHealthCheckProtocol:   config.OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol,
 		stopChan:                          make(chan struct{}),
 	}
 	eIPC.initRetryFramework()
@@ -468,8 +501,14 @@ func (eIPC *egressIPClusterController) Start() error {
 	if config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout == 0 {
 		klog.V(2).Infof("EgressIP node reachability check disabled")
 	} else if config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort != 0 {
-		klog.Infof("EgressIP node reachability enabled and using gRPC port %d",
-			config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort)
+		if config.OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol == config.EgressIPNodeHealthCheckProtocolBFD {
+			klog.Infof("EgressIP node reachability enabled and using BFD on UDP port %d, interval %dms, multiplier %d",
+				config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort, config.OVNKubernetesFeature.EgressIPBFDInterval,
+				config.OVNKubernetesFeature.EgressIPBFDMultiplier)
+		} else {
+			klog.Infof("EgressIP node reachability enabled and using gRPC port %d",
+				config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort)
+		}
 	}
 	return nil
 }
@@ -512,8 +551,8 @@ type egressIPNodeStatus struct {
 	Name string
 }
 
-// getSortedEgressData returns a sorted slice of all egressNodes based on the
-// amount of allocations found in the cache
+// getSortedEgressData returns a slice of all assignable egressNodes sorted by
+// the placement strategy
 func (eIPC *egressIPClusterController) getSortedEgressData() ([]*egressNode, map[string]egressIPNodeStatus) {
 	assignableNodes := []*egressNode{}
 	allAllocations := make(map[string]egressIPNodeStatus)
@@ -525,9 +564,7 @@ func (eIPC *egressIPClusterController) getSortedEgressData() ([]*egressNode, map
 			allAllocations[ip] = egressIPNodeStatus{Node: eNode.name, Name: eipName}
 		}
 	}
-	sort.Slice(assignableNodes, func(i, j int) bool {
-		return len(assignableNodes[i].allocations) < len(assignableNodes[j].allocations)
-	})
+	eIPC.placement.sortNodes(assignableNodes)
 	return assignableNodes, allAllocations
 }
 
@@ -609,6 +646,8 @@ func (eIPC *egressIPClusterController) checkEgressNodesReachability() {
 		select {
 		case <-timer.C:
 			checkEgressNodesReachabilityIterate(eIPC)
+		case <-eIPC.reachabilityCheckCh:
+			checkEgressNodesReachabilityIterate(eIPC)
 		case <-eIPC.stopChan:
 			klog.V(5).Infof("Stop channel got triggered: will stop checkEgressNodesReachability")
 			return
@@ -616,6 +655,16 @@ func (eIPC *egressIPClusterController) checkEgressNodesReachability() {
 	}
 }
 
+// requestReachabilityCheck requests a reachability check of the egress nodes without waiting for the next
+// reachability check interval, e.g. when a BFD session detects that a node is not reachable anymore.
+func (eIPC *egressIPClusterController) requestReachabilityCheck() {
+	select {
+	case eIPC.reachabilityCheckCh <- struct{}{}:
+	default:
+		// a check is already pending
+	}
+}
+
 func checkEgressNodesReachabilityIterate(eIPC *egressIPClusterController) {
 	reAddOrDelete := map[string]bool{}
 	eIPC.nodeAllocator.Lock()
@@ -661,6 +710,85 @@ func checkEgressNodesReachabilityIterate(eIPC *egressIPClusterController) {
 	}
 }
 
+// rebalanceEgressIPs moves assigned egress IPs to other nodes, one at a time,
+// until the assignments follow the placement strategy. It is called when egress
+// nodes come and go or when their placement settings change. An error is
+// returned when an egress IP which should move can't be moved yet, for the
+// retry framework to rebalance again later.
+func (eIPC *egressIPClusterController) rebalanceEgressIPs() error {
+	eIPC.nodeAllocator.Lock()
+	maxMoves := 0
+	for _, eNode := range eIPC.nodeAllocator.cache {
+		maxMoves += len(eNode.allocations)
+	}
+	eIPC.nodeAllocator.Unlock()
+	// every move lowers the load of a pair of nodes, bound the number of moves
+	// anyway in case the allocations change concurrently
+	for i := 0; i < maxMoves; i++ {
+		eIPC.nodeAllocator.Lock()
+		assignableNodes, _ := eIPC.getSortedEgressData()
+		move := eIPC.placement.nextMove(assignableNodes, eIPC.canHostEgressIP)
+		eIPC.nodeAllocator.Unlock()
+		if move == nil {
+			return nil
+		}
+		if err := eIPC.moveEgressIP(move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveEgressIP moves an assigned egress IP away from its node.
+func (eIPC *egressIPClusterController) moveEgressIP(move *egressIPMove) error {
+	egressIP, err := eIPC.watchFactory.GetEgressIP(move.egressIPName)
+	if err != nil {
+		return fmt.Errorf("unable to get EgressIP %s: %w", move.egressIPName, err)
+	}
+	// Only move assignments which are reflected in the status and which have
+	// no pending cloud operation, others are moved when retrying.
+	statusItem := egressipv1.EgressIPStatusItem{Node: move.node, EgressIP: move.egressIP}
+	if !slices.Contains(egressIP.Status.Items, statusItem) || eIPC.hasPendingCloudPrivateIPConfigOps(egressIP.Name) {
+		return fmt.Errorf("unable to move egress IP %s of EgressIP %s away from node %s yet: its assignment is not settled",
+			move.egressIP, move.egressIPName, move.node)
+	}
+	eIPC.nodeAllocator.Lock()
+	if eNode, exists := eIPC.nodeAllocator.cache[move.node]; exists {
+		eNode.moving.Insert(move.egressIP)
+	}
+	eIPC.nodeAllocator.Unlock()
+	klog.Infof("Moving egress IP %s of EgressIP %s away from node %s to follow the placement strategy",
+		move.egressIP, move.egressIPName, move.node)
+	// Send a "synthetic update": the reconciliation will consider the
+	// assignment to the node as invalid and assign the egress IP elsewhere.
+	return eIPC.reconcileEgressIP(nil, egressIP)
+}
+
+// canHostEgressIP returns whether the node may host the egress IP of the
+// EgressIP in addition to its current allocations. It must be called with the
+// nodeAllocator lock held.
+func (eIPC *egressIPClusterController) canHostEgressIP(eNode *egressNode, egressIPName, egressIP string) bool {
+	if eNode.getAllocationCountForEgressIP(egressIPName) > 0 {
+		return false
+	}
+	eIP := net.ParseIP(egressIP)
+	if !eNode.hasIPCapacity(eIP) {
+		return false
+	}
+	node, err := eIPC.watchFactory.GetNode(eNode.name)
+	if err != nil {
+		return false
+	}
+	network, err := util.GetEgressIPNetwork(node, eNode.egressIPConfig, eIP)
+	return err == nil && network != ""
+}
+
+func (eIPC *egressIPClusterController) hasPendingCloudPrivateIPConfigOps(egressIPName string) bool {
+	eIPC.pendingCloudPrivateIPConfigsMutex.Lock()
+	defer eIPC.pendingCloudPrivateIPConfigsMutex.Unlock()
+	return len(eIPC.pendingCloudPrivateIPConfigsOps[egressIPName]) > 0
+}
+
 func (eIPC *egressIPClusterController) isReachable(nodeName string, mgmtIPs []net.IP, healthClient healthcheck.EgressIPHealthClient) bool {
 	// Check if we need to do node reachability check
 	if eIPC.egressIPTotalTimeout == 0 {
@@ -670,6 +798,9 @@ func (eIPC *egressIPClusterController) isReachable(nodeName string, mgmtIPs []ne
 	if eIPC.egressIPNodeHealthCheckPort == 0 {
 		return isReachableLegacy(nodeName, mgmtIPs, eIPC.egressIPTotalTimeout)
 	}
+	if eIPC.egressIPNodeHealthCheckProtocol == config.EgressIPNodeHealthCheckProtocolBFD {
+		return isReachableViaBFD(mgmtIPs, healthClient, eIPC.egressIPNodeHealthCheckPort, eIPC.egressIPTotalTimeout)
+	}
 	return isReachableViaGRPC(mgmtIPs, healthClient, eIPC.egressIPNodeHealthCheckPort, eIPC.egressIPTotalTimeout)
 }
 
@@ -785,6 +916,10 @@ func (eIPC *egressIPClusterController) addEgressNode(nodeName string) error {
 			}
 		}
 	}
+	// the new node may take over egress IPs assigned to other nodes
+	if err := eIPC.rebalanceEgressIPs(); err != nil {
+		errors = append(errors, fmt.Errorf("failed to rebalance egress IPs, err: %v", err))
+	}
 
 	if len(errors) > 0 {
 		return utilerrors.Join(errors...)
@@ -831,6 +966,10 @@ func (eIPC *egressIPClusterController) deleteEgressNode(nodeName string) error {
 			}
 		}
 	}
+	// the remaining nodes may not follow the placement strategy anymore
+	if err := eIPC.rebalanceEgressIPs(); err != nil {
+		errorAggregate = append(errorAggregate, fmt.Errorf("failed to rebalance egress IPs, err: %v", err))
+	}
 	if len(errorAggregate) > 0 {
 		return utilerrors.Join(errorAggregate...)
 	}
@@ -850,18 +989,27 @@ func (eIPC *egressIPClusterController) initEgressIPAllocator(node *corev1.Node)
 	for i, subnet := range nodeSubnets {
 		mgmtIPs[i] = util.GetNodeManagementIfAddr(subnet).IP
 	}
+	// an invalid placement annotation should not prevent the node from
+	// hosting egress IPs, the default placement is used instead
+	placement, err := util.ParseNodeEgressIPPlacement(node)
+	if err != nil {
+		klog.Warningf("Using the default egress IP placement for node %s: %v", node.Name, err)
+	}
 	eIPC.nodeAllocator.Lock()
 	defer eIPC.nodeAllocator.Unlock()
 	if eNode, exists := eIPC.nodeAllocator.cache[node.Name]; !exists {
 		eIPC.nodeAllocator.cache[node.Name] = &egressNode{
 			name:           node.Name,
 			egressIPConfig: parsedEgressIPConfig,
+			placement:      placement,
 			mgmtIPs:        mgmtIPs,
 			allocations:    make(map[string]string),
-			healthClient:   hccAllocator.allocate(node.Name),
+			moving:         sets.New[string](),
+			healthClient:   hccAllocator.allocate(node.Name, eIPC.requestReachabilityCheck),
 		}
 	} else {
 		eNode.egressIPConfig = parsedEgressIPConfig
+		eNode.placement = placement
 		eNode.mgmtIPs = mgmtIPs
 	}
 	return nil
@@ -954,6 +1102,7 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	if err != nil {
 		return fmt.Errorf("invalid EgressIP spec, err: %v", err)
 	}
+	specIPs := validSpecIPs.Clone()
 
 	// Validate the status, on restart it could be the case that what might have
 	// been assigned when ovnkube-master last ran is not a valid assignment
@@ -980,6 +1129,7 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	ipsToAssign := validSpecIPs
 	ipsToRemove := sets.New[string]()
 	statusToAdd := make([]egressipv1.EgressIPStatusItem, 0, len(ipsToAssign))
+	var unassigned []egressipv1.EgressIPUnassignedItem
 	statusToKeep := make([]egressipv1.EgressIPStatusItem, 0, len(validStatus))
 	for status := range validStatus {
 		statusToKeep = append(statusToKeep, status)
@@ -1035,7 +1185,7 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 			eIPC.deleteAllocatorEgressIPAssignments(statusToRemove)
 		}
 		if len(ipsToAssign) > 0 {
-			statusToAdd = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			statusToAdd, unassigned = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
 			statusToKeep = append(statusToKeep, statusToAdd...)
 		}
 		// Add all assignments which are to be kept to the allocator cache,
@@ -1044,8 +1194,9 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		eIPC.addAllocatorEgressIPAssignments(name, statusToKeep)
 		// Update the object only on an ADD/UPDATE. If we are processing a
 		// DELETE, new will be nil and we should not update the object.
-		if len(statusToAdd) > 0 || (len(statusToRemove) > 0 && new != nil) {
-			if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, statusToKeep)...); err != nil {
+		status := newEgressIPStatus(statusToKeep, mergeUnassignedStatus(specIPs, unassigned, newEIP.Status.Unassigned))
+		if len(statusToAdd) > 0 || (new != nil && (len(statusToRemove) > 0 || !slices.Equal(status.Unassigned, new.Status.Unassigned))) {
+			if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, status)...); err != nil {
 				return err
 			}
 		}
@@ -1073,7 +1224,7 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 			// Update the object only on an ADD/UPDATE. If we are processing a
 			// DELETE, new will be nil and we should not update the object.
 			if new != nil {
-				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, statusToKeep)...); err != nil {
+				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, newEgressIPStatus(statusToKeep, new.Status.Unassigned))...); err != nil {
 					return err
 				}
 			}
@@ -1098,10 +1249,22 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		// it can assign the IPs. reconcileCloudPrivateIPConfig will take care of
 		// processing the answer from the requests we make here, and update OVN
 		// accordingly when we know what the outcome is.
+		assignedStatus := statusToKeep
 		if len(ipsToAssign) > 0 {
-			statusToAdd = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			statusToAdd, unassigned = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
 			statusToKeep = append(statusToKeep, statusToAdd...)
 		}
+		// Report the egress IPs which could not be assigned, those which were
+		// assigned get reported once the CloudPrivateIPConfig changes are
+		// confirmed.
+		if new != nil {
+			status := newEgressIPStatus(assignedStatus, mergeUnassignedStatus(specIPs, unassigned, new.Status.Unassigned))
+			if !slices.Equal(status.Unassigned, newEgressIPStatus(assignedStatus, new.Status.Unassigned).Unassigned) {
+				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, status)...); err != nil {
+					return err
+				}
+			}
+		}
 		// Same as above: Add all assignments which are to be kept to the
 		// allocator cache, allowing us to track all assignments which have been
 		// performed and avoid incorrect future assignments due to a
@@ -1121,6 +1284,26 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	return nil
 }
 
+// mergeUnassignedStatus returns the unassigned items of the egress IPs of the
+// spec: the items found by the last assignment attempt, or else the items
+// previously reported.
+func mergeUnassignedStatus(specIPs sets.Set[string], attempted, previous []egressipv1.EgressIPUnassignedItem) []egressipv1.EgressIPUnassignedItem {
+	merged := make(map[string]egressipv1.EgressIPUnassignedItem, len(previous)+len(attempted))
+	for _, item := range previous {
+		if specIPs.Has(item.EgressIP) {
+			merged[item.EgressIP] = item
+		}
+	}
+	for _, item := range attempted {
+		merged[item.EgressIP] = item
+	}
+	var unassigned []egressipv1.EgressIPUnassignedItem
+	for _, egressIP := range sets.List(sets.KeySet(merged)) {
+		unassigned = append(unassigned, merged[egressIP])
+	}
+	return unassigned
+}
+
 // syncCloudPrivateIPConfigs This method takes care syncing stale data in the
 // egress ip status with cloud private ip config upon master reboot cases.
 // cloud private ip config entry would have been deleted when master was down
@@ -1157,7 +1340,7 @@ func (eIPC *egressIPClusterController) syncCloudPrivateIPConfigs(objs []interfac
 		if cloudPrivateIPNotFound {
 			// There could be one or more stale entry found in egress ip object, remove it by patching egressip
 			// object with updated status.
-			err = eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, updatedStatus)...)
+			err = eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, newEgressIPStatus(updatedStatus, egressIP.Status.Unassigned))...)
 			if err != nil {
 				return fmt.Errorf("syncCloudPrivateIPConfigs unable to update EgressIP status: %w", err)
 			}
@@ -1188,18 +1371,22 @@ func (eIPC *egressIPClusterController) getCloudPrivateIPConfigMap(objs []interfa
 // the IP cannot already be assigned and reference by another EgressIP object d)
 // no two egress IPs for the same EgressIP object can be assigned to the same
 // node e) (for public clouds) the amount of egress IPs assigned to one node
-// must respect its assignment capacity. Moreover there is a soft constraint:
-// the assignments need to be balanced across all cluster nodes, so that no node
-// becomes a bottleneck. The balancing is achieved by sorting the nodes in
-// ascending order following their existing amount of allocations, and trying to
-// assign the egress IP to the node with the lowest amount of allocations every
+// must respect its assignment capacity f) (for the weighted placement
+// strategy) the amount of egress IPs assigned to one node must not exceed its
+// maximum. Moreover there is a soft constraint: the assignments need to be
+// balanced across all cluster nodes, so that no node becomes a bottleneck. The
+// balancing is achieved by sorting the nodes following the placement strategy,
+// by default in ascending order following their existing amount of
+// allocations, and trying to assign the egress IP to the first node every
 // time, this does not guarantee complete balance, but mostly complete.
 // For Egress IPs that are hosted by secondary host networks, there must be at least
 // one node that hosts the network and exposed via the nodes host-cidrs annotation.
-func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []string) []egressipv1.EgressIPStatusItem {
+// The egress IPs which could not be assigned are returned along with the reason.
+func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []string) ([]egressipv1.EgressIPStatusItem, []egressipv1.EgressIPUnassignedItem) {
 	eIPC.nodeAllocator.Lock()
 	defer eIPC.nodeAllocator.Unlock()
 	assignments := []egressipv1.EgressIPStatusItem{}
+	unassigned := []egressipv1.EgressIPUnassignedItem{}
 	assignableNodes, existingAllocations := eIPC.getSortedEgressData()
 	if len(assignableNodes) == 0 {
 		eIPRef := corev1.ObjectReference{
@@ -1208,7 +1395,14 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "NoMatchingNodeFound", "no assignable nodes for EgressIP: %s, please tag at least one node with label: %s", name, util.GetNodeEgressLabel())
 		klog.Errorf("No assignable nodes found for EgressIP: %s and requested IPs: %v", name, egressIPs)
-		return assignments
+		for _, egressIP := range egressIPs {
+			unassigned = append(unassigned, egressipv1.EgressIPUnassignedItem{
+				EgressIP: net.ParseIP(egressIP).String(),
+				Reason:   egressipv1.EgressIPReasonNoEgressNode,
+				Message:  fmt.Sprintf("no node is labeled with %s, ready and reachable", util.GetNodeEgressLabel()),
+			})
+		}
+		return assignments, unassigned
 	}
 	klog.V(5).Infof("Current assignments are: %+v", existingAllocations)
 	for _, egressIP := range egressIPs {
@@ -1219,7 +1413,7 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		// cluster, therefore there maybe still conflicts when we attempt to assign an egress IP with a different scope.
 		if isIPConflict, conflictedHost, err := eIPC.isEgressIPAddrConflict(eIP); err != nil {
 			klog.Errorf("Egress IP: %v failed to check if EgressIP already is assigned on any interface throughout the cluster: %v", eIP, err)
-			return assignments
+			return assignments, unassigned
 		} else if isIPConflict {
 			eIPRef := corev1.ObjectReference{
 				Kind: "EgressIP",
@@ -1228,7 +1422,12 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "EgressIPConflict", "Egress IP %s with IP "+
 				"%v is conflicting with a host (%s) IP address and will not be assigned", name, eIP, conflictedHost)
 			klog.Errorf("Egress IP: %v address is already assigned on an interface on node %s", eIP, conflictedHost)
-			return assignments
+			unassigned = append(unassigned, egressipv1.EgressIPUnassignedItem{
+				EgressIP: eIP.String(),
+				Reason:   egressipv1.EgressIPReasonAddressConflict,
+				Message:  fmt.Sprintf("conflicting with a host IP address of node %s", conflictedHost),
+			})
+			return assignments, unassigned
 		}
 		if status, exists := existingAllocations[eIP.String()]; exists {
 			// On public clouds we will re-process assignments for the same IP
@@ -1280,7 +1479,12 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 					"IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node,
 				)
 				klog.Errorf("IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node)
-				return assignments
+				unassigned = append(unassigned, egressipv1.EgressIPUnassignedItem{
+					EgressIP: eIP.String(),
+					Reason:   egressipv1.EgressIPReasonAlreadyAllocated,
+					Message:  fmt.Sprintf("already allocated for EgressIP %s", status.Name),
+				})
+				return assignments, unassigned
 			}
 		}
 		// Egress IP for secondary host networks is only available on baremetal environments
@@ -1317,10 +1521,14 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			}
 		}
 
-		var assignmentSuccessful bool
+		var assignmentSuccessful, capacityExhausted bool
 		for i := 0; i < len(assignableNodes) && !assignmentSuccessful; i++ {
 			eNode := assignableNodes[i]
 			klog.V(5).Infof("Attempting assignment on egress node: %+v", eNode)
+			if eNode.moving.Has(eIP.String()) {
+				klog.V(5).Infof("Egress IP: %s is moved away from node: %s, trying another node", eIP.String(), eNode.name)
+				continue
+			}
 			if eNode.getAllocationCountForEgressIP(name) > 0 {
 				klog.V(5).Infof("Node: %s is already in use by another egress IP for this EgressIP: %s, trying another node", eNode.name, name)
 				continue
@@ -1339,23 +1547,14 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			if egressIPNetwork == "" {
 				continue
 			}
-			if eNode.egressIPConfig.Capacity.IP != nil && *eNode.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
-				if *eNode.egressIPConfig.Capacity.IP-len(eNode.allocations) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", eNode.name)
-					continue
-				}
-			}
-			if eNode.egressIPConfig.Capacity.IPv4 != nil && *eNode.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIP) {
-				if *eNode.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(eNode.allocations, false) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", eNode.name)
-					continue
-				}
+			if !eNode.hasIPCapacity(eIP) {
+				capacityExhausted = true
+				continue
 			}
-			if eNode.egressIPConfig.Capacity.IPv6 != nil && *eNode.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIP) {
-				if *eNode.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(eNode.allocations, true) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", eNode.name)
-					continue
-				}
+			if !eIPC.placement.hasCapacity(eNode) {
+				klog.V(5).Infof("Additional allocation on Node: %s exceeds its maximum number of egress IPs, trying another node", eNode.name)
+				capacityExhausted = true
+				continue
 			}
 			assignments = append(assignments, egressipv1.EgressIPStatusItem{
 				Node:     eNode.name,
@@ -1366,6 +1565,23 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			klog.Infof("Successful assignment of egress IP: %s to network %s on node: %+v", egressIP, egressIPNetwork, eNode)
 			break
 		}
+		// the move is over once the egress IP has been considered for
+		// assignment, whatever its outcome
+		for _, eNode := range eIPC.nodeAllocator.cache {
+			eNode.moving.Delete(eIP.String())
+		}
+		if !assignmentSuccessful {
+			item := egressipv1.EgressIPUnassignedItem{
+				EgressIP: eIP.String(),
+				Reason:   egressipv1.EgressIPReasonNoMatchingNode,
+				Message:  "no egress node has a network which can host the egress IP without hosting another egress IP of the same EgressIP",
+			}
+			if capacityExhausted {
+				item.Reason = egressipv1.EgressIPReasonCapacityExhausted
+				item.Message = "the egress nodes which can host the egress IP reached their egress IP capacity"
+			}
+			unassigned = append(unassigned, item)
+		}
 	}
 	if len(assignments) == 0 {
 		eIPRef := corev1.ObjectReference{
@@ -1374,7 +1590,7 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "NoMatchingNodeFound", "No matching nodes found, which can host any of the egress IPs: %v for object EgressIP: %s", egressIPs, name)
 		klog.Errorf("No matching host found for EgressIP: %s", name)
-		return assignments
+		return assignments, unassigned
 	}
 	if len(assignments) < len(egressIPs) {
 		eIPRef := corev1.ObjectReference{
@@ -1383,7 +1599,31 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "UnassignedRequest", "Not all egress IPs for EgressIP: %s could be assigned, please tag more nodes", name)
 	}
-	return assignments
+	return assignments, unassigned
+}
+
+// hasIPCapacity returns whether the node has the IP capacity to host one more
+// egress IP of the family of eIP.
+func (e *egressNode) hasIPCapacity(eIP net.IP) bool {
+	if e.egressIPConfig.Capacity.IP != nil && *e.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
+		if *e.egressIPConfig.Capacity.IP-len(e.allocations) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", e.name)
+			return false
+		}
+	}
+	if e.egressIPConfig.Capacity.IPv4 != nil && *e.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIP) {
+		if *e.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(e.allocations, false) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", e.name)
+			return false
+		}
+	}
+	if e.egressIPConfig.Capacity.IPv6 != nil && *e.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIP) {
+		if *e.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(e.allocations, true) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", e.name)
+			return false
+		}
+	}
+	return true
 }
 
 func getIPFamilyAllocationCount(allocations map[string]string, isIPv6 bool) (count int) {
@@ -1475,6 +1715,10 @@ func (eIPC *egressIPClusterController) validateEgressIPStatus(name string, items
 				klog.Errorf("Allocator error: EgressIP: %s assigned to node: %s which is not ready, will attempt rebalancing", name, eIPStatus.Node)
 				validAssignment = false
 			}
+			if eNode.moving.Has(eIPStatus.EgressIP) {
+				klog.V(2).Infof("EgressIP: %s IP: %s is moved away from node: %s to follow the placement strategy", name, eIPStatus.EgressIP, eIPStatus.Node)
+				validAssignment = false
+			}
 			ip := net.ParseIP(eIPStatus.EgressIP)
 			if ip == nil {
 				klog.Errorf("Allocator error: EgressIP allocation contains unparsable IP address: %q", eIPStatus.EgressIP)
@@ -1586,7 +1830,7 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 					updatedStatus = append(updatedStatus, status)
 				}
 			}
-			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, updatedStatus)...); err != nil {
+			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, newEgressIPStatus(updatedStatus, egressIP.Status.Unassigned))...); err != nil {
 				return err
 			}
 		}
@@ -1633,7 +1877,7 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 		}
 		if !hasStatus {
 			statusToKeep := append(egressIP.Status.Items, statusItem)
-			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, statusToKeep)...); err != nil {
+			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, newEgressIPStatus(statusToKeep, egressIP.Status.Unassigned))...); err != nil {
 				return err
 			}
 		}
@@ -1791,7 +2035,7 @@ func (eIPC *egressIPClusterController) patchEgressIP(name string, patches ...jso
 // mark range exhaustion. Primary default network egress IP currently does not utilize marks to config EgressIP.
 // Generating the status patch is mandatory
 func (eIPC *egressIPClusterController) generateEgressIPPatches(name string, annotations map[string]string,
-	statusItems []egressipv1.EgressIPStatusItem) []jsonPatchOperation {
+	status egressipv1.EgressIPStatus) []jsonPatchOperation {
 	patches := make([]jsonPatchOperation, 0, 1)
 	if !util.IsEgressIPMarkSet(annotations) {
 		if mark, _, err := eIPC.getOrAllocMark(name); err != nil {
@@ -1800,7 +2044,7 @@ func (eIPC *egressIPClusterController) generateEgressIPPatches(name string, anno
 			patches = append(patches, generateMarkPatchOp(mark))
 		}
 	}
-	return append(patches, generateStatusPatchOp(statusItems))
+	return append(patches, generateStatusPatchOp(status))
 }
 
 func generateMarkPatchOp(mark int) jsonPatchOperation {
@@ -1815,14 +2059,26 @@ func createAnnotWithMark(mark int) map[string]string {
 	return map[string]string{util.EgressIPMarkAnnotation: fmt.Sprintf("%d", mark)}
 }
 
-func generateStatusPatchOp(statusItems []egressipv1.EgressIPStatusItem) jsonPatchOperation {
+func generateStatusPatchOp(status egressipv1.EgressIPStatus) jsonPatchOperation {
 	return jsonPatchOperation{
 		Operation: "replace",
 		Path:      "/status",
-		Value: egressipv1.EgressIPStatus{
-			Items: statusItems,
-		},
+		Value:     status,
+	}
+}
+
+// newEgressIPStatus returns the status made of the assigned items and of the
+// unassigned items for egress IPs which are not part of the assigned items.
+func newEgressIPStatus(items []egressipv1.EgressIPStatusItem, unassigned []egressipv1.EgressIPUnassignedItem) egressipv1.EgressIPStatus {
+	status := egressipv1.EgressIPStatus{Items: items}
+	for _, item := range unassigned {
+		if !slices.ContainsFunc(items, func(assigned egressipv1.EgressIPStatusItem) bool {
+			return assigned.EgressIP == item.EgressIP
+		}) {
+			status.Unassigned = append(status.Unassigned, item)
+		}
 	}
+	return status
 }
 
 // syncEgressIPMarkAllocator iterates over all existing EgressIPs. It builds a mark cache of existing marks stored on each
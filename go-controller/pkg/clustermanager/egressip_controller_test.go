@@ -41,6 +41,7 @@ type fakeEgressIPHealthClient struct {
 	Connected        bool
 	ProbeCount       int
 	FakeProbeFailure bool
+	notify           func()
 	mutex            sync.Mutex
 }
 
@@ -91,8 +92,8 @@ func (fehc *fakeEgressIPHealthClient) setFakeProbeFailure(probeFailure bool) {
 
 type fakeEgressIPHealthClientAllocator struct{}
 
-func (f *fakeEgressIPHealthClientAllocator) allocate(string) healthcheck.EgressIPHealthClient {
-	return &fakeEgressIPHealthClient{}
+func (f *fakeEgressIPHealthClientAllocator) allocate(_ string, notify func()) healthcheck.EgressIPHealthClient {
+	return &fakeEgressIPHealthClient{notify: notify}
 }
 
 func newNamespaceMeta(namespace string, additionalLabels map[string]string) metav1.ObjectMeta {
@@ -164,8 +165,10 @@ func setupNode(nodeName string, ipNets []string, mockAllocationIPs map[string]st
 
 	node := egressNode{
 		egressIPConfig:     config,
+		placement:          util.EgressIPPlacement{MaxEgressIPs: util.UnlimitedNodeCapacity, Weight: 1},
 		allocations:        mockAllcations,
-		healthClient:       hccAllocator.allocate(nodeName), // using fakeEgressIPHealthClientAllocator
+		moving:             sets.New[string](),
+		healthClient:       hccAllocator.allocate(nodeName, nil), // using fakeEgressIPHealthClientAllocator
 		name:               nodeName,
 		isReady:            true,
 		isReachable:        true,
@@ -1710,6 +1713,75 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("should re-assign egress IPs as soon as the health client notifies a reachability change", func() {
+			// Test steps:
+			//  - disable periodic check from running in background
+			//  - assign egress IP to an available node
+			//  - make the node unreachable and notify it like the BFD health client does
+			//  - verify that the egress IP was unassigned without waiting for the periodic check
+			app.Action = func(*cli.Context) error {
+				egressIP := "192.168.126.101"
+				nodeIPv4 := "192.168.126.51/24"
+				node := corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: node1Name,
+						Annotations: map[string]string{
+							"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\"}", nodeIPv4),
+							"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\"]}", v4NodeSubnet),
+							util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", nodeIPv4),
+						},
+						Labels: map[string]string{
+							"k8s.ovn.org/egress-assignable": "",
+						},
+					},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{
+							{
+								Type:   corev1.NodeReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+				eIP1 := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP},
+					},
+				}
+				fakeClusterManagerOVN.start(
+					&egressipv1.EgressIPList{
+						Items: []egressipv1.EgressIP{eIP1},
+					},
+					&corev1.NodeList{
+						Items: []corev1.Node{node},
+					},
+				)
+
+				// Virtually disable background reachability check by using a huge interval
+				fakeClusterManagerOVN.eIPC.reachabilityCheckInterval = time.Hour
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPStatusLen(eIP1.Name)).Should(gomega.Equal(1))
+				fakeClusterManagerOVN.eIPC.nodeAllocator.Lock()
+				hcClient := fakeClusterManagerOVN.eIPC.nodeAllocator.cache[node.Name].healthClient.(*fakeEgressIPHealthClient)
+				fakeClusterManagerOVN.eIPC.nodeAllocator.Unlock()
+				gomega.Expect(hcClient.notify).NotTo(gomega.BeNil())
+				hcClient.setFakeProbeFailure(true)
+				hcClient.notify()
+				gomega.Eventually(getEgressIPStatusLen(eIP1.Name)).Should(gomega.Equal(0))
+
+				hcClient.setFakeProbeFailure(false)
+				hcClient.notify()
+				gomega.Eventually(getEgressIPStatusLen(eIP1.Name)).Should(gomega.Equal(1))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("egress node update should not mark the node as reachable if there was no label/readiness change", func() {
 			// When an egress node becomes reachable during a node update event and there is no changes to node labels/readiness
 			// unassigned egress IP should be eventually added by the periodic reachability check.
@@ -1866,7 +1938,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -1948,7 +2020,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -2035,7 +2107,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -2129,7 +2201,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1SecondaryHost).String()))
@@ -2215,10 +2287,10 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2Name))
-				assignedStatuses = fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ = fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2Name))
 				return nil
@@ -2300,7 +2372,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 
 				return nil
@@ -2383,7 +2455,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 
 				return nil
@@ -2462,7 +2534,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2541,7 +2613,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2620,7 +2692,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -2701,7 +2773,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2857,7 +2929,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -4427,6 +4499,255 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 		})
 	})
 
+	ginkgo.Context("Placement strategy", func() {
+		newPlacementNode := func(name, ipv4, placement string) corev1.Node {
+			node := corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\", \"ipv6\": \"%s\"}", ipv4, ""),
+						"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\", \"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+						util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", ipv4),
+					},
+					Labels: map[string]string{
+						"k8s.ovn.org/egress-assignable": "",
+					},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			}
+			if placement != "" {
+				node.Annotations[util.OVNNodeEgressIPPlacement] = placement
+			}
+			return node
+		}
+
+		getEgressIPUnassigned := func(egressIPName string) func() []egressipv1.EgressIPUnassignedItem {
+			return func() []egressipv1.EgressIPUnassignedItem {
+				tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				return tmp.Status.Unassigned
+			}
+		}
+
+		ginkgo.It("should report why an egress IP is not assigned", func() {
+			app.Action = func(*cli.Context) error {
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+				node1IPv4 := "192.168.126.12/24"
+
+				node1 := newPlacementNode(node1Name, node1IPv4, "")
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP1, egressIP2},
+					},
+				}
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(1))
+				egressIPs, _ := getEgressIPStatus(egressIPName)
+				unassignedIP := egressIP1
+				if egressIPs[0] == egressIP1 {
+					unassignedIP = egressIP2
+				}
+				gomega.Eventually(getEgressIPUnassigned(egressIPName)).Should(gomega.ConsistOf(
+					gomega.SatisfyAll(
+						gomega.HaveField("EgressIP", unassignedIP),
+						gomega.HaveField("Reason", egressipv1.EgressIPReasonNoMatchingNode),
+					),
+				))
+
+				// the reason is cleared once the egress IP gets assigned
+				node2 := newPlacementNode(node2Name, "192.168.126.51/24", "")
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Create(context.TODO(), &node2, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(2))
+				gomega.Eventually(getEgressIPUnassigned(egressIPName)).Should(gomega.BeEmpty())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should assign egress IPs following the node weights and maximum number of egress IPs", func() {
+			app.Action = func(*cli.Context) error {
+				config.OVNKubernetesFeature.EgressIPPlacementStrategy = config.EgressIPPlacementStrategyWeighted
+				node1IPv4 := "192.168.126.12/24"
+				node2IPv4 := "192.168.126.51/24"
+
+				node1 := newPlacementNode(node1Name, node1IPv4, `{"maxEgressIPs": 2}`)
+				node2 := newPlacementNode(node2Name, node2IPv4, `{"maxEgressIPs": 2, "weight": 3}`)
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1, node2}},
+				)
+				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
+				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
+				fakeClusterManagerOVN.eIPC.setNodeEgressAssignable(node1Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressAssignable(node2Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReady(node1Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReady(node2Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReachable(node1Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReachable(node2Name, true)
+
+				// the heavier node2 gets the first egress IP and twice as many
+				// egress IPs as node1 until it reaches its maximum
+				expectedNodes := []string{node2Name, node1Name, node2Name, node1Name}
+				for i, expectedNode := range expectedNodes {
+					egressIP := fmt.Sprintf("192.168.126.%d", 101+i)
+					assignedStatuses, unassigned := fakeClusterManagerOVN.eIPC.assignEgressIPs(fmt.Sprintf("egressip-%d", i), []string{egressIP})
+					gomega.Expect(unassigned).To(gomega.BeEmpty())
+					gomega.Expect(assignedStatuses).To(gomega.Equal([]egressipv1.EgressIPStatusItem{{Node: expectedNode, EgressIP: egressIP}}))
+				}
+
+				assignedStatuses, unassigned := fakeClusterManagerOVN.eIPC.assignEgressIPs("egressip-4", []string{"192.168.126.105"})
+				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
+				gomega.Expect(unassigned).To(gomega.HaveLen(1))
+				gomega.Expect(unassigned[0].EgressIP).To(gomega.Equal("192.168.126.105"))
+				gomega.Expect(unassigned[0].Reason).To(gomega.Equal(egressipv1.EgressIPReasonCapacityExhausted))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not exceed the maximum number of egress IPs of a node with the balanced strategy", func() {
+			app.Action = func(*cli.Context) error {
+				node1IPv4 := "192.168.126.12/24"
+
+				node1 := newPlacementNode(node1Name, node1IPv4, `{"maxEgressIPs": 1}`)
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1}},
+				)
+				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
+				fakeClusterManagerOVN.eIPC.setNodeEgressAssignable(node1Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReady(node1Name, true)
+				fakeClusterManagerOVN.eIPC.setNodeEgressReachable(node1Name, true)
+
+				assignedStatuses, unassigned := fakeClusterManagerOVN.eIPC.assignEgressIPs("egressip-0", []string{"192.168.126.101"})
+				gomega.Expect(unassigned).To(gomega.BeEmpty())
+				gomega.Expect(assignedStatuses).To(gomega.Equal([]egressipv1.EgressIPStatusItem{{Node: node1Name, EgressIP: "192.168.126.101"}}))
+
+				assignedStatuses, unassigned = fakeClusterManagerOVN.eIPC.assignEgressIPs("egressip-1", []string{"192.168.126.102"})
+				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
+				gomega.Expect(unassigned).To(gomega.HaveLen(1))
+				gomega.Expect(unassigned[0].Reason).To(gomega.Equal(egressipv1.EgressIPReasonCapacityExhausted))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not assign a moved egress IP back to the node it moves away from", func() {
+			app.Action = func(*cli.Context) error {
+				egressIP1 := "192.168.126.101"
+				node1IPv4 := "192.168.126.12/24"
+				node2IPv4 := "192.168.126.51/24"
+
+				node1 := newPlacementNode(node1Name, node1IPv4, "")
+				node2 := newPlacementNode(node2Name, node2IPv4, "")
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1, node2}},
+				)
+				// node1 is the least loaded node but egressIP1 is moved away from it
+				egressNode1 := setupNode(node1Name, []string{node1IPv4}, map[string]string{})
+				egressNode1.moving.Insert(egressIP1)
+				egressNode2 := setupNode(node2Name, []string{node2IPv4}, map[string]string{"192.168.126.102": "egressip-1"})
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
+
+				assignedStatuses, unassigned := fakeClusterManagerOVN.eIPC.assignEgressIPs("egressip-0", []string{egressIP1})
+				gomega.Expect(unassigned).To(gomega.BeEmpty())
+				gomega.Expect(assignedStatuses).To(gomega.Equal([]egressipv1.EgressIPStatusItem{{Node: node2Name, EgressIP: egressIP1}}))
+				gomega.Expect(egressNode1.moving.Len()).To(gomega.BeZero())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should move egress IPs to a node labeled for egress", func() {
+			app.Action = func(*cli.Context) error {
+				config.OVNKubernetesFeature.EgressIPPlacementStrategy = config.EgressIPPlacementStrategyWeighted
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+				egressIP3 := "192.168.126.103"
+				egressIP4 := "192.168.126.104"
+				node1IPv4 := "192.168.126.12/24"
+				node2IPv4 := "192.168.126.51/24"
+
+				node1 := newPlacementNode(node1Name, node1IPv4, "")
+				node2 := newPlacementNode(node2Name, node2IPv4, "")
+				delete(node2.Labels, "k8s.ovn.org/egress-assignable")
+				egressIPs := []egressipv1.EgressIP{}
+				for i, egressIP := range []string{egressIP1, egressIP2, egressIP3, egressIP4} {
+					egressIPs = append(egressIPs, egressipv1.EgressIP{
+						ObjectMeta: newEgressIPMeta(fmt.Sprintf("egressip-%d", i)),
+						Spec: egressipv1.EgressIPSpec{
+							EgressIPs: []string{egressIP},
+						},
+						Status: egressipv1.EgressIPStatus{
+							Items: []egressipv1.EgressIPStatusItem{{Node: node1Name, EgressIP: egressIP}},
+						},
+					})
+				}
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1, node2}},
+					&egressipv1.EgressIPList{Items: egressIPs},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				getNodeEgressIPs := func(nodeName string) func() []string {
+					return func() []string {
+						var nodeEgressIPs []string
+						for _, eIP := range egressIPs {
+							statusIPs, nodes := getEgressIPStatus(eIP.Name)
+							for i, node := range nodes {
+								if node == nodeName {
+									nodeEgressIPs = append(nodeEgressIPs, statusIPs[i])
+								}
+							}
+						}
+						return nodeEgressIPs
+					}
+				}
+				gomega.Consistently(getNodeEgressIPs(node1Name)).Should(gomega.HaveLen(4))
+
+				// node2 gets half of the egress IPs once it is labeled
+				node2.Labels["k8s.ovn.org/egress-assignable"] = ""
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node2, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getNodeEgressIPs(node2Name)).Should(gomega.HaveLen(2))
+				gomega.Eventually(getNodeEgressIPs(node1Name)).Should(gomega.HaveLen(2))
+				gomega.Consistently(getNodeEgressIPs(node2Name)).Should(gomega.HaveLen(2))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("EgressIP Mark cache", func() {
 		ginkgo.It("should round robin when mark range is exhausted", func() {
 			nodeAlloc := getEgressIPMarkAllocator()
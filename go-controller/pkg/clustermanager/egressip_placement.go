@@ -0,0 +1,136 @@
+package clustermanager
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// egressIPPlacementStrategy decides which egress node hosts an egress IP. All
+// methods are called with the nodeAllocator lock held.
+type egressIPPlacementStrategy interface {
+	// sortNodes orders the assignable egress nodes by preference: an egress
+	// IP is assigned to the first node of the list which can host it.
+	sortNodes(nodes []*egressNode)
+	// hasCapacity returns whether the node may host one more egress IP.
+	hasCapacity(node *egressNode) bool
+	// nextMove returns the next assigned egress IP to move to another node for
+	// the assignments to follow the strategy, or nil if none should move.
+	// canHost reports whether a node may host an egress IP of an EgressIP.
+	nextMove(nodes []*egressNode, canHost func(node *egressNode, egressIPName, egressIP string) bool) *egressIPMove
+}
+
+// egressIPMove is an egress IP assignment which should move to another node
+type egressIPMove struct {
+	egressIPName string
+	egressIP     string
+	node         string
+}
+
+func newEgressIPPlacementStrategy(strategy string) egressIPPlacementStrategy {
+	if strategy == config.EgressIPPlacementStrategyWeighted {
+		return &weightedEgressIPPlacement{}
+	}
+	return &balancedEgressIPPlacement{}
+}
+
+// balancedEgressIPPlacement assigns an egress IP to the node with the lowest
+// amount of allocations, without exceeding its maximum number of egress IPs,
+// and never moves assigned egress IPs.
+type balancedEgressIPPlacement struct{}
+
+func (b *balancedEgressIPPlacement) sortNodes(nodes []*egressNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return len(nodes[i].allocations) < len(nodes[j].allocations)
+	})
+}
+
+func (b *balancedEgressIPPlacement) hasCapacity(node *egressNode) bool {
+	return len(node.allocations) < node.placement.MaxEgressIPs
+}
+
+func (b *balancedEgressIPPlacement) nextMove([]*egressNode, func(*egressNode, string, string) bool) *egressIPMove {
+	return nil
+}
+
+// weightedEgressIPPlacement assigns egress IPs to nodes in proportion of their
+// weight, without exceeding their maximum number of egress IPs. Assigned egress
+// IPs are moved one at a time to nodes with a lower load, i.e.: allocations
+// divided by weight, so that assignments converge when nodes come and go.
+type weightedEgressIPPlacement struct{}
+
+// lessLoaded returns whether node a has a lower load than node b.
+func lessLoaded(a, b *egressNode) bool {
+	// compare len(a)/weight(a) with len(b)/weight(b) without divisions
+	loadA := len(a.allocations) * b.placement.Weight
+	loadB := len(b.allocations) * a.placement.Weight
+	if loadA != loadB {
+		return loadA < loadB
+	}
+	if a.placement.Weight != b.placement.Weight {
+		return a.placement.Weight > b.placement.Weight
+	}
+	return a.name < b.name
+}
+
+func (w *weightedEgressIPPlacement) sortNodes(nodes []*egressNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return lessLoaded(nodes[i], nodes[j])
+	})
+}
+
+func (w *weightedEgressIPPlacement) hasCapacity(node *egressNode) bool {
+	return len(node.allocations) < node.placement.MaxEgressIPs
+}
+
+func (w *weightedEgressIPPlacement) nextMove(nodes []*egressNode, canHost func(*egressNode, string, string) bool) *egressIPMove {
+	sorted := make([]*egressNode, len(nodes))
+	copy(sorted, nodes)
+	w.sortNodes(sorted)
+	// Nodes over their maximum number of egress IPs shed egress IPs first.
+	for i := len(sorted) - 1; i >= 0; i-- {
+		from := sorted[i]
+		if len(from.allocations) <= from.placement.MaxEgressIPs {
+			continue
+		}
+		if move := w.moveFrom(from, sorted, canHost); move != nil {
+			return move
+		}
+	}
+	// Then egress IPs move from the most loaded nodes to the least loaded
+	// ones, as long as the move lowers the load of the pair of nodes: when the
+	// load of the target after the move is lower than the load of the source
+	// before it, i.e.: (len(to)+1)/weight(to) < len(from)/weight(from)
+	for i := len(sorted) - 1; i > 0; i-- {
+		from := sorted[i]
+		targets := make([]*egressNode, 0, i)
+		for _, to := range sorted[:i] {
+			if (len(to.allocations)+1)*from.placement.Weight < len(from.allocations)*to.placement.Weight {
+				targets = append(targets, to)
+			}
+		}
+		if move := w.moveFrom(from, targets, canHost); move != nil {
+			return move
+		}
+	}
+	return nil
+}
+
+// moveFrom returns a move of one of the egress IPs of node from to the first
+// of the target nodes which can host it.
+func (w *weightedEgressIPPlacement) moveFrom(from *egressNode, targets []*egressNode, canHost func(*egressNode, string, string) bool) *egressIPMove {
+	for _, to := range targets {
+		if to == from || !w.hasCapacity(to) {
+			continue
+		}
+		for _, egressIP := range sets.List(sets.KeySet(from.allocations)) {
+			egressIPName := from.allocations[egressIP]
+			if canHost(to, egressIPName, egressIP) {
+				return &egressIPMove{egressIPName: egressIPName, egressIP: egressIP, node: from.name}
+			}
+		}
+	}
+	return nil
+}
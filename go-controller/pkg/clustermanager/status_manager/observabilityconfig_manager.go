@@ -0,0 +1,93 @@
+package status_manager
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
+	observabilityconfigapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/applyconfiguration/observabilityconfig/v1"
+	observabilityconfigclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/clientset/versioned"
+	observabilityconfiglisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/listers/observabilityconfig/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+type observabilityConfigManager struct {
+	lister observabilityconfiglisters.ObservabilityConfigLister
+	client observabilityconfigclientset.Interface
+}
+
+func newObservabilityConfigManager(lister observabilityconfiglisters.ObservabilityConfigLister,
+	client observabilityconfigclientset.Interface) *observabilityConfigManager {
+	return &observabilityConfigManager{
+		lister: lister,
+		client: client,
+	}
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives https://github.com/dominikh/go-tools/issues/1440
+func (m *observabilityConfigManager) get(_, name string) (*observabilityconfigapi.ObservabilityConfig, error) {
+	return m.lister.Get(name)
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *observabilityConfigManager) getMessages(observConfig *observabilityconfigapi.ObservabilityConfig) []string {
+	var messages []string
+	for _, condition := range observConfig.Status.Conditions {
+		// Extract zone name from condition Type (format: "Ready-In-Zone-zoneName")
+		// and format message as "zoneName: message" for consistency with message-based resources
+		if strings.HasPrefix(condition.Type, readyInZonePrefix) {
+			zoneName := strings.TrimPrefix(condition.Type, readyInZonePrefix)
+			messages = append(messages, types.GetZoneStatus(zoneName, condition.Message))
+		}
+	}
+	return messages
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *observabilityConfigManager) getManagedFields(observConfig *observabilityconfigapi.ObservabilityConfig) []metav1.ManagedFieldsEntry {
+	return observConfig.ManagedFields
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *observabilityConfigManager) updateStatus(observConfig *observabilityconfigapi.ObservabilityConfig, applyOpts *metav1.ApplyOptions,
+	applyEmptyOrFailed bool) error {
+	if observConfig == nil {
+		return nil
+	}
+	newStatus := "ObservabilityConfig applied"
+	for _, condition := range observConfig.Status.Conditions {
+		if strings.Contains(condition.Message, types.ObservabilityErrorMsg) {
+			newStatus = types.ObservabilityErrorMsg
+			break
+		}
+	}
+	if applyEmptyOrFailed && newStatus != types.ObservabilityErrorMsg {
+		newStatus = ""
+	}
+
+	if observConfig.Status.Status == newStatus {
+		// already set to the same value
+		return nil
+	}
+
+	applyStatus := observabilityconfigapply.ObservabilityConfigStatus()
+	if newStatus != "" {
+		applyStatus.WithStatus(newStatus)
+	}
+
+	applyObj := observabilityconfigapply.ObservabilityConfig(observConfig.Name).
+		WithStatus(applyStatus)
+
+	_, err := m.client.K8sV1().ObservabilityConfigs().ApplyStatus(context.TODO(), applyObj, *applyOpts)
+	return err
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *observabilityConfigManager) cleanupStatus(observConfig *observabilityconfigapi.ObservabilityConfig, applyOpts *metav1.ApplyOptions) error {
+	applyObj := observabilityconfigapply.ObservabilityConfig(observConfig.Name)
+
+	_, err := m.client.K8sV1().ObservabilityConfigs().ApplyStatus(context.TODO(), applyObj, *applyOpts)
+	return err
+}
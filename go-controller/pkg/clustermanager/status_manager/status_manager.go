@@ -22,6 +22,7 @@ import (
 	egressfirewallapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
 	egressqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
 	networkqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -303,6 +304,16 @@ func NewStatusManager(wf *factory.WatchFactory, ovnClient *util.OVNClusterManage
 		)
 		sm.typedManagers["networkqoses"] = networkQoSManager
 	}
+	if config.OVNKubernetesFeature.EnableObservability {
+		observabilityConfigManager := newStatusManager[observabilityconfigapi.ObservabilityConfig](
+			"observabilityconfigs_statusmanager",
+			wf.ObservabilityConfigInformer().Informer(),
+			wf.ObservabilityConfigInformer().Lister().List,
+			newObservabilityConfigManager(wf.ObservabilityConfigInformer().Lister(), ovnClient.ObservabilityConfigClient),
+			sm.withZonesRLock,
+		)
+		sm.typedManagers["observabilityconfigs"] = observabilityConfigManager
+	}
 	return sm
 }
 
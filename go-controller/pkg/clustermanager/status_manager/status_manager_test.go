@@ -24,6 +24,7 @@ import (
 	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
 	egressqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
 	networkqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
 	crdtypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -278,6 +279,52 @@ func checkEmptyNQStatusConsistently(networkQoS *networkqosapi.NetworkQoS, fakeCl
 	}).Should(BeTrue(), "expected Status to be consistently empty")
 }
 
+func newObservabilityConfig(name string) *observabilityconfigapi.ObservabilityConfig {
+	return &observabilityconfigapi.ObservabilityConfig{
+		ObjectMeta: util.NewObjectMeta(name, ""),
+		Spec: observabilityconfigapi.ObservabilityConfigSpec{
+			CollectorSetID: 10,
+			Features: []observabilityconfigapi.FeatureSampling{{
+				Feature:     observabilityconfigapi.NetworkPolicySample,
+				Probability: 100,
+			}},
+		},
+	}
+}
+
+func updateObservabilityConfigStatus(observConfig *observabilityconfigapi.ObservabilityConfig,
+	status *observabilityconfigapi.ObservabilityConfigStatus, fakeClient *util.OVNClusterManagerClientset) {
+	observConfig.Status = *status
+	_, err := fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().
+		Update(context.TODO(), observConfig, metav1.UpdateOptions{})
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func checkOCStatusEventually(observConfig *observabilityconfigapi.ObservabilityConfig, expectFailure bool, expectEmpty bool,
+	fakeClient *util.OVNClusterManagerClientset) {
+	Eventually(func() bool {
+		oc, err := fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().
+			Get(context.TODO(), observConfig.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		if expectFailure {
+			return strings.Contains(oc.Status.Status, types.ObservabilityErrorMsg)
+		} else if expectEmpty {
+			return oc.Status.Status == ""
+		} else {
+			return strings.Contains(oc.Status.Status, "applied")
+		}
+	}).Should(BeTrue(), fmt.Sprintf("expected observability config status with expectFailure=%v expectEmpty=%v", expectFailure, expectEmpty))
+}
+
+func checkEmptyOCStatusConsistently(observConfig *observabilityconfigapi.ObservabilityConfig, fakeClient *util.OVNClusterManagerClientset) {
+	Consistently(func() bool {
+		oc, err := fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().
+			Get(context.TODO(), observConfig.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return oc.Status.Status == ""
+	}).Should(BeTrue(), "expected Status to be consistently empty")
+}
+
 var _ = Describe("Cluster Manager Status Manager", func() {
 	var (
 		statusManager *StatusManager
@@ -850,4 +897,59 @@ var _ = Describe("Cluster Manager Status Manager", func() {
 		checkNQStatusEventually(networkQoS, false, false, fakeClient)
 	})
 
+	It("updates ObservabilityConfig status with 2 zones", func() {
+		config.OVNKubernetesFeature.EnableObservability = true
+		zones := sets.New[string]("zone1", "zone2")
+		observConfig := newObservabilityConfig("debug")
+		start(zones, observConfig)
+
+		updateObservabilityConfigStatus(observConfig, &observabilityconfigapi.ObservabilityConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:    "Ready-In-Zone-zone1",
+				Status:  metav1.ConditionTrue,
+				Reason:  "SetupSucceeded",
+				Message: "ObservabilityConfig applied",
+			}},
+		}, fakeClient)
+
+		checkEmptyOCStatusConsistently(observConfig, fakeClient)
+
+		updateObservabilityConfigStatus(observConfig, &observabilityconfigapi.ObservabilityConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:    "Ready-In-Zone-zone1",
+				Status:  metav1.ConditionTrue,
+				Reason:  "SetupSucceeded",
+				Message: "ObservabilityConfig applied",
+			}, {
+				Type:    "Ready-In-Zone-zone2",
+				Status:  metav1.ConditionTrue,
+				Reason:  "SetupSucceeded",
+				Message: "ObservabilityConfig applied",
+			}},
+		}, fakeClient)
+		checkOCStatusEventually(observConfig, false, false, fakeClient)
+	})
+
+	It("updates ObservabilityConfig status with a failed zone", func() {
+		config.OVNKubernetesFeature.EnableObservability = true
+		zones := sets.New[string]("zone1", "zone2")
+		observConfig := newObservabilityConfig("debug")
+		start(zones, observConfig)
+
+		updateObservabilityConfigStatus(observConfig, &observabilityconfigapi.ObservabilityConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:    "Ready-In-Zone-zone1",
+				Status:  metav1.ConditionTrue,
+				Reason:  "SetupSucceeded",
+				Message: "ObservabilityConfig applied",
+			}, {
+				Type:    "Ready-In-Zone-zone2",
+				Status:  metav1.ConditionFalse,
+				Reason:  "SetupFailed",
+				Message: types.ObservabilityErrorMsg + ": collectorSetID 10 is already used",
+			}},
+		}, fakeClient)
+		checkOCStatusEventually(observConfig, true, false, fakeClient)
+	})
+
 })
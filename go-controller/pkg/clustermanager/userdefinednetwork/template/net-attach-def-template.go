@@ -193,6 +193,10 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter) (map[s
 		if cfg.VLAN != nil && cfg.VLAN.Access != nil {
 			netConfSpec.VLANID = int(cfg.VLAN.Access.ID)
 		}
+		if cfg.VLAN != nil && cfg.VLAN.Trunk != nil {
+			netConfSpec.AllowedVLANs = vlanIDRangesString(cfg.VLAN.Trunk.AllowedVLANs)
+			netConfSpec.NativeVLAN = int(cfg.VLAN.Trunk.NativeVLAN)
+		}
 	}
 	if netConfSpec.AllowPersistentIPs && !config.OVNKubernetesFeature.EnablePersistentIPs {
 		return nil, fmt.Errorf("allowPersistentIPs is set but persistentIPs is Disabled")
@@ -245,6 +249,12 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter) (map[s
 	if netConfSpec.VLANID != 0 {
 		cniNetConf["vlanID"] = netConfSpec.VLANID
 	}
+	if netConfSpec.AllowedVLANs != "" {
+		cniNetConf["allowedVLANs"] = netConfSpec.AllowedVLANs
+	}
+	if netConfSpec.NativeVLAN != 0 {
+		cniNetConf["nativeVLAN"] = netConfSpec.NativeVLAN
+	}
 	if util.IsPreconfiguredUDNAddressesEnabled() {
 		if len(netConfSpec.ReservedSubnets) > 0 {
 			cniNetConf["reservedSubnets"] = netConfSpec.ReservedSubnets
@@ -332,6 +342,14 @@ func ipString(ips userdefinednetworkv1.DualStackIPs) string {
 	return strings.Join(ipStrings, ",")
 }
 
+func vlanIDRangesString(vlans []userdefinednetworkv1.VLANIDRange) string {
+	var vlanStrings []string
+	for _, vlan := range vlans {
+		vlanStrings = append(vlanStrings, string(vlan))
+	}
+	return strings.Join(vlanStrings, ",")
+}
+
 func GetSpec(obj client.Object) SpecGetter {
 	switch o := obj.(type) {
 	case *userdefinednetworkv1.UserDefinedNetwork:
@@ -631,6 +631,34 @@ var _ = Describe("NetAttachDefTemplate", func() {
 			  "allowPersistentIPs": true
 			}`,
 		),
+		Entry("secondary network, localnet, trunk VLAN mode",
+			udnv1.NetworkSpec{
+				Topology: udnv1.NetworkTopologyLocalnet,
+				Localnet: &udnv1.LocalnetConfig{
+					Role:                udnv1.NetworkRoleSecondary,
+					PhysicalNetworkName: "mylocalnet1",
+					VLAN: &udnv1.VLANConfig{Mode: udnv1.VLANModeTrunk, Trunk: &udnv1.TrunkVLANConfig{
+						AllowedVLANs: []udnv1.VLANIDRange{"100", "200-300"},
+						NativeVLAN:   10,
+					}},
+					IPAM: &udnv1.IPAMConfig{
+						Mode: udnv1.IPAMDisabled,
+					},
+				},
+			},
+			`{
+			  "cniVersion": "1.0.0",
+			  "type": "ovn-k8s-cni-overlay",
+			  "name": "cluster_udn_test-net",
+			  "netAttachDefName": "mynamespace/test-net",
+			  "role": "secondary",
+			  "topology": "localnet",
+			  "physicalNetworkName": "mylocalnet1",
+			  "mtu": 1500,
+			  "allowedVLANs": "100,200-300",
+			  "nativeVLAN": 10
+			}`,
+		),
 	)
 
 	It("should correctly assign transit Subnets", func() {
@@ -377,11 +377,44 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	return response, nil
 }
 
-func (pr *PodRequest) cmdCheck() error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
+// cmdCheck verifies that the pod interface plumbed by a previous ADD still
+// matches the pod annotation: the OVS interface and its iface-id, and the IP
+// addresses, routes and MTU of the container interface.
+func (pr *PodRequest) cmdCheck(clientset *ClientSet) error {
+	if !config.CNI.EnableCheck {
+		// noop unless enabled...CMD check has a considerable performance impact to pod
+		// bring up times with CRIO. This is due to the fact that CRIO currently calls check
+		// after CNI ADD before it finishes bringing the container up
+		return nil
+	}
+	namespace := pr.PodNamespace
+	podName := pr.PodName
+	if namespace == "" || podName == "" {
+		return fmt.Errorf("required CNI variable missing")
+	}
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// the pod interface is not plumbed by ovnkube-node, nothing to check from here
+		return nil
+	}
+
+	pod, err := clientset.getPod(namespace, podName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %v", err)
+	}
+	if err = pr.checkOrUpdatePodUID(pod); err != nil {
+		return err
+	}
+	podNADAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, pr.nadName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod annotation: %v", err)
+	}
+	podInterfaceInfo, err := pr.buildPodInterfaceInfo(pod.Annotations, podNADAnnotation, "")
+	if err != nil {
+		return err
+	}
+	podInterfaceInfo.SkipIPConfig = kubevirt.IsPodLiveMigratable(pod)
+
+	return podRequestInterfaceOps.CheckInterface(pr, podInterfaceInfo)
 }
 
 // HandlePodRequest is the callback for all the requests
@@ -407,7 +440,7 @@ func HandlePodRequest(
 	case CNIDel:
 		response, err = request.cmdDel(clientset)
 	case CNICheck:
-		err = request.cmdCheck()
+		err = request.cmdCheck(clientset)
 	default:
 	}
 
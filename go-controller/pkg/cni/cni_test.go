@@ -32,6 +32,7 @@ import (
 
 type podRequestInterfaceOpsStub struct {
 	unconfiguredInterfaces []*PodInterfaceInfo
+	checkedInterfaces      []*PodInterfaceInfo
 }
 
 func (stub *podRequestInterfaceOpsStub) ConfigureInterface(pr *PodRequest, _ PodInfoGetter, pii *PodInterfaceInfo) ([]*current.Interface, error) {
@@ -50,6 +51,11 @@ func (stub *podRequestInterfaceOpsStub) UnconfigureInterface(_ *PodRequest, ifIn
 	return nil
 }
 
+func (stub *podRequestInterfaceOpsStub) CheckInterface(_ *PodRequest, ifInfo *PodInterfaceInfo) error {
+	stub.checkedInterfaces = append(stub.checkedInterfaces, ifInfo)
+	return nil
+}
+
 var _ = Describe("Network Segmentation", func() {
 	var (
 		fakeClientset            *fake.Clientset
@@ -144,6 +150,23 @@ var _ = Describe("Network Segmentation", func() {
 			Expect(pr.cmdDel(clientSet)).NotTo(BeNil())
 			Expect(prInterfaceOpsStub.unconfiguredInterfaces).To(HaveLen(1))
 		})
+		It("should not check the pod interface at cmdCheck unless enabled", func() {
+			pr.Command = CNICheck
+			Expect(pr.cmdCheck(clientSet)).To(Succeed())
+			Expect(prInterfaceOpsStub.checkedInterfaces).To(BeEmpty())
+		})
+		It("should check the pod interface against the pod annotation at cmdCheck", func() {
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+			config.CNI.EnableCheck = true
+
+			pr.Command = CNICheck
+			Expect(pr.cmdCheck(clientSet)).To(Succeed())
+			Expect(prInterfaceOpsStub.checkedInterfaces).To(HaveLen(1))
+			checked := prInterfaceOpsStub.checkedInterfaces[0]
+			Expect(checked.IPs).To(ConsistOf(testing.MustParseIPNet("100.10.10.3/24")))
+			Expect(checked.MAC.String()).To(Equal("0a:58:fd:98:00:01"))
+			Expect(checked.NADName).To(Equal(ovntypes.DefaultNetworkName))
+		})
 	})
 	Context("with network segmentation fg enabled and annotation with role field", func() {
 		BeforeEach(func() {
@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 	nadv1Listers "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -227,6 +228,15 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	if err := json.Unmarshal(b, &cr); err != nil {
 		return nil, err
 	}
+	// GC and STATUS are not bound to a pod sandbox and carry none of the pod
+	// related CNI variables
+	switch command(cr.Env["CNI_COMMAND"]) {
+	case CNIGC:
+		return nil, s.cmdGC(&cr)
+	case CNIStatus:
+		return nil, s.cmdStatus()
+	}
+
 	req, err := cniRequestToPodRequest(&cr)
 	if err != nil {
 		return nil, err
@@ -241,6 +251,47 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	return result, nil
 }
 
+// SetReady marks the node controller startup as finished; STATUS requests
+// report the plugin as not available until then.
+func (s *Server) SetReady() {
+	s.ready.Store(true)
+}
+
+func (s *Server) cmdStatus() error {
+	if !s.ready.Load() {
+		return fmt.Errorf("ovnkube-node has not finished its startup")
+	}
+	return nil
+}
+
+// cmdGC deletes the OVS ports and host-side veths of the pod sandboxes which
+// are not in the list of valid attachments the runtime passes along with the
+// network configuration.
+func (s *Server) cmdGC(cr *Request) error {
+	conf, err := config.ReadCNIConfig(cr.Config)
+	if err != nil {
+		return fmt.Errorf("broken stdin args")
+	}
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// the pod interfaces are not plumbed by ovnkube-node, nothing to do
+		return nil
+	}
+
+	validSandboxes := sets.New[string]()
+	for _, attachment := range conf.ValidAttachments {
+		validSandboxes.Insert(attachment.ContainerID)
+	}
+	// the default network attachment lives as long as the sandbox, so the
+	// interfaces of all the networks of a stale sandbox are deleted; for a
+	// secondary network only the interfaces of its NAD are considered.
+	nadName := ""
+	if conf.Name != types.DefaultNetworkName {
+		nadName = conf.NADName
+	}
+	klog.Infof("%s CNI request for network %s, valid sandboxes: %v", CNIGC, conf.Name, sets.List(validSandboxes))
+	return deleteStaleInterfaces(s.ovsClient, nadName, validSandboxes)
+}
+
 func (s *Server) handleCNIMetrics(w http.ResponseWriter, r *http.Request) {
 	var cm CNIRequestMetrics
 
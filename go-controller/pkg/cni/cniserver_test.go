@@ -286,4 +286,34 @@ func TestCNIServer(t *testing.T) {
 			}
 		}
 	}
+	// STATUS reports the plugin as not available until the server is ready
+	statusRequest := &Request{
+		Env: map[string]string{
+			"CNI_COMMAND": string(CNIStatus),
+		},
+		Config: []byte(cniConfig),
+	}
+	body, code := clientDoCNI(t, client, statusRequest)
+	if code != http.StatusBadRequest {
+		t.Fatalf("[STATUS] expected status %v before the server is ready but got %v", http.StatusBadRequest, code)
+	}
+	if !strings.HasPrefix(string(body), "ovnkube-node has not finished its startup") {
+		t.Fatalf("[STATUS] unexpected error message '%v'", string(body))
+	}
+	s.SetReady()
+	if _, code = clientDoCNI(t, client, statusRequest); code != http.StatusOK {
+		t.Fatalf("[STATUS] expected status %v once the server is ready but got %v", http.StatusOK, code)
+	}
+
+	// GC does not need the pod related CNI variables
+	gcRequest := &Request{
+		Env: map[string]string{
+			"CNI_COMMAND": string(CNIGC),
+		},
+		Config: []byte("{\"cniVersion\": \"1.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"," +
+			"\"cni.dev/valid-attachments\": [{\"containerID\": \"" + sandboxID + "\",\"ifname\": \"eth0\"}]}"),
+	}
+	if body, code = clientDoCNI(t, client, gcRequest); code != http.StatusOK {
+		t.Fatalf("[GC] expected status %v but got %v: %s", http.StatusOK, code, string(body))
+	}
 }
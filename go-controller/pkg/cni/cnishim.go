@@ -340,9 +340,48 @@ func (p *Plugin) CmdDel(args *skel.CmdArgs) error {
 }
 
 // CmdCheck is the callback for 'checking' container's networking is as expected.
-func (p *Plugin) CmdCheck(_ *skel.CmdArgs) error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
+func (p *Plugin) CmdCheck(args *skel.CmdArgs) error {
+	return p.doCNIRequest(args, CNICheck)
+}
+
+// CmdGC is the callback for 'garbage collecting' the interfaces of the pod
+// sandboxes not in the runtime's list of valid attachments.
+func (p *Plugin) CmdGC(args *skel.CmdArgs) error {
+	return p.doCNIRequest(args, CNIGC)
+}
+
+// cniErrPluginNotAvailable is the well-known CNI error code reported when the
+// plugin cannot service ADD requests
+const cniErrPluginNotAvailable uint = 50
+
+// CmdStatus is the callback for 'status' cni calls from skel, reporting
+// whether the plugin is ready to service ADD requests.
+func (p *Plugin) CmdStatus(args *skel.CmdArgs) error {
+	if err := p.doCNIRequest(args, CNIStatus); err != nil {
+		return types.NewError(cniErrPluginNotAvailable, "ovnkube-node is not ready", err.Error())
+	}
 	return nil
 }
+
+// doCNIRequest forwards a request which has no result to the CNI server
+func (p *Plugin) doCNIRequest(args *skel.CmdArgs, cmd command) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, cmd, err)
+		if err != nil {
+			klog.Errorf("Error on %s: %v", cmd, err)
+		}
+	}()
+
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	_, err = p.doCNIFunc("http://dummy/", req)
+	return err
+}
@@ -3,12 +3,14 @@ package cni
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"os"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
 	"github.com/stretchr/testify/require"
 
@@ -319,3 +321,24 @@ func withCNIEnv(t *testing.T, fn func()) {
 		}
 	}
 }
+
+func TestCmdStatus(t *testing.T) {
+	p := &Plugin{}
+	args := &skel.CmdArgs{
+		StdinData: []byte(`{"cniVersion":"1.1.0","name":"mynet","type":"ovn-k8s-cni-overlay"}`),
+	}
+
+	// the server reports ovnkube-node is not ready yet
+	p.doCNIFunc = func(_ string, _ interface{}) ([]byte, error) {
+		return nil, errors.New("CNI request failed with status 400: 'ovnkube-node has not finished its startup'")
+	}
+	err := p.CmdStatus(args)
+	var cniErr *types.Error
+	require.ErrorAs(t, err, &cniErr)
+	require.Equal(t, cniErrPluginNotAvailable, cniErr.Code)
+
+	p.doCNIFunc = func(_ string, _ interface{}) ([]byte, error) {
+		return nil, nil
+	}
+	require.NoError(t, p.CmdStatus(args))
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,11 +20,17 @@ import (
 	"github.com/safchain/ethtool"
 	"github.com/vishvananda/netlink"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/knftables"
 
+	"github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops/ovs"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
 )
 
 type CNIPluginLibOps interface {
@@ -205,6 +212,70 @@ func setupNetwork(link netlink.Link, ifInfo *PodInterfaceInfo) error {
 	return nil
 }
 
+// checkNetwork verifies that the MTU, IP addresses and routes of the container
+// interface match the pod interface info, as set up by setupNetwork
+func checkNetwork(link netlink.Link, ifInfo *PodInterfaceInfo) error {
+	name := link.Attrs().Name
+	if link.Attrs().MTU != ifInfo.MTU {
+		return fmt.Errorf("interface %s has MTU %d, expected %d", name, link.Attrs().MTU, ifInfo.MTU)
+	}
+
+	if ifInfo.SkipIPConfig {
+		return nil
+	}
+
+	addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list the IP addresses of %s: %v", name, err)
+	}
+	for _, ip := range ifInfo.IPs {
+		found := false
+		for _, addr := range addrs {
+			if addr.IPNet != nil && addr.IPNet.String() == ip.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("interface %s is missing IP address %s", name, ip)
+		}
+	}
+
+	routes, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list the routes of %s: %v", name, err)
+	}
+	hasRoute := func(dest *net.IPNet, gw net.IP) bool {
+		for _, route := range routes {
+			if !route.Gw.Equal(gw) {
+				continue
+			}
+			if dest == nil {
+				if route.Dst == nil {
+					return true
+				}
+				if ones, _ := route.Dst.Mask.Size(); ones == 0 {
+					return true
+				}
+			} else if route.Dst != nil && route.Dst.String() == dest.String() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, gw := range ifInfo.Gateways {
+		if !hasRoute(nil, gw) {
+			return fmt.Errorf("interface %s is missing the default route via %s", name, gw)
+		}
+	}
+	for _, route := range ifInfo.Routes {
+		if !hasRoute(route.Dest, route.NextHop) {
+			return fmt.Errorf("interface %s is missing pod route %s via %s", name, route.Dest, route.NextHop)
+		}
+	}
+	return nil
+}
+
 func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInterfaceInfo) (*current.Interface, *current.Interface, error) {
 	hostIface := &current.Interface{}
 	contIface := &current.Interface{}
@@ -580,6 +651,7 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 type PodRequestInterfaceOps interface {
 	ConfigureInterface(pr *PodRequest, getter PodInfoGetter, ifInfo *PodInterfaceInfo) ([]*current.Interface, error)
 	UnconfigureInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error
+	CheckInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error
 }
 
 type defaultPodRequestInterfaceOps struct{}
@@ -779,6 +851,51 @@ func (*defaultPodRequestInterfaceOps) UnconfigureInterface(pr *PodRequest, ifInf
 	return nil
 }
 
+// CheckInterface verifies that the OVS interface of the pod and the container
+// interface are configured as described by the pod interface info
+func (*defaultPodRequestInterfaceOps) CheckInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error {
+	ifaceID := util.GetIfaceId(pr.PodNamespace, pr.PodName)
+	condString := []string{"external-ids:sandbox=" + pr.SandboxID}
+	if ifInfo.NetName != types.DefaultNetworkName {
+		ifaceID = util.GetUDNIfaceId(pr.PodNamespace, pr.PodName, ifInfo.NADName)
+		condString = append(condString, fmt.Sprintf("external_ids:%s=%s", types.NADExternalID, ifInfo.NADName))
+	} else {
+		condString = append(condString, fmt.Sprintf("external_ids:%s{=}[]", types.NADExternalID))
+	}
+	ovsIfNames, err := ovsFind("Interface", "name", condString...)
+	if err != nil {
+		return fmt.Errorf("failed to find the OVS interface of sandbox %s: %v", pr.SandboxID, err)
+	}
+	if len(ovsIfNames) != 1 {
+		return fmt.Errorf("expected one OVS interface for sandbox %s, found %d", pr.SandboxID, len(ovsIfNames))
+	}
+	hostIfName := ovsIfNames[0]
+	ovsIfaceID, err := ovsGet("Interface", hostIfName, "external_ids", "iface-id")
+	if err != nil {
+		return fmt.Errorf("failed to get the iface-id of OVS interface %s: %v", hostIfName, err)
+	}
+	if ovsIfaceID != ifaceID {
+		return fmt.Errorf("OVS interface %s has iface-id %q, expected %q", hostIfName, ovsIfaceID, ifaceID)
+	}
+
+	// nothing to check in the container namespace for a VFIO device
+	if pr.IsVFIO {
+		return nil
+	}
+	netns, err := ns.GetNS(pr.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", pr.Netns, err)
+	}
+	defer netns.Close()
+	return netns.Do(func(_ ns.NetNS) error {
+		link, err := util.GetNetLinkOps().LinkByName(pr.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to lookup container interface %s: %v", pr.IfName, err)
+		}
+		return checkNetwork(link, ifInfo)
+	})
+}
+
 func (pr *PodRequest) deletePodConntrack() {
 	if pr.CNIConf.PrevResult == nil {
 		return
@@ -828,6 +945,70 @@ func (pr *PodRequest) deletePorts(ifaces []string, podNamespace, podName string)
 	}
 }
 
+// deleteStaleInterfaces deletes the OVS ports, and their host-side veths, of
+// the pod sandboxes which are not in validSandboxes. When nadName is set only
+// the interfaces attached to that NAD are considered. Nothing is deleted when
+// validSandboxes is empty.
+func deleteStaleInterfaces(ovsClient client.Client, nadName string, validSandboxes sets.Set[string]) error {
+	if validSandboxes.Len() == 0 {
+		// an empty list of valid attachments is more likely a runtime that
+		// does not track them than a node without pods: do not wipe out every
+		// pod interface on the node
+		klog.Warningf("No valid pod sandboxes given, not deleting any pod interface")
+		return nil
+	}
+	ifaces, err := ovs.FindInterfacesWithPredicate(ovsClient, func(iface *vswitchd.Interface) bool {
+		sandbox := iface.ExternalIDs["sandbox"]
+		if sandbox == "" || validSandboxes.Has(sandbox) {
+			return false
+		}
+		return nadName == "" || iface.ExternalIDs[types.NADExternalID] == nadName
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find the OVS interfaces of pod sandboxes: %w", err)
+	}
+	slices.SortFunc(ifaces, func(a, b *vswitchd.Interface) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var errs []error
+	staleSandboxes := sets.New[string]()
+	for _, iface := range ifaces {
+		sandbox := iface.ExternalIDs["sandbox"]
+		klog.Infof("Deleting OVS port %s of stale sandbox %s", iface.Name, sandbox)
+		if out, err := ovsExec("--if-exists", "--with-iface", "del-port", "br-int", iface.Name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete OVS port %s of stale sandbox %s: %v\n  %q",
+				iface.Name, sandbox, err, out))
+			continue
+		}
+		staleSandboxes.Insert(sandbox)
+
+		// only the host-side veths are deleted, SR-IOV representors stay
+		link, err := util.GetNetLinkOps().LinkByName(iface.Name)
+		if err != nil {
+			if !util.GetNetLinkOps().IsLinkNotFoundError(err) {
+				errs = append(errs, fmt.Errorf("failed to lookup interface %s of stale sandbox %s: %v",
+					iface.Name, sandbox, err))
+			}
+			continue
+		}
+		if link.Type() != "veth" {
+			continue
+		}
+		if err := util.GetNetLinkOps().LinkDelete(link); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete interface %s of stale sandbox %s: %v",
+				iface.Name, sandbox, err))
+		}
+	}
+
+	for _, sandbox := range sets.List(staleSandboxes) {
+		if err := clearPodBandwidthForPorts(nil, sandbox); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clear the QoS of stale sandbox %s: %v", sandbox, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
 // setupIngressFilter sets up an ingress filter using nftables to block
 // unwanted ICMPv6 Router Advertisement (RA) packets on a specific device.
 // It creates a new nftables table, chain, and rule to drop RA packets
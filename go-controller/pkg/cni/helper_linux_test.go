@@ -19,6 +19,7 @@ import (
 	"github.com/vishvananda/netlink"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
 	kexec "k8s.io/utils/exec"
 	"sigs.k8s.io/knftables"
@@ -26,6 +27,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/mocks"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	cni_type_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/containernetworking/cni/pkg/types"
 	cni_ns_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/containernetworking/plugins/pkg/ns"
 	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
@@ -34,6 +36,7 @@ import (
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	util_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
 )
 
 func TestRenameLink(t *testing.T) {
@@ -455,6 +458,109 @@ func TestSetupNetwork(t *testing.T) {
 	}
 }
 
+func TestCheckNetwork(t *testing.T) {
+	ifInfo := &PodInterfaceInfo{
+		PodAnnotation: util.PodAnnotation{
+			IPs:      ovntest.MustParseIPNets("192.168.0.5/24"),
+			MAC:      ovntest.MustParseMAC("0A:58:FD:98:00:01"),
+			Gateways: ovntest.MustParseIPs("192.168.0.1"),
+			Routes: []util.PodRoute{
+				{
+					Dest:    ovntest.MustParseIPNet("192.168.1.0/24"),
+					NextHop: net.ParseIP("192.168.0.2"),
+				},
+			},
+		},
+		MTU: 1400,
+	}
+	addrs := []netlink.Addr{
+		{IPNet: ovntest.MustParseIPNet("192.168.0.5/24")},
+		{IPNet: ovntest.MustParseIPNet("fe80::858:fdff:fe98:1/64")},
+	}
+	routes := []netlink.Route{
+		{Dst: ovntest.MustParseIPNet("192.168.0.0/24")},
+		{Dst: ovntest.MustParseIPNet("0.0.0.0/0"), Gw: net.ParseIP("192.168.0.1")},
+		{Dst: ovntest.MustParseIPNet("192.168.1.0/24"), Gw: net.ParseIP("192.168.0.2")},
+	}
+
+	tests := []struct {
+		desc         string
+		mtu          int
+		skipIPConfig bool
+		addrs        []netlink.Addr
+		routes       []netlink.Route
+		errMatch     error
+	}{
+		{
+			desc:   "interface matches the pod interface info",
+			mtu:    1400,
+			addrs:  addrs,
+			routes: routes,
+		},
+		{
+			desc:   "default route without destination matches the gateway",
+			mtu:    1400,
+			addrs:  addrs,
+			routes: append([]netlink.Route{{Gw: net.ParseIP("192.168.0.1")}}, routes[2]),
+		},
+		{
+			desc:     "MTU mismatch",
+			mtu:      1500,
+			errMatch: fmt.Errorf("interface eth0 has MTU 1500, expected 1400"),
+		},
+		{
+			desc:         "IP configuration is not checked when skipped",
+			mtu:          1400,
+			skipIPConfig: true,
+		},
+		{
+			desc:     "missing IP address",
+			mtu:      1400,
+			addrs:    addrs[1:],
+			errMatch: fmt.Errorf("interface eth0 is missing IP address 192.168.0.5/24"),
+		},
+		{
+			desc:     "missing default route",
+			mtu:      1400,
+			addrs:    addrs,
+			routes:   []netlink.Route{routes[0], routes[2]},
+			errMatch: fmt.Errorf("interface eth0 is missing the default route via 192.168.0.1"),
+		},
+		{
+			desc:     "pod route with another next hop",
+			mtu:      1400,
+			addrs:    addrs,
+			routes:   []netlink.Route{routes[1], {Dst: ovntest.MustParseIPNet("192.168.1.0/24"), Gw: net.ParseIP("192.168.0.3")}},
+			errMatch: fmt.Errorf("interface eth0 is missing pod route 192.168.1.0/24 via 192.168.0.2"),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNetLinkOps := new(util_mocks.NetLinkOps)
+			util.SetNetLinkOpMockInst(mockNetLinkOps)
+			defer util.ResetNetLinkOpMockInst()
+
+			link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: tc.mtu}}
+			if tc.addrs != nil {
+				mockNetLinkOps.On("AddrList", link, netlink.FAMILY_ALL).Return(tc.addrs, nil)
+			}
+			if tc.routes != nil {
+				mockNetLinkOps.On("RouteList", link, netlink.FAMILY_ALL).Return(tc.routes, nil)
+			}
+			podIfInfo := *ifInfo
+			podIfInfo.SkipIPConfig = tc.skipIPConfig
+
+			err := checkNetwork(link, &podIfInfo)
+			if tc.errMatch != nil {
+				assert.EqualError(t, err, tc.errMatch.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSetupInterface(t *testing.T) {
 	mockNetLinkOps := new(util_mocks.NetLinkOps)
 	mockCNIPlugin := new(mocks.CNIPluginLibOps)
@@ -1667,6 +1773,105 @@ add rule netdev ingress_filter input icmpv6 type nd-router-advert ip6 saddr != f
 	assert.Equal(t, expectedDump, strings.TrimRight(output, "\n"), "The nftables dump output does not match the expected output")
 }
 
+func TestDeleteStaleInterfaces(t *testing.T) {
+	const nadName = "ns1/nad1"
+	type ovsIface struct {
+		name        string
+		externalIDs map[string]string
+	}
+	ifaces := []ovsIface{
+		{name: "validsandbox000", externalIDs: map[string]string{"sandbox": "validsandbox000000"}},
+		{name: "stalesandbox000", externalIDs: map[string]string{"sandbox": "stalesandbox000000"}},
+		{name: "stalesandbox0_5", externalIDs: map[string]string{"sandbox": "stalesandbox000000", ovntypes.NADExternalID: nadName}},
+		{name: "stalesandbox0_7", externalIDs: map[string]string{"sandbox": "stalesandbox000000", ovntypes.NADExternalID: "ns1/nad2"}},
+		{name: "enp1s0f0_1", externalIDs: map[string]string{"sandbox": "stalesriov0000000"}},
+		{name: "ovn-k8s-mp0"},
+	}
+
+	tests := []struct {
+		desc           string
+		nadName        string
+		validSandboxes sets.Set[string]
+		expectedPorts  []string
+		expectedVeths  []string
+		staleSandboxes []string
+	}{
+		{
+			desc:           "no valid sandboxes deletes nothing",
+			validSandboxes: sets.New[string](),
+		},
+		{
+			desc:           "default network deletes the interfaces of all the networks of the stale sandboxes",
+			expectedPorts:  []string{"enp1s0f0_1", "stalesandbox000", "stalesandbox0_5", "stalesandbox0_7"},
+			expectedVeths:  []string{"stalesandbox000", "stalesandbox0_5", "stalesandbox0_7"},
+			staleSandboxes: []string{"stalesandbox000000", "stalesriov0000000"},
+		},
+		{
+			desc:           "secondary network only deletes the interfaces of its NAD",
+			nadName:        nadName,
+			expectedPorts:  []string{"stalesandbox0_5"},
+			expectedVeths:  []string{"stalesandbox0_5"},
+			staleSandboxes: []string{"stalesandbox000000"},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			bridge := &vswitchd.Bridge{UUID: "u0000000001", Name: "br-int"}
+			testData := []libovsdbtest.TestData{
+				&vswitchd.OpenvSwitch{UUID: "u0000000000", Bridges: []string{bridge.UUID}},
+				bridge,
+			}
+			for j, iface := range ifaces {
+				ifaceUUID := fmt.Sprintf("u%010d", 100+j)
+				portUUID := fmt.Sprintf("u%010d", 200+j)
+				testData = append(testData,
+					&vswitchd.Interface{UUID: ifaceUUID, Name: iface.name, ExternalIDs: iface.externalIDs},
+					&vswitchd.Port{UUID: portUUID, Name: iface.name, Interfaces: []string{ifaceUUID}},
+				)
+				bridge.Ports = append(bridge.Ports, portUUID)
+			}
+			ovsClient, cleanup, err := libovsdbtest.NewOVSTestHarness(libovsdbtest.TestSetup{OVSData: testData})
+			require.NoError(t, err)
+			defer cleanup.Cleanup()
+
+			mockNetLinkOps := new(util_mocks.NetLinkOps)
+			util.SetNetLinkOpMockInst(mockNetLinkOps)
+			defer util.ResetNetLinkOpMockInst()
+			execMock := ovntest.NewFakeExec()
+			require.NoError(t, SetExec(execMock))
+			defer ResetRunner()
+
+			for _, port := range tc.expectedPorts {
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=30 --if-exists --with-iface del-port br-int " + port,
+				})
+				if port == "enp1s0f0_1" {
+					// SR-IOV representors are not deleted
+					mockNetLinkOps.On("LinkByName", port).Return(&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: port}}, nil)
+				}
+			}
+			for _, veth := range tc.expectedVeths {
+				link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: veth}}
+				mockNetLinkOps.On("LinkByName", veth).Return(link, nil)
+				mockNetLinkOps.On("LinkDelete", link).Return(nil)
+			}
+			for _, sandbox := range tc.staleSandboxes {
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: genOVSFindCmd("30", "qos", "_uuid", "external-ids:sandbox="+sandbox),
+				})
+			}
+
+			validSandboxes := tc.validSandboxes
+			if validSandboxes == nil {
+				validSandboxes = sets.New("validsandbox000000")
+			}
+			require.NoError(t, deleteStaleInterfaces(ovsClient, tc.nadName, validSandboxes))
+			assert.True(t, execMock.CalledMatchesExpected(), execMock.ErrorDesc())
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
 // TODO(leih): Below functions are copied from pkg/node/base_node_network_controller_dpu_test.go.
 // Move them to a common place to elimate duplications.
 func genOVSFindCmd(timeout, table, column, condition string) string {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	current "github.com/containernetworking/cni/pkg/types/100"
@@ -78,6 +79,13 @@ const CNIDel command = "DEL"
 // CNICheck is the command representing check operation on a pod
 const CNICheck command = "CHECK"
 
+// CNIGC is the command representing garbage collection of the stale pod
+// interfaces not in the runtime's list of valid attachments
+const CNIGC command = "GC"
+
+// CNIStatus is the command representing the readiness check of the plugin
+const CNIStatus command = "STATUS"
+
 // Request sent to the Server by the OVN CNI plugin
 type Request struct {
 	// CNI environment variables, like CNI_COMMAND and CNI_NETNS
@@ -203,4 +211,7 @@ type Server struct {
 	kubeAuth             *KubeAPIAuth
 	networkManager       networkmanager.Interface
 	ovsClient            client.Client
+	// ready is set once the node controller has finished its startup, until
+	// then STATUS requests report the plugin as not available
+	ready atomic.Bool
 }
@@ -66,6 +66,13 @@ type NetConf struct {
 	DefaultGatewayIPs string `json:"defaultGatewayIPs,omitempty"`
 	// VLANID, valid in localnet topology network only
 	VLANID int `json:"vlanID,omitempty"`
+	// AllowedVLANs is a comma-separated list of VLAN IDs and VLAN ID ranges, e.g.: "100,200-300",
+	// which are passed through tagged to the pods, in trunk mode. Frames tagged with other VLAN IDs
+	// are dropped. Valid in localnet topology network only, mutually exclusive with VLANID.
+	AllowedVLANs string `json:"allowedVLANs,omitempty"`
+	// NativeVLAN is the VLAN ID of the untagged traffic in trunk mode. Untagged traffic is dropped
+	// when omitted. Valid in localnet topology network only, requires AllowedVLANs.
+	NativeVLAN int `json:"nativeVLAN,omitempty"`
 	// AllowPersistentIPs is valid on both localnet / layer topologies.
 	// It allows for having IP allocations that outlive the pod for which
 	// they are originally created - e.g. a KubeVirt VM's migration, or
@@ -23,9 +23,14 @@ var ErrorChainingNotSupported = errors.New("CNI plugin chaining is not supported
 // if the file doesn't already exist, or is different than the content that would
 // be written.
 func WriteCNIConfig() error {
+	cniVersion := "0.4.0"
+	if CNI.EnableGCAndStatus {
+		// 1.1.0 is the first version for which runtimes issue GC and STATUS
+		cniVersion = "1.1.0"
+	}
 	netConf := &ovncnitypes.NetConf{
 		NetConf: types.NetConf{
-			CNIVersion: "0.4.0",
+			CNIVersion: cniVersion,
 			Name:       "ovn-kubernetes",
 			Type:       CNI.Plugin,
 		},
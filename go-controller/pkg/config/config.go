@@ -130,6 +130,11 @@ var (
 		CacheMaxFlows:      0,
 	}
 
+	// CoPP holds the Control Plane Protection configuration of the gateway routers
+	CoPP = CoPPConfig{
+		Rate: 25,
+	}
+
 	// CNI holds CNI-related parsed config file parameters and command-line overrides
 	CNI = CNIConfig{
 		ConfDir: "/etc/cni/net.d",
@@ -157,6 +162,10 @@ var (
 	// OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
 		EgressIPReachabiltyTotalTimeout: 1,
+		EgressIPPlacementStrategy:       EgressIPPlacementStrategyBalanced,
+		EgressIPNodeHealthCheckProtocol: EgressIPNodeHealthCheckProtocolGRPC,
+		EgressIPBFDInterval:             100,
+		EgressIPBFDMultiplier:           3,
 		AdvertisedUDNIsolationMode:      AdvertisedUDNIsolationModeStrict,
 	}
 
@@ -225,6 +234,14 @@ var (
 	// EnableMulticast enables multicast support between the pods within the same namespace
 	EnableMulticast bool
 
+	// MulticastQueryInterval is the interval, in seconds, between the IGMP/MLD general
+	// queries sent by the logical switches; 0 keeps the OVN default
+	MulticastQueryInterval uint
+
+	// MulticastTableSize is the maximum number of multicast groups snooped by a
+	// logical switch; 0 keeps the OVN default
+	MulticastTableSize uint
+
 	// IPv4Mode captures whether we are using IPv4 for OVN logical topology. (ie, single-stack IPv4 or dual-stack)
 	IPv4Mode bool
 
@@ -233,7 +250,8 @@ var (
 
 	// OvnKubeNode holds ovnkube-node parsed config file parameters and command-line overrides
 	OvnKubeNode = OvnKubeNodeConfig{
-		Mode: types.NodeModeFull,
+		Mode:              types.NodeModeFull,
+		KubeletConfigFile: "/var/lib/kubelet/config.yaml",
 	}
 
 	ClusterManager = ClusterManagerConfig{
@@ -391,12 +409,44 @@ type IPFIXConfig struct {
 	CacheMaxFlows uint `gcfg:"cache-max-flows"`
 }
 
+// CoPPConfig holds the Control Plane Protection (CoPP) configuration, that is
+// the rate limits of the traffic punted to ovn-controller by the gateway routers.
+type CoPPConfig struct {
+	// Rate is the rate, in packets per second, of the meters of the protocols
+	// without a rate limit in RateLimits.
+	Rate uint `gcfg:"rate"`
+	// Burst is the burst size, in packets, of the meters of the protocols
+	// without a rate limit in RateLimits. 0 means no burst.
+	Burst uint `gcfg:"burst"`
+	// RawRateLimits holds the unparsed per protocol rate limits.
+	// Should only be used inside config module.
+	RawRateLimits string `gcfg:"rate-limits"`
+	// RateLimits holds the parsed per protocol rate limits, indexed by protocol
+	// name, and may be used outside the config module.
+	RateLimits map[string]CoPPRateLimit
+}
+
+// CoPPRateLimit is the rate limit of the meter of a CoPP protocol
+type CoPPRateLimit struct {
+	// Rate in packets per second
+	Rate uint
+	// Burst size in packets, 0 means no burst
+	Burst uint
+}
+
 // CNIConfig holds CNI-related parsed config file parameters and command-line overrides
 type CNIConfig struct {
 	// ConfDir specifies the CNI config directory in which to write the overlay CNI config file
 	ConfDir string `gcfg:"conf-dir"`
 	// Plugin specifies the name of the CNI plugin
 	Plugin string `gcfg:"plugin"`
+	// EnableCheck makes CNI CHECK verify the pod interface against the pod
+	// annotation, it is a no-op otherwise since some container runtimes
+	// call it right after every ADD
+	EnableCheck bool `gcfg:"enable-check"`
+	// EnableGCAndStatus writes the CNI config with version 1.1.0, so that
+	// container runtimes supporting it issue GC and STATUS requests
+	EnableGCAndStatus bool `gcfg:"enable-gc-and-status"`
 }
 
 // KubernetesConfig holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -476,6 +526,15 @@ type OVNKubernetesFeatureConfig struct {
 	// This feature requires a kernel fix https://github.com/torvalds/linux/commit/7f3287db654395f9c5ddd246325ff7889f550286
 	// to work on a kind cluster. Flag allows to disable it for current CI, will be turned on when github runners have this fix.
 	AdvertisedUDNIsolationMode string `gcfg:"advertised-udn-isolation-mode"`
+	// EgressIPPlacementStrategy is the strategy used to place egress IPs on egress nodes
+	EgressIPPlacementStrategy string `gcfg:"egressip-placement-strategy"`
+	// EgressIPNodeHealthCheckProtocol is the protocol used to check the egress node reachability on
+	// EgressIPNodeHealthCheckPort
+	EgressIPNodeHealthCheckProtocol string `gcfg:"egressip-node-healthcheck-protocol"`
+	// EgressIPBFDInterval is the BFD transmit and receive interval in milliseconds
+	EgressIPBFDInterval int `gcfg:"egressip-bfd-interval"`
+	// EgressIPBFDMultiplier is the number of BFD packets lost before an egress node is unreachable
+	EgressIPBFDMultiplier int `gcfg:"egressip-bfd-multiplier"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -497,6 +556,26 @@ const (
 	AdvertisedUDNIsolationModeLoose = "loose"
 )
 
+const (
+	// EgressIPPlacementStrategyBalanced places an egress IP on the egress node with the fewest egress IPs, within
+	// its maximum number of egress IPs set with the k8s.ovn.org/egress-ip-placement node annotation.
+	EgressIPPlacementStrategyBalanced = "balanced"
+	// EgressIPPlacementStrategyWeighted places egress IPs on egress nodes in proportion of their weight and
+	// within their maximum number of egress IPs, both set with the k8s.ovn.org/egress-ip-placement node
+	// annotation, and moves assigned egress IPs to follow these proportions when egress nodes change.
+	EgressIPPlacementStrategyWeighted = "weighted"
+)
+
+const (
+	// EgressIPNodeHealthCheckProtocolGRPC checks the egress node reachability with a gRPC health service on
+	// the node.
+	EgressIPNodeHealthCheckProtocolGRPC = "grpc"
+	// EgressIPNodeHealthCheckProtocolBFD checks the egress node reachability with BFD sessions between the
+	// cluster manager and the node, over UDP. The node also serves the gRPC health service, which is used by
+	// the egress services.
+	EgressIPNodeHealthCheckProtocolBFD = "bfd"
+)
+
 // GatewayConfig holds node gateway-related parsed config file parameters and command-line overrides
 type GatewayConfig struct {
 	// Mode is the gateway mode; if may be either empty (disabled), "shared", or "local"
@@ -604,6 +683,28 @@ type OvnKubeNodeConfig struct {
 	Mode                   string `gcfg:"mode"`
 	MgmtPortNetdev         string `gcfg:"mgmt-port-netdev"`
 	MgmtPortDPResourceName string `gcfg:"mgmt-port-dp-resource-name"`
+	// OVSVSwitchdCPUs is the CPU list ovs-vswitchd threads are pinned to, or KubeletReservedCPUs
+	OVSVSwitchdCPUs string `gcfg:"ovs-vswitchd-cpus"`
+	// OVSPMDCPUs is the CPU list set as ovs-vswitchd PMD CPU mask, the PMD threads are pinned by ovs-vswitchd
+	OVSPMDCPUs string `gcfg:"ovs-pmd-cpus"`
+	// OVSHandlerCPUs overrides OVSVSwitchdCPUs for the ovs-vswitchd handler threads
+	OVSHandlerCPUs string `gcfg:"ovs-handler-cpus"`
+	// OVSRevalidatorCPUs overrides OVSVSwitchdCPUs for the ovs-vswitchd revalidator threads
+	OVSRevalidatorCPUs string `gcfg:"ovs-revalidator-cpus"`
+	// OVSDBServerCPUs is the CPU list ovsdb-server threads are pinned to, or KubeletReservedCPUs
+	OVSDBServerCPUs string `gcfg:"ovsdb-server-cpus"`
+	// KubeletConfigFile is the kubelet configuration file holding the kubelet reserved CPUs
+	KubeletConfigFile string `gcfg:"kubelet-config-file"`
+}
+
+// KubeletReservedCPUs can be used instead of a CPU list to pin the OVS daemons to the
+// CPUs reserved by the kubelet for the system daemons (reservedSystemCPUs).
+const KubeletReservedCPUs = "reserved"
+
+// OVSCPUPinningConfigured returns true if a CPU pinning policy is configured for the OVS daemons
+func (c *OvnKubeNodeConfig) OVSCPUPinningConfigured() bool {
+	return c.OVSVSwitchdCPUs != "" || c.OVSPMDCPUs != "" || c.OVSHandlerCPUs != "" ||
+		c.OVSRevalidatorCPUs != "" || c.OVSDBServerCPUs != ""
 }
 
 // ClusterManagerConfig holds configuration for ovnkube-cluster-manager
@@ -632,6 +733,7 @@ type config struct {
 	Logging              LoggingConfig
 	Monitoring           MonitoringConfig
 	IPFIX                IPFIXConfig
+	CoPP                 CoPPConfig
 	CNI                  CNIConfig
 	OVNKubernetesFeature OVNKubernetesFeatureConfig
 	Kubernetes           KubernetesConfig
@@ -652,6 +754,7 @@ var (
 	savedLogging              LoggingConfig
 	savedMonitoring           MonitoringConfig
 	savedIPFIX                IPFIXConfig
+	savedCoPP                 CoPPConfig
 	savedCNI                  CNIConfig
 	savedOVNKubernetesFeature OVNKubernetesFeatureConfig
 	savedKubernetes           KubernetesConfig
@@ -682,6 +785,7 @@ func init() {
 	savedLogging = Logging
 	savedMonitoring = Monitoring
 	savedIPFIX = IPFIX
+	savedCoPP = CoPP
 	savedCNI = CNI
 	savedOVNKubernetesFeature = OVNKubernetesFeature
 	savedKubernetes = Kubernetes
@@ -714,6 +818,7 @@ func PrepareTestConfig() error {
 	Logging.Level = 5
 	Monitoring = savedMonitoring
 	IPFIX = savedIPFIX
+	CoPP = savedCoPP
 	CNI = savedCNI
 	OVNKubernetesFeature = savedOVNKubernetesFeature
 	Kubernetes = savedKubernetes
@@ -728,6 +833,8 @@ func PrepareTestConfig() error {
 	OvsPaths = savedOvsPaths
 	Kubernetes.DisableRequestedChassis = false
 	EnableMulticast = false
+	MulticastQueryInterval = 0
+	MulticastTableSize = 0
 	UnprivilegedMode = false
 	Default.OVSDBTxnTimeout = 5 * time.Second
 	if Gateway.Mode != GatewayModeDisabled {
@@ -969,6 +1076,18 @@ var CommonFlags = []cli.Flag{
 		Usage:       "Adds multicast support. Valid only with --init-master option.",
 		Destination: &EnableMulticast,
 	},
+	&cli.UintFlag{
+		Name: "multicast-query-interval",
+		Usage: "The interval, in seconds, between the IGMP/MLD general queries sent by the logical switches " +
+			"when multicast is enabled. 0 keeps the OVN default.",
+		Destination: &MulticastQueryInterval,
+	},
+	&cli.UintFlag{
+		Name: "multicast-table-size",
+		Usage: "The maximum number of multicast groups snooped by a logical switch when multicast is enabled. " +
+			"0 keeps the OVN default.",
+		Destination: &MulticastTableSize,
+	},
 	// Logging options
 	&cli.IntFlag{
 		Name:        "loglevel",
@@ -1080,6 +1199,32 @@ var IPFIXFlags = []cli.Flag{
 	},
 }
 
+// CoPPFlags capture Control Plane Protection options
+var CoPPFlags = []cli.Flag{
+	&cli.UintFlag{
+		Name: "copp-rate",
+		Usage: "Rate, in packets per second, of the CoPP meters of the gateway routers for the protocols without a rate limit in --copp-rate-limits. " +
+			"Changes only apply after an ovnkube-controller restart",
+		Destination: &cliConfig.CoPP.Rate,
+		Value:       CoPP.Rate,
+	},
+	&cli.UintFlag{
+		Name: "copp-burst",
+		Usage: "Burst size, in packets, of the CoPP meters of the gateway routers for the protocols without a rate limit in --copp-rate-limits. " +
+			"If 0, bursts are not allowed. Changes only apply after an ovnkube-controller restart",
+		Destination: &cliConfig.CoPP.Burst,
+		Value:       CoPP.Burst,
+	},
+	&cli.StringFlag{
+		Name: "copp-rate-limits",
+		Usage: "A comma separated set of per protocol CoPP meter rate limits, in the form protocol=rate[:burst] " +
+			"(eg, \"bfd=500:1000,reject=10\"). Valid protocols are " + strings.Join(types.CoPPProtocols, ", ") + ". " +
+			"Changes only apply after an ovnkube-controller restart",
+		Destination: &cliConfig.CoPP.RawRateLimits,
+		Value:       CoPP.RawRateLimits,
+	},
+}
+
 // CNIFlags capture CNI-related options
 var CNIFlags = []cli.Flag{
 	// CNI options
@@ -1095,6 +1240,20 @@ var CNIFlags = []cli.Flag{
 		Destination: &cliConfig.CNI.Plugin,
 		Value:       CNI.Plugin,
 	},
+	&cli.BoolFlag{
+		Name: "cni-enable-check",
+		Usage: "verify the pod interface against the pod annotation on CNI CHECK requests; " +
+			"container runtimes calling CHECK right after ADD then slow down every pod sandbox creation",
+		Destination: &cliConfig.CNI.EnableCheck,
+		Value:       CNI.EnableCheck,
+	},
+	&cli.BoolFlag{
+		Name: "cni-enable-gc-and-status",
+		Usage: "write the CNI config with version 1.1.0 so that the container runtime issues GC and STATUS requests; " +
+			"the container runtime must support CNI 1.1.0",
+		Destination: &cliConfig.CNI.EnableGCAndStatus,
+		Value:       CNI.EnableGCAndStatus,
+	},
 }
 
 // OVNK8sFeatureFlags capture OVN-Kubernetes feature related options
@@ -1134,6 +1293,31 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Usage:       "Configure EgressIP node reachability using gRPC on this TCP port.",
 		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
 	},
+	&cli.StringFlag{
+		Name: "egressip-node-healthcheck-protocol",
+		Usage: "Protocol used to check the EgressIP node reachability on egressip-node-healthcheck-port. " +
+			"Valid values are 'grpc' or 'bfd' (UDP), which detects the node failures within egressip-bfd-interval x egressip-bfd-multiplier.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol,
+		Value:       OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-bfd-interval",
+		Usage:       "EgressIP node reachability BFD transmit and receive interval in milliseconds.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPBFDInterval,
+		Value:       OVNKubernetesFeature.EgressIPBFDInterval,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-bfd-multiplier",
+		Usage:       "Number of EgressIP node reachability BFD packets lost before the node is unreachable.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPBFDMultiplier,
+		Value:       OVNKubernetesFeature.EgressIPBFDMultiplier,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-placement-strategy",
+		Usage:       "Strategy used to place egress IPs on egress nodes. Valid values are 'balanced' or 'weighted'.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPPlacementStrategy,
+		Value:       OVNKubernetesFeature.EgressIPPlacementStrategy,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-multi-network",
 		Usage:       "Use multiple NetworkAttachmentDefinition CRD feature with ovn-kubernetes.",
@@ -1729,6 +1913,45 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.MgmtPortDPResourceName,
 		Destination: &cliConfig.OvnKubeNode.MgmtPortDPResourceName,
 	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-ovs-vswitchd-cpus",
+		Usage: "When provided, pin the ovs-vswitchd threads to this CPU list (e.g. 0-1,8) or to the kubelet " +
+			"reserved CPUs with \"" + KubeletReservedCPUs + "\". Replaces the dynamic CPU affinity enabled by " +
+			"/etc/openvswitch/enable_dynamic_cpu_affinity.",
+		Value:       OvnKubeNode.OVSVSwitchdCPUs,
+		Destination: &cliConfig.OvnKubeNode.OVSVSwitchdCPUs,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ovs-pmd-cpus",
+		Usage:       "When provided, set the ovs-vswitchd PMD CPU mask (other_config:pmd-cpu-mask) to this CPU list.",
+		Value:       OvnKubeNode.OVSPMDCPUs,
+		Destination: &cliConfig.OvnKubeNode.OVSPMDCPUs,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ovs-handler-cpus",
+		Usage:       "When provided, pin the ovs-vswitchd handler threads to this CPU list instead of ovnkube-node-ovs-vswitchd-cpus.",
+		Value:       OvnKubeNode.OVSHandlerCPUs,
+		Destination: &cliConfig.OvnKubeNode.OVSHandlerCPUs,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ovs-revalidator-cpus",
+		Usage:       "When provided, pin the ovs-vswitchd revalidator threads to this CPU list instead of ovnkube-node-ovs-vswitchd-cpus.",
+		Value:       OvnKubeNode.OVSRevalidatorCPUs,
+		Destination: &cliConfig.OvnKubeNode.OVSRevalidatorCPUs,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-ovsdb-server-cpus",
+		Usage: "When provided, pin the ovsdb-server threads to this CPU list (e.g. 0-1,8) or to the kubelet " +
+			"reserved CPUs with \"" + KubeletReservedCPUs + "\".",
+		Value:       OvnKubeNode.OVSDBServerCPUs,
+		Destination: &cliConfig.OvnKubeNode.OVSDBServerCPUs,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-kubelet-config-file",
+		Usage:       "The kubelet configuration file the CPUs reserved for the system daemons are read from, to pin the OVS daemons.",
+		Value:       OvnKubeNode.KubeletConfigFile,
+		Destination: &cliConfig.OvnKubeNode.KubeletConfigFile,
+	},
 }
 
 // ClusterManagerFlags captures ovnkube-cluster-manager specific configurations
@@ -1777,6 +2000,7 @@ func GetFlags(customFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, HybridOverlayFlags...)
 	flags = append(flags, MonitoringFlags...)
 	flags = append(flags, IPFIXFlags...)
+	flags = append(flags, CoPPFlags...)
 	flags = append(flags, OvnKubeNodeFlags...)
 	flags = append(flags, ClusterManagerFlags...)
 	flags = append(flags, OvsPathsFlags...)
@@ -2122,6 +2346,24 @@ func buildOVNKubernetesFeatureConfig(cli, file *config) error {
 		return fmt.Errorf("invalid advertised-udn-isolation-mode %q: expect one of %s or %s",
 			OVNKubernetesFeature.AdvertisedUDNIsolationMode, AdvertisedUDNIsolationModeStrict, AdvertisedUDNIsolationModeLoose)
 	}
+	if OVNKubernetesFeature.EgressIPPlacementStrategy != EgressIPPlacementStrategyBalanced && OVNKubernetesFeature.EgressIPPlacementStrategy != EgressIPPlacementStrategyWeighted {
+		return fmt.Errorf("invalid egressip-placement-strategy %q: expect one of %s or %s",
+			OVNKubernetesFeature.EgressIPPlacementStrategy, EgressIPPlacementStrategyBalanced, EgressIPPlacementStrategyWeighted)
+	}
+	if OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol != EgressIPNodeHealthCheckProtocolGRPC && OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol != EgressIPNodeHealthCheckProtocolBFD {
+		return fmt.Errorf("invalid egressip-node-healthcheck-protocol %q: expect one of %s or %s",
+			OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol, EgressIPNodeHealthCheckProtocolGRPC, EgressIPNodeHealthCheckProtocolBFD)
+	}
+	if OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol == EgressIPNodeHealthCheckProtocolBFD {
+		if OVNKubernetesFeature.EgressIPBFDInterval <= 0 || OVNKubernetesFeature.EgressIPBFDInterval > 60000 {
+			return fmt.Errorf("invalid egressip-bfd-interval %d: expect a value between 1 and 60000 milliseconds",
+				OVNKubernetesFeature.EgressIPBFDInterval)
+		}
+		if OVNKubernetesFeature.EgressIPBFDMultiplier <= 0 || OVNKubernetesFeature.EgressIPBFDMultiplier > 255 {
+			return fmt.Errorf("invalid egressip-bfd-multiplier %d: expect a value between 1 and 255",
+				OVNKubernetesFeature.EgressIPBFDMultiplier)
+		}
+	}
 	return nil
 }
 
@@ -2218,6 +2460,38 @@ func buildIPFIXConfig(cli, file *config) error {
 	return overrideFields(&IPFIX, &cli.IPFIX, &savedIPFIX)
 }
 
+func buildCoPPConfig(cli, file *config) error {
+	// Copy config file values over default values
+	if err := overrideFields(&CoPP, &file.CoPP, &savedCoPP); err != nil {
+		return err
+	}
+
+	// And CLI overrides over config file and default values
+	if err := overrideFields(&CoPP, &cli.CoPP, &savedCoPP); err != nil {
+		return err
+	}
+
+	if CoPP.Rate == 0 {
+		return fmt.Errorf("CoPP rate must be greater than 0")
+	}
+
+	var err error
+	CoPP.RateLimits, err = ParseCoPPRateLimits(CoPP.RawRateLimits)
+	if err != nil {
+		return fmt.Errorf("CoPP rate limits invalid: %v", err)
+	}
+
+	return nil
+}
+
+// GetCoPPRateLimit returns the rate limit of the CoPP meter of protocol
+func GetCoPPRateLimit(protocol string) CoPPRateLimit {
+	if rateLimit, ok := CoPP.RateLimits[protocol]; ok {
+		return rateLimit
+	}
+	return CoPPRateLimit{Rate: CoPP.Rate, Burst: CoPP.Burst}
+}
+
 func buildHybridOverlayConfig(cli, file *config) error {
 	// Copy config file values over default values
 	if err := overrideFields(&HybridOverlay, &file.HybridOverlay, &savedHybridOverlay); err != nil {
@@ -2419,6 +2693,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		Default:              savedDefault,
 		Logging:              savedLogging,
 		IPFIX:                savedIPFIX,
+		CoPP:                 savedCoPP,
 		CNI:                  savedCNI,
 		OVNKubernetesFeature: savedOVNKubernetesFeature,
 		Kubernetes:           savedKubernetes,
@@ -2540,6 +2815,10 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
+	if err = buildCoPPConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	if err = buildHybridOverlayConfig(&cliConfig, &cfg); err != nil {
 		return "", err
 	}
@@ -2576,6 +2855,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	klog.V(5).Infof("Logging config: %+v", Logging)
 	klog.V(5).Infof("Monitoring config: %+v", Monitoring)
 	klog.V(5).Infof("IPFIX config: %+v", IPFIX)
+	klog.V(5).Infof("CoPP config: %+v", CoPP)
 	klog.V(5).Infof("CNI config: %+v", CNI)
 	klog.V(5).Infof("Kubernetes config: %+v", stripTokenFromK8sConfig())
 	klog.V(5).Infof("Gateway config: %+v", Gateway)
@@ -2920,5 +3200,20 @@ func buildOvnKubeNodeConfig(cli, file *config) error {
 	if OVNKubernetesFeature.EnableNetworkSegmentation && OvnKubeNode.Mode == types.NodeModeDPUHost && OvnKubeNode.MgmtPortDPResourceName == "" {
 		return fmt.Errorf("ovnkube-node-mgmt-port-dp-resource-name must be provided on dpu-host mode if network segmentation is enabled")
 	}
+
+	for name, cpus := range map[string]string{
+		"ovnkube-node-ovs-vswitchd-cpus":    OvnKubeNode.OVSVSwitchdCPUs,
+		"ovnkube-node-ovs-pmd-cpus":         OvnKubeNode.OVSPMDCPUs,
+		"ovnkube-node-ovs-handler-cpus":     OvnKubeNode.OVSHandlerCPUs,
+		"ovnkube-node-ovs-revalidator-cpus": OvnKubeNode.OVSRevalidatorCPUs,
+		"ovnkube-node-ovsdb-server-cpus":    OvnKubeNode.OVSDBServerCPUs,
+	} {
+		if cpus == "" || cpus == KubeletReservedCPUs {
+			continue
+		}
+		if _, err := ParseCPUList(cpus); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", name, cpus, err)
+		}
+	}
 	return nil
 }
@@ -181,9 +181,16 @@ sampling=123
 cache-max-flows=456
 cache-active-timeout=789
 
+[copp]
+rate=50
+burst=100
+rate-limits=bfd=500:1000
+
 [cni]
 conf-dir=/etc/cni/net.d22
 plugin=ovn-k8s-cni-overlay22
+enable-check=true
+enable-gc-and-status=true
 
 [ovnnorth]
 address=ssl:1.2.3.4:6641
@@ -312,8 +319,13 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(IPFIX.Sampling).To(gomega.Equal(uint(400)))
 			gomega.Expect(IPFIX.CacheMaxFlows).To(gomega.Equal(uint(0)))
 			gomega.Expect(IPFIX.CacheActiveTimeout).To(gomega.Equal(uint(60)))
+			gomega.Expect(CoPP.Rate).To(gomega.Equal(uint(25)))
+			gomega.Expect(CoPP.Burst).To(gomega.Equal(uint(0)))
+			gomega.Expect(CoPP.RateLimits).To(gomega.BeEmpty())
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/etc/cni/net.d"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("ovn-k8s-cni-overlay"))
+			gomega.Expect(CNI.EnableCheck).To(gomega.BeFalse())
+			gomega.Expect(CNI.EnableGCAndStatus).To(gomega.BeFalse())
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(""))
 			gomega.Expect(Kubernetes.BootstrapKubeconfig).To(gomega.Equal(""))
 			gomega.Expect(Kubernetes.CertDir).To(gomega.Equal(""))
@@ -340,12 +352,17 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(OvnKubeNode.Mode).To(gomega.Equal(types.NodeModeFull))
 			gomega.Expect(OvnKubeNode.MgmtPortNetdev).To(gomega.Equal(""))
 			gomega.Expect(OvnKubeNode.MgmtPortDPResourceName).To(gomega.Equal(""))
+			gomega.Expect(OvnKubeNode.OVSCPUPinningConfigured()).To(gomega.BeFalse())
+			gomega.Expect(OvnKubeNode.KubeletConfigFile).To(gomega.Equal("/var/lib/kubelet/config.yaml"))
 			gomega.Expect(Gateway.RouterSubnet).To(gomega.Equal(""))
 			gomega.Expect(Gateway.SingleNode).To(gomega.BeFalse())
 			gomega.Expect(Gateway.DisableForwarding).To(gomega.BeFalse())
 			gomega.Expect(Gateway.AllowNoUplink).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(1))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(0))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol).To(gomega.Equal(EgressIPNodeHealthCheckProtocolGRPC))
+			gomega.Expect(OVNKubernetesFeature.EgressIPBFDInterval).To(gomega.Equal(100))
+			gomega.Expect(OVNKubernetesFeature.EgressIPBFDMultiplier).To(gomega.Equal(3))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EnableNetworkSegmentation).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EnableNetworkConnect).To(gomega.BeFalse())
@@ -642,8 +659,15 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(IPFIX.Sampling).To(gomega.Equal(uint(123)))
 			gomega.Expect(IPFIX.CacheMaxFlows).To(gomega.Equal(uint(456)))
 			gomega.Expect(IPFIX.CacheActiveTimeout).To(gomega.Equal(uint(789)))
+			gomega.Expect(CoPP.Rate).To(gomega.Equal(uint(50)))
+			gomega.Expect(CoPP.Burst).To(gomega.Equal(uint(100)))
+			gomega.Expect(CoPP.RateLimits).To(gomega.Equal(map[string]CoPPRateLimit{"bfd": {Rate: 500, Burst: 1000}}))
+			gomega.Expect(GetCoPPRateLimit("bfd")).To(gomega.Equal(CoPPRateLimit{Rate: 500, Burst: 1000}))
+			gomega.Expect(GetCoPPRateLimit("reject")).To(gomega.Equal(CoPPRateLimit{Rate: 50, Burst: 100}))
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/etc/cni/net.d22"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("ovn-k8s-cni-overlay22"))
+			gomega.Expect(CNI.EnableCheck).To(gomega.BeTrue())
+			gomega.Expect(CNI.EnableGCAndStatus).To(gomega.BeTrue())
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(kubeconfigFile))
 			gomega.Expect(Kubernetes.BootstrapKubeconfig).To(gomega.Equal(bootstrapKubeconfigFile))
 			gomega.Expect(Kubernetes.CertDir).To(gomega.Equal(certDir))
@@ -1311,6 +1335,12 @@ enable-pprof=true
 			gomega.Expect(IPFIX.Sampling).To(gomega.Equal(uint(1123)))
 			gomega.Expect(IPFIX.CacheMaxFlows).To(gomega.Equal(uint(1456)))
 			gomega.Expect(IPFIX.CacheActiveTimeout).To(gomega.Equal(uint(1789)))
+			gomega.Expect(CoPP.Rate).To(gomega.Equal(uint(50)))
+			gomega.Expect(CoPP.Burst).To(gomega.Equal(uint(100)))
+			gomega.Expect(CoPP.RateLimits).To(gomega.Equal(map[string]CoPPRateLimit{
+				"bfd":    {Rate: 1500},
+				"reject": {Rate: 10, Burst: 20},
+			}))
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/some/cni/dir"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("a-plugin"))
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(kubeconfigFile))
@@ -1359,6 +1389,7 @@ enable-pprof=true
 			"-ipfix-sampling=1123",
 			"-ipfix-cache-max-flows=1456",
 			"-ipfix-cache-active-timeout=1789",
+			"-copp-rate-limits=bfd=1500,reject=10:20",
 			"-cni-conf-dir=/some/cni/dir",
 			"-cni-plugin=a-plugin",
 			"-k8s-kubeconfig=" + kubeconfigFile,
@@ -1636,6 +1667,62 @@ advertised-udn-isolation-mode=foo
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("rejects a config with invalid egressip-placement-strategy", func() {
+		err := os.WriteFile(cfgFile.Name(), []byte(`[ovnkubernetesfeature]
+egressip-placement-strategy=foo
+`), 0o644)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(
+				gomega.ContainSubstring("invalid egressip-placement-strategy \"foo\": expect one of balanced or weighted")),
+			)
+			return nil
+		}
+		cliArgs := []string{app.Name, "-config-file=" + cfgFile.Name()}
+		err = app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("configures the egressip node reachability with BFD", func() {
+		err := os.WriteFile(cfgFile.Name(), []byte(`[ovnkubernetesfeature]
+egressip-node-healthcheck-protocol=bfd
+egressip-bfd-interval=50
+`), 0o644)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol).To(gomega.Equal(EgressIPNodeHealthCheckProtocolBFD))
+			gomega.Expect(OVNKubernetesFeature.EgressIPBFDInterval).To(gomega.Equal(50))
+			gomega.Expect(OVNKubernetesFeature.EgressIPBFDMultiplier).To(gomega.Equal(5))
+			return nil
+		}
+		cliArgs := []string{app.Name, "-config-file=" + cfgFile.Name(), "-egressip-bfd-multiplier=5"}
+		err = app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("rejects a config with invalid egressip-node-healthcheck-protocol", func() {
+		err := os.WriteFile(cfgFile.Name(), []byte(`[ovnkubernetesfeature]
+egressip-node-healthcheck-protocol=icmp
+`), 0o644)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(
+				gomega.ContainSubstring("invalid egressip-node-healthcheck-protocol \"icmp\": expect one of grpc or bfd")),
+			)
+			return nil
+		}
+		cliArgs := []string{app.Name, "-config-file=" + cfgFile.Name()}
+		err = app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("rejects a config with invalid syntax", func() {
 		err := os.WriteFile(cfgFile.Name(), []byte(`[default]
 mtu=1234
@@ -2002,6 +2089,49 @@ udn-allowed-default-services= ns/svc, ns1/svc1
 			gomega.Expect(OvnKubeNode.MgmtPortDPResourceName).To(gomega.Equal("openshift.io/mgmtvf"))
 		})
 
+		It("Overrides the OVS CPU pinning policy", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:              types.NodeModeFull,
+					OVSVSwitchdCPUs:   KubeletReservedCPUs,
+					OVSPMDCPUs:        "4-5",
+					KubeletConfigFile: "/etc/kubernetes/kubelet.conf",
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:            types.NodeModeFull,
+					OVSVSwitchdCPUs: "0-1",
+					OVSDBServerCPUs: "0",
+				},
+			}
+			err := buildOvnKubeNodeConfig(&cliConfig, &file)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.OVSCPUPinningConfigured()).To(gomega.BeTrue())
+			gomega.Expect(OvnKubeNode.OVSVSwitchdCPUs).To(gomega.Equal(KubeletReservedCPUs))
+			gomega.Expect(OvnKubeNode.OVSPMDCPUs).To(gomega.Equal("4-5"))
+			gomega.Expect(OvnKubeNode.OVSHandlerCPUs).To(gomega.Equal(""))
+			gomega.Expect(OvnKubeNode.OVSDBServerCPUs).To(gomega.Equal("0"))
+			gomega.Expect(OvnKubeNode.KubeletConfigFile).To(gomega.Equal("/etc/kubernetes/kubelet.conf"))
+		})
+
+		It("Fails with an invalid OVS CPU list", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeFull,
+					OVSHandlerCPUs: "2-1",
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			err := buildOvnKubeNodeConfig(&cliConfig, &file)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("invalid ovnkube-node-ovs-handler-cpus"))
+		})
+
 		It("Fails with unsupported mode", func() {
 			cliConfig := config{
 				OvnKubeNode: OvnKubeNodeConfig{
@@ -2122,3 +2252,29 @@ udn-allowed-default-services= ns/svc, ns1/svc1
 		})
 	})
 })
+
+var _ = Describe("CNI config", func() {
+	BeforeEach(func() {
+		gomega.Expect(PrepareTestConfig()).To(gomega.Succeed())
+		CNI.ConfDir = GinkgoT().TempDir()
+	})
+
+	readCNIVersion := func() string {
+		bytes, err := os.ReadFile(filepath.Join(CNI.ConfDir, CNIConfFileName))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		conf, err := ReadCNIConfig(bytes)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return conf.CNIVersion
+	}
+
+	It("is written with version 0.4.0 by default", func() {
+		gomega.Expect(WriteCNIConfig()).To(gomega.Succeed())
+		gomega.Expect(readCNIVersion()).To(gomega.Equal("0.4.0"))
+	})
+
+	It("is written with version 1.1.0 when GC and STATUS are enabled", func() {
+		CNI.EnableGCAndStatus = true
+		gomega.Expect(WriteCNIConfig()).To(gomega.Succeed())
+		gomega.Expect(readCNIVersion()).To(gomega.Equal("1.1.0"))
+	})
+})
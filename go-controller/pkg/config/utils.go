@@ -6,12 +6,16 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	iputils "github.com/containernetworking/plugins/pkg/ip"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 // HostPort is the object that holds the definition for a host and port tuple
@@ -386,3 +390,70 @@ func getKernelEphemeralPortRange() (string, error) {
 
 	return fmt.Sprintf("%d-%d", minPort, maxPort), nil
 }
+
+// ParseCoPPRateLimits parses a comma separated set of per protocol CoPP rate
+// limits, in the form protocol=rate[:burst].
+func ParseCoPPRateLimits(rateLimits string) (map[string]CoPPRateLimit, error) {
+	parsedRateLimits := map[string]CoPPRateLimit{}
+	if strings.TrimSpace(rateLimits) == "" {
+		return parsedRateLimits, nil
+	}
+	for _, rateLimit := range strings.Split(rateLimits, ",") {
+		protocol, limit, found := strings.Cut(strings.TrimSpace(rateLimit), "=")
+		if !found {
+			return nil, fmt.Errorf("rate limit %q is not in the form protocol=rate[:burst]", rateLimit)
+		}
+		if !slices.Contains(types.CoPPProtocols, protocol) {
+			return nil, fmt.Errorf("unknown CoPP protocol %q, valid protocols are %v", protocol, types.CoPPProtocols)
+		}
+		if _, ok := parsedRateLimits[protocol]; ok {
+			return nil, fmt.Errorf("duplicate rate limit for CoPP protocol %q", protocol)
+		}
+		rawRate, rawBurst, hasBurst := strings.Cut(limit, ":")
+		rate, err := strconv.ParseUint(rawRate, 10, 32)
+		if err != nil || rate == 0 {
+			return nil, fmt.Errorf("invalid rate %q for CoPP protocol %q", rawRate, protocol)
+		}
+		var burst uint64
+		if hasBurst {
+			burst, err = strconv.ParseUint(rawBurst, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid burst %q for CoPP protocol %q", rawBurst, protocol)
+			}
+		}
+		parsedRateLimits[protocol] = CoPPRateLimit{Rate: uint(rate), Burst: uint(burst)}
+	}
+	return parsedRateLimits, nil
+}
+
+// ParseCPUList parses a CPU list in the linux list format (e.g. "0-3,8,10-11") and
+// returns the sorted list of the CPUs it holds.
+// See http://man7.org/linux/man-pages/man7/cpuset.7.html#FORMATS
+func ParseCPUList(cpuList string) ([]int, error) {
+	cpus := sets.New[int]()
+	if strings.TrimSpace(cpuList) == "" {
+		return []int{}, nil
+	}
+	for _, rng := range strings.Split(cpuList, ",") {
+		rng = strings.TrimSpace(rng)
+		rawStart, rawEnd, isRange := strings.Cut(rng, "-")
+		start, err := strconv.ParseUint(rawStart, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU %q", rawStart)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.ParseUint(rawEnd, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CPU %q", rawEnd)
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid CPU range %q", rng)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus.Insert(int(cpu))
+		}
+	}
+	return sets.List(cpus), nil
+}
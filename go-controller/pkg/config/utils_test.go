@@ -2,6 +2,7 @@ package config
 
 import (
 	"net"
+	"reflect"
 	"testing"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -354,3 +355,136 @@ func TestParseFlowCollectors_DeduplicateEquivalentEntries(t *testing.T) {
 		t.Errorf("parsed hostPorts returned unexpected results: %+v", hp)
 	}
 }
+
+func TestParseCoPPRateLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		rateLimits string
+		expected   map[string]CoPPRateLimit
+		expectErr  bool
+	}{
+		{
+			name:       "empty",
+			rateLimits: "",
+			expected:   map[string]CoPPRateLimit{},
+		},
+		{
+			name:       "rate and burst",
+			rateLimits: "bfd=500:1000, reject=10,arp=100:0",
+			expected: map[string]CoPPRateLimit{
+				"bfd":    {Rate: 500, Burst: 1000},
+				"reject": {Rate: 10},
+				"arp":    {Rate: 100},
+			},
+		},
+		{
+			name:       "unknown protocol",
+			rateLimits: "dhcp=100",
+			expectErr:  true,
+		},
+		{
+			name:       "missing rate",
+			rateLimits: "bfd",
+			expectErr:  true,
+		},
+		{
+			name:       "zero rate",
+			rateLimits: "bfd=0",
+			expectErr:  true,
+		},
+		{
+			name:       "invalid burst",
+			rateLimits: "bfd=10:-1",
+			expectErr:  true,
+		},
+		{
+			name:       "duplicate protocol",
+			rateLimits: "bfd=10,bfd=20",
+			expectErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rateLimits, err := ParseCoPPRateLimits(tc.rateLimits)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error parsing %q, got %+v", tc.rateLimits, rateLimits)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("can't parse CoPP rate limits %q: %v", tc.rateLimits, err)
+			}
+			if !reflect.DeepEqual(rateLimits, tc.expected) {
+				t.Errorf("parsed CoPP rate limits returned unexpected results: %+v", rateLimits)
+			}
+		})
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	tests := []struct {
+		name      string
+		cpuList   string
+		expected  []int
+		expectErr bool
+	}{
+		{
+			name:     "empty list",
+			cpuList:  "",
+			expected: []int{},
+		},
+		{
+			name:     "single CPU",
+			cpuList:  "3",
+			expected: []int{3},
+		},
+		{
+			name:     "CPUs and ranges",
+			cpuList:  "8, 0-2,10-11",
+			expected: []int{0, 1, 2, 8, 10, 11},
+		},
+		{
+			name:     "overlapping ranges",
+			cpuList:  "0-3,2-4",
+			expected: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:      "invalid CPU",
+			cpuList:   "0,a",
+			expectErr: true,
+		},
+		{
+			name:      "negative CPU",
+			cpuList:   "-1",
+			expectErr: true,
+		},
+		{
+			name:      "reversed range",
+			cpuList:   "4-2",
+			expectErr: true,
+		},
+		{
+			name:      "empty entry",
+			cpuList:   "0,,1",
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cpus, err := ParseCPUList(tc.cpuList)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error parsing %q, got %+v", tc.cpuList, cpus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("can't parse CPU list %q: %v", tc.cpuList, err)
+			}
+			if !reflect.DeepEqual(cpus, tc.expected) {
+				t.Errorf("parsed CPU list returned unexpected results: %+v", cpus)
+			}
+		})
+	}
+}
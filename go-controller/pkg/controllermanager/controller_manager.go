@@ -69,6 +69,9 @@ type ControllerManager struct {
 
 	// eIPController programs OVN to support EgressIP
 	eIPController *ovn.EgressIPController
+
+	// observManager configures the sampling of the features with the ObservabilityConfigs
+	observManager *observability.Manager
 }
 
 func (cm *ControllerManager) NewNetworkController(nInfo util.NetInfo) (networkmanager.NetworkController, error) {
@@ -245,6 +248,7 @@ func NewControllerManager(ovnClient *util.OVNClientset, wf *factory.WatchFactory
 			IPAMClaimsClient:     ovnClient.IPAMClaimsClient,
 			NetworkQoSClient:     ovnClient.NetworkQoSClient,
 			NADClient:            ovnClient.NetworkAttchDefClient,
+			ObservConfigClient:   ovnClient.ObservabilityConfigClient,
 		},
 		stopChan:         stopCh,
 		watchFactory:     wf,
@@ -461,10 +465,10 @@ func (cm *ControllerManager) Start(ctx context.Context) error {
 		}
 	}
 
-	var observabilityManager *observability.Manager
 	if config.OVNKubernetesFeature.EnableObservability {
-		observabilityManager = observability.NewManager(cm.nbClient)
-		if err = observabilityManager.Init(); err != nil {
+		cm.observManager = observability.NewManagerWithConfigs(cm.nbClient, cm.kube.ObservConfigClient,
+			cm.watchFactory.ObservabilityConfigInformer(), cm.watchFactory.NamespaceCoreInformer(), zone)
+		if err = cm.observManager.Init(); err != nil {
 			return fmt.Errorf("failed to init observability manager: %w", err)
 		}
 	} else {
@@ -484,7 +488,7 @@ func (cm *ControllerManager) Start(ctx context.Context) error {
 		}()
 	}
 
-	err = cm.initDefaultNetworkController(observabilityManager)
+	err = cm.initDefaultNetworkController(cm.observManager)
 	if err != nil {
 		return fmt.Errorf("failed to init default network controller: %v", err)
 	}
@@ -533,6 +537,10 @@ func (cm *ControllerManager) Stop() {
 	if cm.routeImportManager != nil {
 		cm.routeImportManager.Stop()
 	}
+
+	if cm.observManager != nil {
+		cm.observManager.Stop()
+	}
 }
 
 func (cm *ControllerManager) Reconcile(_ string, _, _ util.NetInfo) error {
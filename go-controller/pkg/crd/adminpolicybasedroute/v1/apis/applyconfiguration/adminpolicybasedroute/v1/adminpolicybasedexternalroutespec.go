@@ -17,11 +17,16 @@ limitations under the License.
 
 package v1
 
+import (
+	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
+)
+
 // AdminPolicyBasedExternalRouteSpecApplyConfiguration represents a declarative configuration of the AdminPolicyBasedExternalRouteSpec type for use
 // with apply.
 type AdminPolicyBasedExternalRouteSpecApplyConfiguration struct {
-	From     *ExternalNetworkSourceApplyConfiguration `json:"from,omitempty"`
-	NextHops *ExternalNextHopsApplyConfiguration      `json:"nextHops,omitempty"`
+	From             *ExternalNetworkSourceApplyConfiguration `json:"from,omitempty"`
+	NextHops         *ExternalNextHopsApplyConfiguration      `json:"nextHops,omitempty"`
+	NetworkSelectors *types.NetworkSelectors                  `json:"networkSelectors,omitempty"`
 }
 
 // AdminPolicyBasedExternalRouteSpecApplyConfiguration constructs a declarative configuration of the AdminPolicyBasedExternalRouteSpec type for use with
@@ -45,3 +50,11 @@ func (b *AdminPolicyBasedExternalRouteSpecApplyConfiguration) WithNextHops(value
 	b.NextHops = value
 	return b
 }
+
+// WithNetworkSelectors sets the NetworkSelectors field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkSelectors field is set to the value of the last call.
+func (b *AdminPolicyBasedExternalRouteSpecApplyConfiguration) WithNetworkSelectors(value types.NetworkSelectors) *AdminPolicyBasedExternalRouteSpecApplyConfiguration {
+	b.NetworkSelectors = &value
+	return b
+}
@@ -25,9 +25,10 @@ import (
 // AdminPolicyBasedRouteStatusApplyConfiguration represents a declarative configuration of the AdminPolicyBasedRouteStatus type for use
 // with apply.
 type AdminPolicyBasedRouteStatusApplyConfiguration struct {
-	LastTransitionTime *metav1.Time                        `json:"lastTransitionTime,omitempty"`
-	Messages           []string                            `json:"messages,omitempty"`
-	Status             *adminpolicybasedroutev1.StatusType `json:"status,omitempty"`
+	LastTransitionTime *metav1.Time                         `json:"lastTransitionTime,omitempty"`
+	Messages           []string                             `json:"messages,omitempty"`
+	Status             *adminpolicybasedroutev1.StatusType  `json:"status,omitempty"`
+	BFDSessions        []BFDSessionStatusApplyConfiguration `json:"bfdSessions,omitempty"`
 }
 
 // AdminPolicyBasedRouteStatusApplyConfiguration constructs a declarative configuration of the AdminPolicyBasedRouteStatus type for use with
@@ -61,3 +62,16 @@ func (b *AdminPolicyBasedRouteStatusApplyConfiguration) WithStatus(value adminpo
 	b.Status = &value
 	return b
 }
+
+// WithBFDSessions adds the given value to the BFDSessions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the BFDSessions field.
+func (b *AdminPolicyBasedRouteStatusApplyConfiguration) WithBFDSessions(values ...*BFDSessionStatusApplyConfiguration) *AdminPolicyBasedRouteStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithBFDSessions")
+		}
+		b.BFDSessions = append(b.BFDSessions, *values[i])
+	}
+	return b
+}
@@ -28,6 +28,7 @@ type DynamicHopApplyConfiguration struct {
 	NamespaceSelector     *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
 	NetworkAttachmentName *string                                 `json:"networkAttachmentName,omitempty"`
 	BFDEnabled            *bool                                   `json:"bfdEnabled,omitempty"`
+	BFD                   *BFDConfigApplyConfiguration            `json:"bfd,omitempty"`
 }
 
 // DynamicHopApplyConfiguration constructs a declarative configuration of the DynamicHop type for use with
@@ -67,3 +68,11 @@ func (b *DynamicHopApplyConfiguration) WithBFDEnabled(value bool) *DynamicHopApp
 	b.BFDEnabled = &value
 	return b
 }
+
+// WithBFD sets the BFD field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BFD field is set to the value of the last call.
+func (b *DynamicHopApplyConfiguration) WithBFD(value *BFDConfigApplyConfiguration) *DynamicHopApplyConfiguration {
+	b.BFD = value
+	return b
+}
@@ -20,8 +20,9 @@ package v1
 // StaticHopApplyConfiguration represents a declarative configuration of the StaticHop type for use
 // with apply.
 type StaticHopApplyConfiguration struct {
-	IP         *string `json:"ip,omitempty"`
-	BFDEnabled *bool   `json:"bfdEnabled,omitempty"`
+	IP         *string                      `json:"ip,omitempty"`
+	BFDEnabled *bool                        `json:"bfdEnabled,omitempty"`
+	BFD        *BFDConfigApplyConfiguration `json:"bfd,omitempty"`
 }
 
 // StaticHopApplyConfiguration constructs a declarative configuration of the StaticHop type for use with
@@ -45,3 +46,11 @@ func (b *StaticHopApplyConfiguration) WithBFDEnabled(value bool) *StaticHopApply
 	b.BFDEnabled = &value
 	return b
 }
+
+// WithBFD sets the BFD field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BFD field is set to the value of the last call.
+func (b *StaticHopApplyConfiguration) WithBFD(value *BFDConfigApplyConfiguration) *StaticHopApplyConfiguration {
+	b.BFD = value
+	return b
+}
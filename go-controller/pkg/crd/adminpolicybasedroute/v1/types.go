@@ -18,6 +18,8 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdtypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
 )
 
 // AdminPolicyBasedExternalRoute is a CRD allowing the cluster administrators to configure policies for external gateway IPs to be applied to all the pods contained in selected namespaces.
@@ -46,6 +48,16 @@ type AdminPolicyBasedExternalRouteSpec struct {
 	From ExternalNetworkSource `json:"from"`
 	// NextHops defines two types of hops: Static and Dynamic. Each hop defines at least one external gateway IP.
 	NextHops ExternalNextHops `json:"nextHops"`
+	// NetworkSelectors selects the networks of the target namespaces the policy applies to. The routes of a target
+	// namespace are programmed on the gateway routers of its primary network. When not set, the policy only applies
+	// to the target namespaces attached to the default network.
+	// The gateway routers of the user defined networks always SNAT the network subnet to the node IP, so the external
+	// gateways see the node IP as the source of the traffic of the pods on a user defined network, unlike the pod IP
+	// on the default network when disable-snat-multiple-gws is set.
+	// Supported types are `DefaultNetwork`, `PrimaryUserDefinedNetworks` and `ClusterUserDefinedNetworks`.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(sel, sel.networkSelectionType == 'DefaultNetwork' || sel.networkSelectionType == 'PrimaryUserDefinedNetworks' || sel.networkSelectionType == 'ClusterUserDefinedNetworks')", message="Unsupported network selection type"
+	NetworkSelectors crdtypes.NetworkSelectors `json:"networkSelectors,omitempty"`
 }
 
 // ExternalNetworkSource contains the selectors used to determine the namespaces where the policy will be applied to
@@ -64,6 +76,7 @@ type ExternalNextHops struct {
 }
 
 // StaticHop defines the configuration of a static IP that acts as an external Gateway Interface. IP field is mandatory.
+// +kubebuilder:validation:XValidation:rule="!has(self.bfd) || self.bfdEnabled",message="bfd requires bfdEnabled"
 type StaticHop struct {
 	//IP defines the static IP to be used for egress traffic. The IP can be either IPv4 or IPv6.
 	// + Regex taken from: https://blog.markhatton.co.uk/2011/03/15/regular-expressions-for-ip-addresses-cidr-ranges-and-hostnames/
@@ -76,6 +89,11 @@ type StaticHop struct {
 	// +kubebuilder:default:=false
 	// +default=false
 	BFDEnabled bool `json:"bfdEnabled,omitempty"`
+	// BFD defines the timers of the BFD sessions with the hop. It can only be set when BFDEnabled is true.
+	// The BFD session with a hop is shared by all the policies using it, so a policy setting other timers
+	// for the hop than an existing policy fails.
+	// +optional
+	BFD *BFDConfig `json:"bfd,omitempty"`
 	// SkipHostSNAT determines whether to disable Source NAT to the host IP. Defaults to false.
 	// +optional
 	// +kubebuilder:default:=false
@@ -87,6 +105,7 @@ type StaticHop struct {
 // These interfaces are wrapped around a pod object that resides inside the cluster.
 // The field NetworkAttachmentName captures the name of the multus network name to use when retrieving the gateway IP to use.
 // The PodSelector and the NamespaceSelector are mandatory fields.
+// +kubebuilder:validation:XValidation:rule="!has(self.bfd) || self.bfdEnabled",message="bfd requires bfdEnabled"
 type DynamicHop struct {
 	// PodSelector defines the selector to filter the pods that are external gateways.
 	// +kubebuilder:validation:Required
@@ -107,6 +126,11 @@ type DynamicHop struct {
 	// +kubebuilder:default:=false
 	// +default=false
 	BFDEnabled bool `json:"bfdEnabled,omitempty"`
+	// BFD defines the timers of the BFD sessions with the hop. It can only be set when BFDEnabled is true.
+	// The BFD session with a hop is shared by all the policies using it, so a policy setting other timers
+	// for the hop than an existing policy fails.
+	// +optional
+	BFD *BFDConfig `json:"bfd,omitempty"`
 	// SkipHostSNAT determines whether to disable Source NAT to the host IP. Defaults to false
 	// +optional
 	// +kubebuilder:default:=false
@@ -114,6 +138,24 @@ type DynamicHop struct {
 	// SkipHostSNAT bool `json:"skipHostSNAT,omitempty"`
 }
 
+// BFDConfig defines the timers of the BFD sessions with an external gateway. The OVN defaults are used for the
+// fields that are not set.
+type BFDConfig struct {
+	// MinTx defines the minimum interval, in milliseconds, between the BFD control packets sent to the gateway.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinTx *int32 `json:"minTx,omitempty"`
+	// MinRx defines the minimum interval, in milliseconds, between the BFD control packets received from the gateway.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinRx *int32 `json:"minRx,omitempty"`
+	// DetectMult defines the number of BFD control packets that can be missed before the gateway is considered down.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	DetectMult *int32 `json:"detectMult,omitempty"`
+}
+
 // AdminPolicyBasedExternalRouteList contains a list of AdminPolicyBasedExternalRoutes
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -136,8 +178,39 @@ type AdminPolicyBasedRouteStatus struct {
 	// A concise indication of whether the AdminPolicyBasedRoute resource is applied with success
 	// +optional
 	Status StatusType `json:"status,omitempty"`
+	// BFDSessions reports the state of the BFD sessions with the hops that have BFD enabled, for every node of
+	// the target pods.
+	// +listType=map
+	// +listMapKey=node
+	// +listMapKey=nextHop
+	// +optional
+	BFDSessions []BFDSessionStatus `json:"bfdSessions,omitempty"`
 }
 
+// BFDSessionStatus contains the state of the BFD session of a node with an external gateway.
+type BFDSessionStatus struct {
+	// Node is the name of the node running the BFD session.
+	// +required
+	Node string `json:"node"`
+	// NextHop is the IP of the external gateway.
+	// +required
+	NextHop string `json:"nextHop"`
+	// State is the state of the BFD session.
+	// +required
+	State BFDSessionState `json:"state"`
+}
+
+// BFDSessionState defines the states of a BFD session.
+// +kubebuilder:validation:Enum=admin_down;down;init;up
+type BFDSessionState string
+
+const (
+	BFDSessionStateAdminDown BFDSessionState = "admin_down"
+	BFDSessionStateDown      BFDSessionState = "down"
+	BFDSessionStateInit      BFDSessionState = "init"
+	BFDSessionStateUp        BFDSessionState = "up"
+)
+
 // StatusType defines the types of status used in the Status field. The value determines if the
 // deployment of the CR was successful or if it failed.
 type StatusType string
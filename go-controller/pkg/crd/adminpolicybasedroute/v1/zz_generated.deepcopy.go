@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -90,6 +91,13 @@ func (in *AdminPolicyBasedExternalRouteSpec) DeepCopyInto(out *AdminPolicyBasedE
 	*out = *in
 	in.From.DeepCopyInto(&out.From)
 	in.NextHops.DeepCopyInto(&out.NextHops)
+	if in.NetworkSelectors != nil {
+		in, out := &in.NetworkSelectors, &out.NetworkSelectors
+		*out = make(types.NetworkSelectors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -112,6 +120,11 @@ func (in *AdminPolicyBasedRouteStatus) DeepCopyInto(out *AdminPolicyBasedRouteSt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BFDSessions != nil {
+		in, out := &in.BFDSessions, &out.BFDSessions
+		*out = make([]BFDSessionStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -125,11 +138,63 @@ func (in *AdminPolicyBasedRouteStatus) DeepCopy() *AdminPolicyBasedRouteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BFDConfig) DeepCopyInto(out *BFDConfig) {
+	*out = *in
+	if in.MinTx != nil {
+		in, out := &in.MinTx, &out.MinTx
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRx != nil {
+		in, out := &in.MinRx, &out.MinRx
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DetectMult != nil {
+		in, out := &in.DetectMult, &out.DetectMult
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BFDConfig.
+func (in *BFDConfig) DeepCopy() *BFDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BFDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BFDSessionStatus) DeepCopyInto(out *BFDSessionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BFDSessionStatus.
+func (in *BFDSessionStatus) DeepCopy() *BFDSessionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BFDSessionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicHop) DeepCopyInto(out *DynamicHop) {
 	*out = *in
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -170,7 +235,7 @@ func (in *ExternalNextHops) DeepCopyInto(out *ExternalNextHops) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(StaticHop)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -201,6 +266,11 @@ func (in *ExternalNextHops) DeepCopy() *ExternalNextHops {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticHop) DeepCopyInto(out *StaticHop) {
 	*out = *in
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -20,8 +20,9 @@ package v1
 // EgressFirewallStatusApplyConfiguration represents a declarative configuration of the EgressFirewallStatus type for use
 // with apply.
 type EgressFirewallStatusApplyConfiguration struct {
-	Status   *string  `json:"status,omitempty"`
-	Messages []string `json:"messages,omitempty"`
+	Status   *string                                         `json:"status,omitempty"`
+	Messages []string                                        `json:"messages,omitempty"`
+	DNSNames []EgressFirewallDNSNameStatusApplyConfiguration `json:"dnsNames,omitempty"`
 }
 
 // EgressFirewallStatusApplyConfiguration constructs a declarative configuration of the EgressFirewallStatus type for use with
@@ -47,3 +48,16 @@ func (b *EgressFirewallStatusApplyConfiguration) WithMessages(values ...string)
 	}
 	return b
 }
+
+// WithDNSNames adds the given value to the DNSNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DNSNames field.
+func (b *EgressFirewallStatusApplyConfiguration) WithDNSNames(values ...*EgressFirewallDNSNameStatusApplyConfiguration) *EgressFirewallStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDNSNames")
+		}
+		b.DNSNames = append(b.DNSNames, *values[i])
+	}
+	return b
+}
@@ -33,10 +33,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=k8s.ovn.org, Version=v1
 	case v1.SchemeGroupVersion.WithKind("EgressFirewall"):
 		return &egressfirewallv1.EgressFirewallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EgressFirewallDNSNameStatus"):
+		return &egressfirewallv1.EgressFirewallDNSNameStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressFirewallDestination"):
 		return &egressfirewallv1.EgressFirewallDestinationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressFirewallPort"):
 		return &egressfirewallv1.EgressFirewallPortApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EgressFirewallResolvedName"):
+		return &egressfirewallv1.EgressFirewallResolvedNameApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressFirewallRule"):
 		return &egressfirewallv1.EgressFirewallRuleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressFirewallSpec"):
@@ -41,6 +41,32 @@ type EgressFirewallStatus struct {
 	// +listType=set
 	// +optional
 	Messages []string `json:"messages,omitempty"`
+	// dnsNames lists the DNS names used in the egress firewall rules along with the names they
+	// currently resolve. It is only reported when the DNSNameResolver feature is enabled.
+	// +listType=map
+	// +listMapKey=dnsName
+	// +optional
+	DNSNames []EgressFirewallDNSNameStatus `json:"dnsNames,omitempty"`
+}
+
+// EgressFirewallDNSNameStatus describes the resolution of a DNS name used in the egress firewall rules
+type EgressFirewallDNSNameStatus struct {
+	// dnsName is the DNS name of the rules, as a lower case fully qualified domain name.
+	DNSName string `json:"dnsName"`
+	// resolvedNames lists the names which currently resolve to at least one IP address. For a
+	// wildcard DNS name, these are the matched subdomains which have not expired yet.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ResolvedNames []EgressFirewallResolvedName `json:"resolvedNames,omitempty"`
+}
+
+// EgressFirewallResolvedName is a name which resolves to IP addresses allowed or denied by the egress firewall rules
+type EgressFirewallResolvedName struct {
+	// name is the resolved domain name.
+	Name string `json:"name"`
+	// ipCount is the number of IP addresses the name currently resolves to.
+	IPCount int32 `json:"ipCount"`
 }
 
 // EgressFirewallSpec is a desired state description of EgressFirewall.
@@ -53,6 +53,27 @@ func (in *EgressFirewall) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressFirewallDNSNameStatus) DeepCopyInto(out *EgressFirewallDNSNameStatus) {
+	*out = *in
+	if in.ResolvedNames != nil {
+		in, out := &in.ResolvedNames, &out.ResolvedNames
+		*out = make([]EgressFirewallResolvedName, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressFirewallDNSNameStatus.
+func (in *EgressFirewallDNSNameStatus) DeepCopy() *EgressFirewallDNSNameStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressFirewallDNSNameStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressFirewallDestination) DeepCopyInto(out *EgressFirewallDestination) {
 	*out = *in
@@ -123,6 +144,22 @@ func (in *EgressFirewallPort) DeepCopy() *EgressFirewallPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressFirewallResolvedName) DeepCopyInto(out *EgressFirewallResolvedName) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressFirewallResolvedName.
+func (in *EgressFirewallResolvedName) DeepCopy() *EgressFirewallResolvedName {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressFirewallResolvedName)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressFirewallRule) DeepCopyInto(out *EgressFirewallRule) {
 	*out = *in
@@ -176,6 +213,13 @@ func (in *EgressFirewallStatus) DeepCopyInto(out *EgressFirewallStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]EgressFirewallDNSNameStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -20,7 +20,8 @@ package v1
 // EgressIPStatusApplyConfiguration represents a declarative configuration of the EgressIPStatus type for use
 // with apply.
 type EgressIPStatusApplyConfiguration struct {
-	Items []EgressIPStatusItemApplyConfiguration `json:"items,omitempty"`
+	Items      []EgressIPStatusItemApplyConfiguration     `json:"items,omitempty"`
+	Unassigned []EgressIPUnassignedItemApplyConfiguration `json:"unassigned,omitempty"`
 }
 
 // EgressIPStatusApplyConfiguration constructs a declarative configuration of the EgressIPStatus type for use with
@@ -41,3 +42,16 @@ func (b *EgressIPStatusApplyConfiguration) WithItems(values ...*EgressIPStatusIt
 	}
 	return b
 }
+
+// WithUnassigned adds the given value to the Unassigned field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Unassigned field.
+func (b *EgressIPStatusApplyConfiguration) WithUnassigned(values ...*EgressIPUnassignedItemApplyConfiguration) *EgressIPStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnassigned")
+		}
+		b.Unassigned = append(b.Unassigned, *values[i])
+	}
+	return b
+}
@@ -39,6 +39,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &egressipv1.EgressIPStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressIPStatusItem"):
 		return &egressipv1.EgressIPStatusItemApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EgressIPUnassignedItem"):
+		return &egressipv1.EgressIPUnassignedItemApplyConfiguration{}
 
 	}
 	return nil
@@ -33,6 +33,10 @@ type EgressIP struct {
 type EgressIPStatus struct {
 	// The list of assigned egress IPs and their corresponding node assignment.
 	Items []EgressIPStatusItem `json:"items"`
+	// The list of requested egress IPs which could not be assigned to any node,
+	// along with the reason why.
+	// +optional
+	Unassigned []EgressIPUnassignedItem `json:"unassigned,omitempty"`
 }
 
 // The per node status, for those egress IPs who have been assigned.
@@ -43,6 +47,37 @@ type EgressIPStatusItem struct {
 	EgressIP string `json:"egressIP"`
 }
 
+// The status of an egress IP which could not be assigned to any node.
+type EgressIPUnassignedItem struct {
+	// Unassigned egress IP
+	EgressIP string `json:"egressIP"`
+	// Reason is a brief CamelCase reason of why the egress IP is not assigned
+	Reason string `json:"reason"`
+	// Message is a human readable description of why the egress IP is not assigned
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Reasons for an egress IP to be reported as unassigned
+const (
+	// EgressIPReasonNoEgressNode is reported when no node is labeled for egress IP
+	// assignment, ready and reachable.
+	EgressIPReasonNoEgressNode = "NoEgressNode"
+	// EgressIPReasonNoMatchingNode is reported when none of the egress nodes has a
+	// network which can host the egress IP, or when all of them already host another
+	// egress IP of the same EgressIP.
+	EgressIPReasonNoMatchingNode = "NoMatchingNode"
+	// EgressIPReasonCapacityExhausted is reported when the egress nodes which could
+	// host the egress IP reached their egress IP capacity.
+	EgressIPReasonCapacityExhausted = "CapacityExhausted"
+	// EgressIPReasonAddressConflict is reported when the egress IP is already in use
+	// by a node of the cluster.
+	EgressIPReasonAddressConflict = "AddressConflict"
+	// EgressIPReasonAlreadyAllocated is reported when the egress IP is already
+	// assigned for another EgressIP.
+	EgressIPReasonAlreadyAllocated = "AlreadyAllocated"
+)
+
 // EgressIPSpec is a desired state description of EgressIP.
 type EgressIPSpec struct {
 	// EgressIPs is the list of egress IP addresses requested. Can be IPv4 and/or IPv6.
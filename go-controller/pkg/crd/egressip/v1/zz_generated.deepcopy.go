@@ -116,6 +116,11 @@ func (in *EgressIPStatus) DeepCopyInto(out *EgressIPStatus) {
 		*out = make([]EgressIPStatusItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.Unassigned != nil {
+		in, out := &in.Unassigned, &out.Unassigned
+		*out = make([]EgressIPUnassignedItem, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -144,3 +149,19 @@ func (in *EgressIPStatusItem) DeepCopy() *EgressIPStatusItem {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPUnassignedItem) DeepCopyInto(out *EgressIPUnassignedItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPUnassignedItem.
+func (in *EgressIPUnassignedItem) DeepCopy() *EgressIPUnassignedItem {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPUnassignedItem)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -37,6 +37,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &networkqosv1alpha1.ClassifierApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Destination"):
 		return &networkqosv1alpha1.DestinationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IngressClassifier"):
+		return &networkqosv1alpha1.IngressClassifierApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IngressRule"):
+		return &networkqosv1alpha1.IngressRuleApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("NetworkQoS"):
 		return &networkqosv1alpha1.NetworkQoSApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Port"):
@@ -71,7 +71,16 @@ type Spec struct {
 	// determined by the order in which the rule is written. Thus, a rule that appears
 	// first in the list of egress rules would take the lower precedence.
 	// +kubebuilder:validation:MaxItems=20
-	Egress []Rule `json:"egress"`
+	// +optional
+	Egress []Rule `json:"egress,omitempty"`
+
+	// ingress a collection of Ingress NetworkQoS rule objects, applied to the traffic
+	// received by the selected pods. A total of 20 rules will be allowed in each
+	// NetworkQoS instance. The relative precedence of ingress rules follows the same
+	// order as the egress rules.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Ingress []IngressRule `json:"ingress,omitempty"`
 }
 
 type Rule struct {
@@ -99,6 +108,32 @@ type Classifier struct {
 	Ports []*Port `json:"ports"`
 }
 
+type IngressRule struct {
+	// dscp marking value for the traffic received by the matching pods.
+	// +kubebuilder:validation:Maximum:=63
+	// +kubebuilder:validation:Minimum:=0
+	DSCP int `json:"dscp"`
+
+	// classifier The classifier on which packets should match
+	// to apply the NetworkQoS Rule.
+	// This field is optional, and in case it is not set the rule is applied
+	// to all ingress traffic regardless of the source.
+	// +optional
+	Classifier IngressClassifier `json:"classifier"`
+
+	// +optional
+	Bandwidth Bandwidth `json:"bandwidth"`
+}
+
+type IngressClassifier struct {
+	// +optional
+	From []Destination `json:"from"`
+
+	// ports are the ports of the selected pods on which the traffic is received.
+	// +optional
+	Ports []*Port `json:"ports"`
+}
+
 // Bandwidth controls the maximum of rate traffic that can be sent
 // or received on the matching packets.
 type Bandwidth struct {
@@ -132,7 +167,8 @@ type Port struct {
 	Port *int32 `json:"port"`
 }
 
-// Destination describes a peer to apply NetworkQoS configuration for the outgoing traffic.
+// Destination describes a peer to apply NetworkQoS configuration for the outgoing traffic,
+// or for the incoming traffic in the ingress rules.
 // Only certain combinations of fields are allowed.
 // +kubebuilder:validation:XValidation:rule="!(has(self.ipBlock) && (has(self.podSelector) || has(self.namespaceSelector)))",message="Can't specify both podSelector/namespaceSelector and ipBlock"
 type Destination struct {
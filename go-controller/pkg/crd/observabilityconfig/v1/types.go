@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObservabilityConfig configures the sampling of the OVN-Kubernetes features for a collector set.
+// Every ObservabilityConfig sends its samples to its own OVS collector set, which is used by the sample
+// consumers, e.g. ovnkube-observ, to only receive the samples of that configuration.
+// When no ObservabilityConfig exists, all the features are sampled with 100% probability to the default
+// collector set.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=observabilityconfigs,scope=Cluster,singular=observabilityconfig
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Collector Set",type=integer,JSONPath=".spec.collectorSetID"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ObservabilityConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +required
+	Spec ObservabilityConfigSpec `json:"spec"`
+
+	// +optional
+	Status ObservabilityConfigStatus `json:"status,omitempty"`
+}
+
+// ObservabilityConfigSpec defines the desired state of ObservabilityConfig.
+type ObservabilityConfigSpec struct {
+	// collectorSetID is the ID of the OVS collector set the samples are sent to.
+	// It must be unique across the ObservabilityConfigs, when several ObservabilityConfigs use the same
+	// collectorSetID only the oldest one is applied.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	CollectorSetID int32 `json:"collectorSetID"`
+
+	// features sets the sampling probability of the features.
+	// The features are sampled with the probability of the first item of the feature selecting the sampled object,
+	// features without a selecting item are not sampled.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +required
+	Features []FeatureSampling `json:"features"`
+}
+
+// FeatureSampling sets the sampling probability of a feature, for the objects of the feature in the selected
+// namespaces and networks.
+type FeatureSampling struct {
+	// feature is the sampled feature.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Feature SampleFeature `json:"feature"`
+
+	// probability is the percentage of the packets sampled.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +required
+	Probability int32 `json:"probability"`
+
+	// namespaceSelector selects the namespaces of the sampled objects, e.g. the namespace of a network policy or
+	// of an egress firewall. When set, the objects that don't belong to a namespace, e.g. admin network policies,
+	// are not selected.
+	// When not set, the objects of all namespaces and the cluster-scoped objects are selected.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// networks selects the sampled objects by the name of the network they are configured for, "default" for the
+	// default network. When not set, the objects of all networks are selected.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	Networks []string `json:"networks,omitempty"`
+}
+
+// SampleFeature is an OVN-Kubernetes feature that can be sampled.
+// +kubebuilder:validation:Enum=AdminNetworkPolicy;EgressFirewall;Multicast;NetworkPolicy;UDNIsolation
+type SampleFeature string
+
+const (
+	AdminNetworkPolicySample SampleFeature = "AdminNetworkPolicy"
+	EgressFirewallSample     SampleFeature = "EgressFirewall"
+	MulticastSample          SampleFeature = "Multicast"
+	NetworkPolicySample      SampleFeature = "NetworkPolicy"
+	UDNIsolationSample       SampleFeature = "UDNIsolation"
+)
+
+// ObservabilityConfigStatus defines the observed state of ObservabilityConfig.
+type ObservabilityConfigStatus struct {
+	// A concise indication of whether the ObservabilityConfig resource is applied with success.
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// An array of condition objects indicating details about status of ObservabilityConfig object.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// ObservabilityConfigList contains a list of ObservabilityConfig.
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ObservabilityConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObservabilityConfig `json:"items"`
+}
@@ -53,6 +53,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &userdefinednetworkv1.NetworkSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("NoOverlayOptions"):
 		return &userdefinednetworkv1.NoOverlayOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TrunkVLANConfig"):
+		return &userdefinednetworkv1.TrunkVLANConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("UserDefinedNetwork"):
 		return &userdefinednetworkv1.UserDefinedNetworkApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("UserDefinedNetworkSpec"):
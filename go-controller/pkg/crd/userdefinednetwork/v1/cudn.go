@@ -195,8 +195,10 @@ type LocalnetConfig struct {
 	// vlan configuration for the network.
 	// vlan.mode is the VLAN mode.
 	//   When "Access" is set, OVN-Kubernetes configures the network logical switch port in access mode.
+	//   When "Trunk" is set, OVN-Kubernetes passes the tagged traffic of the allowed VLANs through to the connected pods.
 	// vlan.access is the access VLAN configuration.
 	// vlan.access.id is the VLAN ID (VID) to be set on the network logical switch port.
+	// vlan.trunk is the trunk VLAN configuration.
 	// vlan is optional, when omitted the underlying network default VLAN will be used (usually `1`).
 	// When set, OVN-Kubernetes will apply VLAN configuration to the SDN infra and to the connected pods.
 	//
@@ -214,18 +216,48 @@ type AccessVLANConfig struct {
 	ID int32 `json:"id"`
 }
 
-// +kubebuilder:validation:Enum=Access
+// VLANIDRange is a VLAN ID, or an inclusive range of VLAN IDs separated by `-`, e.g. "100" or "200-300".
+// +kubebuilder:validation:XValidation:rule="self.matches('^[0-9]{1,4}(-[0-9]{1,4})?$')", message="must be a VLAN ID or a range of VLAN IDs, e.g. 100 or 200-300"
+// +kubebuilder:validation:XValidation:rule="!self.matches('^[0-9]{1,4}(-[0-9]{1,4})?$') || self.split('-').all(id, int(id) >= 1 && int(id) <= 4094)", message="VLAN IDs should be higher than 0 and lower than 4095"
+// +kubebuilder:validation:XValidation:rule="!self.matches('^[0-9]{1,4}-[0-9]{1,4}$') || int(self.split('-')[0]) <= int(self.split('-')[1])", message="the start of a VLAN ID range cannot be higher than its end"
+// +kubebuilder:validation:MaxLength=9
+type VLANIDRange string
+
+// TrunkVLANConfig describes a trunk VLAN configuration.
+type TrunkVLANConfig struct {
+	// allowedVLANs is the list of VLAN IDs and VLAN ID ranges whose tagged traffic is passed through to the connected pods.
+	// Traffic tagged with other VLAN IDs is dropped.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	AllowedVLANs []VLANIDRange `json:"allowedVLANs"`
+
+	// nativeVLAN is the VLAN ID of the untagged traffic, it must match the native VLAN of the underlying physical network.
+	// nativeVLAN is optional, when omitted the untagged traffic is dropped.
+	// nativeVLAN should be higher than 0 and lower than 4095.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	NativeVLAN int32 `json:"nativeVLAN,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Access;Trunk
 type VLANMode string
 
-const VLANModeAccess VLANMode = "Access"
+const (
+	VLANModeAccess VLANMode = "Access"
+	VLANModeTrunk  VLANMode = "Trunk"
+)
 
 // VLANConfig describes the network VLAN configuration.
 // +union
 // +kubebuilder:validation:XValidation:rule="has(self.mode) && self.mode == 'Access' ? has(self.access): !has(self.access)", message="vlan access config is required when vlan mode is 'Access', and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="has(self.mode) && self.mode == 'Trunk' ? has(self.trunk): !has(self.trunk)", message="vlan trunk config is required when vlan mode is 'Trunk', and forbidden otherwise"
 type VLANConfig struct {
 	// mode describe the network VLAN mode.
-	// Allowed value is "Access".
+	// Allowed values are "Access" and "Trunk".
 	// Access sets the network logical switch port in access mode, according to the config.
+	// Trunk passes the tagged traffic of the allowed VLANs through to the connected pods, according to the config.
 	// +required
 	// +unionDiscriminator
 	Mode VLANMode `json:"mode"`
@@ -233,6 +265,10 @@ type VLANConfig struct {
 	// Access is the access VLAN configuration
 	// +optional
 	Access *AccessVLANConfig `json:"access"`
+
+	// Trunk is the trunk VLAN configuration
+	// +optional
+	Trunk *TrunkVLANConfig `json:"trunk,omitempty"`
 }
 
 type TransportOption string
@@ -375,6 +375,27 @@ func (in *NoOverlayOptions) DeepCopy() *NoOverlayOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrunkVLANConfig) DeepCopyInto(out *TrunkVLANConfig) {
+	*out = *in
+	if in.AllowedVLANs != nil {
+		in, out := &in.AllowedVLANs, &out.AllowedVLANs
+		*out = make([]VLANIDRange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrunkVLANConfig.
+func (in *TrunkVLANConfig) DeepCopy() *TrunkVLANConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrunkVLANConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserDefinedNetwork) DeepCopyInto(out *UserDefinedNetwork) {
 	*out = *in
@@ -493,6 +514,11 @@ func (in *VLANConfig) DeepCopyInto(out *VLANConfig) {
 		*out = new(AccessVLANConfig)
 		**out = **in
 	}
+	if in.Trunk != nil {
+		in, out := &in.Trunk, &out.Trunk
+		*out = new(TrunkVLANConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -90,6 +90,10 @@ import (
 	networkqosinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/informers/externalversions"
 	networkqosinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/informers/externalversions/networkqos/v1alpha1"
 	networkqoslister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/listers/networkqos/v1alpha1"
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
+	observabilityconfigscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/clientset/versioned/scheme"
+	observabilityconfiginformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/informers/externalversions"
+	observabilityconfiginformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/informers/externalversions/observabilityconfig/v1"
 	routeadvertisementsapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1"
 	routeadvertisementsscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned/scheme"
 	routeadvertisementsinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/informers/externalversions"
@@ -129,6 +133,7 @@ type WatchFactory struct {
 	raFactory            routeadvertisementsinformerfactory.SharedInformerFactory
 	frrFactory           frrinformerfactory.SharedInformerFactory
 	networkQoSFactory    networkqosinformerfactory.SharedInformerFactory
+	observConfigFactory  observabilityconfiginformerfactory.SharedInformerFactory
 	informers            map[reflect.Type]*informer
 
 	stopChan chan struct{}
@@ -158,6 +163,7 @@ func (wf *WatchFactory) ShallowClone() *WatchFactory {
 		raFactory:            wf.raFactory,
 		frrFactory:           wf.frrFactory,
 		networkQoSFactory:    wf.networkQoSFactory,
+		observConfigFactory:  wf.observConfigFactory,
 		informers:            wf.informers,
 		stopChan:             wf.stopChan,
 
@@ -370,6 +376,10 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		return nil, err
 	}
 
+	if err := observabilityconfigapi.AddToScheme(observabilityconfigscheme.Scheme); err != nil {
+		return nil, err
+	}
+
 	// For Services and Endpoints, pre-populate the shared Informer with one that
 	// has a label selector excluding headless services.
 	wf.iFactory.InformerFor(&corev1.Service{}, func(c kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
@@ -528,6 +538,14 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableObservability {
+		wf.observConfigFactory = observabilityconfiginformerfactory.NewSharedInformerFactory(ovnClientset.ObservabilityConfigClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.observConfigFactory.Start() it is initialized and caches are synced.
+		wf.observConfigFactory.K8s().V1().ObservabilityConfigs().Informer()
+		// make sure namespace informer cache is initialized and synced on Start().
+		wf.iFactory.Core().V1().Namespaces().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -633,6 +651,13 @@ func (wf *WatchFactory) Start() error {
 		}
 	}
 
+	if wf.observConfigFactory != nil {
+		wf.observConfigFactory.Start(wf.stopChan)
+		if err := waitForCacheSyncWithTimeout(wf.observConfigFactory, wf.stopChan); err != nil {
+			return err
+		}
+	}
+
 	if wf.raFactory != nil {
 		wf.raFactory.Start(wf.stopChan)
 		if err := waitForCacheSyncWithTimeout(wf.raFactory, wf.stopChan); err != nil {
@@ -704,6 +729,10 @@ func (wf *WatchFactory) Stop() {
 	if wf.networkQoSFactory != nil {
 		wf.networkQoSFactory.Shutdown()
 	}
+
+	if wf.observConfigFactory != nil {
+		wf.observConfigFactory.Shutdown()
+	}
 }
 
 // NewNodeWatchFactory initializes a watch factory with significantly fewer
@@ -926,6 +955,9 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 	if err := frrapi.AddToScheme(frrscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := observabilityconfigapi.AddToScheme(observabilityconfigscheme.Scheme); err != nil {
+		return nil, err
+	}
 
 	// For Services and Endpoints, pre-populate the shared Informer with one that
 	// has a label selector excluding headless services.
@@ -1097,6 +1129,12 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 		wf.frrFactory.Api().V1beta1().FRRConfigurations().Informer()
 	}
 
+	if config.OVNKubernetesFeature.EnableObservability {
+		wf.observConfigFactory = observabilityconfiginformerfactory.NewSharedInformerFactory(ovnClientset.ObservabilityConfigClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.observConfigFactory.Start() it is initialized and caches are synced.
+		wf.observConfigFactory.K8s().V1().ObservabilityConfigs().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -1676,6 +1714,12 @@ func (wf *WatchFactory) GetNetworkPolicy(namespace, name string) (*knet.NetworkP
 	return networkPolicyLister.NetworkPolicies(namespace).Get(name)
 }
 
+// GetAllNetworkPolicies returns all the network policies in the cluster
+func (wf *WatchFactory) GetAllNetworkPolicies() ([]*knet.NetworkPolicy, error) {
+	networkPolicyLister := wf.informers[PolicyType].lister.(netlisters.NetworkPolicyLister)
+	return networkPolicyLister.List(labels.Everything())
+}
+
 // GetMultinetworkPolicy gets a specific multinetwork policy by the namespace/name
 func (wf *WatchFactory) GetMultiNetworkPolicy(namespace, name string) (*mnpapi.MultiNetworkPolicy, error) {
 	multinetworkPolicyLister := wf.informers[MultiNetworkPolicyType].lister.(mnplister.MultiNetworkPolicyLister)
@@ -1824,6 +1868,10 @@ func (wf *WatchFactory) NetworkQoSInformer() networkqosinformer.NetworkQoSInform
 	return wf.networkQoSFactory.K8s().V1alpha1().NetworkQoSes()
 }
 
+func (wf *WatchFactory) ObservabilityConfigInformer() observabilityconfiginformer.ObservabilityConfigInformer {
+	return wf.observConfigFactory.K8s().V1().ObservabilityConfigs()
+}
+
 // withServiceNameAndNoHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices with a non-empty
 // "kubernetes.io/service-name" label and without "service.kubernetes.io/headless"
@@ -38,6 +38,20 @@ func WithIPv4Router(router string) func(*dhcpConfigs) {
 	}
 }
 
+// WithIPv4ARPProxyRouter configures the ARP proxy IP as router, it is answered
+// by every node switch so it stays valid after the VM is live migrated to a
+// node with a different subnet. Since it is outside of the VM subnet a
+// classless static route makes it reachable on link.
+func WithIPv4ARPProxyRouter() func(*dhcpConfigs) {
+	return func(configs *dhcpConfigs) {
+		if configs.V4 == nil {
+			return
+		}
+		configs.V4.Options["router"] = ARPProxyIPv4
+		configs.V4.Options["classless_static_route"] = fmt.Sprintf("{%[1]s/32,0.0.0.0,0.0.0.0/0,%[1]s}", ARPProxyIPv4)
+	}
+}
+
 func WithIPv4MTU(mtu int) func(*dhcpConfigs) {
 	return func(configs *dhcpConfigs) {
 		if configs.V4 == nil {
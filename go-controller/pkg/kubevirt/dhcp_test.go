@@ -72,6 +72,38 @@ var _ = Describe("Kubevirt", func() {
 				},
 			},
 		}),
+		Entry("IPv4 Single stack with ARP proxy router", dhcpTest{
+			cidrs:          []string{"192.168.25.0/24"},
+			controllerName: "defaultController",
+			namespace:      "namespace1",
+			vmName:         "foo1",
+			opts: []DHCPConfigsOpt{
+				WithIPv4DNSServer("192.167.23.44"),
+				WithIPv4ARPProxyRouter(),
+			},
+			expectedDHCPConfigs: dhcpConfigs{
+				V4: &nbdb.DHCPOptions{
+					Cidr: "192.168.25.0/24",
+					ExternalIDs: map[string]string{
+						"k8s.ovn.org/owner-controller": "defaultController",
+						"k8s.ovn.org/owner-type":       "VirtualMachine",
+						"k8s.ovn.org/name":             "namespace1/foo1",
+						"k8s.ovn.org/cidr":             "192.168.25.0/24",
+						"k8s.ovn.org/id":               "defaultController:VirtualMachine:namespace1/foo1:192.168.25.0/24",
+						"k8s.ovn.org/zone":             "local",
+					},
+					Options: map[string]string{
+						"lease_time":             "3500",
+						"server_id":              ARPProxyIPv4,
+						"server_mac":             ARPProxyMAC,
+						"hostname":               `"foo1"`,
+						"dns_server":             "192.167.23.44",
+						"router":                 ARPProxyIPv4,
+						"classless_static_route": "{169.254.1.1/32,0.0.0.0,0.0.0.0/0,169.254.1.1}",
+					},
+				},
+			},
+		}),
 		Entry("IPv6 Single stack and dns", dhcpTest{
 			cidrs:          []string{"2002:0:0:1234::/64"},
 			controllerName: "defaultController",
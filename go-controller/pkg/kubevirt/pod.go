@@ -217,13 +217,13 @@ func ZoneContainsPodSubnet(lsManager *logicalswitchmanager.LogicalSwitchManager,
 }
 
 // nodeContainsPodSubnet will return true if the node subnet annotation
-// contains the subnets from the argument
-func nodeContainsPodSubnet(watchFactory *factory.WatchFactory, nodeName string, podAnnotation *util.PodAnnotation, nadName string) (bool, error) {
+// of the network contains the subnets from the argument
+func nodeContainsPodSubnet(watchFactory *factory.WatchFactory, nodeName string, podAnnotation *util.PodAnnotation, networkName string) (bool, error) {
 	node, err := watchFactory.GetNode(nodeName)
 	if err != nil {
 		return false, err
 	}
-	nodeHostSubNets, err := util.ParseNodeHostSubnetAnnotation(node, nadName)
+	nodeHostSubNets, err := util.ParseNodeHostSubnetAnnotation(node, networkName)
 	if err != nil {
 		return false, err
 	}
@@ -248,8 +248,9 @@ func ExtractVMNameFromPod(pod *corev1.Pod) *ktypes.NamespacedName {
 }
 
 // CleanUpLiveMigratablePod remove routing and DHCP ovn related resources
-// when all the pods for the same VM as `pod` argument are completed.
-func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, pod *corev1.Pod) error {
+// of the network when all the pods for the same VM as `pod` argument are
+// completed.
+func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, netInfo util.NetInfo, pod *corev1.Pod) error {
 	if !IsPodLiveMigratable(pod) {
 		return nil
 	}
@@ -267,23 +268,30 @@ func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *fact
 	if err := DeleteDHCPOptions(nbClient, pod); err != nil {
 		return err
 	}
-	if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+	if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 		return err
 	}
 	return nil
 }
 
-func SyncVirtualMachines(nbClient libovsdbclient.Client, vms map[ktypes.NamespacedName]bool) error {
-	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, ovntypes.OVNClusterRouter, func(item *nbdb.LogicalRouterStaticRoute) bool {
+// SyncVirtualMachines removes the stale VM routing from the network cluster
+// router, DHCP options are shared by all the networks and only synced by the
+// default network.
+func SyncVirtualMachines(nbClient libovsdbclient.Client, netInfo util.NetInfo, vms map[ktypes.NamespacedName]bool) error {
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, clusterRouter, func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return ownsItAndIsOrphanOrWrongZone(item.ExternalIDs, vms)
 	}); err != nil {
 		return fmt.Errorf("failed deleting stale vm static routes: %v", err)
 	}
-	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, ovntypes.OVNClusterRouter, func(item *nbdb.LogicalRouterPolicy) bool {
+	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, clusterRouter, func(item *nbdb.LogicalRouterPolicy) bool {
 		return ownsItAndIsOrphanOrWrongZone(item.ExternalIDs, vms)
 	}); err != nil {
 		return fmt.Errorf("failed deleting stale vm policies: %v", err)
 	}
+	if !netInfo.IsDefault() {
+		return nil
+	}
 	if err := libovsdbops.DeleteDHCPOptionsWithPredicate(nbClient, func(item *nbdb.DHCPOptions) bool {
 		return ownsItAndIsOrphanOrWrongZone(item.ExternalIDs, vms)
 	}); err != nil {
@@ -389,10 +397,20 @@ func IsPodOwnedByVirtualMachine(pod *corev1.Pod) bool {
 }
 
 // IsPodAllowedForMigration determines whether a given pod is eligible for live migration
+// on a user defined network. On layer3 the VM subnet belongs to a single node
+// so, as on the default network, only primary networks are eligible and the
+// migrated VM is reached with point to point routes.
 func IsPodAllowedForMigration(pod *corev1.Pod, netInfo util.NetInfo) bool {
-	return IsPodOwnedByVirtualMachine(pod) &&
-		(netInfo.TopologyType() == ovntypes.Layer2Topology ||
-			netInfo.TopologyType() == ovntypes.LocalnetTopology)
+	if !IsPodOwnedByVirtualMachine(pod) {
+		return false
+	}
+	switch netInfo.TopologyType() {
+	case ovntypes.Layer2Topology, ovntypes.LocalnetTopology:
+		return true
+	case ovntypes.Layer3Topology:
+		return netInfo.IsPrimaryNetwork() && IsPodLiveMigratable(pod)
+	}
+	return false
 }
 
 func isTargetPodReady(targetPod *corev1.Pod) bool {
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"time"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -160,6 +163,34 @@ var _ = Describe("Kubevirt Pod", func() {
 			},
 		),
 	)
+
+	DescribeTable("IsPodAllowedForMigration", func(pod corev1.Pod, topology, role string, expectedAllowed bool) {
+		subnets := "10.128.0.0/16"
+		if topology == ovntypes.Layer3Topology {
+			subnets = "10.128.0.0/16/24"
+		}
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: "tenant-blue"},
+			Topology: topology,
+			Role:     role,
+			Subnets:  subnets,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsPodAllowedForMigration(&pod, netInfo)).To(Equal(expectedAllowed))
+	},
+		Entry("allows kubevirt pods on layer2 networks",
+			runningKubevirtPod(t0), ovntypes.Layer2Topology, ovntypes.NetworkRoleSecondary, true),
+		Entry("allows kubevirt pods on localnet networks",
+			runningKubevirtPod(t0), ovntypes.LocalnetTopology, ovntypes.NetworkRoleSecondary, true),
+		Entry("allows live migratable kubevirt pods on layer3 primary networks",
+			liveMigratableKubevirtPod(t0), ovntypes.Layer3Topology, ovntypes.NetworkRolePrimary, true),
+		Entry("rejects non live migratable kubevirt pods on layer3 primary networks",
+			runningKubevirtPod(t0), ovntypes.Layer3Topology, ovntypes.NetworkRolePrimary, false),
+		Entry("rejects live migratable kubevirt pods on layer3 secondary networks",
+			liveMigratableKubevirtPod(t0), ovntypes.Layer3Topology, ovntypes.NetworkRoleSecondary, false),
+		Entry("rejects non kubevirt pods",
+			nonKubevirtPod(), ovntypes.Layer2Topology, ovntypes.NetworkRoleSecondary, false),
+	)
 })
 
 func completedKubevirtPod(creationOffset time.Duration) corev1.Pod {
@@ -174,6 +205,10 @@ func runningKubevirtPod(creationOffset time.Duration) corev1.Pod {
 	return newKubevirtPod(corev1.PodRunning, nil, creationOffset)
 }
 
+func liveMigratableKubevirtPod(creationOffset time.Duration) corev1.Pod {
+	return newKubevirtPod(corev1.PodRunning, map[string]string{kubevirtv1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}, creationOffset)
+}
+
 func domainReadyKubevirtPod(creationOffset time.Duration) corev1.Pod {
 	return newKubevirtPod(corev1.PodRunning, map[string]string{kubevirtv1.MigrationTargetReadyTimestamp: "some-timestamp"}, creationOffset)
 }
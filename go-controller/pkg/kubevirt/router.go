@@ -21,7 +21,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
-func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, pod *corev1.Pod, zone string) error {
+func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod, zone string) error {
 	vm := ExtractVMNameFromPod(pod)
 	predicate := func(itemExternalIDs map[string]string) bool {
 		containsZone := true
@@ -33,23 +33,24 @@ func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, pod *co
 	routePredicate := func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return predicate(item.ExternalIDs)
 	}
-	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, types.OVNClusterRouter, routePredicate); err != nil {
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, clusterRouter, routePredicate); err != nil {
 		return fmt.Errorf("failed deleting pod routing when deleting the LR static routes: %v", err)
 	}
 	policyPredicate := func(item *nbdb.LogicalRouterPolicy) bool {
 		return predicate(item.ExternalIDs)
 	}
-	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, types.OVNClusterRouter, policyPredicate); err != nil {
+	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, clusterRouter, policyPredicate); err != nil {
 		return fmt.Errorf("failed deleting pod routing when deleting the LR policies: %v", err)
 	}
 	return nil
 }
 
-func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, pod *corev1.Pod) error {
-	return DeleteRoutingForMigratedPodWithZone(nbClient, pod, "")
+func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod) error {
+	return DeleteRoutingForMigratedPodWithZone(nbClient, netInfo, pod, "")
 }
 
-// EnsureLocalZonePodAddressesToNodeRoute will add static routes and policies to ovn_cluster_route logical router
+// EnsureLocalZonePodAddressesToNodeRoute will add static routes and policies to the network cluster logical router
 // to ensure VM traffic work as expected after live migration if the pod is running at the local/global zone.
 //
 // NOTE: IC with multiple nodes per zone is not supported
@@ -67,7 +68,7 @@ func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, pod *corev1.Pod
 // Both:
 //   - static route with VM ip as dst-ip prefix and output port the LRP pointing to the VM's node switch
 func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client,
-	lsManager *logicalswitchmanager.LogicalSwitchManager, pod *corev1.Pod, nadName string, clusterSubnets []config.CIDRNetworkEntry) error {
+	lsManager *logicalswitchmanager.LogicalSwitchManager, netInfo util.NetInfo, pod *corev1.Pod, nadName string, clusterSubnets []config.CIDRNetworkEntry) error {
 	vmReady, err := virtualMachineReady(watchFactory, pod)
 	if err != nil {
 		return err
@@ -80,17 +81,21 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		return fmt.Errorf("failed reading local pod annotation: %v", err)
 	}
 
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	nodeSwitch := netInfo.GetNetworkScopedSwitchName(pod.Spec.NodeName)
 	nodeOwningSubnet, _ := ZoneContainsPodSubnet(lsManager, podAnnotation.IPs)
-	vmRunningAtNodeOwningSubnet := nodeOwningSubnet == pod.Spec.NodeName
+	vmRunningAtNodeOwningSubnet := nodeOwningSubnet == nodeSwitch
 	if vmRunningAtNodeOwningSubnet {
 		// Point to point routing is no longer needed if vm
 		// is running at the node that owns the subnet
-		if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+		if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 			return fmt.Errorf("failed configuring pod routing when deleting stale static routes or policies for pod %s/%s: %v", pod.Namespace, pod.Name, err)
 		}
 		return nil
 	}
 
+	gatewayRouter := netInfo.GetNetworkScopedGWRouterName(pod.Spec.NodeName)
+
 	// For interconnect at static route with a cluster-wide src-ip address is
 	// needed to route egress n/s traffic
 	if config.OVNKubernetesFeature.EnableInterconnect {
@@ -100,17 +105,17 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		if err != nil {
 			return fmt.Errorf("failed getting to list node %q for pod %s/%s: %w", pod.Spec.NodeName, pod.Namespace, pod.Name, err)
 		}
-		gatewayIPs, err := udn.GetGWRouterIPs(node, &util.DefaultNetInfo{})
+		gatewayIPs, err := udn.GetGWRouterIPs(node, netInfo)
 		if err != nil {
-			return fmt.Errorf("failed to get default network gateway router join IPs for node %q: %w", node.Name, err)
+			return fmt.Errorf("failed to get network %s gateway router join IPs for node %q: %w", netInfo.GetNetworkName(), node.Name, err)
 		}
-		if err := libovsdbutil.CreateDefaultRouteToExternal(nbClient, types.OVNClusterRouter,
-			types.GWRouterPrefix+pod.Spec.NodeName, clusterSubnets, gatewayIPs); err != nil {
+		if err := libovsdbutil.CreateDefaultRouteToExternal(nbClient, clusterRouter,
+			gatewayRouter, clusterSubnets, gatewayIPs); err != nil {
 			return err
 		}
 	}
 
-	lrpName := types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + pod.Spec.NodeName
+	lrpName := types.GWRouterToJoinSwitchPrefix + gatewayRouter
 	lrpAddresses, err := libovsdbutil.GetLRPAddrs(nbClient, lrpName)
 	if err != nil {
 		return fmt.Errorf("failed configuring pod routing when reading LRP %s addresses: %v", lrpName, err)
@@ -140,15 +145,15 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 					NamespaceExternalIDsKey:      pod.Namespace,
 				},
 			}
-			if err := libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nbClient, types.OVNClusterRouter, &egressPolicy, func(item *nbdb.LogicalRouterPolicy) bool {
+			if err := libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nbClient, clusterRouter, &egressPolicy, func(item *nbdb.LogicalRouterPolicy) bool {
 				return item.Priority == egressPolicy.Priority && item.Match == egressPolicy.Match && item.Action == egressPolicy.Action
 			}); err != nil {
 				return fmt.Errorf("failed adding point to point policy for pod %s/%s : %v", pod.Namespace, pod.Name, err)
 			}
 		}
 		// Add a route for reroute ingress traffic to the VM port since
-		// the subnet is alien to ovn_cluster_router
-		outputPort := types.RouterToSwitchPrefix + pod.Spec.NodeName
+		// the subnet is alien to the cluster router
+		outputPort := types.RouterToSwitchPrefix + nodeSwitch
 		ingressRoute := nbdb.LogicalRouterStaticRoute{
 			IPPrefix:   podAddress,
 			Nexthop:    podAddress,
@@ -160,7 +165,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 				NamespaceExternalIDsKey:      pod.Namespace,
 			},
 		}
-		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, types.OVNClusterRouter, &ingressRoute, func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, clusterRouter, &ingressRoute, func(item *nbdb.LogicalRouterStaticRoute) bool {
 			matches := item.IPPrefix == ingressRoute.IPPrefix && item.Policy != nil && *item.Policy == *ingressRoute.Policy
 			return matches
 		}); err != nil {
@@ -175,7 +180,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 // port of the node where the pod is running:
 //   - A dst-ip with live migrated pod ip as prefix and nexthop the pod's
 //     current node transit switch port.
-func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client, pod *corev1.Pod, nadName string) error {
+func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod, nadName string) error {
 	vmReady, err := virtualMachineReady(watchFactory, pod)
 	if err != nil {
 		return err
@@ -194,21 +199,21 @@ func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		return fmt.Errorf("failed reading remote pod annotation: %v", err)
 	}
 
-	vmRunningAtNodeOwningSubnet, err := nodeContainsPodSubnet(watchFactory, pod.Spec.NodeName, podAnnotation, nadName)
+	vmRunningAtNodeOwningSubnet, err := nodeContainsPodSubnet(watchFactory, pod.Spec.NodeName, podAnnotation, netInfo.GetNetworkName())
 	if err != nil {
 		return err
 	}
 	if vmRunningAtNodeOwningSubnet {
 		// Point to point routing is no longer needed if vm
 		// is running at the node with VM's subnet
-		if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+		if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 			return err
 		}
 		return nil
 	} else {
 		// Since we are at remote zone we should not have local zone point to
 		// to point routing
-		if err := DeleteRoutingForMigratedPodWithZone(nbClient, pod, OvnLocalZone); err != nil {
+		if err := DeleteRoutingForMigratedPodWithZone(nbClient, netInfo, pod, OvnLocalZone); err != nil {
 			return err
 		}
 	}
@@ -237,7 +242,7 @@ func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 				NamespaceExternalIDsKey:      pod.Namespace,
 			},
 		}
-		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, types.OVNClusterRouter, &route, func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, netInfo.GetNetworkScopedClusterRouterName(), &route, func(item *nbdb.LogicalRouterStaticRoute) bool {
 			matches := item.IPPrefix == route.IPPrefix && item.Policy != nil && *item.Policy == *route.Policy
 			return matches
 		}); err != nil {
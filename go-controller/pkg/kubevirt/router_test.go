@@ -0,0 +1,129 @@
+package kubevirt
+
+import (
+	"context"
+	"net"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kubevirt Router", func() {
+	const (
+		networkName     = "tenant-blue"
+		nadName         = "default/blue"
+		sourceNodeName  = "node1"
+		targetNodeName  = "node2"
+		vmIP            = "10.128.1.5"
+		targetNodeTSIP  = "100.88.0.3"
+		routeUUID       = "vm-route-UUID"
+		clusterRouterID = "cluster-router-UUID"
+	)
+
+	newNode := func(name, hostSubnet, transitSwitchPortAddr string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					"k8s.ovn.org/node-subnets":    `{"` + networkName + `":"` + hostSubnet + `"}`,
+					util.OvnTransitSwitchPortAddr: `{"ipv4":"` + transitSwitchPortAddr + `"}`,
+				},
+			},
+		}
+	}
+
+	It("routes a VM migrated on a layer3 primary user defined network to its node until the VM is gone", func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		config.OVNKubernetesFeature.EnableInterconnect = true
+
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: networkName},
+			Topology: ovntypes.Layer3Topology,
+			Role:     ovntypes.NetworkRolePrimary,
+			Subnets:  "10.128.0.0/16/24",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		// the VM IP was allocated from the subnet of the source node but the
+		// VM is now running at the target node
+		vmPod := newKubevirtPod(corev1.PodRunning, map[string]string{
+			kubevirtv1.AllowPodBridgeNetworkLiveMigrationAnnotation: "",
+		}, 0)
+		vmPod.Labels[kubevirtv1.NodeNameLabel] = targetNodeName
+		vmPod.Spec.NodeName = targetNodeName
+		vmPod.Annotations, err = util.MarshalPodAnnotation(vmPod.Annotations, &util.PodAnnotation{
+			IPs:  []*net.IPNet{{IP: net.ParseIP(vmIP).To4(), Mask: net.CIDRMask(24, 32)}},
+			MAC:  util.IPAddrToHWAddr(net.ParseIP(vmIP)),
+			Role: ovntypes.NetworkRolePrimary,
+		}, nadName)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClient := util.GetOVNClientset(
+			newNode(sourceNodeName, "10.128.1.0/24", "100.88.0.2/16"),
+			newNode(targetNodeName, "10.128.2.0/24", targetNodeTSIP+"/16"),
+			&vmPod,
+		).GetOVNKubeControllerClientset()
+		wf, err := factory.NewOVNKubeControllerWatchFactory(fakeClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		clusterRouter := &nbdb.LogicalRouter{
+			UUID: clusterRouterID,
+			Name: netInfo.GetNetworkScopedClusterRouterName(),
+		}
+		nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{clusterRouter},
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		defer cleanup.Cleanup()
+
+		Expect(EnsureRemoteZonePodAddressesToNodeRoute(wf, nbClient, netInfo, &vmPod, nadName)).To(Succeed())
+		routedClusterRouter := clusterRouter.DeepCopy()
+		routedClusterRouter.StaticRoutes = []string{routeUUID}
+		Expect(nbClient).To(libovsdbtest.HaveData(
+			routedClusterRouter,
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:     routeUUID,
+				IPPrefix: vmIP,
+				Nexthop:  targetNodeTSIP,
+				Policy:   &nbdb.LogicalRouterStaticRoutePolicyDstIP,
+				ExternalIDs: map[string]string{
+					OvnZoneExternalIDKey:         OvnRemoteZone,
+					VirtualMachineExternalIDsKey: vmName,
+					NamespaceExternalIDsKey:      vmPod.Namespace,
+				},
+			},
+		))
+
+		vmPod.Status.Phase = corev1.PodSucceeded
+		_, err = fakeClient.KubeClient.CoreV1().Pods(vmPod.Namespace).UpdateStatus(context.Background(), &vmPod, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(func() (corev1.PodPhase, error) {
+			pod, err := wf.GetPod(vmPod.Namespace, vmPod.Name)
+			if err != nil {
+				return "", err
+			}
+			return pod.Status.Phase, nil
+		}).Should(Equal(corev1.PodSucceeded))
+
+		Expect(CleanUpLiveMigratablePod(nbClient, wf, netInfo, &vmPod)).To(Succeed())
+		Expect(nbClient).To(libovsdbtest.HaveData(clusterRouter))
+	})
+})
@@ -23,8 +23,8 @@ const (
 // ComposeARPProxyLSPOption returns the "arp_proxy" field needed at router type
 // LSP to implement stable default gw for pod ip migration, it consists of
 // generated MAC address, a link local ipv4 and ipv6( it's the same
-// for all the logical switches) and the cluster subnet to allow the migrated
-// vm to ping pods for the same subnet.
+// for all the logical switches) and the cluster subnets of the network to
+// allow the migrated vm to ping pods for the same subnet.
 // This is how it works step by step:
 // For default gw:
 //   - VM is configured with arp proxy IPv4/IPv6 as default gw
@@ -46,9 +46,9 @@ const (
 //     back with arp_proxy mac
 //   - VM will send the message to that mac and it will end being route by
 //     ovn
-func ComposeARPProxyLSPOption() string {
+func ComposeARPProxyLSPOption(clusterSubnets []config.CIDRNetworkEntry) string {
 	arpProxy := []string{ARPProxyMAC, ARPProxyIPv4, ARPProxyIPv6}
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range clusterSubnets {
 		arpProxy = append(arpProxy, clusterSubnet.CIDR.String())
 	}
 	return strings.Join(arpProxy, " ")
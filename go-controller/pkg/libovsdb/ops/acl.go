@@ -149,6 +149,27 @@ func UpdateACLsOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, acls .
 	return modelClient.CreateOrUpdateOps(ops, opModels...)
 }
 
+// UpdateACLsSamplesOps updates the samples of the provided ACLs with the samplingConfig,
+// the other ACL fields are not updated.
+func UpdateACLsSamplesOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, samplingConfig *SamplingConfig, acls ...*nbdb.ACL) ([]ovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, 2*len(acls))
+	for i := range acls {
+		// can't use i in the predicate, for loop replaces it in-memory
+		acl := acls[i]
+		opModels = addSample(samplingConfig, opModels, acl)
+		opModel := operationModel{
+			Model:          acl,
+			OnModelUpdates: []interface{}{&acl.SampleNew, &acl.SampleEst},
+			ErrNotFound:    true,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	modelClient := newModelClient(nbClient)
+	return modelClient.CreateOrUpdateOps(ops, opModels...)
+}
+
 // CreateOrUpdateACLs creates or updates the provided ACLs
 func CreateOrUpdateACLs(nbClient libovsdbclient.Client, samplingConfig *SamplingConfig, acls ...*nbdb.ACL) error {
 	ops, err := CreateOrUpdateACLsOps(nbClient, nil, samplingConfig, acls...)
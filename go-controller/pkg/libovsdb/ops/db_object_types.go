@@ -20,6 +20,7 @@ const (
 	AdminNetworkPolicyOwnerType         ownerType = "AdminNetworkPolicy"
 	BaselineAdminNetworkPolicyOwnerType ownerType = "BaselineAdminNetworkPolicy"
 	NetworkQoSOwnerType                 ownerType = "NetworkQoS"
+	NetworkQoSPodOwnerType              ownerType = "NetworkQoSPod"
 	// NetworkPolicyOwnerType is deprecated for address sets, should only be used for sync.
 	// New owner of network policy address sets, is PodSelectorOwnerType.
 	NetworkPolicyOwnerType ownerType = "NetworkPolicy"
@@ -374,3 +375,8 @@ var NetworkQoS = newObjectIDsType(qos, NetworkQoSOwnerType, []ExternalIDKey{
 	// rule index
 	RuleIndex,
 })
+
+var NetworkQoSPod = newObjectIDsType(qos, NetworkQoSPodOwnerType, []ExternalIDKey{
+	// pod namespace:name
+	ObjectNameKey,
+})
@@ -2,6 +2,7 @@ package ops
 
 import (
 	"hash/fnv"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -138,14 +139,29 @@ const (
 	UDNIsolationSample       SampleFeature = "UDNIsolation"
 )
 
+// CollectorSelector returns the UUIDs of the collectors sampling the db objects of a feature,
+// for the namespace and the network of the sampled object.
+// namespace is empty for cluster-scoped objects.
+type CollectorSelector func(feature SampleFeature, namespace, network string) []string
+
 // SamplingConfig is used to configure sampling for different db objects.
 type SamplingConfig struct {
-	featureCollectors map[SampleFeature][]string
+	selectCollectors CollectorSelector
 }
 
+// NewSamplingConfig returns a SamplingConfig that samples all the objects of a feature with the same collectors.
 func NewSamplingConfig(featureCollectors map[SampleFeature][]string) *SamplingConfig {
 	return &SamplingConfig{
-		featureCollectors: featureCollectors,
+		selectCollectors: func(feature SampleFeature, _, _ string) []string {
+			return featureCollectors[feature]
+		},
+	}
+}
+
+// NewSamplingConfigWithSelector returns a SamplingConfig that selects the collectors of every sampled object.
+func NewSamplingConfigWithSelector(selectCollectors CollectorSelector) *SamplingConfig {
+	return &SamplingConfig{
+		selectCollectors: selectCollectors,
 	}
 }
 
@@ -164,7 +180,7 @@ func createOrUpdateSampleForACL(opModels []operationModel, c *SamplingConfig, ac
 		acl.SampleNew = nil
 		return opModels
 	}
-	collectors := c.featureCollectors[getACLSampleFeature(acl)]
+	collectors := c.selectCollectors(GetACLSampleFeature(acl), GetACLSampleNamespace(acl), getACLSampleNetwork(acl))
 	if len(collectors) == 0 {
 		acl.SampleEst = nil
 		acl.SampleNew = nil
@@ -206,7 +222,8 @@ func GetACLSampleID(acl *nbdb.ACL) uint32 {
 	return h.Sum32()
 }
 
-func getACLSampleFeature(acl *nbdb.ACL) SampleFeature {
+// GetACLSampleFeature returns the sample feature of an ACL, empty if the ACL is not sampled.
+func GetACLSampleFeature(acl *nbdb.ACL) SampleFeature {
 	switch acl.ExternalIDs[OwnerTypeKey.String()] {
 	case AdminNetworkPolicyOwnerType, BaselineAdminNetworkPolicyOwnerType:
 		return AdminNetworkPolicySample
@@ -221,3 +238,26 @@ func getACLSampleFeature(acl *nbdb.ACL) SampleFeature {
 	}
 	return ""
 }
+
+// GetACLSampleNamespace returns the namespace of the object an ACL is created for,
+// empty for cluster-scoped objects.
+func GetACLSampleNamespace(acl *nbdb.ACL) string {
+	objectName := acl.ExternalIDs[ObjectNameKey.String()]
+	switch acl.ExternalIDs[OwnerTypeKey.String()] {
+	case NetworkPolicyOwnerType:
+		namespace, _, err := ParseNamespaceNameKey(objectName)
+		if err != nil {
+			return ""
+		}
+		return namespace
+	case NetpolNamespaceOwnerType, MulticastNamespaceOwnerType, EgressFirewallOwnerType:
+		return objectName
+	}
+	return ""
+}
+
+// getACLSampleNetwork returns the name of the network an ACL is created for,
+// based on the name of the network controller owning it.
+func getACLSampleNetwork(acl *nbdb.ACL) string {
+	return strings.TrimSuffix(acl.ExternalIDs[OwnerControllerKey.String()], "-network-controller")
+}
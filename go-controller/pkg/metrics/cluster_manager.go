@@ -159,6 +159,11 @@ func RegisterClusterManagerFunctional() {
 			panic(err)
 		}
 	}
+	if err := prometheus.Register(MetricResourceRetryPendingCount); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			panic(err)
+		}
+	}
 }
 
 // RecordSubnetUsage records the number of subnets allocated for nodes
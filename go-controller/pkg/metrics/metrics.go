@@ -76,6 +76,15 @@ var MetricResourceRetryFailuresCount = prometheus.NewCounter(prometheus.CounterO
 	Help:      "The total number of times processing a Kubernetes resource reached the maximum retry limit and was no longer processed",
 })
 
+// MetricResourceRetryPendingCount is the number of Kubernetes resources waiting for a retry to
+// be reconciled, per resource type. This metric doesn't need Subsystem string since it is
+// applicable for both master and node.
+var MetricResourceRetryPendingCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Name:      "resource_retry_pending",
+	Help:      "The number of Kubernetes resources waiting for a retry to be processed, per resource type",
+}, []string{"resource_type"})
+
 // OVN/OVS components, namely ovn-northd, ovn-controller, and ovs-vswitchd provide various
 // metrics through the 'coverage/show' command. The following data structure holds all the
 // metrics we are interested in that output for a given component. We generalize capturing
@@ -499,6 +508,19 @@ func writePlainText(statusCode int, text string, w http.ResponseWriter) {
 	fmt.Fprintln(w, text)
 }
 
+var (
+	debugHandlersLock sync.Mutex
+	debugHandlers     = map[string]http.Handler{}
+)
+
+// RegisterDebugHandler registers a handler to be served by the metrics server started
+// by StartMetricsServer, it must be called before the server is started.
+func RegisterDebugHandler(pattern string, handler http.Handler) {
+	debugHandlersLock.Lock()
+	defer debugHandlersLock.Unlock()
+	debugHandlers[pattern] = handler
+}
+
 // StartMetricsServer runs the prometheus listener so that OVN K8s metrics can be collected
 // It puts the endpoint behind TLS if certFile and keyFile are defined.
 func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, keyFile string,
@@ -517,6 +539,12 @@ func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, k
 		mux.HandleFunc("/debug/flags/v", stringFlagPutHandler(klogSetter))
 	}
 
+	debugHandlersLock.Lock()
+	for pattern, handler := range debugHandlers {
+		mux.Handle(pattern, handler)
+	}
+	debugHandlersLock.Unlock()
+
 	startMetricsServer(bindAddress, certFile, keyFile, mux, stopChan, wg)
 }
 
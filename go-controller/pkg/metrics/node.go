@@ -47,6 +47,31 @@ var metricOvnKubeNodeLogFileSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	},
 )
 
+// MetricOVSThreadCPUAffinity reports the number of OVS daemon threads of each thread class
+// per CPU affinity, when a CPU pinning policy is configured for the OVS daemons
+var MetricOVSThreadCPUAffinity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "ovs_thread_cpu_affinity",
+	Help:      "The number of OVS daemon threads of a thread class with the given CPU affinity."},
+	[]string{
+		"thread_class",
+		"cpus",
+	},
+)
+
+// MetricOVSCPUAffinityCorrections counts the OVS daemon threads whose CPU affinity didn't
+// match the configured CPU pinning policy and was set to it
+var MetricOVSCPUAffinityCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "ovs_cpu_affinity_corrections_total",
+	Help:      "The number of times the CPU affinity of an OVS daemon thread was set to match the configured CPU pinning policy."},
+	[]string{
+		"thread_class",
+	},
+)
+
 var registerNodeMetricsOnce sync.Once
 
 func RegisterNodeMetrics(stopChan <-chan struct{}) {
@@ -79,6 +104,13 @@ func RegisterNodeMetrics(stopChan <-chan struct{}) {
 				panic(err)
 			}
 		}
+		if err := prometheus.Register(MetricResourceRetryPendingCount); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				panic(err)
+			}
+		}
+		prometheus.MustRegister(MetricOVSThreadCPUAffinity)
+		prometheus.MustRegister(MetricOVSCPUAffinityCorrections)
 		prometheus.MustRegister(metricOvnKubeNodeLogFileSize)
 		go ovnKubeLogFileSizeMetricsUpdater(metricOvnKubeNodeLogFileSize, stopChan)
 	})
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -99,6 +100,12 @@ var metricOVNControllerSBDBConnection = prometheus.NewGauge(prometheus.GaugeOpts
 	Help:      "Specifies if OVN controller is connected to OVN southbound database (1) or not (0)",
 })
 
+var metricCoPPMeterDroppedPackets = prometheus.NewDesc(
+	prometheus.BuildFQName(types.MetricOvnNamespace, types.MetricOvnSubsystemController, "copp_meter_dropped_packets_total"),
+	"The total number of packets punted to ovn-controller that were dropped by the CoPP meter of a protocol.",
+	[]string{"protocol"}, nil,
+)
+
 var (
 	ovnControllerVersion       string
 	ovnControllerOvsLibVersion string
@@ -409,6 +416,89 @@ func updateSBDBConnectionMetric(ovsAppctl ovsClient, retry int, retrySleep time.
 	}
 }
 
+// coppMeterCollector collects the number of packets dropped by the CoPP meters
+// of the gateway routers from the OpenFlow meters of the integration bridge.
+type coppMeterCollector struct {
+	ovnAppctl ovsClient
+	ofctl     ovsClient
+}
+
+func (c *coppMeterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricCoPPMeterDroppedPackets
+}
+
+func (c *coppMeterCollector) Collect(ch chan<- prometheus.Metric) {
+	droppedPackets, err := c.getDroppedPackets()
+	if err != nil {
+		klog.Errorf("Failed to get the packets dropped by the CoPP meters: %v", err)
+		return
+	}
+	for protocol, packets := range droppedPackets {
+		ch <- prometheus.MustNewConstMetric(metricCoPPMeterDroppedPackets, prometheus.CounterValue, packets, protocol)
+	}
+}
+
+// getDroppedPackets returns the number of packets dropped by the CoPP meters,
+// per protocol. A CoPP meter is named <protocol>-rate-limiter, fair meters are
+// instantiated by OVN once per logical router as <protocol>-rate-limiter__<uuid>.
+func (c *coppMeterCollector) getDroppedPackets() (map[string]float64, error) {
+	stdout, stderr, err := c.ovnAppctl("meter-table-list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ovn-controller meters, stderr(%s): %v", stderr, err)
+	}
+	meterProtocols := map[string]string{}
+	for _, line := range strings.Split(stdout, "\n") {
+		idx := strings.LastIndex(line, ":")
+		if idx < 0 {
+			continue
+		}
+		name, id := strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:])
+		name, _, _ = strings.Cut(name, "__")
+		protocol, found := strings.CutSuffix(name, "-"+types.OvnRateLimitingMeter)
+		if !found || protocol == "" {
+			continue
+		}
+		meterProtocols[id] = protocol
+	}
+	if len(meterProtocols) == 0 {
+		return nil, nil
+	}
+
+	stdout, stderr, err = c.ofctl("-t", "5", "-O", "OpenFlow15", "meter-stats", "br-int")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get br-int meter stats, stderr(%s): %v", stderr, err)
+	}
+	droppedPackets := map[string]float64{}
+	for _, protocol := range meterProtocols {
+		droppedPackets[protocol] = 0
+	}
+	var protocol string
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if id, found := strings.CutPrefix(fields[0], "meter:"); found {
+			// the packets of the bands of the meter are the dropped ones
+			protocol = meterProtocols[id]
+			continue
+		}
+		if protocol == "" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if value, found := strings.CutPrefix(field, "packet_count:"); found {
+				packets, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse meter band packet count %q: %v", value, err)
+				}
+				droppedPackets[protocol] += packets
+			}
+		}
+	}
+	return droppedPackets, nil
+}
+
 func RegisterOvnControllerMetrics(ovsDBClient libovsdbclient.Client,
 	metricsScrapeInterval int, stopChan <-chan struct{}) {
 	getOvnControllerVersionInfo()
@@ -473,6 +563,11 @@ func RegisterOvnControllerMetrics(ovsDBClient libovsdbclient.Client,
 			return getPortCount(ovsDBClient, "geneve")
 		}))
 
+	ovnRegistry.MustRegister(&coppMeterCollector{
+		ovnAppctl: util.RunOVNControllerAppCtl,
+		ofctl:     util.RunOVSOfctl,
+	})
+
 	// register ovn-controller configuration metrics
 	ovnRegistry.MustRegister(metricRemoteProbeInterval)
 	ovnRegistry.MustRegister(metricOpenFlowProbeInterval)
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+const (
+	ovnAppctlMeterTableListSampleOutput = `arp-rate-limiter__1e5c2e14-1bb9-4d3a-9c1c-7f3f2f1d9b1c: 1
+bfd-rate-limiter__1e5c2e14-1bb9-4d3a-9c1c-7f3f2f1d9b1c: 2
+bfd-rate-limiter__5b8f40a5-49a8-4a0d-a3cb-2e0e0d1cba71: 3
+reject-rate-limiter: 4
+acl-logging: 5`
+	ovsOfctlMeterStatsSampleOutput = `OFPST_METER reply (OF1.5) (xid=0x2):
+meter:1 flow_count:4 packet_in_count:120 byte_in_count:7200 duration:100.0s bands:
+0: packet_count:20 byte_count:1200
+
+meter:2 flow_count:2 packet_in_count:3000 byte_in_count:180000 duration:100.0s bands:
+0: packet_count:1000 byte_count:60000
+
+meter:3 flow_count:2 packet_in_count:2000 byte_in_count:120000 duration:100.0s bands:
+0: packet_count:500 byte_count:30000
+
+meter:4 flow_count:1 packet_in_count:10 byte_in_count:600 duration:100.0s bands:
+0: packet_count:0 byte_count:0
+
+meter:5 flow_count:8 packet_in_count:50 byte_in_count:3000 duration:100.0s bands:
+0: packet_count:7 byte_count:420`
+)
+
+var _ = ginkgo.Describe("OVN controller metrics", func() {
+	ginkgo.Context("CoPP meters", func() {
+		ginkgo.It("sums the packets dropped by the CoPP meters per protocol", func() {
+			ovnAppctl := NewFakeOVSClient([]clientOutput{{stdout: ovnAppctlMeterTableListSampleOutput}})
+			ofctl := NewFakeOVSClient([]clientOutput{{stdout: ovsOfctlMeterStatsSampleOutput}})
+			collector := &coppMeterCollector{ovnAppctl: ovnAppctl.FakeCall, ofctl: ofctl.FakeCall}
+			droppedPackets, err := collector.getDroppedPackets()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(droppedPackets).To(gomega.Equal(map[string]float64{
+				"arp":    20,
+				"bfd":    1500,
+				"reject": 0,
+			}))
+		})
+
+		ginkgo.It("doesn't get the meter stats when there is no CoPP meter", func() {
+			ovnAppctl := NewFakeOVSClient([]clientOutput{{stdout: "acl-logging: 5"}})
+			ofctl := NewFakeOVSClient(nil)
+			collector := &coppMeterCollector{ovnAppctl: ovnAppctl.FakeCall, ofctl: ofctl.FakeCall}
+			droppedPackets, err := collector.getDroppedPackets()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(droppedPackets).To(gomega.BeEmpty())
+			gomega.Expect(ofctl.dataIndex).To(gomega.BeZero())
+		})
+
+		ginkgo.It("returns an error when the meters can't be listed", func() {
+			ovnAppctl := NewFakeOVSClient([]clientOutput{{err: fmt.Errorf("ovn-controller is not running")}})
+			collector := &coppMeterCollector{ovnAppctl: ovnAppctl.FakeCall}
+			_, err := collector.getDroppedPackets()
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+})
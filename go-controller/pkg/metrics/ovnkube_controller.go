@@ -419,6 +419,11 @@ func RegisterOVNKubeControllerFunctional(stopChan <-chan struct{}) {
 			panic(err)
 		}
 	}
+	if err := prometheus.Register(MetricResourceRetryPendingCount); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			panic(err)
+		}
+	}
 	// ovnkube-controller logfile size metric
 	prometheus.MustRegister(metricOvnKubeControllerLogFileSize)
 	go ovnKubeLogFileSizeMetricsUpdater(metricOvnKubeControllerLogFileSize, stopChan)
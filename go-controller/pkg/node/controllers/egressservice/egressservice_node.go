@@ -18,6 +18,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	discoverylisters "k8s.io/client-go/listers/discovery/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -59,6 +60,7 @@ type Controller struct {
 	// See https://github.com/ovn-org/ovn-kubernetes/pull/3064 for more details.
 	returnMark string
 	thisNode   string // name of the node we're running on
+	recorder   record.EventRecorder
 
 	egressServiceLister egressservicelisters.EgressServiceLister
 	egressServiceSynced cache.InformerSynced
@@ -89,13 +91,15 @@ type svcState struct {
 func NewController(stopCh <-chan struct{}, returnMark, thisNode string,
 	esInformer egressserviceinformer.EgressServiceInformer,
 	serviceInformer cache.SharedIndexInformer,
-	endpointSliceInformer cache.SharedIndexInformer) (*Controller, error) {
+	endpointSliceInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder) (*Controller, error) {
 	klog.Info("Setting up event handlers for Egress Services")
 
 	c := &Controller{
 		stopCh:     stopCh,
 		returnMark: returnMark,
 		thisNode:   thisNode,
+		recorder:   recorder,
 		services:   map[string]*svcState{},
 	}
 
@@ -161,12 +165,41 @@ func (c *Controller) onEgressServiceUpdate(oldObj, newObj interface{}) {
 		return
 	}
 
+	if oldEQ.Status.Host != newEQ.Status.Host {
+		c.recordHostChange(newEQ, oldEQ.Status.Host)
+	}
+
 	key, err := cache.MetaNamespaceKeyFunc(newObj)
 	if err == nil {
 		c.egressServiceQueue.Add(key)
 	}
 }
 
+// recordHostChange records an event on the EgressService when its host moves
+// away from a node. Only the new host records the event, or the previous host
+// when no node is left to host the EgressService.
+func (c *Controller) recordHostChange(es *egressserviceapi.EgressService, oldHost string) {
+	newHost := es.Status.Host
+	if oldHost == "" || oldHost == types.EgressServiceNoSNATHost || newHost == types.EgressServiceNoSNATHost {
+		return
+	}
+	esRef := &corev1.ObjectReference{
+		APIVersion: egressserviceapi.SchemeGroupVersion.String(),
+		Kind:       "EgressService",
+		Namespace:  es.Namespace,
+		Name:       es.Name,
+		UID:        es.UID,
+	}
+	switch {
+	case newHost == c.thisNode:
+		c.recorder.Eventf(esRef, corev1.EventTypeNormal, "EgressServiceFailover",
+			"Egress service host moved from node %s to node %s", oldHost, newHost)
+	case newHost == "" && oldHost == c.thisNode:
+		c.recorder.Eventf(esRef, corev1.EventTypeWarning, "EgressServiceHostLost",
+			"Egress service is no longer hosted by node %s and no other node can host it", oldHost)
+	}
+}
+
 // onEgressServiceDelete queues the EgressService for processing.
 func (c *Controller) onEgressServiceDelete(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
@@ -711,8 +744,12 @@ func (c *Controller) syncEgressService(key string) error {
 		}
 	}
 
+	// this node becomes the host of the service when it did not configure it
+	// before, or when it only configured it as part of the no SNAT host
+	becameHost := cachedState == nil
 	if cachedState != nil {
 		lbsChanged = v4LB != cachedState.v4LB || v6LB != cachedState.v6LB
+		becameHost = cachedState.v4LB == "" && cachedState.v6LB == "" && (v4LB != "" || v6LB != "")
 	}
 
 	if lbsChanged {
@@ -797,6 +834,16 @@ func (c *Controller) syncEgressService(key string) error {
 		return err
 	}
 
+	// Connections of the endpoints were SNATed to another IP, e.g. the IP of
+	// the node, before this node became the host of the service. Flush them
+	// so that they are SNATed to the ingress of the service from now on.
+	if becameHost && cachedState.v4LB != "" {
+		flushSNATedFlows(v4ToAdd, cachedState.v4LB, false)
+	}
+	if becameHost && cachedState.v6LB != "" {
+		flushSNATedFlows(v6ToAdd, cachedState.v6LB, false)
+	}
+
 	// At this point we finished handling the SNAT rules
 	// Now we create the relevant ip rules according to the object's "Network"
 
@@ -967,6 +1014,9 @@ func (c *Controller) clearServiceSNATRules(state *svcState) error {
 	}
 	tx := nft.NewTransaction()
 
+	v4Eps, v6Eps := state.v4Eps.Clone(), state.v6Eps.Clone()
+	v4LB, v6LB := state.v4LB, state.v6LB
+
 	for ip := range state.v4Eps {
 		tx.Delete(&knftables.Element{
 			Map: NFTablesMapV4,
@@ -989,6 +1039,15 @@ func (c *Controller) clearServiceSNATRules(state *svcState) error {
 	if err != nil {
 		return err
 	}
+
+	// The connections SNATed to the ingress of the service would keep using it
+	// even though the node does not host the service anymore.
+	if v4LB != "" {
+		flushSNATedFlows(v4Eps, v4LB, true)
+	}
+	if v6LB != "" {
+		flushSNATedFlows(v6Eps, v6LB, true)
+	}
 	return nil
 }
 
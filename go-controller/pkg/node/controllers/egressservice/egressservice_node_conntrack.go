@@ -0,0 +1,64 @@
+package egressservice
+
+import (
+	"net"
+
+	"github.com/vishvananda/netlink"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// snatFlowFilter matches the conntrack flows originated by one of the endpoints
+// of an egress service which are SNATed on this node. When toLB is true, it
+// matches the flows SNATed to the ingress IP of the service, otherwise the flows
+// SNATed to any other IP.
+type snatFlowFilter struct {
+	endpoints sets.Set[string]
+	lb        net.IP
+	toLB      bool
+}
+
+func (f *snatFlowFilter) MatchConntrackFlow(flow *netlink.ConntrackFlow) bool {
+	if !f.endpoints.Has(flow.Forward.SrcIP.String()) {
+		return false
+	}
+	snatIP := flow.Reverse.DstIP
+	if snatIP.Equal(flow.Forward.SrcIP) {
+		// the flow is not SNATed
+		return false
+	}
+	return snatIP.Equal(f.lb) == f.toLB
+}
+
+// flushSNATedFlows deletes the conntrack flows of the given endpoints which are
+// SNATed to the ingress IP lb of the service when toLB is true, or to any other
+// IP otherwise. Only the flows affected by a change of the SNAT rules of the
+// service are deleted, so that the connections of the endpoints pick up the new
+// rules while the others are left untouched. Errors are logged as the flows
+// would eventually expire.
+func flushSNATedFlows(endpoints sets.Set[string], lb string, toLB bool) {
+	if endpoints.Len() == 0 {
+		return
+	}
+	lbIP := utilnet.ParseIPSloppy(lb)
+	if lbIP == nil {
+		return
+	}
+	family := netlink.FAMILY_V4
+	if utilnet.IsIPv6(lbIP) {
+		family = netlink.FAMILY_V6
+	}
+	filter := &snatFlowFilter{endpoints: endpoints, lb: lbIP, toLB: toLB}
+	deleted, err := util.GetNetLinkOps().ConntrackDeleteFilters(netlink.ConntrackTable, netlink.InetFamily(family), filter)
+	if err != nil {
+		klog.Errorf("Failed to delete conntrack entries of egress service endpoints %v SNATed to %s (toLB: %t): %v",
+			sets.List(endpoints), lb, toLB, err)
+		return
+	}
+	klog.V(4).Infof("Deleted %d conntrack entries of egress service endpoints %v SNATed to %s (toLB: %t)",
+		deleted, sets.List(endpoints), lb, toLB)
+}
@@ -1230,7 +1230,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	if config.OVNKubernetesFeature.EnableEgressService && config.OvnKubeNode.Mode != types.NodeModeDPU {
 		wf := nc.watchFactory.(*factory.WatchFactory)
 		c, err := egressservice.NewController(nc.stopChan, nodetypes.OvnKubeNodeSNATMark, nc.name,
-			wf.EgressServiceInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer())
+			wf.EgressServiceInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer(), nc.recorder)
 		if err != nil {
 			return err
 		}
@@ -1266,6 +1266,11 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		ovspinning.Run(nc.stopChan)
 	}()
 
+	// report the CNI plugin as ready now that the startup is over
+	if nc.cniServer != nil {
+		nc.cniServer.SetReady()
+	}
+
 	klog.Infof("Default node network controller initialized and ready.")
 	return nil
 }
@@ -1310,6 +1315,27 @@ func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPo
 		defer nc.wg.Done()
 		healthServer.Run(nc.stopChan)
 	}()
+
+	// The gRPC health server keeps running with BFD, it is used by the egress services
+	if config.OVNKubernetesFeature.EgressIPNodeHealthCheckProtocol != config.EgressIPNodeHealthCheckProtocolBFD {
+		return nil
+	}
+	mgmtIPs := make([]net.IP, 0, len(mgmtAddresses))
+	for _, mgmtAddress := range mgmtAddresses {
+		mgmtIPs = append(mgmtIPs, mgmtAddress.IP)
+	}
+	bfdServer, err := healthcheck.NewEgressIPBFDServer(mgmtIPs, healthCheckPort,
+		time.Duration(config.OVNKubernetesFeature.EgressIPBFDInterval)*time.Millisecond,
+		config.OVNKubernetesFeature.EgressIPBFDMultiplier)
+	if err != nil {
+		return fmt.Errorf("unable to allocate BFD health checking server: %v", err)
+	}
+
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		bfdServer.Run(nc.stopChan)
+	}()
 	return nil
 }
 
@@ -6,12 +6,15 @@ import (
 	"net"
 	"sync"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/urfave/cli/v2"
+	"github.com/vishvananda/netlink"
 
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/knftables"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -42,8 +45,17 @@ var _ = Describe("Egress Service Operations", func() {
 		fExec       *ovntest.FakeExec
 		nft         *knftables.Fake
 		netlinkMock *mocks.NetLinkOps
+
+		conntrackFiltersLock sync.Mutex
+		conntrackFilters     []netlink.CustomConntrackFilter
 	)
 
+	getConntrackFilters := func() []netlink.CustomConntrackFilter {
+		conntrackFiltersLock.Lock()
+		defer conntrackFiltersLock.Unlock()
+		return append([]netlink.CustomConntrackFilter{}, conntrackFilters...)
+	}
+
 	origNetlinkInst := util.GetNetLinkOps()
 
 	BeforeEach(func() {
@@ -51,6 +63,14 @@ var _ = Describe("Egress Service Operations", func() {
 		Expect(config.PrepareTestConfig()).To(Succeed())
 		netlinkMock = &mocks.NetLinkOps{}
 		util.SetNetLinkOpMockInst(netlinkMock)
+		conntrackFilters = nil
+		netlinkMock.On("ConntrackDeleteFilters", netlink.ConntrackTableType(netlink.ConntrackTable), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				conntrackFiltersLock.Lock()
+				defer conntrackFiltersLock.Unlock()
+				conntrackFilters = append(conntrackFilters, args.Get(2).(netlink.CustomConntrackFilter))
+			}).
+			Return(uint(0), nil)
 
 		app = cli.NewApp()
 		app.Name = "test"
@@ -305,6 +325,7 @@ var _ = Describe("Egress Service Operations", func() {
 					wf.EgressServiceInformer(),
 					wf.ServiceInformer(),
 					wf.EndpointSliceInformer(),
+					record.NewFakeRecorder(10),
 				)
 				Expect(err).ToNot(HaveOccurred())
 				err = c.Run(wg, 1)
@@ -411,6 +432,7 @@ add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.4 comment "nam
 					wf.EgressServiceInformer(),
 					wf.ServiceInformer(),
 					wf.EndpointSliceInformer(),
+					record.NewFakeRecorder(10),
 				)
 				Expect(err).ToNot(HaveOccurred())
 				err = c.Run(wg, 1)
@@ -439,6 +461,170 @@ add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.3 comment "nam
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("flushes the conntrack entries of the endpoints and records an event when the host changes", func() {
+			app.Action = func(*cli.Context) error {
+				fExec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ip -4 --json rule show",
+					Output: "[]",
+					Err:    nil,
+				})
+
+				epPortName := "https"
+				epPortValue := int32(443)
+
+				egressService := egressserviceapi.EgressService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "service1",
+						Namespace:       "namespace1",
+						ResourceVersion: "1",
+					},
+					Status: egressserviceapi.EgressServiceStatus{
+						Host: "node2",
+					},
+				}
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]corev1.ServicePort{
+						{
+							NodePort: int32(31111),
+							Protocol: corev1.ProtocolTCP,
+							Port:     int32(8080),
+						},
+					},
+					corev1.ServiceTypeLoadBalancer,
+					[]string{},
+					corev1.ServiceStatus{
+						LoadBalancer: corev1.LoadBalancerStatus{
+							Ingress: []corev1.LoadBalancerIngress{{
+								IP: "5.5.5.5",
+							}},
+						},
+					},
+					false, false,
+				)
+				endpointSlice := *newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{{Addresses: []string{"10.128.0.3"}}},
+					[]discovery.EndpointPort{{Name: &epPortName, Port: &epPortValue}},
+				)
+
+				objects := []runtime.Object{
+					&service,
+					&endpointSlice,
+					&egressService,
+				}
+				stopChan := make(chan struct{})
+				wg := &sync.WaitGroup{}
+				fakeClient := util.GetOVNClientset(objects...).GetNodeClientset()
+				wf, err := factory.NewNodeWatchFactory(fakeClient, "node")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(wf.Start()).To(Succeed())
+				defer func() {
+					close(stopChan)
+					wg.Wait()
+					wf.Shutdown()
+				}()
+
+				recorder := record.NewFakeRecorder(10)
+				c, err := egressservice.NewController(
+					stopChan,
+					nodetypes.OvnKubeNodeSNATMark,
+					"node",
+					wf.EgressServiceInformer(),
+					wf.ServiceInformer(),
+					wf.EndpointSliceInformer(),
+					recorder,
+				)
+				Expect(err).ToNot(HaveOccurred())
+				err = c.Run(wg, 1)
+				Expect(err).ToNot(HaveOccurred())
+
+				Consistently(func() error {
+					return nodenft.MatchNFTRules(nftablesRulesEgressServicesBase, nft.Dump())
+				}).ShouldNot(HaveOccurred())
+				Expect(getConntrackFilters()).To(BeEmpty())
+
+				// the flows of the endpoint SNATed to the node IP are stale once
+				// this node becomes the host, the ones SNATed to the ingress IP
+				// of the service are stale once it stops being the host
+				snatedToNodeIP := &netlink.ConntrackFlow{
+					Forward: netlink.IPTuple{SrcIP: net.ParseIP("10.128.0.3"), DstIP: net.ParseIP("1.1.1.1")},
+					Reverse: netlink.IPTuple{SrcIP: net.ParseIP("1.1.1.1"), DstIP: net.ParseIP("192.168.18.15")},
+				}
+				snatedToLB := &netlink.ConntrackFlow{
+					Forward: netlink.IPTuple{SrcIP: net.ParseIP("10.128.0.3"), DstIP: net.ParseIP("1.1.1.1")},
+					Reverse: netlink.IPTuple{SrcIP: net.ParseIP("1.1.1.1"), DstIP: net.ParseIP("5.5.5.5")},
+				}
+				notSNATed := &netlink.ConntrackFlow{
+					Forward: netlink.IPTuple{SrcIP: net.ParseIP("10.128.0.3"), DstIP: net.ParseIP("10.128.1.3")},
+					Reverse: netlink.IPTuple{SrcIP: net.ParseIP("10.128.1.3"), DstIP: net.ParseIP("10.128.0.3")},
+				}
+				otherEndpoint := &netlink.ConntrackFlow{
+					Forward: netlink.IPTuple{SrcIP: net.ParseIP("10.128.0.4"), DstIP: net.ParseIP("1.1.1.1")},
+					Reverse: netlink.IPTuple{SrcIP: net.ParseIP("1.1.1.1"), DstIP: net.ParseIP("192.168.18.15")},
+				}
+
+				egressService.ResourceVersion = "2"
+				egressService.Status.Host = "node"
+				_, err = fakeClient.EgressServiceClient.K8sV1().EgressServices("namespace1").Update(context.TODO(), &egressService, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				expectedNFT := nftablesRulesEgressServicesBase + `
+add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.3 comment "namespace1/service1" : 5.5.5.5 }
+`
+				Eventually(func() error {
+					return nodenft.MatchNFTRules(expectedNFT, nft.Dump())
+				}).ShouldNot(HaveOccurred())
+				Eventually(getConntrackFilters).Should(HaveLen(1))
+				filter := getConntrackFilters()[0]
+				Expect(filter.MatchConntrackFlow(snatedToNodeIP)).To(BeTrue())
+				Expect(filter.MatchConntrackFlow(snatedToLB)).To(BeFalse())
+				Expect(filter.MatchConntrackFlow(notSNATed)).To(BeFalse())
+				Expect(filter.MatchConntrackFlow(otherEndpoint)).To(BeFalse())
+				Eventually(recorder.Events).Should(Receive(Equal(
+					"Normal EgressServiceFailover Egress service host moved from node node2 to node node")))
+
+				// a new endpoint of the service hosted by this node has no
+				// stale connections to flush
+				endpointSlice.ResourceVersion = "2"
+				endpointSlice.Endpoints = append(endpointSlice.Endpoints, discovery.Endpoint{Addresses: []string{"10.128.0.4"}})
+				_, err = fakeClient.KubeClient.DiscoveryV1().EndpointSlices("namespace1").Update(context.TODO(), &endpointSlice, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				expectedNFT = nftablesRulesEgressServicesBase + `
+add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.3 comment "namespace1/service1" : 5.5.5.5 }
+add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.4 comment "namespace1/service1" : 5.5.5.5 }
+`
+				Eventually(func() error {
+					return nodenft.MatchNFTRules(expectedNFT, nft.Dump())
+				}).ShouldNot(HaveOccurred())
+				Consistently(getConntrackFilters).Should(HaveLen(1))
+
+				egressService.ResourceVersion = "3"
+				egressService.Status.Host = ""
+				_, err = fakeClient.EgressServiceClient.K8sV1().EgressServices("namespace1").Update(context.TODO(), &egressService, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(func() error {
+					return nodenft.MatchNFTRules(nftablesRulesEgressServicesBase, nft.Dump())
+				}).ShouldNot(HaveOccurred())
+				Eventually(getConntrackFilters).Should(HaveLen(2))
+				filter = getConntrackFilters()[1]
+				Expect(filter.MatchConntrackFlow(snatedToNodeIP)).To(BeFalse())
+				Expect(filter.MatchConntrackFlow(snatedToLB)).To(BeTrue())
+				Expect(filter.MatchConntrackFlow(notSNATed)).To(BeFalse())
+				Expect(filter.MatchConntrackFlow(otherEndpoint)).To(BeFalse())
+				Eventually(recorder.Events).Should(Receive(Equal(
+					"Warning EgressServiceHostLost Egress service is no longer hosted by node node and no other node can host it")))
+
+				Expect(fExec.CalledMatchesExpected()).To(BeTrue(), fExec.ErrorDesc)
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("manages iptables/ip rules for LoadBalancer egress service backed by ovn-k pods with Network", func() {
 			app.Action = func(*cli.Context) error {
 				fExec.AddFakeCmd(&ovntest.ExpectedCmd{
@@ -616,6 +802,7 @@ add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.3 comment "nam
 					wf.EgressServiceInformer(),
 					wf.ServiceInformer(),
 					wf.EndpointSliceInformer(),
+					record.NewFakeRecorder(10),
 				)
 				Expect(err).ToNot(HaveOccurred())
 				err = c.Run(wg, 1)
@@ -811,6 +998,7 @@ add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.11 comment "na
 					wf.EgressServiceInformer(),
 					wf.ServiceInformer(),
 					wf.EndpointSliceInformer(),
+					record.NewFakeRecorder(10),
 				)
 				Expect(err).ToNot(HaveOccurred())
 				err = c.Run(wg, 1)
@@ -970,6 +1158,7 @@ add element inet ovn-kubernetes egress-service-snat-v4 { 10.128.0.11 comment "na
 					wf.EgressServiceInformer(),
 					wf.ServiceInformer(),
 					wf.EndpointSliceInformer(),
+					record.NewFakeRecorder(10),
 				)
 				Expect(err).ToNot(HaveOccurred())
 				err = c.Run(wg, 1)
@@ -17,6 +17,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -26,10 +27,16 @@ var getOvsVSwitchdPIDFn func() (string, error) = util.GetOvsVSwitchdPID
 var getOvsDBServerPIDFn func() (string, error) = util.GetOvsDBServerPID
 var featureEnablerFile string = "/etc/openvswitch/enable_dynamic_cpu_affinity"
 
-// Run monitors OVS daemon's processes (ovs-vswitchd and ovsdb-server) and sets their CPU affinity
-// masks to that of the current process.
-// This feature is enabled by the presence of a non-empty file in the path `/etc/openvswitch/enable_dynamic_cpu_affinity`
+// Run monitors OVS daemon's processes (ovs-vswitchd and ovsdb-server) and sets their CPU affinity.
+// When a CPU pinning policy is configured (see config.OvnKubeNodeConfig), the threads of the daemons
+// are pinned to the CPUs configured for their thread class.
+// Otherwise, their CPU affinity masks are set to that of the current process. This feature is enabled
+// by the presence of a non-empty file in the path `/etc/openvswitch/enable_dynamic_cpu_affinity`
 func Run(stopCh <-chan struct{}) {
+	if config.OvnKubeNode.OVSCPUPinningConfigured() {
+		runPinningPolicy(stopCh)
+		return
+	}
 
 	// The file must be present at startup to enable the feature
 	isFeatureEnabled, err := isFileNotEmpty(featureEnablerFile)
@@ -0,0 +1,330 @@
+//go:build linux
+// +build linux
+
+package ovspinning
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// This variable is meant to be used in unit tests
+var ensurePMDCPUMaskFn func(cpus unix.CPUSet) error = ensurePMDCPUMask
+
+// Thread classes of the OVS daemons a CPU pinning policy applies to
+const (
+	threadClassVSwitchd    = "ovs-vswitchd"
+	threadClassPMD         = "pmd"
+	threadClassHandler     = "handler"
+	threadClassRevalidator = "revalidator"
+	threadClassOvsDBServer = "ovsdb-server"
+)
+
+// pinningPolicy holds the CPU affinity of the thread classes managed by a CPU pinning policy
+type pinningPolicy map[string]unix.CPUSet
+
+func (p pinningPolicy) String() string {
+	classes := make([]string, 0, len(p))
+	for class, cpus := range p {
+		classes = append(classes, fmt.Sprintf("%s=%s", class, printCPUSet(cpus)))
+	}
+	sort.Strings(classes)
+	return strings.Join(classes, " ")
+}
+
+// threadAffinity identifies the threads of a thread class having the same CPU affinity
+type threadAffinity struct {
+	class string
+	cpus  string
+}
+
+// kubeletConfiguration holds the fields of the kubelet configuration file used by the CPU pinning policy
+type kubeletConfiguration struct {
+	ReservedSystemCPUs string `json:"reservedSystemCPUs"`
+}
+
+// runPinningPolicy pins the OVS daemon threads to the CPUs configured for their thread class, and
+// periodically sets back the CPU affinity of the threads which drifted from it (e.g. new threads
+// or daemon restarts).
+func runPinningPolicy(stopCh <-chan struct{}) {
+	policy, err := getPinningPolicy()
+	if err != nil {
+		klog.Errorf("Can't start OVS CPU pinning: %v", err)
+		return
+	}
+
+	klog.Infof("Starting OVS daemon CPU pinning with policy: %s", policy)
+	defer klog.Infof("Stopping OVS daemon CPU pinning")
+
+	ticker := time.NewTicker(tickDuration)
+	defer ticker.Stop()
+
+	// ovs-vswitchd pins the PMD threads itself, to the CPUs of its pmd-cpu-mask
+	pmdCPUs, pinPMDThreads := policy[threadClassPMD]
+	for {
+		if pinPMDThreads {
+			if err := ensurePMDCPUMaskFn(pmdCPUs); err != nil {
+				klog.Warningf("Error while setting the ovs-vswitchd PMD CPU mask: %v", err)
+			} else {
+				pinPMDThreads = false
+			}
+		}
+		applyPinningPolicy(policy)
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// getPinningPolicy builds the CPU pinning policy configured for the OVS daemons. Explicit CPU lists must
+// be part of the kubelet reserved CPUs, when the kubelet reserves CPUs for the system daemons. The PMD
+// threads don't follow the vswitchd CPUs: ovs-vswitchd creates one PMD thread per CPU of its PMD CPU mask.
+func getPinningPolicy() (pinningPolicy, error) {
+	kubeletConfigFile := config.OvnKubeNode.KubeletConfigFile
+	reservedCPUs, err := getKubeletReservedCPUs(kubeletConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	vswitchdCPUs := config.OvnKubeNode.OVSVSwitchdCPUs
+	classCPUs := map[string]string{
+		threadClassVSwitchd:    vswitchdCPUs,
+		threadClassPMD:         config.OvnKubeNode.OVSPMDCPUs,
+		threadClassHandler:     vswitchdCPUs,
+		threadClassRevalidator: vswitchdCPUs,
+		threadClassOvsDBServer: config.OvnKubeNode.OVSDBServerCPUs,
+	}
+	for class, cpus := range map[string]string{
+		threadClassHandler:     config.OvnKubeNode.OVSHandlerCPUs,
+		threadClassRevalidator: config.OvnKubeNode.OVSRevalidatorCPUs,
+	} {
+		if cpus != "" {
+			classCPUs[class] = cpus
+		}
+	}
+
+	policy := pinningPolicy{}
+	for class, cpuList := range classCPUs {
+		if cpuList == "" {
+			continue
+		}
+		if cpuList == config.KubeletReservedCPUs {
+			if reservedCPUs == nil {
+				return nil, fmt.Errorf("can't pin %s threads to the kubelet reserved CPUs: no reservedSystemCPUs in %s",
+					class, kubeletConfigFile)
+			}
+			policy[class] = *reservedCPUs
+			continue
+		}
+		cpus, err := parseCPUSet(cpuList)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list for %s threads: %w", class, err)
+		}
+		if reservedCPUs != nil && !isCPUSubset(cpus, *reservedCPUs) {
+			return nil, fmt.Errorf("CPUs %s of %s threads are not part of the kubelet reserved CPUs %s",
+				printCPUSet(cpus), class, printCPUSet(*reservedCPUs))
+		}
+		policy[class] = cpus
+	}
+	return policy, nil
+}
+
+// getKubeletReservedCPUs returns the CPUs reserved by the kubelet for the system daemons, or nil if
+// the kubelet doesn't reserve any CPU.
+func getKubeletReservedCPUs(kubeletConfigFile string) (*unix.CPUSet, error) {
+	data, err := os.ReadFile(kubeletConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			klog.Infof("Kubelet configuration file %s not found, OVS CPU pinning is not validated against the kubelet reserved CPUs",
+				kubeletConfigFile)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't read kubelet configuration file %s: %w", kubeletConfigFile, err)
+	}
+
+	kubeletConfig := kubeletConfiguration{}
+	if err := yaml.Unmarshal(data, &kubeletConfig); err != nil {
+		return nil, fmt.Errorf("can't parse kubelet configuration file %s: %w", kubeletConfigFile, err)
+	}
+	if kubeletConfig.ReservedSystemCPUs == "" {
+		return nil, nil
+	}
+
+	reservedCPUs, err := parseCPUSet(kubeletConfig.ReservedSystemCPUs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubelet reservedSystemCPUs in %s: %w", kubeletConfigFile, err)
+	}
+	return &reservedCPUs, nil
+}
+
+// applyPinningPolicy sets the CPU affinity of the OVS daemon threads to the given policy and
+// updates the OVS thread CPU affinity metrics
+func applyPinningPolicy(policy pinningPolicy) {
+	threadAffinities := map[threadAffinity]int{}
+
+	ovsVSwitchdPID, err := getOvsVSwitchdPIDFn()
+	if err != nil {
+		klog.Warningf("Can't retrieve ovs-vswitchd PID: %v", err)
+	} else if err = pinProcessThreads(ovsVSwitchdPID, policy, getVSwitchdThreadClass, threadAffinities); err != nil {
+		klog.Warningf("Error while pinning ovs-vswitchd[%s] threads: %v", ovsVSwitchdPID, err)
+	}
+
+	ovsDBServerPID, err := getOvsDBServerPIDFn()
+	if err != nil {
+		klog.Warningf("Can't retrieve ovsdb-server PID: %v", err)
+	} else if err = pinProcessThreads(ovsDBServerPID, policy, func(string) string { return threadClassOvsDBServer },
+		threadAffinities); err != nil {
+		klog.Warningf("Error while pinning ovsdb-server[%s] threads: %v", ovsDBServerPID, err)
+	}
+
+	metrics.MetricOVSThreadCPUAffinity.Reset()
+	for affinity, count := range threadAffinities {
+		metrics.MetricOVSThreadCPUAffinity.WithLabelValues(affinity.class, affinity.cpus).Set(float64(count))
+	}
+}
+
+// pinProcessThreads sets the CPU affinity of each thread of the given process to the CPUs of its thread class
+// in the policy, and counts the threads of the process per thread class and CPU affinity. The PMD threads are
+// left to ovs-vswitchd, which pins them from its PMD CPU mask.
+func pinProcessThreads(targetPIDStr string, policy pinningPolicy, getThreadClass func(threadName string) string,
+	threadAffinities map[threadAffinity]int) error {
+
+	targetPID, err := strconv.Atoi(targetPIDStr)
+	if err != nil {
+		return fmt.Errorf("can't convert PID[%s] to integer: %w", targetPIDStr, err)
+	}
+
+	taskIDs, err := getThreadsOfProcess(targetPID)
+	if err != nil {
+		return fmt.Errorf("can't get tasks of PID(%d):%w", targetPID, err)
+	}
+
+	for _, taskID := range taskIDs {
+		// The task may have been stopped, don't break the loop and continue with the other tasks.
+		taskName, err := getThreadName(targetPID, taskID)
+		if err != nil {
+			klog.V(5).Infof("Can't get the name of task(%d) PID(%d): %v", taskID, targetPID, err)
+			continue
+		}
+		class := getThreadClass(taskName)
+
+		var taskCPUs unix.CPUSet
+		if err = unix.SchedGetaffinity(taskID, &taskCPUs); err != nil {
+			klog.V(5).Infof("Can't get CPU affinity of task(%d) PID(%d): %v", taskID, targetPID, err)
+			continue
+		}
+
+		if policyCPUs, ok := policy[class]; ok && class != threadClassPMD && policyCPUs != taskCPUs {
+			klog.Infof("Setting CPU affinity of %s thread %s(%d) PID(%d) to %s, was %s", class, taskName, taskID, targetPID,
+				printCPUSet(policyCPUs), printCPUSet(taskCPUs))
+			if err = unix.SchedSetaffinity(taskID, &policyCPUs); err != nil {
+				klog.Warningf("Error while setting CPU affinity of task(%d) PID(%d) to %s: %v", taskID, targetPID,
+					printCPUSet(policyCPUs), err)
+			} else {
+				metrics.MetricOVSCPUAffinityCorrections.WithLabelValues(class).Inc()
+				taskCPUs = policyCPUs
+			}
+		}
+
+		threadAffinities[threadAffinity{class: class, cpus: printCPUSet(taskCPUs)}]++
+	}
+
+	return nil
+}
+
+// ensurePMDCPUMask sets the CPU mask ovs-vswitchd creates and pins its PMD threads to
+func ensurePMDCPUMask(cpus unix.CPUSet) error {
+	mask := getCPUMask(cpus)
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:pmd-cpu-mask")
+	if err != nil {
+		return fmt.Errorf("can't get the PMD CPU mask, stderr: %q: %w", stderr, err)
+	}
+	if strings.Trim(stdout, "\"") == mask {
+		return nil
+	}
+	klog.Infof("Setting the ovs-vswitchd PMD CPU mask to %s (CPUs %s), was %q", mask, printCPUSet(cpus), stdout)
+	if _, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".", "other_config:pmd-cpu-mask="+mask); err != nil {
+		return fmt.Errorf("can't set the PMD CPU mask to %s, stderr: %q: %w", mask, stderr, err)
+	}
+	return nil
+}
+
+// getCPUMask returns the hexadecimal CPU mask of a CPU set, e.g. 0xc for CPUs 2-3
+func getCPUMask(cpus unix.CPUSet) string {
+	mask := new(big.Int)
+	for cpu := 0; cpu < len(cpus)*strconv.IntSize; cpu++ {
+		if cpus.IsSet(cpu) {
+			mask.SetBit(mask, cpu, 1)
+		}
+	}
+	return "0x" + mask.Text(16)
+}
+
+// getVSwitchdThreadClass returns the thread class of an ovs-vswitchd thread from its name
+func getVSwitchdThreadClass(threadName string) string {
+	switch {
+	case strings.HasPrefix(threadName, "pmd"):
+		return threadClassPMD
+	case strings.HasPrefix(threadName, "handler"):
+		return threadClassHandler
+	case strings.HasPrefix(threadName, "revalidator"):
+		return threadClassRevalidator
+	default:
+		return threadClassVSwitchd
+	}
+}
+
+// getThreadName returns the name of the given thread of a process
+func getThreadName(pid, taskID int) (string, error) {
+	name, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%d/comm", pid, taskID))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(name)), nil
+}
+
+// parseCPUSet parses a CPU list in the linux list format into a unix.CPUSet
+func parseCPUSet(cpuList string) (unix.CPUSet, error) {
+	var cpus unix.CPUSet
+	cpuIDs, err := config.ParseCPUList(cpuList)
+	if err != nil {
+		return cpus, err
+	}
+	if len(cpuIDs) == 0 {
+		return cpus, fmt.Errorf("empty CPU list")
+	}
+	maxCPUs := len(cpus) * strconv.IntSize
+	for _, cpu := range cpuIDs {
+		if cpu >= maxCPUs {
+			return cpus, fmt.Errorf("CPU %d is out of range", cpu)
+		}
+		cpus.Set(cpu)
+	}
+	return cpus, nil
+}
+
+// isCPUSubset returns true if all the CPUs of cpus are part of superset
+func isCPUSubset(cpus, superset unix.CPUSet) bool {
+	for i := range cpus {
+		if cpus[i]&^superset[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,361 @@
+//go:build linux
+// +build linux
+
+package ovspinning
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestPinningPolicy(t *testing.T) {
+	require.NoError(t, config.PrepareTestConfig())
+	defer func() {
+		assert.NoError(t, config.PrepareTestConfig())
+	}()
+
+	ovsDBPid, ovsDBStop := mockOvsdbProcess(t)
+	defer ovsDBStop()
+
+	ovsVSwitchdPid, ovsVSwitchdStop := mockNamedThreadsOvsVSwitchdProcess(t, "handler1", "handler2", "revalidator3",
+		"pmd-c00/id:4")
+	defer ovsVSwitchdStop()
+
+	var pmdCPUMask unix.CPUSet
+	var pmdCPUMaskLock sync.Mutex
+	previousEnsurePMDCPUMask := ensurePMDCPUMaskFn
+	ensurePMDCPUMaskFn = func(cpus unix.CPUSet) error {
+		pmdCPUMaskLock.Lock()
+		defer pmdCPUMaskLock.Unlock()
+		pmdCPUMask = cpus
+		return nil
+	}
+	defer func() {
+		ensurePMDCPUMaskFn = previousEnsurePMDCPUMask
+	}()
+
+	lastCPU := runtime.NumCPU() - 1
+	var firstCPUSet, lastCPUSet unix.CPUSet
+	firstCPUSet.Set(0)
+	lastCPUSet.Set(lastCPU)
+
+	config.OvnKubeNode.OVSVSwitchdCPUs = "0"
+	config.OvnKubeNode.OVSPMDCPUs = fmt.Sprintf("%d", lastCPU)
+	config.OvnKubeNode.OVSHandlerCPUs = fmt.Sprintf("%d", lastCPU)
+	config.OvnKubeNode.OVSDBServerCPUs = config.KubeletReservedCPUs
+	config.OvnKubeNode.KubeletConfigFile = mockKubeletConfigFile(t, fmt.Sprintf("0,%d", lastCPU))
+
+	defer setTickDuration(20 * time.Millisecond)()
+
+	var wg sync.WaitGroup
+	stopCh := make(chan struct{})
+	defer func() {
+		close(stopCh)
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		Run(stopCh)
+	}()
+
+	expectedCPUSets := map[string]unix.CPUSet{
+		threadClassVSwitchd:    firstCPUSet,
+		threadClassHandler:     lastCPUSet,
+		threadClassRevalidator: firstCPUSet,
+	}
+	// the PMD threads keep the CPU affinity set by ovs-vswitchd, the PMD CPU mask is set instead
+	var processCPUSet unix.CPUSet
+	require.NoError(t, unix.SchedGetaffinity(ovsVSwitchdPid, &processCPUSet))
+	tasks, err := getThreadsOfProcess(ovsVSwitchdPid)
+	require.NoError(t, err)
+	handlerTask := 0
+	for _, task := range tasks {
+		threadName, err := getThreadName(ovsVSwitchdPid, task)
+		require.NoError(t, err)
+		class := getVSwitchdThreadClass(threadName)
+		if class == threadClassHandler {
+			handlerTask = task
+		}
+		if class == threadClassPMD {
+			assertTaskHasSchedAffinity(t, ovsVSwitchdPid, task, processCPUSet)
+			continue
+		}
+		assertTaskHasSchedAffinity(t, ovsVSwitchdPid, task, expectedCPUSets[class])
+	}
+	pmdCPUMaskLock.Lock()
+	assert.Equal(t, lastCPUSet, pmdCPUMask)
+	pmdCPUMaskLock.Unlock()
+	var reservedCPUSet unix.CPUSet
+	reservedCPUSet.Set(0)
+	reservedCPUSet.Set(lastCPU)
+	assertPIDHasSchedAffinity(t, ovsDBPid, reservedCPUSet)
+
+	for affinity, count := range map[threadAffinity]int{
+		{class: threadClassHandler, cpus: printCPUSet(lastCPUSet)}:      2,
+		{class: threadClassRevalidator, cpus: printCPUSet(firstCPUSet)}: 1,
+	} {
+		assert.Eventually(t, func() bool {
+			return getOVSThreadCPUAffinityGauge(t, affinity) == float64(count)
+		}, time.Second, 10*time.Millisecond, "expected %d %s threads with CPU affinity %s", count, affinity.class, affinity.cpus)
+	}
+
+	if lastCPU == 0 {
+		t.Log("Not enough CPUs to test the CPU affinity drift correction")
+		return
+	}
+
+	// Move a handler thread to another CPU, it must be pinned back
+	err = unix.SchedSetaffinity(handlerTask, &firstCPUSet)
+	require.NoError(t, err)
+	assertTaskHasSchedAffinity(t, ovsVSwitchdPid, handlerTask, lastCPUSet)
+}
+
+func TestGetPinningPolicy(t *testing.T) {
+	var cpus01, cpus23, cpus0123 unix.CPUSet
+	cpus01.Set(0)
+	cpus01.Set(1)
+	cpus23.Set(2)
+	cpus23.Set(3)
+	cpus0123 = cpus01
+	cpus0123.Set(2)
+	cpus0123.Set(3)
+
+	tests := []struct {
+		name           string
+		config         config.OvnKubeNodeConfig
+		kubeletConfig  string
+		expectedPolicy pinningPolicy
+		expectError    bool
+	}{
+		{
+			name:   "vswitchd CPUs apply to all the ovs-vswitchd thread classes but the PMD threads",
+			config: config.OvnKubeNodeConfig{OVSVSwitchdCPUs: "0-1"},
+			expectedPolicy: pinningPolicy{
+				threadClassVSwitchd:    cpus01,
+				threadClassHandler:     cpus01,
+				threadClassRevalidator: cpus01,
+			},
+		},
+		{
+			name: "thread class CPUs override the vswitchd CPUs",
+			config: config.OvnKubeNodeConfig{
+				OVSVSwitchdCPUs: "0-1",
+				OVSPMDCPUs:      "2-3",
+				OVSDBServerCPUs: "0-3",
+			},
+			expectedPolicy: pinningPolicy{
+				threadClassVSwitchd:    cpus01,
+				threadClassPMD:         cpus23,
+				threadClassHandler:     cpus01,
+				threadClassRevalidator: cpus01,
+				threadClassOvsDBServer: cpus0123,
+			},
+		},
+		{
+			name:           "only the configured thread classes are managed",
+			config:         config.OvnKubeNodeConfig{OVSRevalidatorCPUs: "2-3"},
+			expectedPolicy: pinningPolicy{threadClassRevalidator: cpus23},
+		},
+		{
+			name: "kubelet reserved CPUs",
+			config: config.OvnKubeNodeConfig{
+				OVSVSwitchdCPUs: config.KubeletReservedCPUs,
+				OVSHandlerCPUs:  "2-3",
+				OVSDBServerCPUs: config.KubeletReservedCPUs,
+			},
+			kubeletConfig: "0-3",
+			expectedPolicy: pinningPolicy{
+				threadClassVSwitchd:    cpus0123,
+				threadClassHandler:     cpus23,
+				threadClassRevalidator: cpus0123,
+				threadClassOvsDBServer: cpus0123,
+			},
+		},
+		{
+			name:          "CPUs not reserved by the kubelet",
+			config:        config.OvnKubeNodeConfig{OVSDBServerCPUs: "1-2"},
+			kubeletConfig: "0-1",
+			expectError:   true,
+		},
+		{
+			name:        "kubelet reserved CPUs without reserved CPUs",
+			config:      config.OvnKubeNodeConfig{OVSVSwitchdCPUs: config.KubeletReservedCPUs},
+			expectError: true,
+		},
+		{
+			name:        "CPU out of range",
+			config:      config.OvnKubeNodeConfig{OVSVSwitchdCPUs: "100000"},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, config.PrepareTestConfig())
+			defer func() {
+				assert.NoError(t, config.PrepareTestConfig())
+			}()
+			config.OvnKubeNode = tc.config
+			config.OvnKubeNode.KubeletConfigFile = filepath.Join(t.TempDir(), "config.yaml")
+			if tc.kubeletConfig != "" {
+				config.OvnKubeNode.KubeletConfigFile = mockKubeletConfigFile(t, tc.kubeletConfig)
+			}
+
+			policy, err := getPinningPolicy()
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicy, policy)
+		})
+	}
+}
+
+func TestEnsurePMDCPUMask(t *testing.T) {
+	var cpus23 unix.CPUSet
+	cpus23.Set(2)
+	cpus23.Set(3)
+
+	tests := []struct {
+		name        string
+		currentMask string
+		expectSet   bool
+	}{
+		{
+			name:        "sets the mask when it is not set",
+			currentMask: "",
+			expectSet:   true,
+		},
+		{
+			name:        "sets the mask when it differs",
+			currentMask: `"0x3"`,
+			expectSet:   true,
+		},
+		{
+			name:        "keeps the mask when it is already set",
+			currentMask: `"0xc"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:pmd-cpu-mask",
+				Output: tc.currentMask,
+			})
+			if tc.expectSet {
+				fexec.AddFakeCmdsNoOutputNoError([]string{
+					"ovs-vsctl --timeout=15 set Open_vSwitch . other_config:pmd-cpu-mask=0xc",
+				})
+			}
+			require.NoError(t, util.SetExec(fexec))
+			defer util.ResetRunner()
+
+			require.NoError(t, ensurePMDCPUMask(cpus23))
+			assert.True(t, fexec.CalledMatchesExpected(), fexec.ErrorDesc())
+		})
+	}
+}
+
+func TestGetVSwitchdThreadClass(t *testing.T) {
+	for threadName, expectedClass := range map[string]string{
+		"ovs-vswitchd":  threadClassVSwitchd,
+		"urcu3":         threadClassVSwitchd,
+		"handler12":     threadClassHandler,
+		"revalidator18": threadClassRevalidator,
+		"pmd-c03/id:9":  threadClassPMD,
+	} {
+		assert.Equal(t, expectedClass, getVSwitchdThreadClass(threadName), "thread %s", threadName)
+	}
+}
+
+// mockNamedThreadsOvsVSwitchdProcess runs a fake ovs-vswitchd process with a thread per given thread name
+func mockNamedThreadsOvsVSwitchdProcess(t *testing.T, threadNames ...string) (int, func()) {
+	t.Helper()
+	binary := filepath.Join(t.TempDir(), "ovs-vswitchd")
+	out, err := exec.Command("go", "build", "-tags", "testing_ovspinning", "-o", binary, "testdata/fake_ovs_vswitchd.go").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	ctx, stopCmd := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, binary, threadNames...)
+	err = cmd.Start()
+	require.NoError(t, err)
+
+	previousGetter := getOvsVSwitchdPIDFn
+	getOvsVSwitchdPIDFn = func() (string, error) {
+		return fmt.Sprintf("%d", cmd.Process.Pid), nil
+	}
+
+	// Ensure the fake process has named its threads
+	assert.Eventually(t, func() bool {
+		tasks, err := getThreadsOfProcess(cmd.Process.Pid)
+		assert.NoError(t, err)
+		names := map[string]bool{}
+		for _, task := range tasks {
+			if name, err := getThreadName(cmd.Process.Pid, task); err == nil {
+				names[name] = true
+			}
+		}
+		for _, threadName := range threadNames {
+			if !names[threadName] {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 100*time.Millisecond, "ovs-vswitchd fake process does not have the expected threads")
+
+	return cmd.Process.Pid, func() {
+		stopCmd()
+		_ = cmd.Wait()
+		getOvsVSwitchdPIDFn = previousGetter
+	}
+}
+
+func mockKubeletConfigFile(t *testing.T, reservedSystemCPUs string) string {
+	t.Helper()
+	kubeletConfigFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(kubeletConfigFile, []byte(fmt.Sprintf(`apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+reservedSystemCPUs: "%s"
+`, reservedSystemCPUs)), 0o644)
+	require.NoError(t, err)
+	return kubeletConfigFile
+}
+
+func getOVSThreadCPUAffinityGauge(t *testing.T, affinity threadAffinity) float64 {
+	t.Helper()
+	metric := &dto.Metric{}
+	err := metrics.MetricOVSThreadCPUAffinity.WithLabelValues(affinity.class, affinity.cpus).Write(metric)
+	require.NoError(t, err)
+	return metric.GetGauge().GetValue()
+}
+
+func assertTaskHasSchedAffinity(t *testing.T, pid, task int, expectedCPUSet unix.CPUSet) {
+	t.Helper()
+	var actual unix.CPUSet
+	assert.Eventually(t, func() bool {
+		err := unix.SchedGetaffinity(task, &actual)
+		assert.NoError(t, err)
+
+		return actual == expectedCPUSet
+	}, time.Second, 10*time.Millisecond, "task[%d] of process[%d] Expected CPUSet %0x != Actual CPUSet %0x", task, pid, expectedCPUSet, actual)
+}
@@ -0,0 +1,30 @@
+//go:build testing_ovspinning
+// +build testing_ovspinning
+
+package main
+
+// This file is meant to be used in unit tests to validate the behavior of the ovspinning package.
+// The purpose is to simulate the ovs-vswitchd daemon, which runs a thread per thread name given as argument
+// (e.g. handler1, revalidator2, pmd-c03/id:4).
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+func main() {
+	for _, threadName := range os.Args[1:] {
+		go func(threadName string) {
+			runtime.LockOSThread()
+			err := os.WriteFile(fmt.Sprintf("/proc/self/task/%d/comm", syscall.Gettid()), []byte(threadName), 0)
+			if err != nil {
+				panic(err)
+			}
+			select {}
+		}(threadName)
+	}
+	time.Sleep(100 * time.Second)
+}
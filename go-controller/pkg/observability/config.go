@@ -0,0 +1,407 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
+	observabilityconfigapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/applyconfiguration/observabilityconfig/v1"
+	observabilityconfigclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/clientset/versioned"
+	observabilityconfiginformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/informers/externalversions/observabilityconfig/v1"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/batching"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	// defaultNetworkControllerName is the owner of the ACLs of the default network controller,
+	// duplicated from the ovn package to avoid an import cycle.
+	defaultNetworkControllerName = "default-network-controller"
+	// syncKey is the only key of the sync reconciler, as all the ObservabilityConfigs are synced together.
+	syncKey                = "observabilityconfigs"
+	readyInZonePrefix      = "Ready-In-Zone-"
+	observConfigAppliedMsg = "ObservabilityConfig applied"
+	// aclResampleBatchSize is the number of ACLs whose samples are updated in a single transaction, every
+	// ACL update may also create and delete Sample rows.
+	aclResampleBatchSize = 1000
+)
+
+// featureRule samples the objects of a feature that are selected by namespaceSelector and networks.
+type featureRule struct {
+	feature libovsdbops.SampleFeature
+	// probability in percent, 0 to 100
+	probability int
+	// namespaceSelector is nil to select the objects of all namespaces and the cluster-scoped objects
+	namespaceSelector labels.Selector
+	// networks is empty to select the objects of all networks
+	networks sets.Set[string]
+	// collectorUUID is set by addCollector, it is empty when the rule has 0 probability
+	collectorUUID string
+}
+
+// observConfigReconciler reconciles the ObservabilityConfigs for the Manager.
+type observConfigReconciler struct {
+	client              observabilityconfigclientset.Interface
+	informer            observabilityconfiginformer.ObservabilityConfigInformer
+	namespaceInformer   corev1informers.NamespaceInformer
+	zone                string
+	configController    controller.Controller
+	namespaceController controller.Controller
+	syncReconciler      controller.Reconciler
+	// configs are the applied collectorConfigs, only accessed with the Manager syncLock
+	configs []*collectorConfig
+}
+
+// NewManagerWithConfigs returns a Manager that samples the features as configured by the ObservabilityConfigs,
+// and reports the status of the ObservabilityConfigs for the zone.
+// The ObservabilityConfigs are reconciled from Init until Stop is called.
+func NewManagerWithConfigs(nbClient libovsdbclient.Client, client observabilityconfigclientset.Interface,
+	informer observabilityconfiginformer.ObservabilityConfigInformer, namespaceInformer corev1informers.NamespaceInformer,
+	zone string) *Manager {
+	m := NewManager(nbClient)
+	r := &observConfigReconciler{
+		client:            client,
+		informer:          informer,
+		namespaceInformer: namespaceInformer,
+		zone:              zone,
+	}
+	r.syncReconciler = controller.NewReconciler("observability-config-sync", &controller.ReconcilerConfig{
+		RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+		Reconcile:   m.syncObservabilityConfigs,
+		Threadiness: 1,
+		MaxAttempts: controller.InfiniteAttempts,
+	})
+	r.configController = controller.NewController("observability-config", &controller.ControllerConfig[observabilityconfigapi.ObservabilityConfig]{
+		RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+		Reconcile: func(string) error {
+			r.syncReconciler.Reconcile(syncKey)
+			return nil
+		},
+		Threadiness:    1,
+		Informer:       informer.Informer(),
+		Lister:         informer.Lister().List,
+		ObjNeedsUpdate: observConfigNeedsUpdate,
+	})
+	r.namespaceController = controller.NewController("observability-config-namespace", &controller.ControllerConfig[corev1.Namespace]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Reconcile:      m.syncNamespace,
+		Threadiness:    1,
+		Informer:       namespaceInformer.Informer(),
+		Lister:         namespaceInformer.Lister().List,
+		ObjNeedsUpdate: namespaceNeedsUpdate,
+	})
+	m.observConfigs = r
+	return m
+}
+
+func observConfigNeedsUpdate(oldObj, newObj *observabilityconfigapi.ObservabilityConfig) bool {
+	// status updates don't change the sampling
+	return oldObj == nil || !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+}
+
+func namespaceNeedsUpdate(oldObj, newObj *corev1.Namespace) bool {
+	return oldObj == nil || !reflect.DeepEqual(oldObj.Labels, newObj.Labels)
+}
+
+// Stop stops reconciling the ObservabilityConfigs.
+func (m *Manager) Stop() {
+	if m.observConfigs == nil {
+		return
+	}
+	controller.Stop(m.observConfigs.configController, m.observConfigs.namespaceController, m.observConfigs.syncReconciler)
+}
+
+func (m *Manager) initWithObservabilityConfigs() error {
+	if err := m.setSamplingAppIDs(); err != nil {
+		return err
+	}
+	m.syncLock.Lock()
+	_, err := m.syncCollectors()
+	m.syncLock.Unlock()
+	if err != nil {
+		return err
+	}
+	// now cleanup stale collectors
+	m.deleteStaleCollectorsWithRetry()
+
+	r := m.observConfigs
+	return controller.Start(r.syncReconciler, r.configController, r.namespaceController)
+}
+
+// getCollectorConfigs returns the collectorConfigs of the ObservabilityConfigs in the order they are applied,
+// and the errors of the ObservabilityConfigs that can't be applied.
+func getCollectorConfigs(observConfigs []*observabilityconfigapi.ObservabilityConfig) ([]*collectorConfig, map[string]error) {
+	// the oldest ObservabilityConfig wins on a collectorSetID conflict
+	slices.SortFunc(observConfigs, func(a, b *observabilityconfigapi.ObservabilityConfig) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	configErrors := map[string]error{}
+	collectorSetIDs := map[int]string{}
+	var configs []*collectorConfig
+	for _, observConfig := range observConfigs {
+		collectorSetID := int(observConfig.Spec.CollectorSetID)
+		if owner, ok := collectorSetIDs[collectorSetID]; ok {
+			configErrors[observConfig.Name] = fmt.Errorf("collectorSetID %d is already used by ObservabilityConfig %s",
+				collectorSetID, owner)
+			continue
+		}
+		config := &collectorConfig{
+			collectorSetID: collectorSetID,
+		}
+		var err error
+		for _, featureSampling := range observConfig.Spec.Features {
+			rule := &featureRule{
+				feature:     string(featureSampling.Feature),
+				probability: int(featureSampling.Probability),
+				networks:    sets.New(featureSampling.Networks...),
+			}
+			if featureSampling.NamespaceSelector != nil {
+				rule.namespaceSelector, err = metav1.LabelSelectorAsSelector(featureSampling.NamespaceSelector)
+				if err != nil {
+					err = fmt.Errorf("invalid namespaceSelector for feature %s: %w", featureSampling.Feature, err)
+					break
+				}
+			}
+			config.rules = append(config.rules, rule)
+		}
+		if err != nil {
+			configErrors[observConfig.Name] = err
+			continue
+		}
+		collectorSetIDs[collectorSetID] = observConfig.Name
+		configs = append(configs, config)
+	}
+	return configs, configErrors
+}
+
+// getDefaultRulesConfig returns the default config, with a rule selecting all the objects of every feature.
+func getDefaultRulesConfig() *collectorConfig {
+	config := getDefaultConfig()
+	for feature, probability := range config.featuresProbability {
+		config.rules = append(config.rules, &featureRule{
+			feature:     feature,
+			probability: probability,
+		})
+	}
+	config.featuresProbability = nil
+	return config
+}
+
+// syncCollectors creates the collectors of the ObservabilityConfigs, or of the default config when no
+// ObservabilityConfig exists, and sets the SamplingConfig. The collectors that are not used anymore
+// are marked for deletion.
+// It returns the errors of the ObservabilityConfigs that can't be applied.
+func (m *Manager) syncCollectors() (map[string]error, error) {
+	r := m.observConfigs
+	observConfigs, err := r.informer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing ObservabilityConfigs: %w", err)
+	}
+	configs, configErrors := getCollectorConfigs(observConfigs)
+	if len(observConfigs) == 0 {
+		configs = []*collectorConfig{getDefaultRulesConfig()}
+	}
+
+	// all existing collectors are unused, until they are used by the current configs
+	if err = m.setDbCollectors(); err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if _, err = m.addCollector(config); err != nil {
+			return nil, fmt.Errorf("error adding collectors for collectorSetID %d: %w", config.collectorSetID, err)
+		}
+	}
+	r.configs = configs
+	m.setSamplingConfig(libovsdbops.NewSamplingConfigWithSelector(m.getCollectorSelector(configs)))
+	return configErrors, nil
+}
+
+// getCollectorSelector returns the collectors of the first rule of every config that selects
+// the sampled object.
+func (m *Manager) getCollectorSelector(configs []*collectorConfig) libovsdbops.CollectorSelector {
+	namespaceLister := m.observConfigs.namespaceInformer.Lister()
+	return func(feature libovsdbops.SampleFeature, namespace, network string) []string {
+		var collectors []string
+		var namespaceLabels labels.Set
+		for _, config := range configs {
+			for _, rule := range config.rules {
+				if rule.feature != feature {
+					continue
+				}
+				if rule.networks.Len() > 0 && !rule.networks.Has(network) {
+					continue
+				}
+				if rule.namespaceSelector != nil {
+					if namespace == "" {
+						continue
+					}
+					if namespaceLabels == nil {
+						ns, err := namespaceLister.Get(namespace)
+						if err != nil {
+							// the namespace is resynced when it is created
+							break
+						}
+						namespaceLabels = labels.Set(ns.Labels)
+					}
+					if !rule.namespaceSelector.Matches(namespaceLabels) {
+						continue
+					}
+				}
+				if rule.collectorUUID != "" {
+					collectors = append(collectors, rule.collectorUUID)
+				}
+				break
+			}
+		}
+		return collectors
+	}
+}
+
+// hasNamespaceSelectors returns true if the sampling depends on the namespace labels.
+func (r *observConfigReconciler) hasNamespaceSelectors() bool {
+	for _, config := range r.configs {
+		for _, rule := range config.rules {
+			if rule.namespaceSelector != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// syncObservabilityConfigs applies the current ObservabilityConfigs to the collectors and to the samples of the
+// existing ACLs, and reports the ObservabilityConfigs status for the zone.
+func (m *Manager) syncObservabilityConfigs(string) error {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+	startTime := time.Now()
+	configErrors, err := m.syncCollectors()
+	if err != nil {
+		return err
+	}
+	if err = m.resampleACLs(func(*nbdb.ACL) bool { return true }); err != nil {
+		return err
+	}
+	if err = m.deleteStaleCollectors(); err != nil {
+		return fmt.Errorf("error deleting stale collectors: %w", err)
+	}
+	if err = m.updateObservabilityConfigsStatus(configErrors); err != nil {
+		return err
+	}
+	klog.Infof("Synced ObservabilityConfigs, took %v", time.Since(startTime))
+	return nil
+}
+
+// syncNamespace updates the samples of the ACLs of a namespace when its labels change.
+func (m *Manager) syncNamespace(namespace string) error {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+	if !m.observConfigs.hasNamespaceSelectors() {
+		return nil
+	}
+	return m.resampleACLs(func(acl *nbdb.ACL) bool {
+		return libovsdbops.GetACLSampleNamespace(acl) == namespace
+	})
+}
+
+// resampleACLs updates the samples of the ACLs selected by p with the current SamplingConfig.
+// Only the ACLs of the controllers that sample with the Manager are updated.
+func (m *Manager) resampleACLs(p func(acl *nbdb.ACL) bool) error {
+	acls, err := libovsdbops.FindACLsWithPredicate(m.nbClient, func(acl *nbdb.ACL) bool {
+		if libovsdbops.GetACLSampleFeature(acl) == "" && acl.SampleNew == nil && acl.SampleEst == nil {
+			return false
+		}
+		// egress firewall ACLs are sampled on all the networks, the other features only on the default network
+		if acl.ExternalIDs[libovsdbops.OwnerControllerKey.String()] != defaultNetworkControllerName &&
+			acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()] != libovsdbops.EgressFirewallOwnerType {
+			return false
+		}
+		return p(acl)
+	})
+	if err != nil {
+		return fmt.Errorf("error finding sampled ACLs: %w", err)
+	}
+	samplingConfig := m.SamplingConfig()
+	return batching.Batch[*nbdb.ACL](aclResampleBatchSize, acls, func(batchACLs []*nbdb.ACL) error {
+		ops, err := libovsdbops.UpdateACLsSamplesOps(m.nbClient, nil, samplingConfig, batchACLs...)
+		if err != nil {
+			return fmt.Errorf("error updating samples of %d ACLs: %w", len(batchACLs), err)
+		}
+		if _, err = libovsdbops.TransactAndCheck(m.nbClient, ops); err != nil {
+			return fmt.Errorf("error updating samples of %d ACLs: %w", len(batchACLs), err)
+		}
+		return nil
+	})
+}
+
+// updateObservabilityConfigsStatus sets the Ready-In-Zone condition of the ObservabilityConfigs for the zone.
+func (m *Manager) updateObservabilityConfigsStatus(configErrors map[string]error) error {
+	r := m.observConfigs
+	observConfigs, err := r.informer.Lister().List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing ObservabilityConfigs: %w", err)
+	}
+	var errs []error
+	for _, observConfig := range observConfigs {
+		newCondition := metav1.Condition{
+			Type:    readyInZonePrefix + r.zone,
+			Status:  metav1.ConditionTrue,
+			Reason:  "SetupSucceeded",
+			Message: observConfigAppliedMsg,
+		}
+		if configErr := configErrors[observConfig.Name]; configErr != nil {
+			newCondition.Status = metav1.ConditionFalse
+			newCondition.Reason = "SetupFailed"
+			newCondition.Message = types.ObservabilityErrorMsg + ": " + configErr.Error()
+		}
+		existingCondition := meta.FindStatusCondition(observConfig.Status.Conditions, newCondition.Type)
+		if existingCondition != nil && existingCondition.Status == newCondition.Status &&
+			existingCondition.Message == newCondition.Message {
+			continue
+		}
+		newConditionApply := &metaapplyv1.ConditionApplyConfiguration{
+			Type:    &newCondition.Type,
+			Status:  &newCondition.Status,
+			Reason:  &newCondition.Reason,
+			Message: &newCondition.Message,
+		}
+		if existingCondition == nil || existingCondition.Status != newCondition.Status {
+			newConditionApply.LastTransitionTime = ptr.To(metav1.NewTime(time.Now()))
+		} else {
+			// the applied condition replaces the existing one, keep its transition time
+			newConditionApply.LastTransitionTime = ptr.To(existingCondition.LastTransitionTime)
+		}
+		applyObj := observabilityconfigapply.ObservabilityConfig(observConfig.Name).
+			WithStatus(observabilityconfigapply.ObservabilityConfigStatus().WithConditions(newConditionApply))
+		_, err = r.client.K8sV1().ObservabilityConfigs().ApplyStatus(context.TODO(), applyObj,
+			metav1.ApplyOptions{FieldManager: r.zone, Force: true})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("error updating status of ObservabilityConfig %s: %w", observConfig.Name, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
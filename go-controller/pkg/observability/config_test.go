@@ -0,0 +1,246 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	observabilityconfigapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1"
+	observabilityconfiginformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/informers/externalversions"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Observability Manager with ObservabilityConfigs", func() {
+	var (
+		nbClient        libovsdbclient.Client
+		libovsdbCleanup *libovsdbtest.Context
+		fakeClient      *util.OVNClientset
+		manager         *Manager
+		stopChan        chan struct{}
+	)
+
+	const zone = "zone1"
+
+	start := func(objects ...runtime.Object) {
+		var err error
+		nbClient, _, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{})
+		Expect(err).NotTo(HaveOccurred())
+		fakeClient = util.GetOVNClientset(objects...)
+		stopChan = make(chan struct{})
+		observConfigFactory := observabilityconfiginformerfactory.NewSharedInformerFactory(fakeClient.ObservabilityConfigClient, 0)
+		observConfigInformer := observConfigFactory.K8s().V1().ObservabilityConfigs()
+		observConfigInformer.Informer()
+		kubeFactory := informers.NewSharedInformerFactory(fakeClient.KubeClient, 0)
+		namespaceInformer := kubeFactory.Core().V1().Namespaces()
+		namespaceInformer.Informer()
+		observConfigFactory.Start(stopChan)
+		kubeFactory.Start(stopChan)
+		observConfigFactory.WaitForCacheSync(stopChan)
+		kubeFactory.WaitForCacheSync(stopChan)
+
+		manager = NewManagerWithConfigs(nbClient, fakeClient.ObservabilityConfigClient, observConfigInformer,
+			namespaceInformer, zone)
+		Expect(manager.Init()).To(Succeed())
+	}
+
+	newObservabilityConfig := func(name string, age time.Duration, collectorSetID int32,
+		features ...observabilityconfigapi.FeatureSampling) *observabilityconfigapi.ObservabilityConfig {
+		return &observabilityconfigapi.ObservabilityConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Spec: observabilityconfigapi.ObservabilityConfigSpec{
+				CollectorSetID: collectorSetID,
+				Features:       features,
+			},
+		}
+	}
+
+	getCollectorUUID := func(collectorSetID, percent int) string {
+		var collectors []*nbdb.SampleCollector
+		Eventually(func(g Gomega) {
+			var err error
+			collectors, err = libovsdbops.FindSampleCollectorWithPredicate(nbClient, func(collector *nbdb.SampleCollector) bool {
+				return collector.SetID == collectorSetID && collector.Probability == percentToProbability(percent)
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(collectors).To(HaveLen(1))
+		}).Should(Succeed())
+		return collectors[0].UUID
+	}
+
+	getACLCollectors := func(acl *nbdb.ACL) []string {
+		acls, err := libovsdbops.FindACLs(nbClient, []*nbdb.ACL{acl})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acls).To(HaveLen(1))
+		if acls[0].SampleNew == nil {
+			return nil
+		}
+		sample, err := libovsdbops.GetSample(nbClient, &nbdb.Sample{UUID: *acls[0].SampleNew})
+		Expect(err).NotTo(HaveOccurred())
+		return sample.Collectors
+	}
+
+	createACL := func(name string, ownerType string, objectName string) *nbdb.ACL {
+		acl := &nbdb.ACL{
+			Name: &name,
+			ExternalIDs: map[string]string{
+				libovsdbops.OwnerControllerKey.String(): defaultNetworkControllerName,
+				libovsdbops.OwnerTypeKey.String():       ownerType,
+				libovsdbops.ObjectNameKey.String():      objectName,
+				libovsdbops.PrimaryIDKey.String():       name,
+			},
+		}
+		ops, err := libovsdbops.CreateOrUpdateACLsOps(nbClient, nil, manager.SamplingConfig(), acl)
+		Expect(err).NotTo(HaveOccurred())
+		ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(nbClient, ops, &nbdb.PortGroup{
+			Name: name,
+			ACLs: []string{acl.UUID},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = libovsdbops.TransactAndCheckAndSetUUIDs(nbClient, acl, ops)
+		Expect(err).NotTo(HaveOccurred())
+		return &nbdb.ACL{UUID: acl.UUID}
+	}
+
+	getReadyCondition := func(name string) *metav1.Condition {
+		observConfig, err := fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().Get(context.TODO(), name,
+			metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return meta.FindStatusCondition(observConfig.Status.Conditions, readyInZonePrefix+zone)
+	}
+
+	AfterEach(func() {
+		if manager != nil {
+			manager.Stop()
+		}
+		if stopChan != nil {
+			close(stopChan)
+		}
+		if libovsdbCleanup != nil {
+			libovsdbCleanup.Cleanup()
+		}
+	})
+
+	It("should sample all the features to the default collector set without ObservabilityConfigs", func() {
+		start()
+		collectorUUID := getCollectorUUID(DefaultObservabilityCollectorSetID, 100)
+		acl := createACL("anp", libovsdbops.AdminNetworkPolicyOwnerType, "anp")
+		Expect(getACLCollectors(acl)).To(ConsistOf(collectorUUID))
+	})
+
+	It("should sample the features per namespace and network", func() {
+		debugNamespace := util.NewNamespace("debug")
+		debugNamespace.Labels = map[string]string{"debug": "true"}
+		prodNamespace := util.NewNamespace("prod")
+		start(debugNamespace, prodNamespace,
+			newObservabilityConfig("namespaces", time.Minute, 10,
+				observabilityconfigapi.FeatureSampling{
+					Feature:           observabilityconfigapi.NetworkPolicySample,
+					Probability:       100,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"debug": "true"}},
+				},
+				observabilityconfigapi.FeatureSampling{
+					Feature:     observabilityconfigapi.NetworkPolicySample,
+					Probability: 10,
+				},
+			),
+			newObservabilityConfig("networks", 0, 20,
+				observabilityconfigapi.FeatureSampling{
+					Feature:     observabilityconfigapi.EgressFirewallSample,
+					Probability: 50,
+					Networks:    []string{"default"},
+				},
+			),
+		)
+		debugCollectorUUID := getCollectorUUID(10, 100)
+		prodCollectorUUID := getCollectorUUID(10, 10)
+		egressFirewallCollectorUUID := getCollectorUUID(20, 50)
+
+		debugACL := createACL("debug-netpol", libovsdbops.NetworkPolicyOwnerType, "debug:netpol")
+		Expect(getACLCollectors(debugACL)).To(ConsistOf(debugCollectorUUID))
+		prodACL := createACL("prod-netpol", libovsdbops.NetworkPolicyOwnerType, "prod:netpol")
+		Expect(getACLCollectors(prodACL)).To(ConsistOf(prodCollectorUUID))
+		egressFirewallACL := createACL("prod-egressfirewall", libovsdbops.EgressFirewallOwnerType, "prod")
+		Expect(getACLCollectors(egressFirewallACL)).To(ConsistOf(egressFirewallCollectorUUID))
+		// the features that are not configured are not sampled
+		anpACL := createACL("anp", libovsdbops.AdminNetworkPolicyOwnerType, "anp")
+		Expect(getACLCollectors(anpACL)).To(BeEmpty())
+		// the default collector set is not used
+		collectors, err := libovsdbops.FindSampleCollectorWithPredicate(nbClient, func(collector *nbdb.SampleCollector) bool {
+			return collector.SetID == DefaultObservabilityCollectorSetID
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collectors).To(BeEmpty())
+		Eventually(func() *metav1.Condition { return getReadyCondition("namespaces") }).Should(
+			HaveField("Status", metav1.ConditionTrue))
+
+		By("labeling the prod namespace for debugging")
+		prodNamespace.Labels = map[string]string{"debug": "true"}
+		_, err = fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), prodNamespace, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() []string { return getACLCollectors(prodACL) }).Should(ConsistOf(debugCollectorUUID))
+	})
+
+	It("should resample the existing ACLs when the ObservabilityConfigs change", func() {
+		start()
+		defaultCollectorUUID := getCollectorUUID(DefaultObservabilityCollectorSetID, 100)
+		acl := createACL("netpol", libovsdbops.NetworkPolicyOwnerType, "ns:netpol")
+		Expect(getACLCollectors(acl)).To(ConsistOf(defaultCollectorUUID))
+
+		_, err := fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().Create(context.TODO(),
+			newObservabilityConfig("debug", 0, 10, observabilityconfigapi.FeatureSampling{
+				Feature:     observabilityconfigapi.NetworkPolicySample,
+				Probability: 50,
+			}), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		collectorUUID := getCollectorUUID(10, 50)
+		Eventually(func() []string { return getACLCollectors(acl) }).Should(ConsistOf(collectorUUID))
+		// the default collector is deleted once it is not used anymore
+		Eventually(func() ([]*nbdb.SampleCollector, error) {
+			return libovsdbops.FindSampleCollectorWithPredicate(nbClient, func(collector *nbdb.SampleCollector) bool {
+				return collector.SetID == DefaultObservabilityCollectorSetID
+			})
+		}).Should(BeEmpty())
+
+		err = fakeClient.ObservabilityConfigClient.K8sV1().ObservabilityConfigs().Delete(context.TODO(), "debug",
+			metav1.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		defaultCollectorUUID = getCollectorUUID(DefaultObservabilityCollectorSetID, 100)
+		Eventually(func() []string { return getACLCollectors(acl) }).Should(ConsistOf(defaultCollectorUUID))
+	})
+
+	It("should report a collectorSetID conflict in the status", func() {
+		start(
+			newObservabilityConfig("old", time.Minute, 10, observabilityconfigapi.FeatureSampling{
+				Feature:     observabilityconfigapi.NetworkPolicySample,
+				Probability: 100,
+			}),
+			newObservabilityConfig("new", 0, 10, observabilityconfigapi.FeatureSampling{
+				Feature:     observabilityconfigapi.NetworkPolicySample,
+				Probability: 50,
+			}),
+		)
+		Eventually(func() *metav1.Condition { return getReadyCondition("old") }).Should(
+			HaveField("Status", metav1.ConditionTrue))
+		Eventually(func() *metav1.Condition { return getReadyCondition("new") }).Should(And(
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Message", ContainSubstring("collectorSetID 10 is already used by ObservabilityConfig old"))))
+		collectorUUID := getCollectorUUID(10, 100)
+		acl := createACL("netpol", libovsdbops.NetworkPolicyOwnerType, "ns:netpol")
+		Expect(getACLCollectors(acl)).To(ConsistOf(collectorUUID))
+	})
+})
@@ -24,7 +24,8 @@ const (
 	ACLEstTrafficSamplingID
 )
 
-// temporary const, until we have dynamic config
+// DefaultObservabilityCollectorSetID is the collector set all the features are sampled to
+// when no ObservabilityConfig exists.
 const DefaultObservabilityCollectorSetID = 42
 
 // this is inferred from nbdb schema, check Sample_Collector.id
@@ -38,6 +39,8 @@ type collectorConfig struct {
 	collectorSetID int
 	// probability in percent, 0 to 100
 	featuresProbability map[libovsdbops.SampleFeature]int
+	// rules select the sampled objects of the features, only set for the configs of ObservabilityConfigs.
+	rules []*featureRule
 }
 
 type Manager struct {
@@ -57,6 +60,13 @@ type Manager struct {
 	// Only maxCollectorID collectors are allowed, each should have unique ID.
 	// this set is tracking already assigned IDs.
 	takenCollectorIDs sets.Set[int]
+
+	// sampConfigLock protects sampConfig, that is replaced when the ObservabilityConfigs change
+	sampConfigLock sync.RWMutex
+	// syncLock serializes the collectors updates with the stale collectors cleanup
+	syncLock sync.Mutex
+	// observConfigs is only set when the sampling is configured with ObservabilityConfigs, see NewManagerWithConfigs
+	observConfigs *observConfigReconciler
 }
 
 func NewManager(nbClient libovsdbclient.Client) *Manager {
@@ -71,12 +81,21 @@ func NewManager(nbClient libovsdbclient.Client) *Manager {
 }
 
 func (m *Manager) SamplingConfig() *libovsdbops.SamplingConfig {
+	m.sampConfigLock.RLock()
+	defer m.sampConfigLock.RUnlock()
 	return m.sampConfig
 }
 
-func (m *Manager) Init() error {
-	// this will be read from the kube-api in the future
-	currentConfig := &collectorConfig{
+func (m *Manager) setSamplingConfig(sampConfig *libovsdbops.SamplingConfig) {
+	m.sampConfigLock.Lock()
+	defer m.sampConfigLock.Unlock()
+	m.sampConfig = sampConfig
+}
+
+// getDefaultConfig returns the config used when the sampling is not configured with ObservabilityConfigs,
+// or when no ObservabilityConfig exists.
+func getDefaultConfig() *collectorConfig {
+	return &collectorConfig{
 		collectorSetID: DefaultObservabilityCollectorSetID,
 		featuresProbability: map[libovsdbops.SampleFeature]int{
 			libovsdbops.EgressFirewallSample:     100,
@@ -86,8 +105,13 @@ func (m *Manager) Init() error {
 			libovsdbops.UDNIsolationSample:       100,
 		},
 	}
+}
 
-	return m.initWithConfig(currentConfig)
+func (m *Manager) Init() error {
+	if m.observConfigs != nil {
+		return m.initWithObservabilityConfigs()
+	}
+	return m.initWithConfig(getDefaultConfig())
 }
 
 func (m *Manager) initWithConfig(config *collectorConfig) error {
@@ -102,7 +126,7 @@ func (m *Manager) initWithConfig(config *collectorConfig) error {
 	if err != nil {
 		return err
 	}
-	m.sampConfig = libovsdbops.NewSamplingConfig(featuresConfig)
+	m.setSamplingConfig(libovsdbops.NewSamplingConfig(featuresConfig))
 
 	// now cleanup stale collectors
 	m.deleteStaleCollectorsWithRetry()
@@ -113,6 +137,7 @@ func (m *Manager) setDbCollectors() error {
 	m.collectorsLock.Lock()
 	defer m.collectorsLock.Unlock()
 	clear(m.dbCollectors)
+	clear(m.unusedCollectors)
 	collectors, err := libovsdbops.ListSampleCollectors(m.nbClient)
 	if err != nil {
 		return fmt.Errorf("error getting sample collectors: %w", err)
@@ -132,6 +157,8 @@ func (m *Manager) setDbCollectors() error {
 // deleteStaleCollectorsWithRetry will retry, considering deletion should eventually succeed when all controllers
 // update their db entries to use the latest observability config.
 func (m *Manager) deleteStaleCollectorsWithRetry() {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
 	if err := m.deleteStaleCollectors(); err != nil {
 		m.collectorsCleanupRetries += 1
 		// allow retries for 1 hour, hopefully it will be enough for all handler to complete initial sync
@@ -228,6 +255,16 @@ func groupByProbability(c *collectorConfig) map[int][]libovsdbops.SampleFeature
 		probability := percentToProbability(percentProbability)
 		probabilities[probability] = append(probabilities[probability], feature)
 	}
+	for _, rule := range c.rules {
+		// rules with 0 probability select objects that are not sampled, they don't need a collector
+		if rule.probability == 0 {
+			continue
+		}
+		probability := percentToProbability(rule.probability)
+		if !slices.Contains(probabilities[probability], rule.feature) {
+			probabilities[probability] = append(probabilities[probability], rule.feature)
+		}
+	}
 	return probabilities
 }
 
@@ -295,6 +332,11 @@ func (m *Manager) addCollector(conf *collectorConfig) (map[libovsdbops.SampleFea
 		for _, feature := range features {
 			sampleFeaturesConfig[feature] = append(sampleFeaturesConfig[feature], collectorUUID)
 		}
+		for _, rule := range conf.rules {
+			if rule.probability != 0 && percentToProbability(rule.probability) == probability {
+				rule.collectorUUID = collectorUUID
+			}
+		}
 	}
 	return sampleFeaturesConfig, nil
 }
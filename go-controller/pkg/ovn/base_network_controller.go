@@ -568,11 +568,17 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 	// If supported, enable IGMP/MLD snooping and querier on the node.
 	if bnc.multicastSupport {
 		logicalSwitch.OtherConfig["mcast_snoop"] = "true"
+		if config.MulticastTableSize > 0 {
+			logicalSwitch.OtherConfig["mcast_table_size"] = strconv.FormatUint(uint64(config.MulticastTableSize), 10)
+		}
 
 		// Configure IGMP/MLD querier if the gateway IP address is known.
 		// Otherwise disable it.
 		if v4Gateway != nil || v6Gateway != nil {
 			logicalSwitch.OtherConfig["mcast_querier"] = "true"
+			if config.MulticastQueryInterval > 0 {
+				logicalSwitch.OtherConfig["mcast_query_interval"] = strconv.FormatUint(uint64(config.MulticastQueryInterval), 10)
+			}
 			logicalSwitch.OtherConfig["mcast_eth_src"] = nodeLRPMAC.String()
 			if v4Gateway != nil {
 				logicalSwitch.OtherConfig["mcast_ip4_src"] = v4Gateway.String()
@@ -600,8 +606,8 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 			libovsdbops.RouterPort: types.RouterToSwitchPrefix + switchName,
 		},
 	}
-	if bnc.IsDefault() {
-		logicalSwitchPort.Options["arp_proxy"] = kubevirt.ComposeARPProxyLSPOption()
+	if bnc.IsDefault() || bnc.IsPrimaryNetwork() {
+		logicalSwitchPort.Options["arp_proxy"] = kubevirt.ComposeARPProxyLSPOption(bnc.Subnets())
 	}
 	sw := nbdb.LogicalSwitch{Name: switchName}
 	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(bnc.nbClient, &sw, &logicalSwitchPort)
@@ -1259,7 +1265,11 @@ func (bnc *BaseNetworkController) ensureDHCP(pod *corev1.Pod, podAnnotation *uti
 	}
 
 	ipv4Gateway, _ := util.MatchFirstIPFamily(false /*ipv4*/, podAnnotation.Gateways)
-	if ipv4Gateway != nil {
+	if bnc.IsUserDefinedNetwork() && bnc.TopologyType() == types.Layer3Topology {
+		// the gateway of the node subnet is not reachable anymore once the VM
+		// is live migrated to another node, use the ARP proxy IP instead
+		opts = append(opts, kubevirt.WithIPv4ARPProxyRouter())
+	} else if ipv4Gateway != nil {
 		opts = append(opts, kubevirt.WithIPv4Router(ipv4Gateway.String()))
 	}
 
@@ -2,14 +2,18 @@ package ovn
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
 type defaultMcastACLTypeID string
@@ -55,17 +59,72 @@ func getMulticastACLIgrMatchV6(addrSetName string) string {
 	return "(mldv1 || mldv2 || (ip6.src == $" + addrSetName + " && " + ipv6DynamicMulticastMatch + "))"
 }
 
+// Allow IGMP traffic and namespace multicast traffic destined to the allowed
+// groups towards pods.
+func getMulticastACLIgrGroupsMatchV4(addrSetName string, groups []string) string {
+	if len(groups) == 0 {
+		return "igmp"
+	}
+	return "(igmp || (ip4.src == $" + addrSetName + " && ip4.mcast && " + getMulticastGroupsMatch("ip4", groups) + "))"
+}
+
+// Allow MLD traffic and namespace multicast traffic destined to the allowed
+// groups towards pods.
+func getMulticastACLIgrGroupsMatchV6(addrSetName string, groups []string) string {
+	if len(groups) == 0 {
+		return "(mldv1 || mldv2)"
+	}
+	return "(mldv1 || mldv2 || (ip6.src == $" + addrSetName + " && " + ipv6DynamicMulticastMatch + " && " +
+		getMulticastGroupsMatch("ip6", groups) + "))"
+}
+
+// Creates the match string for multicast traffic destined to one of the given
+// groups, ipVersion is either "ip4" or "ip6".
+func getMulticastGroupsMatch(ipVersion string, groups []string) string {
+	return ipVersion + ".dst == {" + strings.Join(groups, ", ") + "}"
+}
+
+// splitMulticastGroups splits the given multicast groups by IP family.
+func splitMulticastGroups(groups []string) (groupsV4, groupsV6 []string) {
+	for _, group := range groups {
+		if utilnet.IsIPv6CIDRString(group) {
+			groupsV6 = append(groupsV6, group)
+		} else {
+			groupsV4 = append(groupsV4, group)
+		}
+	}
+	return
+}
+
 // Creates the match string used for ACLs allowing incoming multicast into a
 // namespace, that is, from IPs that are in the namespace's address set.
-func (bnc *BaseNetworkController) getMulticastACLIgrMatch(nsInfo *namespaceInfo) string {
+// If rules is not nil, only the multicast traffic destined to one of the
+// groups of the rules is allowed.
+func (bnc *BaseNetworkController) getMulticastACLIgrMatch(nsInfo *namespaceInfo, rules []util.MulticastGroupsRule) string {
 	var ipv4Match, ipv6Match string
 	addrSetNameV4, addrSetNameV6 := nsInfo.addressSet.GetASHashNames()
 	ipv4Mode, ipv6Mode := bnc.IPMode()
+	if rules == nil {
+		if ipv4Mode {
+			ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
+		}
+		if ipv6Mode {
+			ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+		}
+		return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
+	}
+
+	allGroupsV4, allGroupsV6 := sets.New[string](), sets.New[string]()
+	for _, rule := range rules {
+		groupsV4, groupsV6 := splitMulticastGroups(rule.Groups)
+		allGroupsV4.Insert(groupsV4...)
+		allGroupsV6.Insert(groupsV6...)
+	}
 	if ipv4Mode {
-		ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
+		ipv4Match = getMulticastACLIgrGroupsMatchV4(addrSetNameV4, sets.List(allGroupsV4))
 	}
 	if ipv6Mode {
-		ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+		ipv6Match = getMulticastACLIgrGroupsMatchV6(addrSetNameV6, sets.List(allGroupsV6))
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
 }
@@ -84,6 +143,92 @@ func (bnc *BaseNetworkController) getMulticastACLEgrMatch() string {
 	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
 }
 
+// mcastGroupsSenders holds the address sets of the pods allowed to send
+// multicast traffic to a list of groups.
+type mcastGroupsSenders struct {
+	addrSetNameV4, addrSetNameV6 string
+	groupsV4, groupsV6           []string
+}
+
+// Creates the match string used for ACLs allowing outgoing multicast from a
+// namespace restricted to the groups allowed for each set of senders. IGMP and
+// MLD traffic is always allowed so that pods can join groups.
+func (bnc *BaseNetworkController) getMulticastACLEgrGroupsMatch(senders []mcastGroupsSenders) string {
+	var ipv4Match, ipv6Match string
+	var sendersV4, sendersV6 []string
+	ipv4Mode, ipv6Mode := bnc.IPMode()
+	for _, sender := range senders {
+		if ipv4Mode && sender.addrSetNameV4 != "" && len(sender.groupsV4) > 0 {
+			sendersV4 = append(sendersV4, "(ip4.src == $"+sender.addrSetNameV4+" && "+
+				getMulticastGroupsMatch("ip4", sender.groupsV4)+")")
+		}
+		if ipv6Mode && sender.addrSetNameV6 != "" && len(sender.groupsV6) > 0 {
+			sendersV6 = append(sendersV6, "(ip6.src == $"+sender.addrSetNameV6+" && "+
+				getMulticastGroupsMatch("ip6", sender.groupsV6)+")")
+		}
+	}
+	if ipv4Mode {
+		ipv4Match = "igmp"
+		if len(sendersV4) > 0 {
+			ipv4Match = "(igmp || (ip4.mcast && (" + strings.Join(sendersV4, " || ") + ")))"
+		}
+	}
+	if ipv6Mode {
+		ipv6Match = "(mldv1 || mldv2)"
+		if len(sendersV6) > 0 {
+			ipv6Match = "(mldv1 || mldv2 || (" + ipv6DynamicMulticastMatch + " && (" + strings.Join(sendersV6, " || ") + ")))"
+		}
+	}
+	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
+}
+
+// getMulticastAddrSetBackRef returns the back reference of the pod selector
+// address sets used by the multicast policy of namespace ns.
+func getMulticastAddrSetBackRef(ns string) string {
+	return "Multicast/" + ns
+}
+
+// ensureMulticastGroupsSenders returns the senders of the multicast groups
+// allowed by rules in namespace ns. The pod selector address sets used by the
+// rules are added to addrSetKeys, even on failure, so that they can be cleaned
+// up.
+func (bnc *BaseNetworkController) ensureMulticastGroupsSenders(ns string, nsInfo *namespaceInfo,
+	rules []util.MulticastGroupsRule, addrSetKeys sets.Set[string]) ([]mcastGroupsSenders, error) {
+	senders := make([]mcastGroupsSenders, 0, len(rules))
+	for _, rule := range rules {
+		sender := mcastGroupsSenders{}
+		sender.groupsV4, sender.groupsV6 = splitMulticastGroups(rule.Groups)
+		if rule.PodSelector == nil {
+			// all the pods of the namespace are allowed to send
+			sender.addrSetNameV4, sender.addrSetNameV6 = nsInfo.addressSet.GetASHashNames()
+		} else {
+			addrSetKey, addrSetNameV4, addrSetNameV6, err := bnc.EnsurePodSelectorAddressSet(
+				rule.PodSelector, nil, ns, getMulticastAddrSetBackRef(ns))
+			addrSetKeys.Insert(addrSetKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to ensure pod selector address set %s: %w", addrSetKey, err)
+			}
+			sender.addrSetNameV4, sender.addrSetNameV6 = addrSetNameV4, addrSetNameV6
+		}
+		senders = append(senders, sender)
+	}
+	return senders, nil
+}
+
+// releaseMulticastAddrSets releases the pod selector address sets used by the
+// multicast policy of namespace ns, except the ones in keep.
+func (bnc *BaseNetworkController) releaseMulticastAddrSets(ns string, nsInfo *namespaceInfo, keep sets.Set[string]) error {
+	var errs []error
+	for _, addrSetKey := range sets.List(nsInfo.multicastAddrSetKeys.Difference(keep)) {
+		if err := bnc.DeletePodSelectorAddressSet(addrSetKey, getMulticastAddrSetBackRef(ns)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		nsInfo.multicastAddrSetKeys.Delete(addrSetKey)
+	}
+	return utilerrors.Join(errs...)
+}
+
 func getDefaultMcastACLDbIDs(mcastType defaultMcastACLTypeID, aclDir libovsdbutil.ACLDirection, controller string) *libovsdbops.DbObjectIDs {
 	// there are 2 types of default multicast ACLs in every direction (Ingress/Egress)
 	// DefaultDeny = deny multicast by default
@@ -112,17 +257,35 @@ func getNamespaceMcastACLDbIDs(ns string, aclDir libovsdbutil.ACLDirection, cont
 //   - one "to-lport" ACL allowing ingress multicast traffic to pods in 'ns'.
 //     This matches only traffic originated by pods in 'ns' (based on the
 //     namespace address set).
-func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *namespaceInfo) error {
+//
+// If rules is not nil, the multicast groups are restricted: the egress ACL
+// only allows the pods selected by each rule to send traffic to the groups of
+// the rule, and the ingress ACL only allows traffic destined to the groups of
+// any rule. IGMP/MLD traffic is always allowed, as ACLs can't match on the
+// groups joined by membership reports.
+func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *namespaceInfo, rules []util.MulticastGroupsRule) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
+	egressMcastMatch := bnc.getMulticastACLEgrMatch()
+	addrSetKeys := sets.New[string]()
+	if rules != nil {
+		senders, err := bnc.ensureMulticastGroupsSenders(ns, nsInfo, rules, addrSetKeys)
+		// even if ensureMulticastGroupsSenders failed, track address sets for future cleanup or retry.
+		nsInfo.multicastAddrSetKeys.Insert(addrSetKeys.UnsortedList()...)
+		if err != nil {
+			return err
+		}
+		egressMcastMatch = bnc.getMulticastACLEgrGroupsMatch(senders)
+	}
+
 	aclDir := libovsdbutil.ACLEgress
-	egressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLEgrMatch(), aclDir)
+	egressMatch := libovsdbutil.GetACLMatch(portGroupName, egressMcastMatch, aclDir)
 	dbIDs := getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline := libovsdbutil.ACLDirectionToACLPipeline(aclDir)
 	egressACL := libovsdbutil.BuildACLWithDefaultTier(dbIDs, types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
 
 	aclDir = libovsdbutil.ACLIngress
-	ingressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLIgrMatch(nsInfo), aclDir)
+	ingressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLIgrMatch(nsInfo, rules), aclDir)
 	dbIDs = getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline = libovsdbutil.ACLDirectionToACLPipeline(aclDir)
 	ingressACL := libovsdbutil.BuildACLWithDefaultTier(dbIDs, types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
@@ -143,7 +306,8 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 		return err
 	}
 
-	return nil
+	// release the pod selector address sets not used anymore by the ACLs
+	return bnc.releaseMulticastAddrSets(ns, nsInfo, addrSetKeys)
 }
 
 func (bnc *BaseNetworkController) deleteMulticastAllowPolicy(ns string) error {
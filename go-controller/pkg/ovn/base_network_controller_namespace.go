@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
@@ -14,7 +15,6 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -56,6 +56,13 @@ type namespaceInfo struct {
 	routingExternalPodGWs map[string]gatewayInfo
 
 	multicastEnabled bool
+	// multicastAllowedGroups are the rules restricting the multicast groups allowed
+	// in the namespace, parsed from annotation k8s.ovn.org/multicast-allowed-groups.
+	// nil when all the groups are allowed.
+	multicastAllowedGroups []util.MulticastGroupsRule
+	// multicastAddrSetKeys are the keys of the pod selector address sets used by the
+	// multicast allow policy of the namespace.
+	multicastAddrSetKeys sets.Set[string]
 
 	// If not empty, then it has to be set to a logging a severity level, e.g. "notice", "alert", etc
 	aclLogging libovsdbutil.ACLLoggingLevels
@@ -207,29 +214,40 @@ func (bnc *BaseNetworkController) syncNamespaces(namespaces []interface{}) error
 }
 
 // Creates an explicit "allow" policy for multicast traffic within the
-// namespace if multicast is enabled. Otherwise, removes the "allow" policy.
-// Traffic will be dropped by the default multicast deny ACL.
+// namespace if multicast is enabled, restricted to the allowed groups if any.
+// Otherwise, removes the "allow" policy. Traffic will be dropped by the
+// default multicast deny ACL.
 func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if !bnc.multicastSupport {
 		return nil
 	}
 
 	enabled := isNamespaceMulticastEnabled(ns.Annotations)
+	allowedGroups, err := util.ParseMulticastAllowedGroupsAnnotation(ns.Annotations)
+	if err != nil {
+		// an invalid annotation can only be fixed by a namespace update, don't allow any group until then
+		klog.Errorf("Invalid multicast allowed groups for namespace %s, no group will be allowed: %v", ns.Name, err)
+		allowedGroups = []util.MulticastGroupsRule{}
+	}
 	enabledOld := nsInfo.multicastEnabled
-	if enabledOld == enabled {
+	if enabledOld == enabled && (!enabled || reflect.DeepEqual(nsInfo.multicastAllowedGroups, allowedGroups)) {
 		return nil
 	}
 
-	var err error
-	nsInfo.multicastEnabled = enabled
 	if enabled {
-		err = bnc.createMulticastAllowPolicy(ns.Name, nsInfo)
+		err = bnc.createMulticastAllowPolicy(ns.Name, nsInfo, allowedGroups)
 	} else {
 		err = bnc.deleteMulticastAllowPolicy(ns.Name)
+		if err == nil {
+			err = bnc.releaseMulticastAddrSets(ns.Name, nsInfo, nil)
+		}
+		allowedGroups = nil
 	}
 	if err != nil {
 		return err
 	}
+	nsInfo.multicastEnabled = enabled
+	nsInfo.multicastAllowedGroups = allowedGroups
 	return nil
 }
 
@@ -238,11 +256,12 @@ func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace,
 func (bnc *BaseNetworkController) multicastDeleteNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if nsInfo.multicastEnabled {
 		nsInfo.multicastEnabled = false
+		nsInfo.multicastAllowedGroups = nil
 		if err := bnc.deleteMulticastAllowPolicy(ns.Name); err != nil {
 			return err
 		}
 	}
-	return nil
+	return bnc.releaseMulticastAddrSets(ns.Name, nsInfo, nil)
 }
 
 // ensureNamespaceLockedCommon is a shared function used by both default/secondary network controllers,
@@ -258,6 +277,7 @@ func (bnc *BaseNetworkController) ensureNamespaceLockedCommon(ns string, readOnl
 		nsInfo = &namespaceInfo{
 			relatedNetworkPolicies: map[string]bool{},
 			multicastEnabled:       false,
+			multicastAddrSetKeys:   sets.New[string](),
 			routingExternalPodGWs:  make(map[string]gatewayInfo),
 			routingExternalGWs:     gatewayInfo{gws: sets.New[string](), bfdEnabled: false},
 		}
@@ -466,8 +486,9 @@ func (bsnc *BaseNetworkController) removeRemoteZonePodFromNamespaceAddressSet(po
 	// tracked within the zone, nodeName will be empty which will force
 	// canReleasePodIPs to lookup all nodes.
 	nodeName := pod.Spec.NodeName
-	if !bsnc.IsUserDefinedNetwork() && kubevirt.IsPodLiveMigratable(pod) {
-		nodeName, _ = bsnc.lsManager.GetSubnetName(podIfAddrs)
+	if bsnc.isLayer3LiveMigratablePod(pod) {
+		switchName, _ := bsnc.lsManager.GetSubnetName(podIfAddrs)
+		nodeName = bsnc.RemoveNetworkScopeFromName(switchName)
 	}
 
 	// Remove the pod ips from the namespace address set. Before that check if its a completed pod and
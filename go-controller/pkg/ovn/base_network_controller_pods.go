@@ -428,6 +428,17 @@ func (bnc *BaseNetworkController) getExpectedSwitchName(pod *corev1.Pod) (string
 	return switchName, nil
 }
 
+// isLayer3LiveMigratablePod returns true for the live migratable VM pods of
+// the default network or of a layer3 primary user defined network, their IPs
+// belong to the subnet of the node the VM was first scheduled on and follow
+// the VM to other nodes.
+func (bnc *BaseNetworkController) isLayer3LiveMigratablePod(pod *corev1.Pod) bool {
+	if !bnc.IsUserDefinedNetwork() {
+		return kubevirt.IsPodLiveMigratable(pod)
+	}
+	return bnc.TopologyType() == ovntypes.Layer3Topology && kubevirt.IsPodAllowedForMigration(pod, bnc.GetNetInfo())
+}
+
 // ensurePodAnnotation will copy pod annotations at migratable pods related
 // to the same virtual machine, for normal pods it will unmarshal and return
 // it, also there returned boolean will be true if the pod subnet belong to
@@ -965,6 +976,25 @@ func (bnc *BaseNetworkController) allocatePodAnnotationForUserDefinedNetwork(pod
 		return podAnnotation, false, nil
 	}
 
+	// live migrated VMs keep their IPs, these belong to the subnet of the node
+	// the VM was first scheduled on so reserve them there if it is local
+	if bnc.isLayer3LiveMigratablePod(pod) {
+		podAnnotation, zoneContainsPodSubnet, err := bnc.ensurePodAnnotation(pod, nadName)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to ensure pod annotation: %w", err)
+		}
+		if podAnnotation != nil {
+			if zoneContainsPodSubnet {
+				subnetSwitchName, _ := kubevirt.ZoneContainsPodSubnet(bnc.lsManager, podAnnotation.IPs)
+				if err := bnc.lsManager.AllocateIPs(subnetSwitchName, podAnnotation.IPs); err != nil && !errors.Is(err, ipallocator.ErrAllocated) {
+					return nil, false, fmt.Errorf("unable to ensure IPs allocated for already annotated pod %s/%s/%s, IPs: %s, error: %w",
+						nadName, pod.Namespace, pod.Name, util.JoinIPNetIPs(podAnnotation.IPs, " "), err)
+				}
+			}
+			return podAnnotation, false, nil
+		}
+	}
+
 	if network == nil {
 		network = &nadapi.NetworkSelectionElement{}
 	}
@@ -1040,7 +1070,7 @@ func (bnc *BaseNetworkController) allocatesPodAnnotation() bool {
 
 func (bnc *BaseNetworkController) shouldReleaseDeletedPod(pod *corev1.Pod, switchName, nad string, podIfAddrs []*net.IPNet) (bool, error) {
 	var err error
-	if !bnc.IsUserDefinedNetwork() && kubevirt.IsPodLiveMigratable(pod) {
+	if bnc.isLayer3LiveMigratablePod(pod) {
 		allVMPodsAreCompleted, err := kubevirt.AllVMPodsAreCompleted(bnc.watchFactory.PodCoreInformer().Lister(), pod)
 		if err != nil {
 			return false, err
@@ -1074,7 +1104,7 @@ func (bnc *BaseNetworkController) shouldReleaseDeletedPod(pod *corev1.Pod, switc
 	// will force a lookup for all nodes.
 	zoneOwnsSubnet := true
 	nodeName := pod.Spec.NodeName
-	if !bnc.IsUserDefinedNetwork() && kubevirt.IsPodLiveMigratable(pod) {
+	if bnc.isLayer3LiveMigratablePod(pod) {
 		switchName, zoneOwnsSubnet = bnc.lsManager.GetSubnetName(podIfAddrs)
 	}
 	if !zoneOwnsSubnet {
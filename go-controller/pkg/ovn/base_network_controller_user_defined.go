@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
@@ -359,8 +360,9 @@ func (bsnc *BaseUserDefinedNetworkController) addLogicalPortToNetworkForNAD(pod
 
 	if shouldHandleLiveMigration &&
 		kubevirtLiveMigrationStatus.IsTargetDomainReady() &&
-		// At localnet there is no source pod remote LSP so it should be skipped
-		(bsnc.TopologyType() != types.LocalnetTopology || bsnc.isPodScheduledinLocalZone(kubevirtLiveMigrationStatus.SourcePod)) {
+		// Only layer2 has remote LSPs, at localnet and layer3 there is no source
+		// pod remote LSP so it should be skipped
+		(bsnc.TopologyType() == types.Layer2Topology || bsnc.isPodScheduledinLocalZone(kubevirtLiveMigrationStatus.SourcePod)) {
 		ops, err = bsnc.disableLiveMigrationSourceLSPOps(kubevirtLiveMigrationStatus, nadName, ops)
 		if err != nil {
 			return fmt.Errorf("failed to create LSP ops for source pod during Live-migration status: %w", err)
@@ -496,6 +498,17 @@ func (bsnc *BaseUserDefinedNetworkController) removePodForUserDefinedNetwork(pod
 			continue
 		}
 
+		// live migrated VMs IPs belong to the subnet of the node the VM was
+		// first scheduled on, which might not be served by this zone
+		if bsnc.isLayer3LiveMigratablePod(pod) {
+			switchName, zoneContainsPodSubnet := kubevirt.ZoneContainsPodSubnet(bsnc.lsManager, pInfo.ips)
+			if !zoneContainsPodSubnet {
+				bsnc.forgetPodReleasedBeforeStartup(string(pod.UID), nadName)
+				continue
+			}
+			pInfo.logicalSwitch = switchName
+		}
+
 		// if we allow for persistent IPs, then we need to check if this pod has an IPAM Claim
 		if bsnc.allowPersistentIPs() {
 			hasIPAMClaim, err := bsnc.hasIPAMClaim(pod, nadName)
@@ -591,6 +604,9 @@ func (bsnc *BaseUserDefinedNetworkController) hasIPAMClaim(pod *corev1.Pod, nadN
 
 func (bsnc *BaseUserDefinedNetworkController) syncPodsForUserDefinedNetwork(pods []interface{}) error {
 	annotatedLocalPods := map[*corev1.Pod]map[string]*util.PodAnnotation{}
+	// keep track of the live migratable VMs of layer3 networks and whether
+	// they run on this zone, to clean up the routing of the stale ones
+	vms := map[ktypes.NamespacedName]bool{}
 	// get the list of logical switch ports (equivalent to pods). Reserve all existing Pod IPs to
 	// avoid subsequent new Pods getting the same duplicate Pod IP.
 	expectedLogicalPorts := make(map[string]bool)
@@ -638,7 +654,32 @@ func (bsnc *BaseUserDefinedNetworkController) syncPodsForUserDefinedNetwork(pods
 				continue
 			}
 
-			if bsnc.allocatesPodAnnotation() && isLocalPod {
+			if bsnc.isLayer3LiveMigratablePod(pod) {
+				// live migrated VMs IPs are reserved at the switch owning
+				// their subnet, regardless of the node they run on
+				vmKey, expectedLogicalPortName, _, err := kubevirt.AllocateSyncMigratablePodIPsOnZone(bsnc.watchFactory, bsnc.lsManager, nadName, pod, bsnc.allocatePodIPsOnSwitch)
+				if err != nil {
+					return err
+				}
+				// If there is a vmKey this VM is not stale so it should be in sync
+				if vmKey == nil {
+					continue
+				}
+				vms[*vmKey] = isLocalPod
+				if !isLocalPod {
+					continue
+				}
+				// the IPs might come from a subnet of another zone, still
+				// the pod logical port is local
+				if expectedLogicalPortName == "" {
+					expectedLogicalPortName = bsnc.GetLogicalPortName(pod, nadName)
+				}
+				expectedLogicalPorts[expectedLogicalPortName] = true
+				if annotatedLocalPods[pod] == nil {
+					annotatedLocalPods[pod] = map[string]*util.PodAnnotation{}
+				}
+				annotatedLocalPods[pod][nadName] = annotations
+			} else if bsnc.allocatesPodAnnotation() && isLocalPod {
 				// only keep track of IPs/ports that have been allocated by this
 				// controller
 				expectedLogicalPortName, err := bsnc.allocatePodIPs(pod, annotations, nadName)
@@ -664,6 +705,12 @@ func (bsnc *BaseUserDefinedNetworkController) syncPodsForUserDefinedNetwork(pods
 	// keep track of which pods might have already been released
 	bsnc.trackPodsReleasedBeforeStartup(annotatedLocalPods)
 
+	if bsnc.TopologyType() == types.Layer3Topology && bsnc.IsPrimaryNetwork() {
+		if err := kubevirt.SyncVirtualMachines(bsnc.nbClient, bsnc.GetNetInfo(), vms); err != nil {
+			return fmt.Errorf("failed syncing virtual machines on network %s: %w", bsnc.GetNetworkName(), err)
+		}
+	}
+
 	return bsnc.deleteStaleLogicalSwitchPorts(expectedLogicalPorts)
 }
 
@@ -934,10 +981,20 @@ func getClusterNodesDestinationBasedSNATMatch(ipFamily utilnet.IPFamily, address
 
 func (bsnc *BaseUserDefinedNetworkController) requireDHCP(pod *corev1.Pod) bool {
 	// Configure DHCP only for kubevirt VMs layer2 primary udn with subnets
-	return kubevirt.IsPodOwnedByVirtualMachine(pod) &&
-		util.IsNetworkSegmentationSupportEnabled() &&
-		bsnc.IsPrimaryNetwork() &&
-		bsnc.TopologyType() == types.Layer2Topology
+	// and for live migratable kubevirt VMs of layer3 primary udn, since those
+	// keep their IPs while moving across node subnets
+	if !kubevirt.IsPodOwnedByVirtualMachine(pod) ||
+		!util.IsNetworkSegmentationSupportEnabled() ||
+		!bsnc.IsPrimaryNetwork() {
+		return false
+	}
+	switch bsnc.TopologyType() {
+	case types.Layer2Topology:
+		return true
+	case types.Layer3Topology:
+		return kubevirt.IsPodLiveMigratable(pod)
+	}
+	return false
 }
 
 func (bsnc *BaseUserDefinedNetworkController) setPodLogicalSwitchPortAddressesAndEnabledField(
@@ -995,6 +1052,10 @@ func (bsnc *BaseUserDefinedNetworkController) enableSourceLSPFailedLiveMigration
 		kubevirtLiveMigrationStatus.State != kubevirt.LiveMigrationFailed {
 		return nil
 	}
+	// at layer3 there is no source pod remote LSP to enable
+	if bsnc.TopologyType() == types.Layer3Topology && !bsnc.isPodScheduledinLocalZone(kubevirtLiveMigrationStatus.SourcePod) {
+		return nil
+	}
 	// make sure sourcePod lsp is enabled if migration failed after DomainReady was set.
 	ops, sourcePodLsp, err := bsnc.setPodLogicalSwitchPortAddressesAndEnabledField(kubevirtLiveMigrationStatus.SourcePod, nadName, mac, ips, true, nil)
 	if err != nil {
@@ -2,6 +2,7 @@ package ovn
 
 import (
 	"context"
+	"net"
 
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
@@ -207,6 +208,98 @@ var _ = Describe("BaseUserDefinedNetworkController", func() {
 			},
 		}),
 	)
+	It("with layer3 primary UDN should advertise the ARP proxy IP as DHCP router", func() {
+		fakeOVN := NewFakeOVN(true)
+		lsp := &nbdb.LogicalSwitchPort{
+			Name: "vm-port",
+			UUID: "vm-port-UUID",
+		}
+		fakeOVN.startWithDBSetup(
+			libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					&nbdb.LogicalSwitch{
+						UUID:  "node-switch-UUID",
+						Name:  "node-switch",
+						Ports: []string{lsp.UUID},
+					},
+					lsp,
+				},
+			},
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "worker1",
+					Annotations: map[string]string{
+						"k8s.ovn.org/network-ids": `{"bluenet": "3"}`,
+					},
+				},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "kube-system",
+					Name:      "kube-dns",
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIPs: []string{"10.96.0.100"},
+				},
+			},
+		)
+		defer fakeOVN.shutdown()
+
+		Expect(fakeOVN.NewUserDefinedNetworkController(nad)).To(Succeed())
+		controller, ok := fakeOVN.userDefinedNetworkControllers["bluenet"]
+		Expect(ok).To(BeTrue())
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "dummy",
+				Labels: map[string]string{
+					kubevirtv1.VirtualMachineNameLabel: "vm1",
+				},
+			},
+		}
+		ips, err := util.ParseIPNets([]string{"100.128.1.4/24"})
+		Expect(err).ToNot(HaveOccurred())
+		gateways, err := util.ParseIPNets([]string{"100.128.1.1/24"})
+		Expect(err).ToNot(HaveOccurred())
+		podAnnotation := &util.PodAnnotation{
+			IPs:      ips,
+			Gateways: []net.IP{gateways[0].IP},
+		}
+		Expect(controller.bnc.ensureDHCP(pod, podAnnotation, lsp)).To(Succeed())
+
+		expectedDHCPv4Options := &nbdb.DHCPOptions{
+			UUID: "vm1-dhcpv4-UUID",
+			Cidr: "100.128.1.0/24",
+			ExternalIDs: map[string]string{
+				"k8s.ovn.org/cidr":             "100.128.1.0/24",
+				"k8s.ovn.org/id":               "bluenet-network-controller:VirtualMachine:foo/vm1:100.128.1.0/24",
+				"k8s.ovn.org/zone":             "local",
+				"k8s.ovn.org/owner-controller": "bluenet-network-controller",
+				"k8s.ovn.org/owner-type":       "VirtualMachine",
+				"k8s.ovn.org/name":             "foo/vm1",
+			},
+			Options: map[string]string{
+				"lease_time":             "3500",
+				"server_mac":             "0a:58:a9:fe:01:01",
+				"hostname":               "\"vm1\"",
+				"mtu":                    "1300",
+				"dns_server":             "10.96.0.100",
+				"server_id":              "169.254.1.1",
+				"router":                 "169.254.1.1",
+				"classless_static_route": "{169.254.1.1/32,0.0.0.0,0.0.0.0/0,169.254.1.1}",
+			},
+		}
+		expectedLSP := lsp.DeepCopy()
+		expectedLSP.Dhcpv4Options = &expectedDHCPv4Options.UUID
+		// Refresh logical switch to have the proper ports uuid
+		obtainedLogicalSwitches := []*nbdb.LogicalSwitch{}
+		Expect(fakeOVN.nbClient.List(context.Background(), &obtainedLogicalSwitches)).To(Succeed())
+		Expect(fakeOVN.nbClient).To(libovsdbtest.HaveData(
+			obtainedLogicalSwitches[0],
+			expectedLSP,
+			expectedDHCPv4Options,
+		))
+	})
 	It("should not fail to sync pods if namespace is gone", func() {
 		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
 		config.OVNKubernetesFeature.EnableMultiNetwork = true
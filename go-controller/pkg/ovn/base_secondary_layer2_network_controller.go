@@ -184,6 +184,14 @@ func (oc *BaseLayer2UserDefinedNetworkController) initializeLogicalSwitch(switch
 		}
 	}
 
+	if len(oc.AllowedVLANs()) > 0 {
+		// let the traffic tagged by the pods of a network in trunk mode through
+		if logicalSwitch.OtherConfig == nil {
+			logicalSwitch.OtherConfig = map[string]string{}
+		}
+		logicalSwitch.OtherConfig["vlan-passthru"] = "true"
+	}
+
 	if oc.isLayer2Interconnect() {
 		tunnelKey := zoneinterconnect.BaseTransitSwitchTunnelKey + oc.GetNetworkID()
 		if config.Layer2UsesTransitRouter && oc.IsPrimaryNetwork() {
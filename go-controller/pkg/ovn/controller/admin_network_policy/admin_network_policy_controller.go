@@ -315,6 +315,31 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
 	wg.Wait()
 }
 
+// SyncOnce reconciles every admin network policy and baseline admin network policy present in the informer
+// caches once and synchronously, without starting any worker. Further events are not processed, so it is only
+// meant for translating a static snapshot of the cluster, e.g. when simulating policies offline.
+func (c *Controller) SyncOnce() error {
+	anps, err := c.anpLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("unable to list ANPs from the lister, err: %v", err)
+	}
+	for _, anp := range anps {
+		if err := c.syncAdminNetworkPolicy(anp.Name); err != nil {
+			return fmt.Errorf("failed to sync admin network policy %s: %w", anp.Name, err)
+		}
+	}
+	banps, err := c.banpLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("unable to list BANPs from the lister, err: %v", err)
+	}
+	for _, banp := range banps {
+		if err := c.syncBaselineAdminNetworkPolicy(banp.Name); err != nil {
+			return fmt.Errorf("failed to sync baseline admin network policy %s: %w", banp.Name, err)
+		}
+	}
+	return nil
+}
+
 // worker runs a worker thread that just dequeues items, processes them, and
 // marks them done. You may run as many of these in parallel as you wish; the
 // workqueue guarantees that they will not end up processing the same object
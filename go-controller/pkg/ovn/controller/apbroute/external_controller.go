@@ -9,6 +9,7 @@ import (
 	"time"
 
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,12 +25,15 @@ import (
 	"k8s.io/klog/v2"
 	v1pod "k8s.io/kubernetes/pkg/api/v1/pod"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedrouteinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/informers/externalversions/adminpolicybasedroute/v1"
 	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/apbroute/gateway_info"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
 const (
@@ -225,6 +229,17 @@ type externalPolicyManager struct {
 	namespaceLister   corev1listers.NamespaceLister
 	namespaceInformer cache.SharedIndexInformer
 
+	// networkManager provides the active network of the target namespaces, nil when the networks are not known to
+	// the controller, like on the nodes.
+	networkManager networkmanager.Interface
+	// nadLister is used to match the networks of the target namespaces against the network selectors of the
+	// policies, nil when multi-network is disabled.
+	nadLister nadlisters.NetworkAttachmentDefinitionLister
+	// nadReconciler is notified by the network manager of the NAD changes, that may change the active network of
+	// their namespace, nil when networkManager is nil.
+	nadReconciler   networkmanager.NADReconciler
+	nadReconcilerID uint64
+
 	updatePolicyStatusFunc func(policyName string, gwIPs sets.Set[string], processedError error) error
 }
 
@@ -232,6 +247,9 @@ type policyReferencedObjects struct {
 	targetNamespaces    sets.Set[string]
 	dynamicGWNamespaces sets.Set[string]
 	dynamicGWPods       sets.Set[ktypes.NamespacedName]
+	// bfdGatewayTimers are the BFD timers of the policy's BFD-enabled gateways. A BFD session is shared
+	// by all the policies using the same gateway, so the gateways can't be configured with different timers.
+	bfdGatewayTimers map[string]gateway_info.BFDTimers
 }
 
 func newExternalPolicyManager(
@@ -239,6 +257,8 @@ func newExternalPolicyManager(
 	podInformer coreinformers.PodInformer,
 	namespaceInformer coreinformers.NamespaceInformer,
 	apbRouteInformer adminpolicybasedrouteinformer.AdminPolicyBasedExternalRouteInformer,
+	networkManager networkmanager.Interface,
+	nadLister nadlisters.NetworkAttachmentDefinitionLister,
 	netClient networkClient,
 	updatePolicyStatusFunc func(policyName string, gwIPs sets.Set[string], processedError error) error) *externalPolicyManager {
 
@@ -267,8 +287,21 @@ func newExternalPolicyManager(
 			workqueue.NewTypedItemFastSlowRateLimiter[*corev1.Namespace](time.Second, 5*time.Second, 5),
 			workqueue.TypedRateLimitingQueueConfig[*corev1.Namespace]{Name: "apbexternalroutenamespaces"},
 		),
+		networkManager:         networkManager,
+		nadLister:              nadLister,
 		updatePolicyStatusFunc: updatePolicyStatusFunc,
 	}
+	if networkManager != nil {
+		m.nadReconciler = controller.NewReconciler(
+			"apbexternalroutenads",
+			&controller.ReconcilerConfig{
+				RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+				Reconcile:   m.syncNAD,
+				Threadiness: 1,
+				MaxAttempts: controller.InfiniteAttempts,
+			},
+		)
+	}
 
 	return &m
 }
@@ -305,6 +338,15 @@ func (m *externalPolicyManager) Run(wg *sync.WaitGroup, threadiness int) error {
 	if err != nil {
 		return err
 	}
+	if m.nadReconciler != nil {
+		m.nadReconcilerID, err = m.networkManager.RegisterNADReconciler(m.nadReconciler)
+		if err != nil {
+			return err
+		}
+		if err = controller.Start(m.nadReconciler); err != nil {
+			return err
+		}
+	}
 
 	for i := 0; i < threadiness; i++ {
 		for _, workerFn := range []func(*sync.WaitGroup){
@@ -332,6 +374,12 @@ func (m *externalPolicyManager) Run(wg *sync.WaitGroup, threadiness int) error {
 		// wait until we're told to stop
 		<-m.stopCh
 
+		if m.nadReconciler != nil {
+			if err := m.networkManager.DeRegisterNADReconciler(m.nadReconcilerID); err != nil {
+				klog.Warningf("Failed to deregister the NAD reconciler of the Admin Policy Based Route Controller: %v", err)
+			}
+			controller.Stop(m.nadReconciler)
+		}
 		m.podQueue.ShutDown()
 		m.routeQueue.ShutDown()
 		m.namespaceQueue.ShutDown()
@@ -566,12 +614,13 @@ func (m *externalPolicyManager) onPodUpdate(oldObj, newObj interface{}) {
 		utilruntime.HandleError(errors.New("invalid Pod provided to onPodUpdate()"))
 		return
 	}
-	// if labels AND assigned Pod IPs AND the multus network status annotations AND
+	// if labels AND assigned Pod IPs AND the multus network status annotations AND the OVN pod networks annotation AND
 	// pod PodReady condition AND deletion timestamp (PodTerminating) are
 	// the same, skip processing changes to the pod.
 	if reflect.DeepEqual(o.Labels, n.Labels) &&
 		reflect.DeepEqual(o.Status.PodIPs, n.Status.PodIPs) &&
 		reflect.DeepEqual(o.Annotations[nettypes.NetworkStatusAnnot], n.Annotations[nettypes.NetworkStatusAnnot]) &&
+		reflect.DeepEqual(o.Annotations[util.OvnPodAnnotationName], n.Annotations[util.OvnPodAnnotationName]) &&
 		reflect.DeepEqual(v1pod.GetPodReadyCondition(o.Status), v1pod.GetPodReadyCondition(n.Status)) &&
 		reflect.DeepEqual(o.DeletionTimestamp, n.DeletionTimestamp) {
 		return
@@ -1,12 +1,23 @@
 package apbroute
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+
+	crdtypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
+	userdefinednetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+var cudnController = userdefinednetworkv1.SchemeGroupVersion.WithKind("ClusterUserDefinedNetwork")
+
 func (m *externalPolicyManager) syncNamespace(namespace *corev1.Namespace, routeQueue workqueue.TypedRateLimitingInterface[string]) error {
 	policyKeys, err := m.getPoliciesForNamespaceChange(namespace)
 	if err != nil {
@@ -21,6 +32,26 @@ func (m *externalPolicyManager) syncNamespace(namespace *corev1.Namespace, route
 	return nil
 }
 
+// syncNAD queues the namespace of a NAD processed by the network manager, so that the policies targeting it are
+// synced against its current active network.
+func (m *externalPolicyManager) syncNAD(key string) error {
+	namespaceName, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.Errorf("Failed splitting NAD key %s: %v", key, err)
+		return nil
+	}
+	namespace, err := m.namespaceLister.Get(namespaceName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	klog.V(5).Infof("APB queuing namespace %s for NAD %s", namespaceName, key)
+	m.namespaceQueue.Add(namespace)
+	return nil
+}
+
 func (m *externalPolicyManager) listNamespacesBySelector(selector *metav1.LabelSelector) ([]*corev1.Namespace, error) {
 	s, err := metav1.LabelSelectorAsSelector(selector)
 	if err != nil {
@@ -33,3 +64,82 @@ func (m *externalPolicyManager) listNamespacesBySelector(selector *metav1.LabelS
 	return ns, nil
 
 }
+
+// isNamespaceNetworkSelected returns whether the active network of the namespace is selected by the given network
+// selectors. Only the default network is selected when no network selectors are given. Every namespace is selected
+// when the controller doesn't know about the networks.
+func (m *externalPolicyManager) isNamespaceNetworkSelected(namespace *corev1.Namespace, networkSelectors crdtypes.NetworkSelectors) (bool, error) {
+	if m.networkManager == nil {
+		return true, nil
+	}
+	netInfo, err := m.networkManager.GetActiveNetworkForNamespace(namespace.Name)
+	if err != nil {
+		// the primary network of the namespace may not exist or be processed yet, the namespace is synced again
+		// once its NAD is processed
+		klog.Warningf("Failed to get the active network of namespace %s, considering it not selected: %v", namespace.Name, err)
+		return false, nil
+	}
+	if len(networkSelectors) == 0 {
+		return netInfo.IsDefault(), nil
+	}
+	for _, networkSelector := range networkSelectors {
+		switch networkSelector.NetworkSelectionType {
+		case crdtypes.DefaultNetwork:
+			if netInfo.IsDefault() {
+				return true, nil
+			}
+		case crdtypes.PrimaryUserDefinedNetworks:
+			if networkSelector.PrimaryUserDefinedNetworkSelector == nil {
+				return false, fmt.Errorf("empty primary user defined network selector")
+			}
+			if netInfo.IsDefault() {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(&networkSelector.PrimaryUserDefinedNetworkSelector.NamespaceSelector)
+			if err != nil {
+				return false, err
+			}
+			if selector.Matches(labels.Set(namespace.Labels)) {
+				return true, nil
+			}
+		case crdtypes.ClusterUserDefinedNetworks:
+			if networkSelector.ClusterUserDefinedNetworkSelector == nil {
+				return false, fmt.Errorf("empty cluster user defined network selector")
+			}
+			if netInfo.IsDefault() || m.nadLister == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(&networkSelector.ClusterUserDefinedNetworkSelector.NetworkSelector)
+			if err != nil {
+				return false, err
+			}
+			nadNames, err := util.GetPrimaryNetworkNADNamesForNamespaceFromNetInfo(namespace.Name, netInfo)
+			if err != nil {
+				return false, err
+			}
+			for _, nadName := range nadNames {
+				nadNamespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+				if err != nil {
+					return false, err
+				}
+				nad, err := m.nadLister.NetworkAttachmentDefinitions(nadNamespace).Get(name)
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				if err != nil {
+					return false, fmt.Errorf("failed to get the primary network attachment definition %s of namespace %s: %w",
+						nadName, namespace.Name, err)
+				}
+				// check this NAD is controlled by a CUDN
+				controller := metav1.GetControllerOfNoCopy(nad)
+				isCUDN := controller != nil && controller.Kind == cudnController.Kind && controller.APIVersion == cudnController.GroupVersion().String()
+				if isCUDN && selector.Matches(labels.Set(nad.Labels)) {
+					return true, nil
+				}
+			}
+		default:
+			return false, fmt.Errorf("unsupported network selection type %s", networkSelector.NetworkSelectionType)
+		}
+	}
+	return false, nil
+}
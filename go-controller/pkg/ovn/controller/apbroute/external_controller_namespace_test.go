@@ -30,6 +30,7 @@ const (
 	dynamicHopHostNetPodIP = "192.168.1.1"
 	staticHopGWIP          = "10.10.10.1"
 	networkAttachementName = "foo"
+	udnNetworkName         = "tenant-blue"
 	network_status         = `[{"name":"foo","interface":"net1","ips":["%s"],"mac":"01:23:45:67:89:10"}]`
 )
 
@@ -144,6 +145,7 @@ func expectedPolicyStateAndRefs(targetNamespaces []*namespaceWithPods, staticGWI
 		targetNamespaces:    targetNamespaceNames,
 		dynamicGWNamespaces: dynamicGWNamespaces,
 		dynamicGWPods:       dynamicGWPods,
+		bfdGatewayTimers:    getBFDGatewayTimers(staticGWs, dynamicGWs),
 	}
 }
 
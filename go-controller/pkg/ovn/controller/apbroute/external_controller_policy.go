@@ -321,6 +321,23 @@ func (m *externalPolicyManager) calculateAnnotatedPodGatewayIPsForNamespace(targ
 	return gwIPs, nil
 }
 
+// newHopGatewayInfo builds the GatewayInfo of a static or dynamic hop, including its BFD timers.
+func newHopGatewayInfo(gws sets.Set[string], bfdEnabled bool, bfd *adminpolicybasedrouteapi.BFDConfig) *gateway_info.GatewayInfo {
+	gwInfo := gateway_info.NewGatewayInfo(gws, bfdEnabled)
+	if bfdEnabled && bfd != nil {
+		if bfd.MinTx != nil {
+			gwInfo.BFDTimers.MinTx = int(*bfd.MinTx)
+		}
+		if bfd.MinRx != nil {
+			gwInfo.BFDTimers.MinRx = int(*bfd.MinRx)
+		}
+		if bfd.DetectMult != nil {
+			gwInfo.BFDTimers.DetectMult = int(*bfd.DetectMult)
+		}
+	}
+	return gwInfo
+}
+
 func (m *externalPolicyManager) processStaticHopsGatewayInformation(hops []*adminpolicybasedrouteapi.StaticHop) (*gateway_info.GatewayInfoList, error) {
 	gwList := gateway_info.NewGatewayInfoList()
 
@@ -330,7 +347,7 @@ func (m *externalPolicyManager) processStaticHopsGatewayInformation(hops []*admi
 		if ip == nil {
 			return nil, fmt.Errorf("could not parse routing static gw annotation value '%s'", h.IP)
 		}
-		gwList.InsertOverwrite(gateway_info.NewGatewayInfo(sets.New(ip.String()), h.BFDEnabled))
+		gwList.InsertOverwrite(newHopGatewayInfo(sets.New(ip.String()), h.BFDEnabled, h.BFD))
 	}
 	return gwList, nil
 }
@@ -370,7 +387,7 @@ func (m *externalPolicyManager) processDynamicHopsGatewayInformation(hops []*adm
 					continue
 				}
 				key := ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
-				podsInfo.InsertOverwrite(gateway_info.NewGatewayInfo(foundGws, h.BFDEnabled))
+				podsInfo.InsertOverwrite(newHopGatewayInfo(foundGws, h.BFDEnabled, h.BFD))
 				selectedPods.Insert(key)
 			}
 			selectedNamespaces.Insert(gwNamespace.Name)
@@ -412,6 +429,14 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 	targetNsNames := sets.Set[string]{}
 	targetNamespaces := map[string]map[ktypes.NamespacedName]*corev1.Pod{}
 	for _, ns := range targetNs {
+		selected, err := m.isNamespaceNetworkSelected(ns, policy.Spec.NetworkSelectors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match the network of namespace %s: %w", ns.Name, err)
+		}
+		if !selected {
+			klog.V(5).Infof("Skipping target namespace %s of policy %s: its network is not selected", ns.Name, policy.Name)
+			continue
+		}
 		targetNsNames.Insert(ns.Name)
 		targetPods, err := m.podLister.Pods(ns.Name).List(labels.Everything())
 		if err != nil {
@@ -427,6 +452,7 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 		}
 		targetNamespaces[ns.Name] = podsMap
 	}
+	bfdGatewayTimers := getBFDGatewayTimers(staticGWInfo, dynamicGWInfo)
 	for policyName, refObjs := range m.policyReferencedObjects {
 		if policyName == policy.Name {
 			continue
@@ -436,6 +462,12 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 				policy.Name, refObjs.targetNamespaces.Intersection(targetNsNames).UnsortedList(), policyName)
 
 		}
+		for gw, timers := range bfdGatewayTimers {
+			if otherTimers, ok := refObjs.bfdGatewayTimers[gw]; ok && otherTimers != timers {
+				return nil, fmt.Errorf("failed to update policy %s: BFD timers %+v of next hop %s conflict with the timers %+v set by another policy: %s",
+					policy.Name, timers, gw, otherTimers, policyName)
+			}
+		}
 	}
 
 	if updateRefs {
@@ -443,6 +475,7 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 			targetNamespaces:    targetNsNames,
 			dynamicGWNamespaces: gwNamespaces,
 			dynamicGWPods:       gwPods,
+			bfdGatewayTimers:    bfdGatewayTimers,
 		}
 		m.policyReferencedObjects[policy.Name] = refObjs
 	}
@@ -455,6 +488,22 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 	}, nil
 }
 
+// getBFDGatewayTimers returns the BFD timers of every BFD-enabled gateway of the given lists.
+func getBFDGatewayTimers(gwInfoLists ...*gateway_info.GatewayInfoList) map[string]gateway_info.BFDTimers {
+	bfdGatewayTimers := map[string]gateway_info.BFDTimers{}
+	for _, gwInfoList := range gwInfoLists {
+		for _, gwInfo := range gwInfoList.Elems() {
+			if !gwInfo.BFDEnabled {
+				continue
+			}
+			for gw := range gwInfo.Gateways {
+				bfdGatewayTimers[gw] = gwInfo.BFDTimers
+			}
+		}
+	}
+	return bfdGatewayTimers
+}
+
 func (m *externalPolicyManager) deletePolicyRefObjects(policyName string) {
 	m.policyReferencedObjectsLock.Lock()
 	defer m.policyReferencedObjectsLock.Unlock()
@@ -3,9 +3,11 @@ package apbroute
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -14,15 +16,20 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
+	crdtypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -80,6 +87,8 @@ var (
 	fakeClient         *fake.Clientset
 	mgr                *externalPolicyManager
 	err                error
+	// testNetworkManager provides the active networks of the namespaces, the default network when nil
+	testNetworkManager networkmanager.Interface
 
 	node = &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -146,6 +155,10 @@ func initController(k8sObjects, routePolicyObjects []runtime.Object) {
 	// this package tests apbRoute controller separately from the legacy functionality, therefore
 	// it is not necessary to pass DefaultNetworkController name
 	controllerName := "test-controller"
+	networkManager := testNetworkManager
+	if networkManager == nil {
+		networkManager = networkmanager.Default().Interface()
+	}
 	externalController, err = NewExternalMasterController(
 		fakeRouteClient,
 		stopChan,
@@ -153,6 +166,8 @@ func initController(k8sObjects, routePolicyObjects []runtime.Object) {
 		iFactory.NamespaceInformer(),
 		iFactory.APBRouteInformer(),
 		iFactory.NodeCoreInformer().Lister(),
+		nil,
+		networkManager,
 		nbClient,
 		addressset.NewFakeAddressSetFactory(controllerName),
 		controllerName,
@@ -270,6 +285,7 @@ var _ = Describe("OVN External Gateway policy", func() {
 	AfterEach(func() {
 		shutdownController()
 		nbsbCleanup.Cleanup()
+		testNetworkManager = nil
 	})
 
 	BeforeEach(func() {
@@ -286,6 +302,11 @@ var _ = Describe("OVN External Gateway policy", func() {
 					UUID: "GR_node-UUID",
 					Name: "GR_node",
 				},
+				&nbdb.LogicalRouter{
+					UUID:        "GR_tenant.blue_node-UUID",
+					Name:        "GR_tenant.blue_node",
+					ExternalIDs: map[string]string{types.NetworkExternalID: udnNetworkName},
+				},
 			},
 		}
 		nbClient, _, nbsbCleanup, err = libovsdbtest.NewNBSBTestHarness(initialDB)
@@ -465,6 +486,43 @@ var _ = Describe("OVN External Gateway policy", func() {
 			eventuallyExpectNumberOfPolicies(1)
 			eventuallyExpectConfig(policyName1, expectedPolicy1, expectedRefs1)
 		})
+
+		It("rejects a policy setting different BFD timers for a next hop of another policy", func() {
+			bfdPolicy := newPolicy(
+				"bfd",
+				&metav1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				true,
+			)
+			initController([]runtime.Object{namespaceGW, namespaceTarget, targetPod1, namespaceTarget2, targetPod2},
+				[]runtime.Object{bfdPolicy})
+
+			expectedPolicy, expectedRefs := expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod},
+				[]string{staticHopGWIP},
+				nil, true)
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(bfdPolicy.Name, expectedPolicy, expectedRefs)
+
+			// tunedBFDPolicy uses the same next hop for another namespace with tuned BFD timers
+			tunedBFDPolicy := newPolicy(
+				"tunedbfd",
+				&metav1.LabelSelector{MatchLabels: targetNamespace2Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				true,
+			)
+			tunedBFDPolicy.Spec.NextHops.StaticHops[0].BFD = &adminpolicybasedrouteapi.BFDConfig{MinTx: ptr.To[int32](100)}
+			_, err = fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Create(context.TODO(), tunedBFDPolicy, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			eventuallyCheckAPBRouteStatus(tunedBFDPolicy.Name, true)
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(bfdPolicy.Name, expectedPolicy, expectedRefs)
+		})
 	})
 
 	var _ = Context("when deleting a policy", func() {
@@ -812,8 +870,196 @@ var _ = Describe("OVN External Gateway policy", func() {
 			eventuallyExpectConfig(policyName, expectedPolicy, expectedRefs)
 		})
 	})
+
+	var _ = Context("when the target namespaces are on user defined networks", func() {
+
+		var (
+			multipleMatchPolicy *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute
+			udnTargetPod2       *corev1.Pod
+		)
+
+		BeforeEach(func() {
+			netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: udnNetworkName},
+				Topology: types.Layer3Topology,
+				Subnets:  "10.128.0.0/16/24",
+				Role:     types.NetworkRolePrimary,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			mutableNetInfo := util.NewMutableNetInfo(netInfo)
+			mutableNetInfo.SetNADs(util.GetNADName(namespaceTarget2.Name, udnNetworkName))
+			testNetworkManager = &networkmanager.FakeNetworkManager{
+				PrimaryNetworks: map[string]util.NetInfo{namespaceTarget2.Name: mutableNetInfo},
+			}
+
+			udnTargetPod2 = targetPod2.DeepCopy()
+			udnTargetPod2.Annotations[util.OvnPodAnnotationName] = fmt.Sprintf(
+				`{"%s/%s":{"ip_addresses":["10.128.1.3/24"],"mac_address":"0a:58:0a:80:01:03","role":"primary"}}`,
+				namespaceTarget2.Name, udnNetworkName)
+			namespaceTarget2WithPod = newNamespaceWithPods(namespaceTarget2.Name, udnTargetPod2)
+
+			multipleMatchPolicy = newPolicy(
+				"multiple",
+				&metav1.LabelSelector{MatchLabels: targetMultipleNamespaceMatch},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				false,
+			)
+		})
+
+		It("only targets the namespaces on the default network when the policy has no network selectors", func() {
+			initController([]runtime.Object{namespaceTarget, targetPod1, namespaceTarget2, udnTargetPod2},
+				[]runtime.Object{multipleMatchPolicy})
+
+			expectedPolicy, expectedRefs := expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod},
+				[]string{staticHopGWIP},
+				nil, false)
+
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(multipleMatchPolicy.Name, expectedPolicy, expectedRefs)
+			eventuallyExpectRoutes("GR_node", "192.169.10.1/32 via 10.10.10.1 on rtoe-GR_node")
+			eventuallyExpectRoutes("GR_tenant.blue_node")
+		})
+
+		It("programs the routes on the gateway router of the network of each selected namespace", func() {
+			multipleMatchPolicy.Spec.NetworkSelectors = crdtypes.NetworkSelectors{
+				{NetworkSelectionType: crdtypes.DefaultNetwork},
+				{
+					NetworkSelectionType: crdtypes.PrimaryUserDefinedNetworks,
+					PrimaryUserDefinedNetworkSelector: &crdtypes.PrimaryUserDefinedNetworkSelector{
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: targetNamespace2Match},
+					},
+				},
+			}
+			initController([]runtime.Object{namespaceTarget, targetPod1, namespaceTarget2, udnTargetPod2},
+				[]runtime.Object{multipleMatchPolicy})
+
+			expectedPolicy, expectedRefs := expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod, namespaceTarget2WithPod},
+				[]string{staticHopGWIP},
+				nil, false)
+
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(multipleMatchPolicy.Name, expectedPolicy, expectedRefs)
+			eventuallyExpectRoutes("GR_node", "192.169.10.1/32 via 10.10.10.1 on rtoe-GR_node")
+			eventuallyExpectRoutes("GR_tenant.blue_node", "10.128.1.3/32 via 10.10.10.1 on rtoe-GR_tenant.blue_node")
+
+			deletePolicy(multipleMatchPolicy.Name, fakeRouteClient)
+			eventuallyExpectNumberOfPolicies(0)
+			eventuallyExpectRoutes("GR_node")
+			eventuallyExpectRoutes("GR_tenant.blue_node")
+		})
+
+		It("skips the namespaces without a valid primary network until their NAD is processed", func() {
+			fakeNetworkManager := testNetworkManager.(*networkmanager.FakeNetworkManager)
+			udnNetInfo := fakeNetworkManager.PrimaryNetworks[namespaceTarget2.Name]
+			// a namespace requiring a primary UDN that doesn't exist yet
+			fakeNetworkManager.PrimaryNetworks[namespaceTarget2.Name] = nil
+			multipleMatchPolicy.Spec.NetworkSelectors = crdtypes.NetworkSelectors{
+				{NetworkSelectionType: crdtypes.DefaultNetwork},
+				{
+					NetworkSelectionType: crdtypes.PrimaryUserDefinedNetworks,
+					PrimaryUserDefinedNetworkSelector: &crdtypes.PrimaryUserDefinedNetworkSelector{
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: targetNamespace2Match},
+					},
+				},
+			}
+			initController([]runtime.Object{namespaceTarget, targetPod1, namespaceTarget2, udnTargetPod2},
+				[]runtime.Object{multipleMatchPolicy})
+
+			expectedPolicy, expectedRefs := expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod},
+				[]string{staticHopGWIP},
+				nil, false)
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(multipleMatchPolicy.Name, expectedPolicy, expectedRefs)
+			eventuallyExpectRoutes("GR_node", "192.169.10.1/32 via 10.10.10.1 on rtoe-GR_node")
+			eventuallyExpectRoutes("GR_tenant.blue_node")
+
+			By("processing the NAD of the primary UDN of the namespace")
+			fakeNetworkManager.Lock()
+			fakeNetworkManager.PrimaryNetworks[namespaceTarget2.Name] = udnNetInfo
+			fakeNetworkManager.Unlock()
+			fakeNetworkManager.TriggerHandlers(util.GetNADName(namespaceTarget2.Name, udnNetworkName), udnNetInfo, false)
+
+			expectedPolicy, expectedRefs = expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod, namespaceTarget2WithPod},
+				[]string{staticHopGWIP},
+				nil, false)
+			eventuallyExpectConfig(multipleMatchPolicy.Name, expectedPolicy, expectedRefs)
+			eventuallyExpectRoutes("GR_tenant.blue_node", "10.128.1.3/32 via 10.10.10.1 on rtoe-GR_tenant.blue_node")
+		})
+
+		It("keeps the SNAT of the network subnet to the node IP for the pods on a user defined network", func() {
+			config.Gateway.DisableSNATMultipleGWs = true
+			nodeIP := net.ParseIP("169.254.33.2")
+			// the SNAT of the network subnet programmed by the gateway of the user defined network
+			_, subnet, err := net.ParseCIDR("10.128.1.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			subnetSNAT := libovsdbops.BuildSNAT(&nodeIP, subnet, "", nil)
+			err = libovsdbops.CreateOrUpdateNATs(nbClient, &nbdb.LogicalRouter{Name: "GR_tenant.blue_node"}, subnetSNAT)
+			Expect(err).NotTo(HaveOccurred())
+			multipleMatchPolicy.Spec.NetworkSelectors = crdtypes.NetworkSelectors{
+				{
+					NetworkSelectionType: crdtypes.PrimaryUserDefinedNetworks,
+					PrimaryUserDefinedNetworkSelector: &crdtypes.PrimaryUserDefinedNetworkSelector{
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: targetNamespace2Match},
+					},
+				},
+			}
+			initController([]runtime.Object{namespaceTarget2, udnTargetPod2}, []runtime.Object{multipleMatchPolicy})
+
+			eventuallyExpectRoutes("GR_tenant.blue_node", "10.128.1.3/32 via 10.10.10.1 on rtoe-GR_tenant.blue_node")
+			// the external gateway sees the node IP as the source of the traffic of the pod, not the pod IP
+			Consistently(func() ([]string, error) {
+				return getSNATExternalIPs("GR_tenant.blue_node", "10.128.1.3")
+			}).Should(ConsistOf(nodeIP.String()))
+		})
+	})
 })
 
+// getSNATExternalIPs returns the external IPs of the SNATs of a gateway router applying to a pod IP
+func getSNATExternalIPs(routerName, podIP string) ([]string, error) {
+	router, err := libovsdbops.GetLogicalRouter(nbClient, &nbdb.LogicalRouter{Name: routerName})
+	if err != nil {
+		return nil, err
+	}
+	externalIPs := []string{}
+	for _, natUUID := range router.Nat {
+		nat, err := libovsdbops.GetNAT(nbClient, &nbdb.NAT{UUID: natUUID})
+		if err != nil {
+			return nil, err
+		}
+		if nat.Type != nbdb.NATTypeSNAT {
+			continue
+		}
+		// the logical IP of a per pod SNAT has no mask
+		if _, logicalNet, err := net.ParseCIDR(nat.LogicalIP); nat.LogicalIP == podIP ||
+			err == nil && logicalNet.Contains(net.ParseIP(podIP)) {
+			externalIPs = append(externalIPs, nat.ExternalIP)
+		}
+	}
+	return externalIPs, nil
+}
+
+// eventuallyExpectRoutes checks the pod IP to next hop routes programmed on a gateway router
+func eventuallyExpectRoutes(routerName string, routes ...string) {
+	Eventually(func() ([]string, error) {
+		lrsrs, err := libovsdbops.GetRouterLogicalRouterStaticRoutesWithPredicate(nbClient, &nbdb.LogicalRouter{Name: routerName},
+			func(*nbdb.LogicalRouterStaticRoute) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		found := []string{}
+		for _, lrsr := range lrsrs {
+			found = append(found, fmt.Sprintf("%s via %s on %s", lrsr.IPPrefix, lrsr.Nexthop, *lrsr.OutputPort))
+		}
+		return found, nil
+	}, 5).Should(ConsistOf(routes))
+}
+
 func eventuallyCheckAPBRouteStatus(policyName string, expectFailure bool) {
 	Eventually(func() bool {
 		pol, err := fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.TODO(), policyName, metav1.GetOptions{})
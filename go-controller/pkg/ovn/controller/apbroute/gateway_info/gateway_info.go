@@ -147,14 +147,24 @@ func (g *GatewayInfoList) Equal(g2 *GatewayInfoList) bool {
 	return true
 }
 
+// BFDTimers holds the timers of the BFD sessions with the gateways. Timers set to 0 use the OVN defaults.
+type BFDTimers struct {
+	// MinTx and MinRx are in milliseconds
+	MinTx      int
+	MinRx      int
+	DetectMult int
+}
+
 type GatewayInfo struct {
 	Gateways      sets.Set[string]
 	BFDEnabled    bool
+	BFDTimers     BFDTimers
 	failedToApply bool
 }
 
 func (g *GatewayInfo) String() string {
-	return fmt.Sprintf("BFDEnabled: %t, Gateways: %+v, failedToApply: %t", g.BFDEnabled, g.Gateways, g.failedToApply)
+	return fmt.Sprintf("BFDEnabled: %t, BFDTimers: %+v, Gateways: %+v, failedToApply: %t",
+		g.BFDEnabled, g.BFDTimers, g.Gateways, g.failedToApply)
 }
 
 func NewGatewayInfo(items sets.Set[string], bfdEnabled bool) *GatewayInfo {
@@ -163,7 +173,7 @@ func NewGatewayInfo(items sets.Set[string], bfdEnabled bool) *GatewayInfo {
 
 // SameSpec compares GatewayInfo fields, excluding applied
 func (g *GatewayInfo) SameSpec(g2 *GatewayInfo) bool {
-	return g.BFDEnabled == g2.BFDEnabled && g.Gateways.Equal(g2.Gateways)
+	return g.BFDEnabled == g2.BFDEnabled && g.BFDTimers == g2.BFDTimers && g.Gateways.Equal(g2.Gateways)
 }
 
 func (g *GatewayInfo) RemoveIPs(g2 *GatewayInfo) {
@@ -172,7 +182,7 @@ func (g *GatewayInfo) RemoveIPs(g2 *GatewayInfo) {
 
 // Equal compares all GatewayInfo fields, including BFDEnabled and applied
 func (g *GatewayInfo) Equal(g2 *GatewayInfo) bool {
-	return g.BFDEnabled == g2.BFDEnabled && g.Gateways.Equal(g2.Gateways) && g.failedToApply == g2.failedToApply
+	return g.SameSpec(g2) && g.failedToApply == g2.failedToApply
 }
 
 func (g *GatewayInfo) Has(ip string) bool {
@@ -26,6 +26,15 @@ var _ = Describe("GatewayInfoList", func() {
 				NewGatewayInfo(sets.New("1.1.1.1"), true)))).To(BeTrue())
 		})
 
+		It("InsertOverwrite replaces an element when the BFD timers of the duplicated value change", func() {
+			s1 := NewGatewayInfoList(NewGatewayInfo(sets.New("1.1.1.1"), true))
+			tuned := NewGatewayInfo(sets.New("1.1.1.1"), true)
+			tuned.BFDTimers = BFDTimers{MinTx: 100, MinRx: 100, DetectMult: 5}
+			Expect(s1.Has(tuned)).To(BeFalse())
+			s1.InsertOverwrite(tuned)
+			Expect(s1.Equal(NewGatewayInfoList(tuned))).To(BeTrue())
+		})
+
 		It("InsertOverwrite adds an empty element in the slice and returns no changes in the list and no duplicates", func() {
 			s1 := NewGatewayInfoList(NewGatewayInfo(sets.New("1.1.1.1"), false))
 			s1.InsertOverwrite(NewGatewayInfo(sets.Set[string]{}, false))
@@ -7,21 +7,31 @@ import (
 	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+
+	libovsdbcache "github.com/ovn-kubernetes/libovsdb/cache"
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+	"github.com/ovn-kubernetes/libovsdb/model"
 
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedrouteapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/applyconfiguration/adminpolicybasedroute/v1"
 	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
 	adminpolicybasedrouteinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/informers/externalversions/adminpolicybasedroute/v1"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -45,6 +55,8 @@ func NewExternalMasterController(
 	namespaceInformer coreinformers.NamespaceInformer,
 	apbRouteInformer adminpolicybasedrouteinformer.AdminPolicyBasedExternalRouteInformer,
 	nodeLister corev1listers.NodeLister,
+	nadLister nadlisters.NetworkAttachmentDefinitionLister,
+	networkManager networkmanager.Interface,
 	nbClient libovsdbclient.Client,
 	addressSetFactory addressset.AddressSetFactory,
 	controllerName string,
@@ -57,14 +69,16 @@ func NewExternalMasterController(
 		return nil, err
 	}
 	nbCli := &northBoundClient{
-		routeLister:              apbRouteInformer.Lister(),
-		nodeLister:               nodeLister,
-		podLister:                podInformer.Lister(),
-		nbClient:                 nbClient,
-		addressSetFactory:        addressSetFactory,
-		controllerName:           controllerName,
-		zone:                     zone,
-		externalGatewayRouteInfo: externalGWRouteInfo,
+		routeLister:                  apbRouteInformer.Lister(),
+		nodeLister:                   nodeLister,
+		podLister:                    podInformer.Lister(),
+		nbClient:                     nbClient,
+		addressSetFactory:            addressSetFactory,
+		controllerName:               controllerName,
+		zone:                         zone,
+		externalGatewayRouteInfo:     externalGWRouteInfo,
+		udnExternalGatewayRouteInfos: syncmap.NewSyncMap[*ExternalGatewayRouteInfoCache](),
+		networkManager:               networkManager,
 	}
 
 	c := &ExternalGatewayMasterController{
@@ -78,6 +92,8 @@ func NewExternalMasterController(
 		podInformer,
 		namespaceInformer,
 		apbRouteInformer,
+		networkManager,
+		nadLister,
 		nbCli,
 		c.updateStatusAPBExternalRoute,
 	)
@@ -87,9 +103,55 @@ func NewExternalMasterController(
 func (c *ExternalGatewayMasterController) Run(wg *sync.WaitGroup, threadiness int) error {
 	klog.V(4).Info("Starting Admin Policy Based Route Controller")
 
+	// the state of the BFD sessions is reported in the status of the policies
+	c.nbClient.nbClient.Cache().AddEventHandler(&libovsdbcache.EventHandlerFuncs{
+		UpdateFunc: func(table string, old, new model.Model) {
+			if table != nbdb.BFDTable {
+				return
+			}
+			oldBFD, newBFD := old.(*nbdb.BFD), new.(*nbdb.BFD)
+			if oldBFD.Status == nil && newBFD.Status == nil ||
+				oldBFD.Status != nil && newBFD.Status != nil && *oldBFD.Status == *newBFD.Status {
+				return
+			}
+			c.queuePoliciesForBFDGateway(newBFD.DstIP)
+		},
+	})
+
 	return c.mgr.Run(wg, threadiness)
 }
 
+// queuePoliciesForBFDGateway queues the policies that may have BFD enabled for the given gateway IP, so that their
+// status reports the new state of the BFD sessions.
+func (c *ExternalGatewayMasterController) queuePoliciesForBFDGateway(gwIP string) {
+	policies, err := c.mgr.routeLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list AdminPolicyBasedExternalRoutes for BFD gateway %s: %v", gwIP, err)
+		return
+	}
+	for _, policy := range policies {
+		if policyMayHaveBFDGateway(policy, gwIP) {
+			c.mgr.routeQueue.Add(policy.Name)
+		}
+	}
+}
+
+// policyMayHaveBFDGateway returns true if the policy has a static hop with BFD enabled for the given gateway IP, or
+// a dynamic hop with BFD enabled, since the IPs of the dynamic hops are only known after the policy is synced.
+func policyMayHaveBFDGateway(policy *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute, gwIP string) bool {
+	for _, hop := range policy.Spec.NextHops.StaticHops {
+		if hop.BFDEnabled && utilnet.ParseIPSloppy(hop.IP).String() == gwIP {
+			return true
+		}
+	}
+	for _, hop := range policy.Spec.NextHops.DynamicHops {
+		if hop.BFDEnabled {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ExternalGatewayMasterController) GetAdminPolicyBasedExternalRouteIPsForTargetNamespace(namespaceName string) (sets.Set[string], error) {
 	gwIPs, err := c.mgr.getDynamicGatewayIPsForTargetNamespace(namespaceName)
 	if err != nil {
@@ -126,6 +188,10 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 		newMsg = fmt.Sprintf("%s %s: %v", c.zoneID, types.APBRouteErrorMsg, syncError.Error())
 	}
 	newMsg = types.GetZoneStatus(c.zoneID, newMsg)
+	bfdSessions, err := c.nbClient.getBFDSessions(gwIPs)
+	if err != nil {
+		return err
+	}
 	needsUpdate := true
 	for _, message := range routePolicy.Status.Messages {
 		if message == newMsg {
@@ -135,17 +201,38 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 		}
 	}
 	if !needsUpdate {
-		return nil
+		// the BFD sessions of the other zones are owned by their controllers
+		localBFDSessions := []adminpolicybasedrouteapi.BFDSessionStatus{}
+		for _, session := range routePolicy.Status.BFDSessions {
+			local, err := c.nbClient.isNodeInLocalZone(session.Node)
+			if err != nil {
+				return err
+			}
+			if local {
+				localBFDSessions = append(localBFDSessions, session)
+			}
+		}
+		sortBFDSessions(localBFDSessions)
+		if equality.Semantic.DeepEqual(localBFDSessions, bfdSessions) {
+			return nil
+		}
 	}
 
 	applyOptions := metav1.ApplyOptions{
 		Force:        true,
 		FieldManager: c.zoneID,
 	}
+	statusApply := adminpolicybasedrouteapply.AdminPolicyBasedRouteStatus().
+		WithMessages(newMsg).
+		WithLastTransitionTime(metav1.Now())
+	for _, session := range bfdSessions {
+		statusApply.WithBFDSessions(adminpolicybasedrouteapply.BFDSessionStatus().
+			WithNode(session.Node).
+			WithNextHop(session.NextHop).
+			WithState(session.State))
+	}
 	applyObj := adminpolicybasedrouteapply.AdminPolicyBasedExternalRoute(policyName).
-		WithStatus(adminpolicybasedrouteapply.AdminPolicyBasedRouteStatus().
-			WithMessages(newMsg).
-			WithLastTransitionTime(metav1.Now()))
+		WithStatus(statusApply)
 	_, err = c.apbRoutePolicyClient.K8sV1().AdminPolicyBasedExternalRoutes().ApplyStatus(context.TODO(), applyObj, applyOptions)
 
 	if err != nil {
@@ -164,12 +251,12 @@ func (c *ExternalGatewayMasterController) GetStaticGatewayIPsForTargetNamespace(
 
 // AddHybridRoutePolicyForPod exposes the function addHybridRoutePolicyForPod
 func (c *ExternalGatewayMasterController) AddHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	return c.nbClient.addHybridRoutePolicyForPod(podIP, node)
+	return c.nbClient.addHybridRoutePolicyForPod(&util.DefaultNetInfo{}, podIP, node)
 }
 
 // DelHybridRoutePolicyForPod exposes the function delHybridRoutePolicyForPod
 func (c *ExternalGatewayMasterController) DelHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	return c.nbClient.delHybridRoutePolicyForPod(podIP, node)
+	return c.nbClient.delHybridRoutePolicyForPod(&util.DefaultNetInfo{}, podIP, node)
 }
 
 // DelAllHybridRoutePolicies exposes the function delAllHybridRoutePolicies
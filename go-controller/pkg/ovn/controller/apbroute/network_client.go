@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -18,12 +19,15 @@ import (
 	"github.com/ovn-kubernetes/libovsdb/ovsdb"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/apbroute/gateway_info"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -41,8 +45,14 @@ type northBoundClient struct {
 	nbClient libovsdbclient.Client
 
 	// An address set factory that creates address sets
-	addressSetFactory        addressset.AddressSetFactory
+	addressSetFactory addressset.AddressSetFactory
+	// externalGatewayRouteInfo keeps the routes of the pods of the default network
 	externalGatewayRouteInfo *ExternalGatewayRouteInfoCache
+	// udnExternalGatewayRouteInfos keeps the routes of the pods of every primary user defined network, key is the
+	// network name. The routes of the networks are kept apart since their pod IPs may overlap.
+	udnExternalGatewayRouteInfos *syncmap.SyncMap[*ExternalGatewayRouteInfoCache]
+	// networkManager provides the primary network of the target namespaces
+	networkManager networkmanager.Interface
 
 	controllerName string
 
@@ -81,6 +91,74 @@ func (nb *northBoundClient) isPodInLocalZone(pod *corev1.Pod) (bool, error) {
 	return util.GetNodeZone(node) == nb.zone, nil
 }
 
+// getActiveNetworkForNamespace returns the primary network of the pods of the namespace
+func (nb *northBoundClient) getActiveNetworkForNamespace(namespace string) (util.NetInfo, error) {
+	if nb.networkManager == nil {
+		return &util.DefaultNetInfo{}, nil
+	}
+	return nb.networkManager.GetActiveNetworkForNamespace(namespace)
+}
+
+// getNetwork returns the network of the given name, or nil if the network doesn't exist anymore
+func (nb *northBoundClient) getNetwork(networkName string) util.NetInfo {
+	if networkName == types.DefaultNetworkName {
+		return &util.DefaultNetInfo{}
+	}
+	if nb.networkManager == nil {
+		return nil
+	}
+	return nb.networkManager.GetNetwork(networkName)
+}
+
+// getRouteInfoCache returns the cache of the routes of the pods of the given network
+func (nb *northBoundClient) getRouteInfoCache(networkName string) *ExternalGatewayRouteInfoCache {
+	if networkName == types.DefaultNetworkName {
+		return nb.externalGatewayRouteInfo
+	}
+	routeInfoCache, _ := nb.udnExternalGatewayRouteInfos.LoadOrStore(networkName, NewExternalGatewayRouteInfoCache())
+	return routeInfoCache
+}
+
+// getRouteInfoCacheNetworks returns the names of the networks that have a route info cache
+func (nb *northBoundClient) getRouteInfoCacheNetworks() []string {
+	return append([]string{types.DefaultNetworkName}, nb.udnExternalGatewayRouteInfos.GetKeys()...)
+}
+
+// getPodIPNets returns the IPs of the pod on the given network with a full mask
+func getPodIPNets(pod *corev1.Pod, netInfo util.NetInfo) ([]*net.IPNet, error) {
+	if netInfo.IsUserDefinedNetwork() {
+		return util.GetPodCIDRsWithFullMask(pod, netInfo)
+	}
+	podIPs := make([]*net.IPNet, 0)
+	for _, podIP := range pod.Status.PodIPs {
+		ip := utilnet.ParseIPSloppy(podIP.IP)
+		ipNet := &net.IPNet{
+			IP:   ip,
+			Mask: util.GetIPFullMask(ip),
+		}
+		ipNet = util.IPsToNetworkIPs(ipNet)[0]
+		podIPs = append(podIPs, ipNet)
+	}
+	return podIPs, nil
+}
+
+// getNodeFromGatewayRouter returns the node of a gateway router of the given network
+func getNodeFromGatewayRouter(networkName, gr string) string {
+	node := util.GetWorkerFromGatewayRouter(gr)
+	if networkName == types.DefaultNetworkName {
+		return node
+	}
+	return strings.TrimPrefix(node, util.GetUserDefinedNetworkPrefix(networkName))
+}
+
+// getLogicalRouterNetwork returns the name of the network of a logical router
+func getLogicalRouterNetwork(router *nbdb.LogicalRouter) string {
+	if networkName := router.ExternalIDs[types.NetworkExternalID]; networkName != "" {
+		return networkName
+	}
+	return types.DefaultNetworkName
+}
+
 // delAllHybridRoutePolicies deletes all the 501 hybrid-route-policies that
 // force pod egress traffic to be rerouted to a gateway router for local gateway mode.
 // Called when migrating to SGW from LGW.
@@ -89,9 +167,19 @@ func (nb *northBoundClient) delAllHybridRoutePolicies() error {
 	policyPred := func(item *nbdb.LogicalRouterPolicy) bool {
 		return item.Priority == types.HybridOverlayReroutePriority
 	}
-	err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nb.nbClient, types.OVNClusterRouter, policyPred)
+	// the cluster routers of the user defined networks hold the policies of their pods
+	clusterRouters, err := nb.findLogicalRoutersWithPredicate(func(item *nbdb.LogicalRouter) bool {
+		return item.Name == types.OVNClusterRouter ||
+			item.ExternalIDs[types.NetworkExternalID] != "" && strings.HasSuffix(item.Name, "_"+types.OVNClusterRouter)
+	})
 	if err != nil {
-		return fmt.Errorf("error deleting hybrid route policies on %s: %v", types.OVNClusterRouter, err)
+		return fmt.Errorf("error listing cluster routers: %v", err)
+	}
+	for _, clusterRouter := range clusterRouters {
+		err = libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nb.nbClient, clusterRouter.Name, policyPred)
+		if err != nil {
+			return fmt.Errorf("error deleting hybrid route policies on %s: %v", clusterRouter.Name, err)
+		}
 	}
 
 	// nuke all the address-sets.
@@ -130,8 +218,19 @@ func (nb *northBoundClient) delAllLegacyHybridRoutePolicies() error {
 // deleteGatewayIPs handles deleting static routes for pods on a specific GR.
 // If a set of gateways is given, only routes for that gateway are deleted. If no gateways
 // are given, all routes for the namespace are deleted.
-func (nb *northBoundClient) deleteGatewayIPs(podNsName ktypes.NamespacedName, toBeDeletedGWIPs, _ sets.Set[string]) error {
-	return nb.externalGatewayRouteInfo.Cleanup(podNsName, func(routeInfo *RouteInfo) error {
+func (nb *northBoundClient) deleteGatewayIPs(podNsName ktypes.NamespacedName, toBeDeletedGWIPs, toBeKept sets.Set[string]) error {
+	// the pod has routes in the cache of its network only, but the network of a deleted pod is not known anymore
+	for _, networkName := range nb.getRouteInfoCacheNetworks() {
+		if err := nb.deleteNetworkGatewayIPs(networkName, podNsName, toBeDeletedGWIPs, toBeKept); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteNetworkGatewayIPs deletes the static routes of a pod on the gateway routers of the given network.
+func (nb *northBoundClient) deleteNetworkGatewayIPs(networkName string, podNsName ktypes.NamespacedName, toBeDeletedGWIPs, _ sets.Set[string]) error {
+	return nb.getRouteInfoCache(networkName).Cleanup(podNsName, func(routeInfo *RouteInfo) error {
 		pod, err := nb.podLister.Pods(routeInfo.PodName.Namespace).Get(routeInfo.PodName.Name)
 		var deletedPod bool
 		if err != nil && apierrors.IsNotFound(err) {
@@ -153,7 +252,7 @@ func (nb *northBoundClient) deleteGatewayIPs(podNsName ktypes.NamespacedName, to
 				if toBeDeletedGWIPs.Has(gw) || deletedPod {
 					// we cannot delete an external gateway IP from the north bound if it's also being provided by an external gateway annotation or if it is also
 					// defined by a coexisting policy in the same namespace
-					if err := nb.deletePodGWRoute(routeInfo, podIP, gw, gr); err != nil {
+					if err := nb.deletePodGWRoute(networkName, routeInfo, podIP, gw, gr); err != nil {
 						return fmt.Errorf("APB delete pod GW route failed: %w", err)
 					}
 					delete(routes, gw)
@@ -175,16 +274,16 @@ func (nb *northBoundClient) addGatewayIPs(pod *corev1.Pod, egress *gateway_info.
 	if util.PodCompleted(pod) || util.PodWantsHostNetwork(pod) {
 		return false, nil
 	}
-	klog.V(5).Infof("Processing %s/%s with status %s and IPs %+v", pod.Namespace, pod.Name, pod.Status.Phase, pod.Status.PodIPs)
-	podIPs := make([]*net.IPNet, 0)
-	for _, podIP := range pod.Status.PodIPs {
-		ip := utilnet.ParseIPSloppy(podIP.IP)
-		ipNet := &net.IPNet{
-			IP:   ip,
-			Mask: util.GetIPFullMask(ip),
-		}
-		ipNet = util.IPsToNetworkIPs(ipNet)[0]
-		podIPs = append(podIPs, ipNet)
+	netInfo, err := nb.getActiveNetworkForNamespace(pod.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the active network of namespace %s: %w", pod.Namespace, err)
+	}
+	klog.V(5).Infof("Processing %s/%s on network %s with status %s and IPs %+v", pod.Namespace, pod.Name,
+		netInfo.GetNetworkName(), pod.Status.Phase, pod.Status.PodIPs)
+	podIPs, err := getPodIPNets(pod, netInfo)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the IPs of pod %s/%s on network %s: %w", pod.Namespace, pod.Name,
+			netInfo.GetNetworkName(), err)
 	}
 	if len(podIPs) == 0 {
 		// At this stage the pod is either in Pending or Running phase, but Pending should not have an IP, therefore it should not
@@ -192,7 +291,9 @@ func (nb *northBoundClient) addGatewayIPs(pod *corev1.Pod, egress *gateway_info.
 		klog.Warningf("Will not add gateway routes pod %s/%s. IPs not found!", pod.Namespace, pod.Name)
 		return false, nil
 	}
-	if config.Gateway.DisableSNATMultipleGWs {
+	// the gateway routers of the user defined networks always SNAT the network subnet to the node IP, whatever
+	// DisableSNATMultipleGWs is, so the external gateways see the node IP for the pods on those networks
+	if config.Gateway.DisableSNATMultipleGWs && netInfo.IsDefault() {
 		// delete all perPodSNATs (if this pod was controlled by egressIP controller, it will stop working since
 		// a pod cannot be used for multiple-external-gateways and egressIPs at the same time)
 		if err := nb.deletePodSNAT(pod.Spec.NodeName, util.GetGatewayRouterFromNode(pod.Spec.NodeName), []*net.IPNet{}, podIPs); err != nil {
@@ -200,7 +301,7 @@ func (nb *northBoundClient) addGatewayIPs(pod *corev1.Pod, egress *gateway_info.
 		}
 	}
 	podNsName := ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
-	return true, nb.addGWRoutesForPod(egress.Elems(), podIPs, podNsName, pod.Spec.NodeName, util.GetGatewayRouterFromNode(pod.Spec.NodeName))
+	return true, nb.addGWRoutesForPod(netInfo, egress.Elems(), podIPs, podNsName, pod.Spec.NodeName)
 }
 
 // deletePodSNAT removes per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
@@ -229,8 +330,8 @@ func (nb *northBoundClient) deletePodSNAT(nodeName, gwRouterName string, extIPs,
 	return nil
 }
 
-// addEgressGwRoutesForPod handles adding all routes to gateways for a pod on a specific GR
-func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayInfo, podIfAddrs []*net.IPNet, podNsName ktypes.NamespacedName, node, gr string) error {
+// addEgressGwRoutesForPod handles adding all routes to gateways for a pod on the GR of the node in the pod's network
+func (nb *northBoundClient) addGWRoutesForPod(netInfo util.NetInfo, gateways []*gateway_info.GatewayInfo, podIfAddrs []*net.IPNet, podNsName ktypes.NamespacedName, node string) error {
 	pod, err := nb.podLister.Pods(podNsName.Namespace).Get(podNsName.Name)
 	if err != nil {
 		return err
@@ -251,8 +352,9 @@ func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayIn
 		return err
 	}
 
+	gr := netInfo.GetNetworkScopedGWRouterName(node)
 	port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
-	return nb.externalGatewayRouteInfo.CreateOrLoad(podNsName, func(routeInfo *RouteInfo) error {
+	return nb.getRouteInfoCache(netInfo.GetNetworkName()).CreateOrLoad(podNsName, func(routeInfo *RouteInfo) error {
 		for _, podIPNet := range podIfAddrs {
 			for _, gateway := range gateways {
 				// TODO (trozet): use the go bindings here and batch commands
@@ -270,7 +372,7 @@ func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayIn
 						continue
 					}
 					mask := util.GetIPFullMaskString(podIP)
-					if err := nb.createOrUpdateBFDStaticRoute(gateway.BFDEnabled, gw, podIP, gr, port, mask); err != nil {
+					if err := nb.createOrUpdateBFDStaticRoute(gateway, gw, podIP, gr, port, mask); err != nil {
 						return err
 					}
 					if routeInfo.PodExternalRoutes[podIP] == nil {
@@ -279,7 +381,7 @@ func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayIn
 					routeInfo.PodExternalRoutes[podIP][gw] = gr
 					routesAdded++
 					if len(routeInfo.PodExternalRoutes[podIP]) == 1 {
-						if err := nb.addHybridRoutePolicyForPod(podIPNet.IP, node); err != nil {
+						if err := nb.addHybridRoutePolicyForPod(netInfo, podIPNet.IP, node); err != nil {
 							return err
 						}
 					}
@@ -296,11 +398,12 @@ func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayIn
 }
 
 // AddHybridRoutePolicyForPod handles adding a higher priority allow policy to allow traffic to be routed normally
-// by ecmp routes
-func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	if config.Gateway.Mode == config.GatewayModeLocal {
+// by ecmp routes. Layer2 networks have no cluster router in front of the GR, so they don't need it.
+func (nb *northBoundClient) addHybridRoutePolicyForPod(netInfo util.NetInfo, podIP net.IP, node string) error {
+	if config.Gateway.Mode == config.GatewayModeLocal && netInfo.TopologyType() == types.Layer3Topology {
+		clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
 		// Add podIP to the node's address_set.
-		asIndex := GetHybridRouteAddrSetDbIDs(node, nb.controllerName)
+		asIndex := GetHybridRouteAddrSetDbIDs(netInfo.GetNetworkScopedName(node), nb.controllerName)
 		as, err := nb.addressSetFactory.EnsureAddressSet(asIndex)
 		if err != nil {
 			return fmt.Errorf("cannot ensure that addressSet for node %s exists %v", node, err)
@@ -324,7 +427,7 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 		}
 
 		// get the GR to join switch ip address
-		grJoinIfAddrs, err := libovsdbutil.GetLRPAddrs(nb.nbClient, types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix+node)
+		grJoinIfAddrs, err := libovsdbutil.GetLRPAddrs(nb.nbClient, types.GWRouterToJoinSwitchPrefix+netInfo.GetNetworkScopedGWRouterName(node))
 		if err != nil {
 			return fmt.Errorf("unable to find IP address for node: %s, %s port, err: %v", node, types.GWRouterToJoinSwitchPrefix, err)
 		}
@@ -335,7 +438,7 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range netInfo.Subnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -348,7 +451,7 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 		}
 
 		// traffic destined outside of cluster subnet go to GR
-		matchStr := fmt.Sprintf(`inport == "%s%s" && %s.src == $%s`, types.RouterToSwitchPrefix, node, l3Prefix, matchSrcAS)
+		matchStr := fmt.Sprintf(`inport == "%s%s" && %s.src == $%s`, types.RouterToSwitchPrefix, netInfo.GetNetworkScopedSwitchName(node), l3Prefix, matchSrcAS)
 		matchStr += matchDst
 
 		logicalRouterPolicy := nbdb.LogicalRouterPolicy{
@@ -360,16 +463,16 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 		p := func(item *nbdb.LogicalRouterPolicy) bool {
 			return item.Priority == logicalRouterPolicy.Priority && strings.Contains(item.Match, matchSrcAS)
 		}
-		err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nb.nbClient, types.OVNClusterRouter,
+		err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nb.nbClient, clusterRouter,
 			&logicalRouterPolicy, p, &logicalRouterPolicy.Nexthops, &logicalRouterPolicy.Match, &logicalRouterPolicy.Action)
 		if err != nil {
-			return fmt.Errorf("failed to add policy route %+v to %s: %v", logicalRouterPolicy, types.OVNClusterRouter, err)
+			return fmt.Errorf("failed to add policy route %+v to %s: %v", logicalRouterPolicy, clusterRouter, err)
 		}
 	}
 	return nil
 }
 
-func (nb *northBoundClient) createOrUpdateBFDStaticRoute(bfdEnabled bool, gw string, podIP, gr, port, mask string) error {
+func (nb *northBoundClient) createOrUpdateBFDStaticRoute(gateway *gateway_info.GatewayInfo, gw string, podIP, gr, port, mask string) error {
 	lrsr := nbdb.LogicalRouterStaticRoute{
 		Policy: &nbdb.LogicalRouterStaticRoutePolicySrcIP,
 		Options: map[string]string{
@@ -382,11 +485,22 @@ func (nb *northBoundClient) createOrUpdateBFDStaticRoute(bfdEnabled bool, gw str
 
 	ops := []ovsdb.Operation{}
 	var err error
-	if bfdEnabled {
+	if gateway.BFDEnabled {
+		// the BFD session is shared by all the routes to the gateway through this port, the policies with
+		// conflicting BFD timers for the gateway are rejected
 		bfd := nbdb.BFD{
 			DstIP:       gw,
 			LogicalPort: port,
 		}
+		if gateway.BFDTimers.MinTx > 0 {
+			bfd.MinTx = &gateway.BFDTimers.MinTx
+		}
+		if gateway.BFDTimers.MinRx > 0 {
+			bfd.MinRx = &gateway.BFDTimers.MinRx
+		}
+		if gateway.BFDTimers.DetectMult > 0 {
+			bfd.DetectMult = &gateway.BFDTimers.DetectMult
+		}
 		ops, err = libovsdbops.CreateOrUpdateBFDOps(nb.nbClient, ops, &bfd)
 		if err != nil {
 			return fmt.Errorf("error creating or updating BFD %+v: %v", bfd, err)
@@ -399,7 +513,7 @@ func (nb *northBoundClient) createOrUpdateBFDStaticRoute(bfdEnabled bool, gw str
 			item.Nexthop == lrsr.Nexthop &&
 			item.OutputPort != nil &&
 			*item.OutputPort == *lrsr.OutputPort &&
-			item.Policy == lrsr.Policy
+			libovsdbops.PolicyEqualPredicate(item.Policy, lrsr.Policy)
 	}
 	ops, err = libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(nb.nbClient, ops, gr, &lrsr, p,
 		&lrsr.Options)
@@ -415,8 +529,9 @@ func (nb *northBoundClient) createOrUpdateBFDStaticRoute(bfdEnabled bool, gw str
 	return nil
 }
 
-func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, gwIP, nodeName, gr string, bfdEnabled bool, namespacedName ktypes.NamespacedName) error {
-	return nb.externalGatewayRouteInfo.CreateOrLoad(namespacedName, func(routeInfo *RouteInfo) error {
+func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(netInfo util.NetInfo, podIP, gwIP, nodeName string, gwInfo *gateway_info.GatewayInfo, namespacedName ktypes.NamespacedName) error {
+	gr := netInfo.GetNetworkScopedGWRouterName(nodeName)
+	return nb.getRouteInfoCache(netInfo.GetNetworkName()).CreateOrLoad(namespacedName, func(routeInfo *RouteInfo) error {
 		// if route was already programmed, skip it
 		if foundGR, ok := routeInfo.PodExternalRoutes[podIP][gwIP]; ok && foundGR == gr {
 			return nil
@@ -428,10 +543,10 @@ func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP
 			klog.Warningf("Failed to find ext switch prefix for %s %v", nodeName, err)
 			return err
 		}
-		if bfdEnabled {
+		if gwInfo.BFDEnabled {
 			port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
 			// update the BFD static route just in case it has changed
-			if err := nb.createOrUpdateBFDStaticRoute(bfdEnabled, gwIP, podIP, gr, port, mask); err != nil {
+			if err := nb.createOrUpdateBFDStaticRoute(gwInfo, gwIP, podIP, gr, port, mask); err != nil {
 				return err
 			}
 		} else {
@@ -453,7 +568,7 @@ func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP
 	})
 }
 
-func (nb *northBoundClient) deletePodGWRoute(routeInfo *RouteInfo, podIP, gw, gr string) error {
+func (nb *northBoundClient) deletePodGWRoute(networkName string, routeInfo *RouteInfo, podIP, gw, gr string) error {
 	if utilnet.IsIPv6String(gw) != utilnet.IsIPv6String(podIP) {
 		return nil
 	}
@@ -464,13 +579,16 @@ func (nb *northBoundClient) deletePodGWRoute(routeInfo *RouteInfo, podIP, gw, gr
 			routeInfo.PodName, gr, gw, err)
 	}
 
-	node := util.GetWorkerFromGatewayRouter(gr)
+	node := getNodeFromGatewayRouter(networkName, gr)
 
 	// The gw is deleted from the routes cache after this func is called, length 1
 	// means it is the last gw for the pod and the hybrid route policy should be deleted.
+	// The hybrid route policies of a deleted network are gone with its cluster router.
 	if entry := routeInfo.PodExternalRoutes[podIP]; len(entry) <= 1 {
-		if err := nb.delHybridRoutePolicyForPod(net.ParseIP(podIP), node); err != nil {
-			return fmt.Errorf("unable to delete hybrid route policy for pod %s: err: %v", routeInfo.PodName, err)
+		if netInfo := nb.getNetwork(networkName); netInfo != nil {
+			if err := nb.delHybridRoutePolicyForPod(netInfo, net.ParseIP(podIP), node); err != nil {
+				return fmt.Errorf("unable to delete hybrid route policy for pod %s: err: %v", routeInfo.PodName, err)
+			}
 		}
 	}
 
@@ -529,13 +647,14 @@ func (nb *northBoundClient) deleteLogicalRouterStaticRoute(podIP, mask, gw, gr s
 
 // DelHybridRoutePolicyForPod handles deleting a logical route policy that
 // forces pod egress traffic to be rerouted to a gateway router for local gateway mode.
-func (nb *northBoundClient) delHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	if config.Gateway.Mode != config.GatewayModeLocal {
+func (nb *northBoundClient) delHybridRoutePolicyForPod(netInfo util.NetInfo, podIP net.IP, node string) error {
+	if config.Gateway.Mode != config.GatewayModeLocal || netInfo.TopologyType() != types.Layer3Topology {
 		return nil
 	}
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
 
 	// Delete podIP from the node's address_set.
-	asIndex := GetHybridRouteAddrSetDbIDs(node, nb.controllerName)
+	asIndex := GetHybridRouteAddrSetDbIDs(netInfo.GetNetworkScopedName(node), nb.controllerName)
 	as, err := nb.addressSetFactory.EnsureAddressSet(asIndex)
 	if err != nil {
 		return fmt.Errorf("cannot Ensure that addressSet for node %s exists %v", node, err)
@@ -567,7 +686,7 @@ func (nb *northBoundClient) delHybridRoutePolicyForPod(podIP net.IP, node string
 	if deletePolicy {
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range netInfo.Subnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -578,15 +697,15 @@ func (nb *northBoundClient) delHybridRoutePolicyForPod(podIP net.IP, node string
 			}
 			matchDst += fmt.Sprintf(" && %s.dst != %s", l3Prefix, clusterSubnet.CIDR)
 		}
-		matchStr := fmt.Sprintf(`inport == "%s%s" && %s.src == $%s`, types.RouterToSwitchPrefix, node, l3Prefix, matchSrcAS)
+		matchStr := fmt.Sprintf(`inport == "%s%s" && %s.src == $%s`, types.RouterToSwitchPrefix, netInfo.GetNetworkScopedSwitchName(node), l3Prefix, matchSrcAS)
 		matchStr += matchDst
 
 		p := func(item *nbdb.LogicalRouterPolicy) bool {
 			return item.Priority == types.HybridOverlayReroutePriority && item.Match == matchStr
 		}
-		err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nb.nbClient, types.OVNClusterRouter, p)
+		err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nb.nbClient, clusterRouter, p)
 		if err != nil {
-			return fmt.Errorf("error deleting policy %s on router %s: %v", matchStr, types.OVNClusterRouter, err)
+			return fmt.Errorf("error deleting policy %s on router %s: %v", matchStr, clusterRouter, err)
 		}
 	}
 	if len(ipv4PodIPs) == 0 && len(ipv6PodIPs) == 0 {
@@ -635,6 +754,84 @@ func (nb *northBoundClient) lookupBFDEntry(gatewayIP, gatewayRouter, prefix stri
 	return found, nil
 }
 
+// getBFDSessions returns the state of the BFD sessions of the local zone gateway routers with the given gateway IPs,
+// sorted by node and gateway IP.
+func (nb *northBoundClient) getBFDSessions(gwIPs sets.Set[string]) ([]adminpolicybasedrouteapi.BFDSessionStatus, error) {
+	portInfix := types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix
+	p := func(item *nbdb.BFD) bool {
+		return gwIPs.Has(item.DstIP) && strings.Contains(item.LogicalPort, portInfix)
+	}
+	bfds, err := libovsdbops.FindBFDsWithPredicate(nb.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BFD entries: %w", err)
+	}
+	if len(bfds) == 0 {
+		return []adminpolicybasedrouteapi.BFDSessionStatus{}, nil
+	}
+	grNetworks := map[string]string{}
+	routers, err := libovsdbops.FindLogicalRoutersWithPredicate(nb.nbClient, func(item *nbdb.LogicalRouter) bool {
+		return strings.HasPrefix(item.Name, types.GWRouterPrefix)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gateway routers: %w", err)
+	}
+	for _, router := range routers {
+		grNetworks[router.Name] = getLogicalRouterNetwork(router)
+	}
+	// the gateway routers of several networks of a node may have sessions with the same gateway, the session of the
+	// node is only reported up when all of them are up
+	sessionsByKey := map[string]*adminpolicybasedrouteapi.BFDSessionStatus{}
+	for _, bfd := range bfds {
+		// the logical port is the gateway router port to the external switch: [prefix]rtoe-GR_[network_]<node>
+		gr := bfd.LogicalPort[strings.Index(bfd.LogicalPort, portInfix)+len(types.GWRouterToExtSwitchPrefix):]
+		networkName, ok := grNetworks[gr]
+		if !ok {
+			networkName = types.DefaultNetworkName
+		}
+		node := getNodeFromGatewayRouter(networkName, gr)
+		state := adminpolicybasedrouteapi.BFDSessionStateDown
+		if bfd.Status != nil {
+			state = adminpolicybasedrouteapi.BFDSessionState(*bfd.Status)
+		}
+		key := node + "/" + bfd.DstIP
+		if session, ok := sessionsByKey[key]; ok {
+			if session.State == adminpolicybasedrouteapi.BFDSessionStateUp {
+				session.State = state
+			}
+			continue
+		}
+		sessionsByKey[key] = &adminpolicybasedrouteapi.BFDSessionStatus{Node: node, NextHop: bfd.DstIP, State: state}
+	}
+	sessions := make([]adminpolicybasedrouteapi.BFDSessionStatus, 0, len(sessionsByKey))
+	for _, session := range sessionsByKey {
+		sessions = append(sessions, *session)
+	}
+	sortBFDSessions(sessions)
+	return sessions, nil
+}
+
+// isNodeInLocalZone returns whether the node is in the zone of the controller. Nodes that don't exist anymore are
+// considered local, so that their stale state is cleaned up.
+func (nb *northBoundClient) isNodeInLocalZone(nodeName string) (bool, error) {
+	node, err := nb.nodeLister.Get(nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return util.GetNodeZone(node) == nb.zone, nil
+}
+
+func sortBFDSessions(sessions []adminpolicybasedrouteapi.BFDSessionStatus) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Node != sessions[j].Node {
+			return sessions[i].Node < sessions[j].Node
+		}
+		return sessions[i].NextHop < sessions[j].NextHop
+	})
+}
+
 // buildPodSNAT builds per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
 // if allSNATs flag is set, then all the SNATs (including against egressIPs if any) for that pod will be returned
 func buildPodSNAT(extIPs, podIPNets []*net.IPNet) ([]*nbdb.NAT, error) {
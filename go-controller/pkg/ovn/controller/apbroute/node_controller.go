@@ -32,6 +32,8 @@ func NewExternalNodeController(
 			podInformer,
 			namespaceInformer,
 			apbRouteInformer,
+			nil,
+			nil,
 			&conntrackClient{podLister: podInformer.Lister()},
 			nil),
 	}
@@ -24,9 +24,16 @@ import (
 type managedGWIPs struct {
 	namespacedName ktypes.NamespacedName
 	nodeName       string
+	netInfo        util.NetInfo
 	gwList         *gateway_info.GatewayInfoList
 }
 
+// podIPKey identifies a pod IP on its network, since the pod IPs of different networks may overlap
+type podIPKey struct {
+	networkName string
+	podIP       string
+}
+
 func (c *ExternalGatewayMasterController) Repair() error {
 	start := time.Now()
 	defer func() {
@@ -74,11 +81,11 @@ func (c *ExternalGatewayMasterController) Repair() error {
 	}
 
 	// compare caches and see if OVN routes are stale
-	for podIP, ovnRoutes := range ovnRouteCache {
+	for key, ovnRoutes := range ovnRouteCache {
 		// pod IP does not exist in the cluster
 		// remove route and any hybrid policy
-		expectedNextHopsPolicy, okPolicy := policyGWIPsMap[podIP]
-		expectedNextHopsAnnotation, okAnnotation := annotatedGWIPsMap[podIP]
+		expectedNextHopsPolicy, okPolicy := policyGWIPsMap[key]
+		expectedNextHopsAnnotation, okAnnotation := annotatedGWIPsMap[key]
 		if !okPolicy && !okAnnotation {
 			// No external gateways found for this Pod IP
 			continue
@@ -90,7 +97,7 @@ func (c *ExternalGatewayMasterController) Repair() error {
 				continue
 			}
 
-			node := util.GetWorkerFromGatewayRouter(ovnRoute.router)
+			node := getNodeFromGatewayRouter(key.networkName, ovnRoute.router)
 			// prefix will signify secondary exgw bridge, or empty if normal setup
 			// have to determine if a node changed while master was down and if the route swapped from
 			// the default bridge to a new secondary bridge (or vice versa)
@@ -104,13 +111,13 @@ func (c *ExternalGatewayMasterController) Repair() error {
 				continue
 			}
 			if expectedNextHopsPolicy != nil {
-				ovnRoute.shouldExist = c.processOVNRoute(ovnRoute, expectedNextHopsPolicy.gwList, podIP, expectedNextHopsPolicy, true)
+				ovnRoute.shouldExist = c.processOVNRoute(ovnRoute, expectedNextHopsPolicy.gwList, key.podIP, expectedNextHopsPolicy, true)
 				if ovnRoute.shouldExist {
 					continue
 				}
 			}
 			if expectedNextHopsAnnotation != nil {
-				ovnRoute.shouldExist = c.processOVNRoute(ovnRoute, expectedNextHopsAnnotation.gwList, podIP, expectedNextHopsAnnotation, false)
+				ovnRoute.shouldExist = c.processOVNRoute(ovnRoute, expectedNextHopsAnnotation.gwList, key.podIP, expectedNextHopsAnnotation, false)
 			}
 		}
 	}
@@ -119,12 +126,12 @@ func (c *ExternalGatewayMasterController) Repair() error {
 	klog.V(4).Infof("Cluster ECMP route cache is: %+v", policyGWIPsMap)
 
 	// iterate through ovn routes and remove any stale entries
-	for podIP, ovnRoutes := range ovnRouteCache {
+	for key, ovnRoutes := range ovnRouteCache {
 		podHasAnyECMPRoutes := false
 		for _, ovnRoute := range ovnRoutes {
 			if !ovnRoute.shouldExist {
 				klog.V(4).Infof("Found stale exgw ecmp route, podIP: %s, nexthop: %s, router: %s",
-					podIP, ovnRoute.nextHop, ovnRoute.router)
+					key.podIP, ovnRoute.nextHop, ovnRoute.router)
 				lrsr := nbdb.LogicalRouterStaticRoute{UUID: ovnRoute.uuid}
 				err := c.nbClient.deleteLogicalRouterStaticRoutes(ovnRoute.router, &lrsr)
 				if err != nil {
@@ -132,7 +139,7 @@ func (c *ExternalGatewayMasterController) Repair() error {
 				}
 
 				// check to see if we should also clean up bfd
-				node := util.GetWorkerFromGatewayRouter(ovnRoute.router)
+				node := getNodeFromGatewayRouter(key.networkName, ovnRoute.router)
 				// prefix will signify secondary exgw bridge, or empty if normal setup
 				// have to determine if a node changed while master was down and if the route swapped from
 				// the default bridge to a new secondary bridge (or vice versa)
@@ -153,12 +160,13 @@ func (c *ExternalGatewayMasterController) Repair() error {
 		}
 
 		// if pod had no ECMP routes we need to make sure we remove logical route policy for local gw mode
-		if !podHasAnyECMPRoutes {
+		netInfo := c.nbClient.getNetwork(key.networkName)
+		if !podHasAnyECMPRoutes && netInfo != nil {
 			for _, ovnRoute := range ovnRoutes {
-				node := strings.TrimPrefix(ovnRoute.router, types.GWRouterPrefix)
-				if err := c.nbClient.delHybridRoutePolicyForPod(net.ParseIP(podIP), node); err != nil {
+				node := getNodeFromGatewayRouter(key.networkName, ovnRoute.router)
+				if err := c.nbClient.delHybridRoutePolicyForPod(netInfo, net.ParseIP(key.podIP), node); err != nil {
 					return fmt.Errorf("error while removing hybrid policy for pod IP: %s, on node: %s, error: %v",
-						podIP, node, err)
+						key.podIP, node, err)
 				}
 			}
 		}
@@ -171,15 +179,16 @@ func (c *ExternalGatewayMasterController) Repair() error {
 		if err != nil {
 			return fmt.Errorf("failed to build hybrid cache: %w", err)
 		}
-		for ip, node := range ovnHybridCache {
+		for key, node := range ovnHybridCache {
 			// check if this pod IP has a corresponding policy, if not, remove it
-			_, okPolicy := policyGWIPsMap[ip]
-			_, okAnnotation := annotatedGWIPsMap[ip]
-			if !okPolicy && !okAnnotation {
-				klog.Infof("CleanHybridPRoutes: Removing IP: %s from hybrid route policy", ip)
-				if err := c.nbClient.delHybridRoutePolicyForPod(net.ParseIP(ip), node); err != nil {
+			_, okPolicy := policyGWIPsMap[key]
+			_, okAnnotation := annotatedGWIPsMap[key]
+			netInfo := c.nbClient.getNetwork(key.networkName)
+			if !okPolicy && !okAnnotation && netInfo != nil {
+				klog.Infof("CleanHybridPRoutes: Removing IP: %s of network %s from hybrid route policy", key.podIP, key.networkName)
+				if err := c.nbClient.delHybridRoutePolicyForPod(netInfo, net.ParseIP(key.podIP), node); err != nil {
 					return fmt.Errorf("CleanHybridPRoutes: error while removing hybrid policy for pod IP: %s, on node: %s, error: %v",
-						ip, node, err)
+						key.podIP, node, err)
 				}
 			}
 		}
@@ -190,8 +199,8 @@ func (c *ExternalGatewayMasterController) Repair() error {
 // syncPoliciesWithoutCleanup handles all existing policies and initializes caches. It doesn't do any cleanup,
 // but it returns managedGWIPs that should exist, and relies on the repair code to cleanup everything else.
 // After cleanup is completed, regular handling can be started.
-func (c *ExternalGatewayMasterController) syncPoliciesWithoutCleanup() (map[string]*managedGWIPs, error) {
-	clusterRouteCache := make(map[string]*managedGWIPs)
+func (c *ExternalGatewayMasterController) syncPoliciesWithoutCleanup() (map[podIPKey]*managedGWIPs, error) {
+	clusterRouteCache := make(map[podIPKey]*managedGWIPs)
 	externalRoutePolicies, err := c.mgr.routeLister.List(labels.Everything())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list AdminPolicyBasedExternalRoute: %w", err)
@@ -225,11 +234,21 @@ func (c *ExternalGatewayMasterController) syncPoliciesWithoutCleanup() (map[stri
 					if err != nil {
 						return fmt.Errorf("failed getting target pod %s for policy %s: %w", targetPodNamespacedName, key, err)
 					}
-					for _, targetPodIP := range targetPod.Status.PodIPs {
-						podIPStr := utilnet.ParseIPSloppy(targetPodIP.IP).String()
-						clusterRouteCache[podIPStr] = &managedGWIPs{
+					netInfo, err := c.nbClient.getActiveNetworkForNamespace(targetPod.Namespace)
+					if err != nil {
+						return fmt.Errorf("failed getting the network of target pod %s for policy %s: %w", targetPodNamespacedName, key, err)
+					}
+					targetPodIPs, err := getPodIPNets(targetPod, netInfo)
+					if err != nil {
+						return fmt.Errorf("failed getting the IPs of target pod %s for policy %s: %w", targetPodNamespacedName, key, err)
+					}
+					for _, targetPodIP := range targetPodIPs {
+						podIPStr := targetPodIP.IP.String()
+						ipKey := podIPKey{networkName: netInfo.GetNetworkName(), podIP: podIPStr}
+						clusterRouteCache[ipKey] = &managedGWIPs{
 							namespacedName: ktypes.NamespacedName{Namespace: targetPod.Namespace, Name: targetPod.Name},
 							nodeName:       targetPod.Spec.NodeName,
+							netInfo:        netInfo,
 							gwList:         gateway_info.NewGatewayInfoList()}
 
 						allGWIPs := gateway_info.NewGatewayInfoList()
@@ -241,7 +260,7 @@ func (c *ExternalGatewayMasterController) syncPoliciesWithoutCleanup() (map[stri
 								if utilnet.IsIPv6String(gw) != utilnet.IsIPv6String(podIPStr) {
 									continue
 								}
-								clusterRouteCache[podIPStr].gwList.InsertOverwrite(gwInfo)
+								clusterRouteCache[ipKey].gwList.InsertOverwrite(gwInfo)
 							}
 						}
 					}
@@ -267,8 +286,8 @@ func (c *ExternalGatewayMasterController) processOVNRoute(ovnRoute *ovnRoute, gw
 				if noDbChanges {
 					return true
 				}
-				err := c.nbClient.updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, ovnRoute.nextHop, managedIPGWInfo.nodeName,
-					util.GetGatewayRouterFromNode(managedIPGWInfo.nodeName), gwInfo.BFDEnabled, managedIPGWInfo.namespacedName)
+				err := c.nbClient.updateExternalGWInfoCacheForPodIPWithGatewayIP(managedIPGWInfo.netInfo, podIP, ovnRoute.nextHop,
+					managedIPGWInfo.nodeName, gwInfo, managedIPGWInfo.namespacedName)
 				if err == nil {
 					return true
 				}
@@ -279,8 +298,10 @@ func (c *ExternalGatewayMasterController) processOVNRoute(ovnRoute *ovnRoute, gw
 	return false
 }
 
-func (c *ExternalGatewayMasterController) buildExternalIPGatewaysFromAnnotations() (map[string]*managedGWIPs, error) {
-	clusterRouteCache := make(map[string]*managedGWIPs, 0)
+// buildExternalIPGatewaysFromAnnotations returns the gateways of the pods of the default network set by the legacy
+// external gateway annotations.
+func (c *ExternalGatewayMasterController) buildExternalIPGatewaysFromAnnotations() (map[podIPKey]*managedGWIPs, error) {
+	clusterRouteCache := make(map[podIPKey]*managedGWIPs, 0)
 
 	nsList, err := c.mgr.namespaceLister.List(labels.Everything())
 	if err != nil {
@@ -347,7 +368,7 @@ func (c *ExternalGatewayMasterController) buildExternalIPGatewaysFromAnnotations
 	return clusterRouteCache, nil
 }
 
-func populateManagedGWIPsCacheForPods(gwInfo *gateway_info.GatewayInfo, cache map[string]*managedGWIPs, podList []*corev1.Pod) {
+func populateManagedGWIPsCacheForPods(gwInfo *gateway_info.GatewayInfo, cache map[podIPKey]*managedGWIPs, podList []*corev1.Pod) {
 	for gwIP := range gwInfo.Gateways {
 		for _, pod := range podList {
 			// ignore completed pods, host networked pods, pods not scheduled
@@ -359,21 +380,23 @@ func populateManagedGWIPsCacheForPods(gwInfo *gateway_info.GatewayInfo, cache ma
 				if utilnet.IsIPv6String(gwIP) != utilnet.IsIPv6String(podIPStr) {
 					continue
 				}
-				if _, ok := cache[podIPStr]; !ok {
-					cache[podIPStr] = &managedGWIPs{
+				key := podIPKey{networkName: types.DefaultNetworkName, podIP: podIPStr}
+				if _, ok := cache[key]; !ok {
+					cache[key] = &managedGWIPs{
 						namespacedName: ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
 						nodeName:       pod.Spec.NodeName,
+						netInfo:        &util.DefaultNetInfo{},
 						gwList:         gateway_info.NewGatewayInfoList(),
 					}
 				}
-				cache[podIPStr].gwList.InsertOverwrite(gateway_info.NewGatewayInfo(sets.New(gwIP), gwInfo.BFDEnabled))
+				cache[key].gwList.InsertOverwrite(gateway_info.NewGatewayInfo(sets.New(gwIP), gwInfo.BFDEnabled))
 			}
 		}
 	}
 }
 
 // Build cache of routes in OVN
-// map[podIP][]ovnRoute
+// map[podIPKey][]ovnRoute
 type ovnRoute struct {
 	nextHop     string
 	uuid        string
@@ -382,7 +405,7 @@ type ovnRoute struct {
 	shouldExist bool
 }
 
-func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[string][]*ovnRoute, error) {
+func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[podIPKey][]*ovnRoute, error) {
 	p := func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return item.Options["ecmp_symmetric_reply"] == "true"
 	}
@@ -391,7 +414,7 @@ func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[string][]*ovn
 		return nil, fmt.Errorf("CleanECMPRoutes: failed to list ecmp routes: %v", err)
 	}
 
-	ovnRouteCache := make(map[string][]*ovnRoute)
+	ovnRouteCache := make(map[podIPKey][]*ovnRoute)
 	for _, logicalRouterStaticRoute := range logicalRouterStaticRoutes {
 		p := func(item *nbdb.LogicalRouter) bool {
 			return util.SliceHasStringItem(item.StaticRoutes, logicalRouterStaticRoute.UUID)
@@ -408,18 +431,15 @@ func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[string][]*ovn
 			outport: *logicalRouterStaticRoute.OutputPort,
 		}
 		podIP, _, _ := net.ParseCIDR(logicalRouterStaticRoute.IPPrefix)
-		if _, ok := ovnRouteCache[podIP.String()]; !ok {
-			ovnRouteCache[podIP.String()] = []*ovnRoute{route}
-		} else {
-			ovnRouteCache[podIP.String()] = append(ovnRouteCache[podIP.String()], route)
-		}
+		key := podIPKey{networkName: getLogicalRouterNetwork(logicalRouters[0]), podIP: podIP.String()}
+		ovnRouteCache[key] = append(ovnRouteCache[key], route)
 	}
 	return ovnRouteCache, nil
 }
 
-// returns a hybrid cache of map[podIPs]nodeName
-func (c *ExternalGatewayMasterController) buildOVNHybridCache() (map[string]string, error) {
-	ovnHybridCache := make(map[string]string)
+// returns a hybrid cache of map[podIPKey]nodeName
+func (c *ExternalGatewayMasterController) buildOVNHybridCache() (map[podIPKey]string, error) {
+	ovnHybridCache := make(map[podIPKey]string)
 	p := func(item *nbdb.LogicalRouterPolicy) bool {
 		return item.Priority == types.HybridOverlayReroutePriority
 	}
@@ -451,14 +471,20 @@ func (c *ExternalGatewayMasterController) buildOVNHybridCache() (map[string]stri
 	}
 
 	for _, grPort := range grPorts {
-		nodeName := strings.TrimPrefix(grPort.Name, types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix)
-		if len(nodeName) == 0 && nodeName != grPort.Name {
+		// the GR ports of the user defined networks are named after the network scoped node name
+		scopedNodeName := strings.TrimPrefix(grPort.Name, types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix)
+		if len(scopedNodeName) == 0 && scopedNodeName != grPort.Name {
 			continue
 		}
+		networkName := types.DefaultNetworkName
+		if grPort.ExternalIDs[types.NetworkExternalID] != "" {
+			networkName = grPort.ExternalIDs[types.NetworkExternalID]
+		}
+		nodeName := getNodeFromGatewayRouter(networkName, types.GWRouterPrefix+scopedNodeName)
 
 		// nodeName has been found
 		// get address set and list all addresses
-		asIndex := GetHybridRouteAddrSetDbIDs(nodeName, c.nbClient.controllerName)
+		asIndex := GetHybridRouteAddrSetDbIDs(scopedNodeName, c.nbClient.controllerName)
 		as, err := c.nbClient.addressSetFactory.GetAddressSet(asIndex)
 		if err != nil {
 			klog.Errorf("CleanHybridPRoutes: unable to find get address set %s: %v", asIndex, err)
@@ -466,10 +492,10 @@ func (c *ExternalGatewayMasterController) buildOVNHybridCache() (map[string]stri
 		}
 		ipv4Addrs, ipv6Addrs := as.GetAddresses()
 		for _, ip := range ipv4Addrs {
-			ovnHybridCache[ip] = nodeName
+			ovnHybridCache[podIPKey{networkName: networkName, podIP: ip}] = nodeName
 		}
 		for _, ip := range ipv6Addrs {
-			ovnHybridCache[ip] = nodeName
+			ovnHybridCache[podIPKey{networkName: networkName, podIP: ip}] = nodeName
 		}
 	}
 
@@ -1094,12 +1094,16 @@ func (oc *EFController) GetSamplingConfig() *libovsdbops.SamplingConfig {
 	return nil
 }
 
-// getNamespaceACLLogging retrieves ACLLoggingLevels for the Namespace
+// getNamespaceACLLogging retrieves ACLLoggingLevels for the egress firewall of the Namespace.
+// The egress firewall specific annotation takes precedence over the namespace ACL logging annotation.
 func (oc *EFController) getNamespaceACLLogging(namespace string) (*libovsdbutil.ACLLoggingLevels, error) {
 	ns, err := oc.namespaceLister.Get(namespace)
 	if err != nil {
 		return nil, err
 	}
+	if annotation, ok := ns.Annotations[util.EgressFirewallAclLoggingAnnotation]; ok {
+		return parseACLLogging(annotation), nil
+	}
 	if annotation, ok := ns.Annotations[util.AclLoggingAnnotation]; ok {
 		return parseACLLogging(annotation), nil
 	}
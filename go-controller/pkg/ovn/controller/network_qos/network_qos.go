@@ -109,51 +109,27 @@ func (c *Controller) ensureNetworkQos(nqos *networkqosapi.NetworkQoS) error {
 		}
 	}
 
-	// set EgressRules to desiredNQOSState
+	// set EgressRules and IngressRules to desiredNQOSState
 	rules := []*GressRule{}
 	for index, ruleSpec := range nqos.Spec.Egress {
-		bwRate := int(ruleSpec.Bandwidth.Rate)
-		bwBurst := int(ruleSpec.Bandwidth.Burst)
-		ruleState := &GressRule{
-			Priority: getQoSRulePriority(nqos.Spec.Priority, index),
-			Dscp:     ruleSpec.DSCP,
-		}
-		if bwRate > 0 {
-			ruleState.Rate = &bwRate
-		}
-		if bwBurst > 0 {
-			ruleState.Burst = &bwBurst
-		}
-		destStates := []*Destination{}
-		for _, destSpec := range ruleSpec.Classifier.To {
-			if destSpec.IPBlock != nil && (destSpec.PodSelector != nil || destSpec.NamespaceSelector != nil) {
-				return fmt.Errorf("specifying both ipBlock and podSelector/namespaceSelector is not allowed")
-			}
-			destState := &Destination{}
-			destState.IpBlock = destSpec.IPBlock.DeepCopy()
-			if destSpec.NamespaceSelector != nil && (len(destSpec.NamespaceSelector.MatchLabels) > 0 || len(destSpec.NamespaceSelector.MatchExpressions) > 0) {
-				if selector, err := metav1.LabelSelectorAsSelector(destSpec.NamespaceSelector); err != nil {
-					return fmt.Errorf("error parsing destination namespace selector: %v", err)
-				} else {
-					destState.NamespaceSelector = selector
-				}
-			}
-			if destSpec.PodSelector != nil && (len(destSpec.PodSelector.MatchLabels) > 0 || len(destSpec.PodSelector.MatchExpressions) > 0) {
-				if selector, err := metav1.LabelSelectorAsSelector(destSpec.PodSelector); err != nil {
-					return fmt.Errorf("error parsing destination pod selector: %v", err)
-				} else {
-					destState.PodSelector = selector
-				}
-			}
-			destStates = append(destStates, destState)
-		}
-		ruleState.Classifier = &Classifier{
-			Destinations: destStates,
+		ruleState, err := newGressRule(nqos.Spec.Priority, index, false, ruleSpec.DSCP, ruleSpec.Bandwidth,
+			ruleSpec.Classifier.To, ruleSpec.Classifier.Ports)
+		if err != nil {
+			return err
 		}
-		ruleState.Classifier.Ports = ruleSpec.Classifier.Ports
 		rules = append(rules, ruleState)
 	}
 	desiredNQOSState.EgressRules = rules
+	rules = []*GressRule{}
+	for index, ruleSpec := range nqos.Spec.Ingress {
+		ruleState, err := newGressRule(nqos.Spec.Priority, index, true, ruleSpec.DSCP, ruleSpec.Bandwidth,
+			ruleSpec.Classifier.From, ruleSpec.Classifier.Ports)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, ruleState)
+	}
+	desiredNQOSState.IngressRules = rules
 	if err := desiredNQOSState.initAddressSets(c.addressSetFactory, c.controllerName); err != nil {
 		return err
 	}
@@ -171,6 +147,54 @@ func (c *Controller) ensureNetworkQos(nqos *networkqosapi.NetworkQoS) error {
 	return nil
 }
 
+// newGressRule builds the state of an egress or ingress rule, the peers are the destinations of the egress rules
+// and the sources of the ingress rules.
+func newGressRule(nqosPriority, index int, ingress bool, dscp int, bandwidth networkqosapi.Bandwidth,
+	peers []networkqosapi.Destination, ports []*networkqosapi.Port) (*GressRule, error) {
+	bwRate := int(bandwidth.Rate)
+	bwBurst := int(bandwidth.Burst)
+	ruleState := &GressRule{
+		RuleIndex: getRuleIndex(index, ingress),
+		Priority:  getQoSRulePriority(nqosPriority, index, ingress),
+		Dscp:      dscp,
+	}
+	if bwRate > 0 {
+		ruleState.Rate = &bwRate
+	}
+	if bwBurst > 0 {
+		ruleState.Burst = &bwBurst
+	}
+	destStates := []*Destination{}
+	for _, destSpec := range peers {
+		if destSpec.IPBlock != nil && (destSpec.PodSelector != nil || destSpec.NamespaceSelector != nil) {
+			return nil, fmt.Errorf("specifying both ipBlock and podSelector/namespaceSelector is not allowed")
+		}
+		destState := &Destination{}
+		destState.IpBlock = destSpec.IPBlock.DeepCopy()
+		if destSpec.NamespaceSelector != nil && (len(destSpec.NamespaceSelector.MatchLabels) > 0 || len(destSpec.NamespaceSelector.MatchExpressions) > 0) {
+			if selector, err := metav1.LabelSelectorAsSelector(destSpec.NamespaceSelector); err != nil {
+				return nil, fmt.Errorf("error parsing peer namespace selector: %v", err)
+			} else {
+				destState.NamespaceSelector = selector
+			}
+		}
+		if destSpec.PodSelector != nil && (len(destSpec.PodSelector.MatchLabels) > 0 || len(destSpec.PodSelector.MatchExpressions) > 0) {
+			if selector, err := metav1.LabelSelectorAsSelector(destSpec.PodSelector); err != nil {
+				return nil, fmt.Errorf("error parsing peer pod selector: %v", err)
+			} else {
+				destState.PodSelector = selector
+			}
+		}
+		destStates = append(destStates, destState)
+	}
+	ruleState.Classifier = &Classifier{
+		Destinations: destStates,
+		Ports:        ports,
+		Ingress:      ingress,
+	}
+	return ruleState, nil
+}
+
 // clearNetworkQos will handle the logic for deleting all db objects related
 // to the provided nqos which got deleted. it looks up object in OVN by comparing
 // the nqos name with the metadata in externalIDs.
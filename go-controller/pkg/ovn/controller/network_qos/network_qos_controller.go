@@ -480,9 +480,9 @@ func (c *Controller) onNQOSPodUpdate(oldObj, newObj interface{}) {
 		!newPod.GetDeletionTimestamp().IsZero() {
 		return
 	}
-	// We only care about pod's label changes, pod's IP changes
-	// pod going into completed state and pod getting scheduled and switching
-	// zones. Rest of the cases we may return
+	// We only care about pod's label changes, pod's IP changes,
+	// pod going into completed state, pod getting scheduled and switching
+	// zones and pod's NetworkQoS override changes. Rest of the cases we may return
 	oldPodLabels := labels.Set(oldPod.Labels)
 	newPodLabels := labels.Set(newPod.Labels)
 	oldPodIPs, _ := util.GetPodIPsOfNetwork(oldPod, c.NetInfo)
@@ -493,7 +493,8 @@ func (c *Controller) onNQOSPodUpdate(oldObj, newObj interface{}) {
 		// check for podIP changes (in case we allocate and deallocate) or for dualstack conversion
 		// it will also catch the pod update that will come when LSPAdd and IPAM allocation are done
 		len(oldPodIPs) == len(newPodIPs) &&
-		oldPodCompleted == newPodCompleted {
+		oldPodCompleted == newPodCompleted &&
+		oldPod.Annotations[NetworkQoSOverrideAnnotation] == newPod.Annotations[NetworkQoSOverrideAnnotation] {
 		return
 	}
 	klog.V(5).Infof("Handling update event for pod %s/%s, labels %v, podIPs: %v, PodCompleted?: %v", newPod.Namespace, newPod.Name, newPodLabels, newPodIPs, newPodCompleted)
@@ -4,11 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strconv"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 	"github.com/ovn-kubernetes/libovsdb/ovsdb"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -27,42 +28,107 @@ func (c *Controller) findLogicalSwitch(switchName string) (*nbdb.LogicalSwitch,
 }
 
 func (c *Controller) addQoSToLogicalSwitch(qosState *networkQoSState, switchName string) error {
-	// find lsw
-	lsw, err := c.findLogicalSwitch(switchName)
-	if err != nil {
-		return err
-	}
 	// construct qoses
 	qoses := []*nbdb.QoS{}
 	ipv4Enabled, ipv6Enabled := c.IPMode()
-	for index, rule := range qosState.EgressRules {
-		dbIDs := qosState.getDbObjectIDs(c.controllerName, index)
-		qos := &nbdb.QoS{
-			Action:      map[string]int{},
-			Bandwidth:   map[string]int{},
-			Direction:   nbdb.QoSDirectionToLport,
-			ExternalIDs: dbIDs.GetExternalIDs(),
-			Match:       generateNetworkQoSMatch(qosState, rule, ipv4Enabled, ipv6Enabled),
-			Priority:    rule.Priority,
-		}
-		if c.IsUserDefinedNetwork() {
-			qos.ExternalIDs[types.NetworkExternalID] = c.GetNetworkName()
-		}
-		if rule.Dscp >= 0 {
-			qos.Action[nbdb.QoSActionDSCP] = rule.Dscp
+	for _, rule := range qosState.getRules() {
+		match := generateNetworkQoSMatch(qosState, rule, ipv4Enabled, ipv6Enabled)
+		qoses = append(qoses, c.newQoS(qosState.getDbObjectIDs(c.controllerName, rule.RuleIndex), match, rule.Priority, rule.Dscp, rule.Rate, rule.Burst))
+	}
+	return c.addQoSesToLogicalSwitch(switchName, qoses)
+}
+
+// addPodOverrideToLogicalSwitch adds the QoSes overriding the rules of the NetworkQoS for a pod with the
+// NetworkQoSOverrideAnnotation to the switch of the pod.
+func (c *Controller) addPodOverrideToLogicalSwitch(qosState *networkQoSState, switchName, fullPodName string,
+	addresses []string, override *podQoSOverride) error {
+	qoses := []*nbdb.QoS{}
+	ipv4Enabled, ipv6Enabled := c.IPMode()
+	for _, rule := range qosState.getRules() {
+		dscp, rate, burst := rule.Dscp, rule.Rate, rule.Burst
+		if override.DSCP != nil {
+			dscp = *override.DSCP
 		}
-		if rule.Rate != nil && *rule.Rate > 0 {
-			qos.Bandwidth[nbdb.QoSBandwidthRate] = *rule.Rate
+		if override.Rate != nil {
+			rate = override.Rate
 		}
-		if rule.Burst != nil && *rule.Burst > 0 {
-			qos.Bandwidth[nbdb.QoSBandwidthBurst] = *rule.Burst
+		if override.Burst != nil {
+			burst = override.Burst
 		}
-		qoses = append(qoses, qos)
+		match := generatePodOverrideMatch(rule, addresses, ipv4Enabled, ipv6Enabled)
+		qoses = append(qoses, c.newQoS(qosState.getDbObjectIDs(c.controllerName, getPodOverrideRuleIndex(rule.RuleIndex, fullPodName)), match,
+			rule.Priority+podOverridePriorityOffset, dscp, rate, burst))
+	}
+	return c.addQoSesToLogicalSwitch(switchName, qoses)
+}
+
+// addPodQoSOverrideToLogicalSwitch adds the QoS applying the NetworkQoSOverrideAnnotation to the egress traffic
+// of a pod to the switch of the pod.
+func (c *Controller) addPodQoSOverrideToLogicalSwitch(switchName, fullPodName string, addresses []string,
+	override *podQoSOverride) error {
+	ipv4Enabled, ipv6Enabled := c.IPMode()
+	dscp := -1
+	if override.DSCP != nil {
+		dscp = *override.DSCP
+	}
+	match := addressesToMatchString(addresses, trafficDirSource, ipv4Enabled, ipv6Enabled)
+	qos := c.newQoS(getPodQoSOverrideDbIDs(c.controllerName, fullPodName), match, podQoSOverridePriority,
+		dscp, override.Rate, override.Burst)
+	return c.addQoSesToLogicalSwitch(switchName, []*nbdb.QoS{qos})
+}
+
+// deletePodQoSOverride deletes the QoS applying the NetworkQoSOverrideAnnotation to the egress traffic of a pod
+func (c *Controller) deletePodQoSOverride(fullPodName string) error {
+	predicate := libovsdbops.GetPredicate[*nbdb.QoS](getPodQoSOverrideDbIDs(c.controllerName, fullPodName), nil)
+	qoses, err := libovsdbops.FindQoSesWithPredicate(c.nbClient, predicate)
+	if err != nil {
+		return fmt.Errorf("failed to look up QoS overrides of pod %s: %w", fullPodName, err)
+	}
+	if len(qoses) == 0 {
+		return nil
+	}
+	if err = c.deleteOvnQoSes(qoses); err != nil {
+		return fmt.Errorf("error cleaning up QoS overrides of pod %s: %w", fullPodName, err)
+	}
+	return nil
+}
+
+func (c *Controller) newQoS(dbIDs *libovsdbops.DbObjectIDs, match string, priority, dscp int, rate, burst *int) *nbdb.QoS {
+	// both the egress and the ingress rules are applied to the traffic leaving the switch: towards
+	// the router or the other pods for the egress rules, towards the selected pods for the ingress rules
+	qos := &nbdb.QoS{
+		Action:      map[string]int{},
+		Bandwidth:   map[string]int{},
+		Direction:   nbdb.QoSDirectionToLport,
+		ExternalIDs: dbIDs.GetExternalIDs(),
+		Match:       match,
+		Priority:    priority,
+	}
+	if c.IsUserDefinedNetwork() {
+		qos.ExternalIDs[types.NetworkExternalID] = c.GetNetworkName()
+	}
+	if dscp >= 0 {
+		qos.Action[nbdb.QoSActionDSCP] = dscp
+	}
+	if rate != nil && *rate > 0 {
+		qos.Bandwidth[nbdb.QoSBandwidthRate] = *rate
+	}
+	if burst != nil && *burst > 0 {
+		qos.Bandwidth[nbdb.QoSBandwidthBurst] = *burst
+	}
+	return qos
+}
+
+func (c *Controller) addQoSesToLogicalSwitch(switchName string, qoses []*nbdb.QoS) error {
+	// find lsw
+	lsw, err := c.findLogicalSwitch(switchName)
+	if err != nil {
+		return err
 	}
 	ops := []ovsdb.Operation{}
 	ops, err = libovsdbops.CreateOrUpdateQoSesOps(c.nbClient, ops, qoses...)
 	if err != nil {
-		return fmt.Errorf("failed to create QoS operations for %s/%s: %w", qosState.namespace, qosState.name, err)
+		return fmt.Errorf("failed to create QoS operations for switch %s: %w", switchName, err)
 	}
 	// identify qoses need binding to lsw
 	newQoSes := []*nbdb.QoS{}
@@ -120,15 +186,21 @@ func (c *Controller) cleanupStaleOvnObjects(qosState *networkQoSState) error {
 		return fmt.Errorf("error looking up existing QoSes for %s/%s: %v", qosState.namespace, qosState.name, err)
 	}
 	staleSwitchQoSMap := map[string][]*nbdb.QoS{}
-	totalNumOfRules := len(qosState.EgressRules)
-	for _, qos := range existingQoSes {
-		index := qos.ExternalIDs[libovsdbops.RuleIndex.String()]
-		numIndex, convError := strconv.Atoi(index)
-		indexWithinRange := false
-		if index != "" && convError == nil && numIndex < totalNumOfRules {
-			// rule index is valid
-			indexWithinRange = true
+	ruleIndexes := sets.New[string]()
+	rules := qosState.getRules()
+	for _, rule := range rules {
+		ruleIndexes.Insert(rule.RuleIndex)
+	}
+	qosState.PodOverrides.Range(func(key, _ any) bool {
+		for _, rule := range rules {
+			ruleIndexes.Insert(getPodOverrideRuleIndex(rule.RuleIndex, key.(string)))
 		}
+		return true
+	})
+	for _, qos := range existingQoSes {
+		// rule index is valid if it matches one of the egress or ingress rules, or their overrides for the pods
+		// with the NetworkQoSOverrideAnnotation
+		indexWithinRange := ruleIndexes.Has(qos.ExternalIDs[libovsdbops.RuleIndex.String()])
 		// qos is considered stale since the index is out of range
 		// get switches that reference to the stale qos
 		switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(c.nbClient, func(ls *nbdb.LogicalSwitch) bool {
@@ -14,6 +14,7 @@ import (
 	"k8s.io/klog/v2"
 
 	nqosv1alpha1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
 func (c *Controller) processNextNQOSPodWorkItem(wg *sync.WaitGroup) bool {
@@ -48,13 +49,47 @@ func (c *Controller) syncNetworkQoSPod(eventData *eventData[*corev1.Pod]) error
 	for nqosName := range nqosNames {
 		c.nqosQueue.Add(nqosName)
 	}
+	if err := c.syncPodQoSOverride(eventData); err != nil {
+		return err
+	}
 	recordPodReconcileDuration(c.controllerName, time.Since(startTime).Milliseconds())
 	return nil
 }
 
-// setPodForNQOS will check if the pod meets source selector or dest selector
+// syncPodQoSOverride applies the NetworkQoSOverrideAnnotation of a local pod to its egress traffic, whether
+// a NetworkQoS selects the pod or not, or removes it when the pod is deleted, completed or not annotated anymore.
+func (c *Controller) syncPodQoSOverride(eventData *eventData[*corev1.Pod]) error {
+	pod := eventData.new
+	fullPodName := joinMetaNamespaceAndName(eventData.namespace(), eventData.name(), ":")
+	if pod == nil || util.PodCompleted(pod) || !c.isPodScheduledinLocalZone(pod) {
+		return c.deletePodQoSOverride(fullPodName)
+	}
+	addresses, err := getPodAddresses(pod, c.NetInfo)
+	if err != nil {
+		return fmt.Errorf("failed to parse addresses for pod %s/%s, network %s, err: %v", pod.Namespace, pod.Name, c.GetNetworkName(), err)
+	}
+	if len(addresses) == 0 {
+		// pod either is not attached to this network, or hasn't been annotated with addresses yet
+		return c.deletePodQoSOverride(fullPodName)
+	}
+	override, err := getPodQoSOverride(pod)
+	if err != nil {
+		klog.Warningf("Ignoring NetworkQoS override of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		c.eventRecorder.Eventf(pod, corev1.EventTypeWarning, "InvalidNetworkQoSOverride", err.Error())
+	}
+	if override == nil || *override == (podQoSOverride{}) {
+		return c.deletePodQoSOverride(fullPodName)
+	}
+	switchName := c.getLogicalSwitchName(pod.Spec.NodeName)
+	if switchName == "" {
+		return fmt.Errorf("failed to get logical switch name for node %s, topology %s", pod.Spec.NodeName, c.TopologyType())
+	}
+	return c.addPodQoSOverrideToLogicalSwitch(switchName, fullPodName, addresses, override)
+}
+
+// setPodForNQOS will check if the pod meets source selector or peer selector
 // - match source: add the ip to source address set, bind qos rule to the switch
-// - match dest: add the ip to the destination address set
+// - match peer: add the ip to the destination address set of the egress or ingress rule
 func (c *Controller) setPodForNQOS(pod *corev1.Pod, nqosState *networkQoSState, namespace *corev1.Namespace, addressSetMap map[string]sets.Set[string]) error {
 	addresses, err := getPodAddresses(pod, c.NetInfo)
 	if err == nil && len(addresses) == 0 {
@@ -91,7 +126,7 @@ func (c *Controller) setPodForNQOS(pod *corev1.Pod, nqosState *networkQoSState,
 
 func reconcilePodForDestinations(nqosState *networkQoSState, podNs *corev1.Namespace, pod *corev1.Pod, addresses []string, addressSetMap map[string]sets.Set[string]) error {
 	fullPodName := joinMetaNamespaceAndName(pod.Namespace, pod.Name)
-	for _, rule := range nqosState.EgressRules {
+	for _, rule := range nqosState.getRules() {
 		for index, dest := range rule.Classifier.Destinations {
 			if dest.PodSelector == nil && dest.NamespaceSelector == nil {
 				continue
@@ -134,12 +169,10 @@ func (c *Controller) getNetworkQosForPodChange(eventData *eventData[*corev1.Pod]
 			affectedNetworkQoSes.Insert(joinMetaNamespaceAndName(nqos.Namespace, nqos.Name))
 			continue
 		}
-		// check if pod matches any egress
-		for _, egress := range nqos.Spec.Egress {
-			if podMatchesEgressSelector(podNs, pod, nqos, &egress) {
-				affectedNetworkQoSes.Insert(joinMetaNamespaceAndName(nqos.Namespace, nqos.Name))
-				continue
-			}
+		// check if pod matches any egress or ingress peer
+		if podMatchesPeerSelector(podNs, pod, nqos, getNetworkQoSPeers(nqos)) {
+			affectedNetworkQoSes.Insert(joinMetaNamespaceAndName(nqos.Namespace, nqos.Name))
+			continue
 		}
 		if podSelectionChanged(nqos, eventData.new, eventData.old) {
 			affectedNetworkQoSes.Insert(joinMetaNamespaceAndName(nqos.Namespace, nqos.Name))
@@ -163,12 +196,12 @@ func podMatchesSourceSelector(pod *corev1.Pod, nqos *nqosv1alpha1.NetworkQoS) bo
 	return podSelector.Matches(labels.Set(pod.Labels))
 }
 
-func podMatchesEgressSelector(podNs *corev1.Namespace, pod *corev1.Pod, nqos *nqosv1alpha1.NetworkQoS, egress *nqosv1alpha1.Rule) bool {
+func podMatchesPeerSelector(podNs *corev1.Namespace, pod *corev1.Pod, nqos *nqosv1alpha1.NetworkQoS, peers []nqosv1alpha1.Destination) bool {
 	var nsSelector labels.Selector
 	var podSelector labels.Selector
 	var err error
 	match := false
-	for _, dest := range egress.Classifier.To {
+	for _, dest := range peers {
 		if dest.NamespaceSelector != nil {
 			if nsSelector, err = metav1.LabelSelectorAsSelector(dest.NamespaceSelector); err != nil {
 				klog.Errorf("Failed to convert namespace selector in %s/%s: %v", nqos.Namespace, nqos.Name, err)
@@ -209,16 +242,14 @@ func podSelectionChanged(nqos *nqosv1alpha1.NetworkQoS, new *corev1.Pod, old *co
 			return true
 		}
 	}
-	for _, egress := range nqos.Spec.Egress {
-		for _, dest := range egress.Classifier.To {
-			if dest.PodSelector == nil {
-				continue
-			}
-			if podSelector, err := metav1.LabelSelectorAsSelector(dest.PodSelector); err != nil {
-				klog.Errorf("Failed to convert pod selector in %s/%s: %v", nqos.Namespace, nqos.Name, err)
-			} else if podSelector.Matches(labels.Set(new.Labels)) != podSelector.Matches(labels.Set(old.Labels)) {
-				return true
-			}
+	for _, dest := range getNetworkQoSPeers(nqos) {
+		if dest.PodSelector == nil {
+			continue
+		}
+		if podSelector, err := metav1.LabelSelectorAsSelector(dest.PodSelector); err != nil {
+			klog.Errorf("Failed to convert pod selector in %s/%s: %v", nqos.Namespace, nqos.Name, err)
+		} else if podSelector.Matches(labels.Set(new.Labels)) != podSelector.Matches(labels.Set(old.Labels)) {
+			return true
 		}
 	}
 	return false
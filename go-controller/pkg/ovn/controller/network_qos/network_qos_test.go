@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -1073,6 +1074,436 @@ var _ = Describe("NetworkQoS Controller", func() {
 			Entry("Interconnect Disabled", false),
 			Entry("Interconnect Enabled", true),
 		)
+
+		DescribeTable("When starting controller with a NetworkQoS with ingress rules",
+			func(enableInterconnect bool) {
+				tableEntrySetup(enableInterconnect)
+				const ingressQoSName = "ingress-qos"
+
+				app1Pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: app1Namespace,
+						Name:      "app1-pod",
+						Labels: map[string]string{
+							"component": "service1",
+						},
+						Annotations: map[string]string{
+							"k8s.ovn.org/pod-networks": `{"default":{"ip_addresses":["10.194.188.4/26"],"mac_address":"0a:58:0a:c2:bc:04","gateway_ips":["10.194.188.1"],"routes":[{"dest":"10.194.0.0/16","nextHop":"10.194.188.1"},{"dest":"10.223.0.0/16","nextHop":"10.194.188.1"},{"dest":"100.64.0.0/16","nextHop":"10.194.188.1"}],"mtu":"1500","ip_address":"10.194.188.4/26","gateway_ip":"10.194.188.1"}}`,
+						},
+					},
+					Spec: corev1.PodSpec{
+						HostNetwork: false,
+						NodeName:    "node2",
+					},
+				}
+				_, err := fakeKubeClient.CoreV1().Pods(app1Pod.Namespace).Create(context.TODO(), app1Pod, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				ingressQoS := &nqostype.NetworkQoS{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: nqosNamespace,
+						Name:      ingressQoSName,
+					},
+					Spec: nqostype.Spec{
+						Priority: 10,
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "client",
+							},
+						},
+						Egress: []nqostype.Rule{
+							{
+								DSCP: 20,
+							},
+						},
+						Ingress: []nqostype.IngressRule{
+							{
+								DSCP: 10,
+								Bandwidth: nqostype.Bandwidth{
+									Rate:  20000,
+									Burst: 200000,
+								},
+								Classifier: nqostype.IngressClassifier{
+									From: []nqostype.Destination{
+										{
+											PodSelector: &metav1.LabelSelector{
+												MatchLabels: map[string]string{
+													"component": "service1",
+												},
+											},
+											NamespaceSelector: &metav1.LabelSelector{
+												MatchLabels: map[string]string{
+													"app": "app1",
+												},
+											},
+										},
+									},
+									Ports: []*nqostype.Port{
+										{
+											Protocol: "tcp",
+											Port:     &port8080,
+										},
+									},
+								},
+							},
+							{
+								DSCP: 11,
+								Classifier: nqostype.IngressClassifier{
+									From: []nqostype.Destination{
+										{
+											IPBlock: &networkingv1.IPBlock{
+												CIDR: "128.116.0.0/17",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				By("creates to-lport QoS rules matching the traffic received by the selected pods")
+				{
+					_, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Create(context.TODO(), ingressQoS, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					eventuallyAddressSetHas(defaultAddrsetFactory, nqosNamespace, ingressQoSName, "src", "0", defaultControllerName, "10.192.177.4")
+					eventuallyAddressSetHas(defaultAddrsetFactory, nqosNamespace, ingressQoSName, "ingress-0", "0", defaultControllerName, "10.194.188.4")
+					selectedAddrSet, err := findAddressSet(defaultAddrsetFactory, nqosNamespace, ingressQoSName, "src", "0", defaultControllerName)
+					Expect(err).NotTo(HaveOccurred())
+					selectedHashName4, _ := selectedAddrSet.GetASHashNames()
+					sourceAddrSet, err := findAddressSet(defaultAddrsetFactory, nqosNamespace, ingressQoSName, "ingress-0", "0", defaultControllerName)
+					Expect(err).NotTo(HaveOccurred())
+					sourceHashName4, _ := sourceAddrSet.GetASHashNames()
+
+					var ingress0, ingress1 *nbdb.QoS
+					Eventually(func(g Gomega) {
+						ingress0, err = findQoSWithRuleIndex(defaultControllerName, nqosNamespace, ingressQoSName, "ingress-0")
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(ingress0).NotTo(BeNil())
+						ingress1, err = findQoSWithRuleIndex(defaultControllerName, nqosNamespace, ingressQoSName, "ingress-1")
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(ingress1).NotTo(BeNil())
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+					Expect(ingress0.Direction).To(Equal(nbdb.QoSDirectionToLport))
+					Expect(ingress0.Match).To(Equal(fmt.Sprintf("ip4.dst == {$%s} && ip4.src == {$%s} && tcp && tcp.dst == 8080", selectedHashName4, sourceHashName4)))
+					Expect(ingress0.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 10}))
+					Expect(ingress0.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 20000, nbdb.QoSBandwidthBurst: 200000}))
+					Expect(ingress0.Priority).To(Equal(20100))
+					Expect(ingress1.Match).To(Equal(fmt.Sprintf("ip4.dst == {$%s} && ip4.src == 128.116.0.0/17", selectedHashName4)))
+					Expect(ingress1.Priority).To(Equal(20101))
+					eventuallySwitchHasQoS("node1", ingress0)
+					eventuallySwitchHasQoS("node1", ingress1)
+
+					// the egress rule is still programmed along with the ingress rules
+					egress0 := eventuallyExpectQoS(defaultControllerName, nqosNamespace, ingressQoSName, 0)
+					Expect(egress0.Match).To(Equal(fmt.Sprintf("ip4.src == {$%s}", selectedHashName4)))
+					eventuallySwitchHasQoS("node1", egress0)
+
+					Eventually(func() []metav1.Condition {
+						nqos, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), ingressQoSName, metav1.GetOptions{})
+						Expect(err).NotTo(HaveOccurred())
+						return nqos.Status.Conditions
+					}).WithTimeout(10 * time.Second).Should(ContainElement(And(
+						HaveField("Type", conditionTypeReady+"node1"),
+						HaveField("Status", metav1.ConditionTrue))))
+				}
+
+				By("removes IP from the ingress source address set if pod's labels don't match the selector")
+				{
+					updatePod := app1Pod.DeepCopy()
+					updatePod.Labels["component"] = "dummy"
+					updatePod.ResourceVersion = time.Now().String()
+					_, err := fakeKubeClient.CoreV1().Pods(app1Pod.Namespace).Update(context.TODO(), updatePod, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					eventuallyAddressSetHasNo(defaultAddrsetFactory, nqosNamespace, ingressQoSName, "ingress-0", "0", defaultControllerName, "10.194.188.4")
+				}
+
+				By("deletes stale ingress QoS from ovn nb when an Ingress rule is deleted")
+				{
+					ingress1, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, ingressQoSName, "ingress-1")
+					Expect(err).NotTo(HaveOccurred())
+					egress0, err := findQoS(defaultControllerName, nqosNamespace, ingressQoSName, 0)
+					Expect(err).NotTo(HaveOccurred())
+					nqosUpdate, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), ingressQoSName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					nqosUpdate.ResourceVersion = time.Now().String()
+					nqosUpdate.Spec.Ingress = nqosUpdate.Spec.Ingress[:1]
+					_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Update(context.TODO(), nqosUpdate, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					eventuallySwitchHasNoQoS("node1", ingress1)
+					Eventually(func() (*nbdb.QoS, error) {
+						return findQoSWithRuleIndex(defaultControllerName, nqosNamespace, ingressQoSName, "ingress-1")
+					}).WithTimeout(10 * time.Second).Should(BeNil())
+					eventuallySwitchHasQoS("node1", egress0)
+				}
+
+				By("deletes the ingress QoS after NetworkQoS object is deleted")
+				{
+					err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Delete(context.TODO(), ingressQoSName, metav1.DeleteOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(func() (*nbdb.QoS, error) {
+						return findQoSWithRuleIndex(defaultControllerName, nqosNamespace, ingressQoSName, "ingress-0")
+					}).WithTimeout(10 * time.Second).Should(BeNil())
+					eventuallyExpectNoQoS(defaultControllerName, nqosNamespace, ingressQoSName, 0)
+				}
+			},
+			Entry("Interconnect Disabled", false),
+			Entry("Interconnect Enabled", true),
+		)
+
+		DescribeTable("When both pods of the traffic are selected by an egress and an ingress rule",
+			func(enableInterconnect bool) {
+				tableEntrySetup(enableInterconnect)
+				const serverQoSName = "server-qos"
+
+				serverPod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: app1Namespace,
+						Name:      "server-pod",
+						Labels: map[string]string{
+							"component": "service1",
+						},
+						Annotations: map[string]string{
+							"k8s.ovn.org/pod-networks": `{"default":{"ip_addresses":["10.192.177.5/26"],"mac_address":"0a:58:0a:c0:b1:05","gateway_ips":["10.192.177.1"],"routes":[{"dest":"10.192.0.0/16","nextHop":"10.192.177.1"},{"dest":"10.223.0.0/16","nextHop":"10.192.177.1"},{"dest":"100.64.0.0/16","nextHop":"10.192.177.1"}],"mtu":"1500","ip_address":"10.192.177.5/26","gateway_ip":"10.192.177.1"}}`,
+						},
+					},
+					Spec: corev1.PodSpec{
+						HostNetwork: false,
+						NodeName:    "node1",
+					},
+				}
+				_, err := fakeKubeClient.CoreV1().Pods(serverPod.Namespace).Create(context.TODO(), serverPod, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				// the NetworkQoS of the client pod has a higher priority, its egress rule still
+				// doesn't take precedence over the ingress rule of the server pod
+				serverQoS := &nqostype.NetworkQoS{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: app1Namespace,
+						Name:      serverQoSName,
+					},
+					Spec: nqostype.Spec{
+						Priority: 0,
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"component": "service1",
+							},
+						},
+						Ingress: []nqostype.IngressRule{
+							{
+								DSCP: 30,
+								Classifier: nqostype.IngressClassifier{
+									From: []nqostype.Destination{
+										{
+											NamespaceSelector: &metav1.LabelSelector{
+												MatchLabels: map[string]string{
+													"app": "client",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(app1Namespace).Create(context.TODO(), serverQoS, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("selects the server pod as destination of the egress rule and the client pod as source of the ingress rule")
+				{
+					eventuallyAddressSetHas(defaultAddrsetFactory, nqosNamespace, nqosName, "0", "0", defaultControllerName, "10.192.177.5")
+					eventuallyAddressSetHas(defaultAddrsetFactory, app1Namespace, serverQoSName, "src", "0", defaultControllerName, "10.192.177.5")
+					eventuallyAddressSetHas(defaultAddrsetFactory, app1Namespace, serverQoSName, "ingress-0", "0", defaultControllerName, "10.192.177.4")
+				}
+
+				By("gives the ingress rule precedence over the egress rule on the switch of both pods")
+				{
+					egress0 := eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, 0)
+					var ingress0 *nbdb.QoS
+					Eventually(func(g Gomega) {
+						ingress0, err = findQoSWithRuleIndex(defaultControllerName, app1Namespace, serverQoSName, "ingress-0")
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(ingress0).NotTo(BeNil())
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+					Expect(egress0.Priority).To(Equal(11000))
+					Expect(ingress0.Priority).To(Equal(20000))
+					Expect(ingress0.Priority).To(BeNumerically(">", egress0.Priority+podOverridePriorityOffset))
+					Expect(ingress0.Direction).To(Equal(egress0.Direction))
+					eventuallySwitchHasQoS("node1", egress0)
+					eventuallySwitchHasQoS("node1", ingress0)
+				}
+			},
+			Entry("Interconnect Disabled", false),
+			Entry("Interconnect Enabled", true),
+		)
+
+		DescribeTable("When a pod overrides the NetworkQoS rules with an annotation",
+			func(enableInterconnect bool) {
+				tableEntrySetup(enableInterconnect)
+				fullPodName := joinMetaNamespaceAndName(nqosNamespace, clientPodName)
+
+				qos0 := eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, 0)
+				qos1 := eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, 1)
+				sourceAddrSet, err := findAddressSet(defaultAddrsetFactory, nqosNamespace, nqosName, "src", "0", defaultControllerName)
+				Expect(err).NotTo(HaveOccurred())
+				srcHashName4, _ := sourceAddrSet.GetASHashNames()
+
+				annotatePod := func(override string) {
+					pod, err := fakeKubeClient.CoreV1().Pods(nqosNamespace).Get(context.TODO(), clientPodName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					if override == "" {
+						delete(pod.Annotations, NetworkQoSOverrideAnnotation)
+					} else {
+						pod.Annotations[NetworkQoSOverrideAnnotation] = override
+					}
+					pod.ResourceVersion = time.Now().String()
+					_, err = fakeKubeClient.CoreV1().Pods(nqosNamespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				By("creates QoS rules for the pod taking precedence over the rules of the NetworkQoS")
+				{
+					annotatePod(`{"dscp": 20, "rate": 5000}`)
+					var override0, override1 *nbdb.QoS
+					Eventually(func(g Gomega) {
+						override0, err = findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("0", fullPodName))
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(override0).NotTo(BeNil())
+						override1, err = findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("1", fullPodName))
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(override1).NotTo(BeNil())
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+					// the pod address replaces the address set of the selected pods
+					Expect(override0.Match).To(Equal(strings.Replace(qos0.Match, fmt.Sprintf("{$%s}", srcHashName4), "{10.192.177.4}", 1)))
+					Expect(override0.Priority).To(Equal(qos0.Priority + podOverridePriorityOffset))
+					Expect(override0.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 20}))
+					// the burst isn't overridden
+					Expect(override0.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 5000, nbdb.QoSBandwidthBurst: 100000}))
+					Expect(override1.Match).To(Equal(strings.Replace(qos1.Match, fmt.Sprintf("{$%s}", srcHashName4), "{10.192.177.4}", 1)))
+					Expect(override1.Priority).To(Equal(qos1.Priority + podOverridePriorityOffset))
+					Expect(override1.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 20}))
+					Expect(override1.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 5000}))
+					eventuallySwitchHasQoS("node1", override0)
+					eventuallySwitchHasQoS("node1", override1)
+					eventuallySwitchHasQoS("node1", qos0)
+				}
+
+				By("applies the annotation to the traffic of the pod that isn't classified by the rules")
+				{
+					var podQoS *nbdb.QoS
+					Eventually(func(g Gomega) {
+						podQoS, err = findPodQoSOverride(defaultControllerName, nqosNamespace, clientPodName)
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(podQoS).NotTo(BeNil())
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+					Expect(podQoS.Match).To(Equal("ip4.src == {10.192.177.4}"))
+					Expect(podQoS.Priority).To(BeNumerically("<", qos0.Priority))
+					Expect(podQoS.Priority).To(BeNumerically("<", qos1.Priority))
+					Expect(podQoS.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 20}))
+					Expect(podQoS.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 5000}))
+					eventuallySwitchHasQoS("node1", podQoS)
+				}
+
+				By("updates the QoS rules of the pod when the annotation changes")
+				{
+					annotatePod(`{"burst": 50000}`)
+					Eventually(func(g Gomega) {
+						override0, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("0", fullPodName))
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(override0).NotTo(BeNil())
+						g.Expect(override0.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 50}))
+						g.Expect(override0.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 10000, nbdb.QoSBandwidthBurst: 50000}))
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+				}
+
+				By("removes the QoS rules of the pod when the annotation is invalid")
+				{
+					override0, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("0", fullPodName))
+					Expect(err).NotTo(HaveOccurred())
+					annotatePod(`{"dscp": 64}`)
+					eventuallySwitchHasNoQoS("node1", override0)
+					eventuallySwitchHasQoS("node1", qos0)
+				}
+
+				By("creates the QoS rules of the pod again once the annotation is fixed")
+				{
+					annotatePod(`{"dscp": 63}`)
+					Eventually(func(g Gomega) {
+						override0, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("0", fullPodName))
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(override0).NotTo(BeNil())
+						g.Expect(override0.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 63}))
+					}).WithTimeout(10 * time.Second).Should(Succeed())
+				}
+
+				By("removes the QoS rules of the pod when the annotation is removed")
+				{
+					override0, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("0", fullPodName))
+					Expect(err).NotTo(HaveOccurred())
+					override1, err := findQoSWithRuleIndex(defaultControllerName, nqosNamespace, nqosName, getPodOverrideRuleIndex("1", fullPodName))
+					Expect(err).NotTo(HaveOccurred())
+					podQoS, err := findPodQoSOverride(defaultControllerName, nqosNamespace, clientPodName)
+					Expect(err).NotTo(HaveOccurred())
+					annotatePod("")
+					eventuallySwitchHasNoQoS("node1", override0)
+					eventuallySwitchHasNoQoS("node1", override1)
+					eventuallySwitchHasNoQoS("node1", podQoS)
+					eventuallySwitchHasQoS("node1", qos0)
+					eventuallySwitchHasQoS("node1", qos1)
+				}
+			},
+			Entry("Interconnect Disabled", false),
+			Entry("Interconnect Enabled", true),
+		)
+
+		DescribeTable("When a pod that no NetworkQoS selects has the override annotation",
+			func(enableInterconnect bool) {
+				tableEntrySetup(enableInterconnect)
+				const podName = "unselected-pod"
+
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: app1Namespace,
+						Name:      podName,
+						Annotations: map[string]string{
+							"k8s.ovn.org/pod-networks":   `{"default":{"ip_addresses":["10.192.177.6/26"],"mac_address":"0a:58:0a:c0:b1:06","gateway_ips":["10.192.177.1"],"routes":[{"dest":"10.192.0.0/16","nextHop":"10.192.177.1"},{"dest":"10.223.0.0/16","nextHop":"10.192.177.1"},{"dest":"100.64.0.0/16","nextHop":"10.192.177.1"}],"mtu":"1500","ip_address":"10.192.177.6/26","gateway_ip":"10.192.177.1"}}`,
+							NetworkQoSOverrideAnnotation: `{"dscp": 20, "rate": 5000, "burst": 50000}`,
+						},
+					},
+					Spec: corev1.PodSpec{
+						HostNetwork: false,
+						NodeName:    "node1",
+					},
+				}
+				_, err := fakeKubeClient.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("applies the annotation to the egress traffic of the pod")
+				var podQoS *nbdb.QoS
+				Eventually(func(g Gomega) {
+					podQoS, err = findPodQoSOverride(defaultControllerName, app1Namespace, podName)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(podQoS).NotTo(BeNil())
+				}).WithTimeout(10 * time.Second).Should(Succeed())
+				Expect(podQoS.Match).To(Equal("ip4.src == {10.192.177.6}"))
+				Expect(podQoS.Direction).To(Equal(nbdb.QoSDirectionToLport))
+				Expect(podQoS.Priority).To(Equal(podQoSOverridePriority))
+				Expect(podQoS.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 20}))
+				Expect(podQoS.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 5000, nbdb.QoSBandwidthBurst: 50000}))
+				eventuallySwitchHasQoS("node1", podQoS)
+
+				By("removes the QoS of the pod when the pod is deleted")
+				err = fakeKubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), podName, metav1.DeleteOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				eventuallySwitchHasNoQoS("node1", podQoS)
+				Eventually(func() (*nbdb.QoS, error) {
+					return findPodQoSOverride(defaultControllerName, app1Namespace, podName)
+				}).WithTimeout(10 * time.Second).Should(BeNil())
+			},
+			Entry("Interconnect Disabled", false),
+			Entry("Interconnect Enabled", true),
+		)
 	})
 })
 
@@ -1128,15 +1559,19 @@ func eventuallyExpectNoQoS(controllerName, qosNamespace, qosName string, index i
 }
 
 func findQoS(controllerName, qosNamespace, qosName string, index int) (*nbdb.QoS, error) {
+	return findQoSWithRuleIndex(controllerName, qosNamespace, qosName, strconv.Itoa(index))
+}
+
+func findQoSWithRuleIndex(controllerName, qosNamespace, qosName, ruleIndex string) (*nbdb.QoS, error) {
 	qosKey := joinMetaNamespaceAndName(qosNamespace, qosName, ":")
 	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.NetworkQoS, controllerName, map[libovsdbops.ExternalIDKey]string{
 		libovsdbops.ObjectNameKey: qosKey,
-		libovsdbops.RuleIndex:     fmt.Sprintf("%d", index),
+		libovsdbops.RuleIndex:     ruleIndex,
 	})
 	predicate := libovsdbops.GetPredicate(dbIDs, func(item *nbdb.QoS) bool {
 		return item.ExternalIDs[libovsdbops.OwnerControllerKey.String()] == controllerName &&
 			item.ExternalIDs[libovsdbops.ObjectNameKey.String()] == qosKey &&
-			item.ExternalIDs[libovsdbops.RuleIndex.String()] == strconv.Itoa(index)
+			item.ExternalIDs[libovsdbops.RuleIndex.String()] == ruleIndex
 	})
 	qoses, err := libovsdbops.FindQoSesWithPredicate(nbClient, predicate)
 	if err != nil {
@@ -1148,6 +1583,18 @@ func findQoS(controllerName, qosNamespace, qosName string, index int) (*nbdb.QoS
 	return nil, nil
 }
 
+func findPodQoSOverride(controllerName, podNamespace, podName string) (*nbdb.QoS, error) {
+	predicate := libovsdbops.GetPredicate[*nbdb.QoS](getPodQoSOverrideDbIDs(controllerName, joinMetaNamespaceAndName(podNamespace, podName, ":")), nil)
+	qoses, err := libovsdbops.FindQoSesWithPredicate(nbClient, predicate)
+	if err != nil {
+		return nil, err
+	}
+	if len(qoses) == 1 {
+		return qoses[0], nil
+	}
+	return nil, nil
+}
+
 func eventuallySwitchHasQoS(switchName string, qos *nbdb.QoS) {
 	var ls *nbdb.LogicalSwitch
 	Eventually(func() bool {
@@ -1,8 +1,11 @@
 package networkqos
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 
 	networkqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
@@ -30,7 +33,8 @@ func (c *Controller) repairNetworkQoSes() error {
 	// delete stale ovn qos objects owned by NetworkQoS
 	if err := libovsdbops.DeleteQoSesWithPredicate(c.nbClient, func(qos *nbdb.QoS) bool {
 		if qos.ExternalIDs[libovsdbops.OwnerControllerKey.String()] != c.controllerName ||
-			qos.ExternalIDs[libovsdbops.OwnerTypeKey.String()] == string(libovsdbops.NetworkQoSOwnerType) {
+			qos.ExternalIDs[libovsdbops.OwnerTypeKey.String()] == string(libovsdbops.NetworkQoSOwnerType) ||
+			qos.ExternalIDs[libovsdbops.OwnerTypeKey.String()] == string(libovsdbops.NetworkQoSPodOwnerType) {
 			return false
 		}
 		objName := qos.ExternalIDs[libovsdbops.ObjectNameKey.String()]
@@ -49,6 +53,11 @@ func (c *Controller) repairNetworkQoSes() error {
 		klog.Errorf("Failed to get ops to clean up stale QoSes: %v", err)
 	}
 
+	// delete the pod overrides whose pod has gone in k8s, the pods that still exist are synced by the pod workers
+	if err := c.repairPodQoSOverrides(); err != nil {
+		klog.Errorf("Failed to clean up stale QoS overrides of pods: %v", err)
+	}
+
 	// delete address sets whose networkqos object has gone in k8s
 	if err := libovsdbops.DeleteAddressSetsWithPredicate(c.nbClient, func(addrset *nbdb.AddressSet) bool {
 		if addrset.ExternalIDs[libovsdbops.OwnerControllerKey.String()] != c.controllerName ||
@@ -73,3 +82,29 @@ func (c *Controller) repairNetworkQoSes() error {
 
 	return nil
 }
+
+func (c *Controller) repairPodQoSOverrides() error {
+	staleQoSes, err := libovsdbops.FindQoSesWithPredicate(c.nbClient, func(qos *nbdb.QoS) bool {
+		if qos.ExternalIDs[libovsdbops.OwnerControllerKey.String()] != c.controllerName ||
+			qos.ExternalIDs[libovsdbops.OwnerTypeKey.String()] != string(libovsdbops.NetworkQoSPodOwnerType) {
+			return false
+		}
+		namespace, name, found := strings.Cut(qos.ExternalIDs[libovsdbops.ObjectNameKey.String()], ":")
+		if !found {
+			klog.Warningf("OVN QoS %s doesn't have expected key %s", qos.UUID, libovsdbops.ObjectNameKey.String())
+			return true
+		}
+		if _, err := c.nqosPodLister.Pods(namespace).Get(name); apierrors.IsNotFound(err) {
+			klog.Warningf("OVN QoS %s doesn't have expected pod %s/%s", qos.UUID, namespace, name)
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look up QoS overrides of pods: %w", err)
+	}
+	if len(staleQoSes) == 0 {
+		return nil
+	}
+	return c.deleteOvnQoSes(staleQoSes)
+}
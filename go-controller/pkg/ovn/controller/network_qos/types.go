@@ -1,6 +1,7 @@
 package networkqos
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 	"sort"
@@ -22,6 +23,67 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
+// NetworkQoSOverrideAnnotation is the pod annotation overriding the DSCP and the bandwidth of the traffic of the
+// pod, e.g. '{"dscp": 10, "rate": 10000, "burst": 100000}'. It overrides the rules of the NetworkQoSes selecting
+// the pod, the values that are not set in the annotation are taken from the rules, and applies to the rest of the
+// egress traffic of the pod, whether a NetworkQoS selects the pod or not.
+const NetworkQoSOverrideAnnotation = "k8s.ovn.org/network-qos-override"
+
+// podOverridePriorityOffset is added to the priority of the rules for the QoSes of the pods with the
+// NetworkQoSOverrideAnnotation, so that they take precedence over the rules of all the NetworkQoSes in
+// the same direction
+const podOverridePriorityOffset = 2000
+
+// podQoSOverridePriority is the priority of the QoS applying the NetworkQoSOverrideAnnotation to the egress
+// traffic of a pod, lower than the priority of the rules of all the NetworkQoSes, so that it only applies to
+// the traffic that isn't classified by a rule
+const podQoSOverridePriority = 5000
+
+// ingressRulePriorityOffset is added to the priority of the ingress rules, so that they take precedence
+// over the egress rules, including the pod overrides, when both the sender and the receiver of the traffic
+// are selected: both are applied in the to-lport QoS stage, where only the highest priority match applies
+const ingressRulePriorityOffset = 10000
+
+// podQoSOverride is the value of the NetworkQoSOverrideAnnotation
+type podQoSOverride struct {
+	DSCP  *int `json:"dscp,omitempty"`
+	Rate  *int `json:"rate,omitempty"`
+	Burst *int `json:"burst,omitempty"`
+}
+
+// getPodQoSOverride returns the parsed NetworkQoSOverrideAnnotation of the pod, nil if it isn't set.
+func getPodQoSOverride(pod *corev1.Pod) (*podQoSOverride, error) {
+	annotation, ok := pod.Annotations[NetworkQoSOverrideAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	override := &podQoSOverride{}
+	if err := json.Unmarshal([]byte(annotation), override); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q: %w", NetworkQoSOverrideAnnotation, annotation, err)
+	}
+	if override.DSCP != nil && (*override.DSCP < 0 || *override.DSCP > 63) {
+		return nil, fmt.Errorf("invalid dscp %d in %s annotation: expect a value from 0 to 63", *override.DSCP, NetworkQoSOverrideAnnotation)
+	}
+	if override.Rate != nil && *override.Rate < 1 {
+		return nil, fmt.Errorf("invalid rate %d in %s annotation: expect a positive value", *override.Rate, NetworkQoSOverrideAnnotation)
+	}
+	if override.Burst != nil && *override.Burst < 1 {
+		return nil, fmt.Errorf("invalid burst %d in %s annotation: expect a positive value", *override.Burst, NetworkQoSOverrideAnnotation)
+	}
+	return override, nil
+}
+
+// getPodOverrideRuleIndex returns the rule index of the QoS overriding a rule for a pod
+func getPodOverrideRuleIndex(ruleIndex, fullPodName string) string {
+	return ruleIndex + "/" + fullPodName
+}
+
+func getPodQoSOverrideDbIDs(controller, fullPodName string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.NetworkQoSPod, controller, map[libovsdbops.ExternalIDKey]string{
+		libovsdbops.ObjectNameKey: fullPodName,
+	})
+}
+
 // networkQoSState is the cache that keeps the state of a single
 // network qos in the cluster with namespace+name being unique
 type networkQoSState struct {
@@ -34,22 +96,31 @@ type networkQoSState struct {
 	Pods        sync.Map // pods name -> ips in the srcAddrSet
 	SwitchRefs  sync.Map // switch name -> list of source pods
 	PodSelector labels.Selector
+	// PodOverrides are the source pods with the NetworkQoSOverrideAnnotation
+	PodOverrides sync.Map // pods name -> *podQoSOverride
 
 	// egressRules stores the objects needed to track .Spec.Egress changes
 	EgressRules []*GressRule
+	// IngressRules stores the objects needed to track .Spec.Ingress changes
+	IngressRules []*GressRule
 }
 
 func (nqosState *networkQoSState) getObjectNameKey() string {
 	return joinMetaNamespaceAndName(nqosState.namespace, nqosState.name, ":")
 }
 
-func (nqosState *networkQoSState) getDbObjectIDs(controller string, ruleIndex int) *libovsdbops.DbObjectIDs {
+func (nqosState *networkQoSState) getDbObjectIDs(controller string, ruleIndex string) *libovsdbops.DbObjectIDs {
 	return libovsdbops.NewDbObjectIDs(libovsdbops.NetworkQoS, controller, map[libovsdbops.ExternalIDKey]string{
 		libovsdbops.ObjectNameKey: nqosState.getObjectNameKey(),
-		libovsdbops.RuleIndex:     fmt.Sprintf("%d", ruleIndex),
+		libovsdbops.RuleIndex:     ruleIndex,
 	})
 }
 
+// getRules returns the egress rules followed by the ingress rules
+func (nqosState *networkQoSState) getRules() []*GressRule {
+	return slices.Concat(nqosState.EgressRules, nqosState.IngressRules)
+}
+
 func (nqosState *networkQoSState) emptyPodSelector() bool {
 	return nqosState.PodSelector == nil || nqosState.PodSelector.Empty()
 }
@@ -65,13 +136,13 @@ func (nqosState *networkQoSState) initAddressSets(addressSetFactory addressset.A
 	if err != nil {
 		return fmt.Errorf("failed to init source address set for %s/%s: %w", nqosState.namespace, nqosState.name, err)
 	}
-	// ensure destination address sets
-	for ruleIndex, rule := range nqosState.EgressRules {
+	// ensure destination address sets, or source address sets of the ingress rules
+	for _, rule := range nqosState.getRules() {
 		for destIndex, dest := range rule.Classifier.Destinations {
 			if dest.NamespaceSelector == nil && dest.PodSelector == nil {
 				continue
 			}
-			dest.DestAddrSet, err = addressSetFactory.EnsureAddressSet(GetNetworkQoSAddrSetDbIDs(nqosState.namespace, nqosState.name, strconv.Itoa(ruleIndex), strconv.Itoa(destIndex), controllerName))
+			dest.DestAddrSet, err = addressSetFactory.EnsureAddressSet(GetNetworkQoSAddrSetDbIDs(nqosState.namespace, nqosState.name, rule.RuleIndex, strconv.Itoa(destIndex), controllerName))
 			if err != nil {
 				return fmt.Errorf("failed to init destination address set for %s/%s: %w", nqosState.namespace, nqosState.name, err)
 			}
@@ -123,6 +194,22 @@ func (nqosState *networkQoSState) configureSourcePod(ctrl *Controller, pod *core
 
 	podList = append(podList, fullPodName)
 	nqosState.SwitchRefs.Store(switchName, podList)
+
+	override, err := getPodQoSOverride(pod)
+	if err != nil {
+		// the rules apply without the override until the annotation is fixed, the event is
+		// recorded when the pod is synced
+		klog.Warningf("Ignoring NetworkQoS override of pod %s: %v", fullPodName, err)
+	}
+	if override == nil {
+		// the QoSes of a previous override are cleaned up with the stale QoSes
+		nqosState.PodOverrides.Delete(fullPodName)
+		return nil
+	}
+	if err := ctrl.addPodOverrideToLogicalSwitch(nqosState, switchName, fullPodName, addresses, override); err != nil {
+		return err
+	}
+	nqosState.PodOverrides.Store(fullPodName, override)
 	return nil
 }
 
@@ -140,6 +227,7 @@ func (nqosState *networkQoSState) removePodFromSource(ctrl *Controller, fullPodN
 		}
 	}
 	nqosState.Pods.Delete(fullPodName)
+	nqosState.PodOverrides.Delete(fullPodName)
 	return nqosState.removeZeroQoSNodes(ctrl, fullPodName)
 }
 
@@ -179,7 +267,7 @@ func (nqosState *networkQoSState) getAddressSetHashNames() []string {
 		v4Hash, v6Hash := nqosState.SrcAddrSet.GetASHashNames()
 		addrsetNames = append(addrsetNames, v4Hash, v6Hash)
 	}
-	for _, rule := range nqosState.EgressRules {
+	for _, rule := range nqosState.getRules() {
 		for _, dest := range rule.Classifier.Destinations {
 			if dest.DestAddrSet != nil {
 				v4Hash, v6Hash := dest.DestAddrSet.GetASHashNames()
@@ -206,8 +294,8 @@ func (nqosState *networkQoSState) cleanupStaleAddresses(addressSetMap map[string
 			}
 		}
 	}
-	for _, egress := range nqosState.EgressRules {
-		for _, dest := range egress.Classifier.Destinations {
+	for _, rule := range nqosState.getRules() {
+		for _, dest := range rule.Classifier.Destinations {
 			if dest.DestAddrSet == nil {
 				continue
 			}
@@ -229,7 +317,19 @@ func (nqosState *networkQoSState) cleanupStaleAddresses(addressSetMap map[string
 	return nil
 }
 
+// selectedPodsDirection returns the direction of the pods selected by the NetworkQoS in the match string:
+// they are the sources of the egress rules and the destinations of the ingress rules
+func (rule *GressRule) selectedPodsDirection() trafficDirection {
+	if rule.Classifier.Ingress {
+		return trafficDirDest
+	}
+	return trafficDirSource
+}
+
 type GressRule struct {
+	// RuleIndex identifies the rule in the QoS and address set external IDs, it is the index of the rule
+	// in .Spec.Egress, or the index in .Spec.Ingress prefixed with ingressRuleIndexPrefix
+	RuleIndex  string
 	Priority   int
 	Dscp       int
 	Classifier *Classifier
@@ -239,6 +339,16 @@ type GressRule struct {
 	Burst *int
 }
 
+// ingressRuleIndexPrefix distinguishes the indexes of the ingress rules from the egress ones
+const ingressRuleIndexPrefix = "ingress-"
+
+func getRuleIndex(index int, ingress bool) string {
+	if ingress {
+		return ingressRuleIndexPrefix + strconv.Itoa(index)
+	}
+	return strconv.Itoa(index)
+}
+
 type trafficDirection string
 
 const (
@@ -247,33 +357,47 @@ const (
 )
 
 type Classifier struct {
+	// Destinations are the peers of the rule, which are the sources of the traffic for the ingress rules
 	Destinations []*Destination
 	Ports        []*networkqosv1alpha1.Port
+	// Ingress is set for the classifiers of the ingress rules, matching the traffic received by the
+	// selected pods
+	Ingress bool
+}
+
+// peerDirection returns the direction of the peers in the match string
+func (c *Classifier) peerDirection() trafficDirection {
+	if c.Ingress {
+		return trafficDirSource
+	}
+	return trafficDirDest
 }
 
 // ToQosMatchString generates dest and protocol/port part of QoS match string, based on
 // Classifier's destinations, protocol and port fields, example:
 // (ip4.dst == $addr_set_name || (ip4.dst == 128.116.0.0/17 && ip4.dst != {128.116.0.0,128.116.0.255})) && tcp && tcp.dst == 8080
-// Multiple destinations will be connected by "||".
+// Multiple destinations will be connected by "||". For the ingress rules, the peers are matched
+// as sources while the ports are still the destination ports of the selected pods.
 // See https://github.com/ovn-org/ovn/blob/2bdf1129c19d5bd2cd58a3ddcb6e2e7254b05054/ovn-nb.xml#L2942-L3025 for details
 func (c *Classifier) ToQosMatchString(ipv4Enabled, ipv6Enabled bool) string {
 	if c == nil {
 		return ""
 	}
+	peerDir := c.peerDirection()
 	destMatchStrings := []string{}
 	for _, dest := range c.Destinations {
-		match := "ip4.dst == 0.0.0.0/0 || ip6.dst == ::/0"
+		match := fmt.Sprintf("ip4.%s == 0.0.0.0/0 || ip6.%s == ::/0", peerDir, peerDir)
 		if dest.DestAddrSet != nil {
-			match = addressSetToMatchString(dest.DestAddrSet, trafficDirDest, ipv4Enabled, ipv6Enabled)
+			match = addressSetToMatchString(dest.DestAddrSet, peerDir, ipv4Enabled, ipv6Enabled)
 		} else if dest.IpBlock != nil && dest.IpBlock.CIDR != "" {
 			ipVersion := "ip4"
 			if utilnet.IsIPv6CIDRString(dest.IpBlock.CIDR) {
 				ipVersion = "ip6"
 			}
 			if len(dest.IpBlock.Except) == 0 {
-				match = fmt.Sprintf("%s.%s == %s", ipVersion, trafficDirDest, dest.IpBlock.CIDR)
+				match = fmt.Sprintf("%s.%s == %s", ipVersion, peerDir, dest.IpBlock.CIDR)
 			} else {
-				match = fmt.Sprintf("%s.%s == %s && %s.%s != {%s}", ipVersion, trafficDirDest, dest.IpBlock.CIDR, ipVersion, trafficDirDest, strings.Join(dest.IpBlock.Except, ","))
+				match = fmt.Sprintf("%s.%s == %s && %s.%s != {%s}", ipVersion, peerDir, dest.IpBlock.CIDR, ipVersion, peerDir, strings.Join(dest.IpBlock.Except, ","))
 			}
 		}
 		destMatchStrings = append(destMatchStrings, match)
@@ -383,6 +507,10 @@ func (dest *Destination) removePod(fullPodName string, addresses []string) error
 	return nil
 }
 
-func getQoSRulePriority(qosPriority, ruleIndex int) int {
-	return 10000 + qosPriority*10 + ruleIndex
+func getQoSRulePriority(qosPriority, ruleIndex int, ingress bool) int {
+	priority := 10000 + qosPriority*10 + ruleIndex
+	if ingress {
+		priority += ingressRulePriorityOffset
+	}
+	return priority
 }
@@ -2,9 +2,12 @@ package networkqos
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/utils/net"
 
+	nqosv1alpha1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	ovnkutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -52,8 +55,41 @@ func getPodAddresses(pod *corev1.Pod, networkInfo ovnkutil.NetInfo) ([]string, e
 }
 
 func generateNetworkQoSMatch(qosState *networkQoSState, rule *GressRule, ipv4Enabled, ipv6Enabled bool) string {
-	match := addressSetToMatchString(qosState.SrcAddrSet, trafficDirSource, ipv4Enabled, ipv6Enabled)
+	match := addressSetToMatchString(qosState.SrcAddrSet, rule.selectedPodsDirection(), ipv4Enabled, ipv6Enabled)
+	return appendClassifierMatch(match, rule, ipv4Enabled, ipv6Enabled)
+}
+
+// generatePodOverrideMatch generates the match of a rule for the QoS overriding it for a single pod, the pod
+// addresses replace the address set of the selected pods.
+func generatePodOverrideMatch(rule *GressRule, addresses []string, ipv4Enabled, ipv6Enabled bool) string {
+	match := addressesToMatchString(addresses, rule.selectedPodsDirection(), ipv4Enabled, ipv6Enabled)
+	return appendClassifierMatch(match, rule, ipv4Enabled, ipv6Enabled)
+}
+
+func addressesToMatchString(addresses []string, dir trafficDirection, ipv4Enabled, ipv6Enabled bool) string {
+	var v4Addresses, v6Addresses []string
+	for _, address := range addresses {
+		if utilnet.IsIPv6String(address) {
+			v6Addresses = append(v6Addresses, address)
+		} else {
+			v4Addresses = append(v4Addresses, address)
+		}
+	}
+	matches := []string{}
+	if ipv4Enabled && len(v4Addresses) > 0 {
+		matches = append(matches, fmt.Sprintf("ip4.%s == {%s}", dir, strings.Join(v4Addresses, ",")))
+	}
+	if ipv6Enabled && len(v6Addresses) > 0 {
+		matches = append(matches, fmt.Sprintf("ip6.%s == {%s}", dir, strings.Join(v6Addresses, ",")))
+	}
+	match := strings.Join(matches, " || ")
+	if len(matches) > 1 {
+		match = fmt.Sprintf("(%s)", match)
+	}
+	return match
+}
 
+func appendClassifierMatch(match string, rule *GressRule, ipv4Enabled, ipv6Enabled bool) string {
 	classiferMatchString := rule.Classifier.ToQosMatchString(ipv4Enabled, ipv6Enabled)
 	if classiferMatchString != "" {
 		match = match + " && " + classiferMatchString
@@ -76,6 +112,19 @@ func addressSetToMatchString(addrset addressset.AddressSet, dir trafficDirection
 	return output
 }
 
+// getNetworkQoSPeers returns the peers of all the rules of a NetworkQoS: the destinations of the egress
+// rules and the sources of the ingress rules.
+func getNetworkQoSPeers(nqos *nqosv1alpha1.NetworkQoS) []nqosv1alpha1.Destination {
+	peers := []nqosv1alpha1.Destination{}
+	for _, egress := range nqos.Spec.Egress {
+		peers = append(peers, egress.Classifier.To...)
+	}
+	for _, ingress := range nqos.Spec.Ingress {
+		peers = append(peers, ingress.Classifier.From...)
+	}
+	return peers
+}
+
 func getNamespaceAddressSet(addressSetFactory addressset.AddressSetFactory, controllerName, namespace string) (addressset.AddressSet, error) {
 	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetNamespace, controllerName, map[libovsdbops.ExternalIDKey]string{
 		libovsdbops.ObjectNameKey: namespace,
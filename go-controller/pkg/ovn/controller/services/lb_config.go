@@ -31,6 +31,9 @@ type lbConfig struct {
 
 	clusterEndpoints util.LBEndpoints            // addresses of cluster-wide endpoints
 	nodeEndpoints    map[string]util.LBEndpoints // node -> addresses of local endpoints
+	// topology zone -> addresses of endpoints hinted for that zone,
+	// only set for services preferring close endpoints (trafficDistribution=PreferClose)
+	zoneEndpoints map[string]util.LBEndpoints
 
 	// if true, then vips added on the router are in "local" mode
 	// that means, skipSNAT, and remove any non-local endpoints.
@@ -43,17 +46,38 @@ type lbConfig struct {
 	hasNodePort bool
 }
 
-func makeNodeSwitchTargetIPs(node string, c *lbConfig) (targetIPsV4, targetIPsV6 []string, v4Changed, v6Changed bool) {
+// clusterTargetIPs returns the cluster-wide endpoints to be used as targets on the given node.
+// If the service prefers topologically close endpoints, only the endpoints hinted for the node's
+// topology zone are returned; when the zone has no such endpoints for an IP family, all the
+// cluster endpoints of that family are used instead.
+func (c *lbConfig) clusterTargetIPs(node *nodeInfo) (targetIPsV4, targetIPsV6 []string) {
 	targetIPsV4 = c.clusterEndpoints.V4IPs
 	targetIPsV6 = c.clusterEndpoints.V6IPs
 
+	if node.topologyZone == "" {
+		return
+	}
+	if zoneEndpoints, ok := c.zoneEndpoints[node.topologyZone]; ok {
+		if len(zoneEndpoints.V4IPs) > 0 {
+			targetIPsV4 = zoneEndpoints.V4IPs
+		}
+		if len(zoneEndpoints.V6IPs) > 0 {
+			targetIPsV6 = zoneEndpoints.V6IPs
+		}
+	}
+	return
+}
+
+func makeNodeSwitchTargetIPs(node *nodeInfo, c *lbConfig) (targetIPsV4, targetIPsV6 []string, v4Changed, v6Changed bool) {
+	targetIPsV4, targetIPsV6 = c.clusterTargetIPs(node)
+
 	if c.externalTrafficLocal || c.internalTrafficLocal {
 		// For ExternalTrafficPolicy=Local, remove non-local endpoints from the router/switch targets
 		// NOTE: on the switches, filtered eps are used only by masqueradeVIP
 		// for InternalTrafficPolicy=Local, remove non-local endpoints from the switch targets only
 		localIPsV4 := []string{}
 		localIPsV6 := []string{}
-		if localEndpoints, ok := c.nodeEndpoints[node]; ok {
+		if localEndpoints, ok := c.nodeEndpoints[node.name]; ok {
 			localIPsV4 = localEndpoints.V4IPs
 			localIPsV6 = localEndpoints.V6IPs
 		}
@@ -61,7 +85,7 @@ func makeNodeSwitchTargetIPs(node string, c *lbConfig) (targetIPsV4, targetIPsV6
 		targetIPsV6 = localIPsV6
 	}
 
-	// Local endpoints are a subset of cluster endpoints, so it is enough to compare their length
+	// Local and zone endpoints are subsets of cluster endpoints, so it is enough to compare their length
 	v4Changed = len(targetIPsV4) != len(c.clusterEndpoints.V4IPs)
 	v6Changed = len(targetIPsV6) != len(c.clusterEndpoints.V6IPs)
 
@@ -69,8 +93,7 @@ func makeNodeSwitchTargetIPs(node string, c *lbConfig) (targetIPsV4, targetIPsV6
 }
 
 func makeNodeRouterTargetIPs(node *nodeInfo, c *lbConfig, hostMasqueradeIPV4, hostMasqueradeIPV6 string) (targetIPsV4, targetIPsV6 []string, v4Changed, v6Changed bool) {
-	targetIPsV4 = c.clusterEndpoints.V4IPs
-	targetIPsV6 = c.clusterEndpoints.V6IPs
+	targetIPsV4, targetIPsV6 = c.clusterTargetIPs(node)
 
 	if c.externalTrafficLocal {
 		// For ExternalTrafficPolicy=Local, remove non-local endpoints from the router/switch targets
@@ -96,7 +119,7 @@ func makeNodeRouterTargetIPs(node *nodeInfo, c *lbConfig, hostMasqueradeIPV4, ho
 	targetIPsV4, v4Updated := util.UpdateIPsSlice(targetIPsV4, lbAddresses, []string{hostMasqueradeIPV4})
 	targetIPsV6, v6Updated := util.UpdateIPsSlice(targetIPsV6, lbAddresses, []string{hostMasqueradeIPV6})
 
-	// Local endpoints are a subset of cluster endpoints, so it is enough to compare their length
+	// Local and zone endpoints are subsets of cluster endpoints, so it is enough to compare their length
 	v4Changed = len(targetIPsV4) != len(c.clusterEndpoints.V4IPs) || v4Updated
 	v6Changed = len(targetIPsV6) != len(c.clusterEndpoints.V6IPs) || v6Updated
 
@@ -126,6 +149,7 @@ var protos = []corev1.Protocol{
 // - services with host-network endpoints
 // - services with ExternalTrafficPolicy=Local
 // - services with InternalTrafficPolicy=Local
+// - services with TrafficDistribution=PreferClose whose endpoints carry zone hints
 //
 // Template LBs will be created for
 //   - services with NodePort set but *without* ExternalTrafficPolicy=Local or
@@ -149,6 +173,11 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 			klog.Warningf("Failed to get endpoints for service during LB config build: %v", err)
 		}
 	}
+	// if the service prefers close endpoints, classify them by the zones they are hinted for
+	var portToZoneToEndpoints util.PortToZoneToLBEndpoints
+	if util.ServiceTrafficDistributionPreferClose(service) {
+		portToZoneToEndpoints = util.GetZoneHintedEndpointsForService(endpointSlices, service)
+	}
 	for _, svcPort := range service.Spec.Ports {
 		svcPortKey := util.GetServicePortKey(svcPort.Protocol, svcPort.Name)
 		clusterEndpoints := portToClusterEndpoints[svcPortKey]
@@ -156,6 +185,7 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 		if nodeEndpoints == nil {
 			nodeEndpoints = make(map[string]util.LBEndpoints)
 		}
+		zoneEndpoints := portToZoneToEndpoints[svcPortKey]
 		// if ExternalTrafficPolicy or InternalTrafficPolicy is local, then we need to do things a bit differently
 		externalTrafficLocal := util.ServiceExternalTrafficPolicyLocal(service)
 		internalTrafficLocal := util.ServiceInternalTrafficPolicyLocal(service)
@@ -170,6 +200,7 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 				vips:                 []string{placeholderNodeIPs}, // shortcut for all-physical-ips
 				clusterEndpoints:     clusterEndpoints,
 				nodeEndpoints:        nodeEndpoints,
+				zoneEndpoints:        zoneEndpoints,
 				externalTrafficLocal: externalTrafficLocal,
 				internalTrafficLocal: false, // always false for non-ClusterIPs
 				hasNodePort:          true,
@@ -214,6 +245,7 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 			vips:                 vips,
 			clusterEndpoints:     clusterEndpoints,
 			nodeEndpoints:        nodeEndpoints,
+			zoneEndpoints:        zoneEndpoints,
 			externalTrafficLocal: false, // always false for ClusterIPs
 			internalTrafficLocal: internalTrafficLocal,
 			hasNodePort:          false,
@@ -223,9 +255,11 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 		// unless any of the following are true:
 		// - Any of the endpoints are host-network
 		// - ETP=local service backed by non-local-host-networked endpoints
+		// - the service prefers close endpoints and they carry zone hints
 		//
 		// In that case, we need to create per-node LBs.
-		if hasHostEndpoints(clusterEndpoints.V4IPs) || hasHostEndpoints(clusterEndpoints.V6IPs) || internalTrafficLocal {
+		if hasHostEndpoints(clusterEndpoints.V4IPs) || hasHostEndpoints(clusterEndpoints.V6IPs) || internalTrafficLocal ||
+			len(zoneEndpoints) > 0 {
 			perNodeConfigs = append(perNodeConfigs, clusterIPConfig)
 		} else {
 			clusterConfigs = append(clusterConfigs, clusterIPConfig)
@@ -420,7 +454,7 @@ func buildTemplateLBs(service *corev1.Service, configs []lbConfig, nodes []nodeI
 
 				for _, node := range nodes {
 
-					switchV4TargetIPs, switchV6TargetIPs, v4Changed, v6Changed := makeNodeSwitchTargetIPs(node.name, &cfg)
+					switchV4TargetIPs, switchV6TargetIPs, v4Changed, v6Changed := makeNodeSwitchTargetIPs(&node, &cfg)
 					if !switchV4TargetNeedsTemplate && v4Changed {
 						switchV4TargetNeedsTemplate = true
 					}
@@ -619,7 +653,7 @@ func buildPerNodeLBs(service *corev1.Service, configs []lbConfig, nodes []nodeIn
 
 			for _, cfg := range configs {
 
-				switchV4TargetIPs, switchV6TargetIPs, _, _ := makeNodeSwitchTargetIPs(node.name, &cfg)
+				switchV4TargetIPs, switchV6TargetIPs, _, _ := makeNodeSwitchTargetIPs(&node, &cfg)
 
 				routerV4TargetIPs, routerV6TargetIPs, _, _ := makeNodeRouterTargetIPs(
 					&node,
@@ -630,8 +664,9 @@ func buildPerNodeLBs(service *corev1.Service, configs []lbConfig, nodes []nodeIn
 				routerV4targets := joinHostsPort(routerV4TargetIPs, cfg.clusterEndpoints.Port)
 				routerV6targets := joinHostsPort(routerV6TargetIPs, cfg.clusterEndpoints.Port)
 
-				switchV4targets := joinHostsPort(cfg.clusterEndpoints.V4IPs, cfg.clusterEndpoints.Port)
-				switchV6targets := joinHostsPort(cfg.clusterEndpoints.V6IPs, cfg.clusterEndpoints.Port)
+				clusterV4TargetIPs, clusterV6TargetIPs := cfg.clusterTargetIPs(&node)
+				switchV4targets := joinHostsPort(clusterV4TargetIPs, cfg.clusterEndpoints.Port)
+				switchV6targets := joinHostsPort(clusterV6TargetIPs, cfg.clusterEndpoints.Port)
 
 				// Substitute the special vip "node" for the node's physical ips
 				// This is used for nodeport
@@ -4458,7 +4458,7 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 	tc := []struct {
 		name                string
 		config              *lbConfig
-		node                string
+		node                *nodeInfo
 		expectedTargetIPsV4 []string
 		expectedTargetIPsV6 []string
 		expectedV4Changed   bool
@@ -4483,7 +4483,7 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 					},
 				},
 			},
-			node:                nodeA,
+			node:                &nodeInfo{name: nodeA},
 			expectedTargetIPsV4: []string{"192.168.0.1"},
 			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2"},
 			expectedV4Changed:   false,
@@ -4510,7 +4510,7 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 				},
 				externalTrafficLocal: true,
 			},
-			node:                nodeA,
+			node:                &nodeInfo{name: nodeA},
 			expectedTargetIPsV4: []string{"192.168.0.1"}, // only the endpoint on nodeA is kept
 			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2"},
 			expectedV4Changed:   true,
@@ -4537,7 +4537,7 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 				},
 				externalTrafficLocal: true,
 			},
-			node:                nodeA,
+			node:                &nodeInfo{name: nodeA},
 			expectedTargetIPsV4: []string{"192.168.0.1"},
 			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2"},
 			expectedV4Changed:   false,
@@ -4556,12 +4556,119 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 				// nothing on nodeA
 				externalTrafficLocal: true,
 			},
-			node:                nodeA,
+			node:                &nodeInfo{name: nodeA},
 			expectedTargetIPsV4: []string{},
 			expectedTargetIPsV6: []string{}, // no local endpoints
 			expectedV4Changed:   true,
 			expectedV6Changed:   true,
 		},
+		{
+			name: "service with TrafficDistribution=PreferClose, zone endpoints",
+			config: &lbConfig{
+				vips:     []string{"1.2.3.4", "fe10::1"},
+				protocol: corev1.ProtocolTCP,
+				inport:   80,
+				clusterEndpoints: util.LBEndpoints{
+					V4IPs: []string{"192.168.0.1", "192.168.1.1"},
+					V6IPs: []string{"fe00:0:0:0:1::2", "fe00:0:0:0:2::2"},
+					Port:  8080,
+				},
+				zoneEndpoints: util.PortToLBEndpoints{
+					"zone-a": {
+						V4IPs: []string{"192.168.0.1"},
+						V6IPs: []string{"fe00:0:0:0:1::2"},
+						Port:  8080,
+					},
+					"zone-b": {
+						V4IPs: []string{"192.168.1.1"},
+						V6IPs: []string{"fe00:0:0:0:2::2"},
+						Port:  8080,
+					},
+				},
+			},
+			node:                &nodeInfo{name: nodeA, topologyZone: "zone-a"},
+			expectedTargetIPsV4: []string{"192.168.0.1"}, // only the endpoint hinted for zone-a is kept
+			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2"},
+			expectedV4Changed:   true,
+			expectedV6Changed:   true,
+		},
+		{
+			name: "service with TrafficDistribution=PreferClose, no endpoints hinted for the node's zone",
+			config: &lbConfig{
+				vips:     []string{"1.2.3.4", "fe10::1"},
+				protocol: corev1.ProtocolTCP,
+				inport:   80,
+				clusterEndpoints: util.LBEndpoints{
+					V4IPs: []string{"192.168.0.1", "192.168.1.1"},
+					V6IPs: []string{"fe00:0:0:0:1::2", "fe00:0:0:0:2::2"},
+					Port:  8080,
+				},
+				zoneEndpoints: util.PortToLBEndpoints{
+					"zone-b": {
+						V4IPs: []string{"192.168.1.1"},
+						Port:  8080,
+					},
+				},
+			},
+			node:                &nodeInfo{name: nodeA, topologyZone: "zone-a"},
+			expectedTargetIPsV4: []string{"192.168.0.1", "192.168.1.1"}, // fall back to all endpoints
+			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2", "fe00:0:0:0:2::2"},
+			expectedV4Changed:   false,
+			expectedV6Changed:   false,
+		},
+		{
+			name: "service with TrafficDistribution=PreferClose, zone has endpoints of a single family",
+			config: &lbConfig{
+				vips:     []string{"1.2.3.4", "fe10::1"},
+				protocol: corev1.ProtocolTCP,
+				inport:   80,
+				clusterEndpoints: util.LBEndpoints{
+					V4IPs: []string{"192.168.0.1", "192.168.1.1"},
+					V6IPs: []string{"fe00:0:0:0:1::2", "fe00:0:0:0:2::2"},
+					Port:  8080,
+				},
+				zoneEndpoints: util.PortToLBEndpoints{
+					"zone-b": {
+						V4IPs: []string{"192.168.1.1"},
+						Port:  8080,
+					},
+				},
+			},
+			node:                &nodeInfo{name: nodeB, topologyZone: "zone-b"},
+			expectedTargetIPsV4: []string{"192.168.1.1"},
+			expectedTargetIPsV6: []string{"fe00:0:0:0:1::2", "fe00:0:0:0:2::2"}, // no v6 endpoints in zone-b
+			expectedV4Changed:   true,
+			expectedV6Changed:   false,
+		},
+		{
+			name: "service with ETP=local and TrafficDistribution=PreferClose, local endpoints win",
+			config: &lbConfig{
+				vips:     []string{"1.2.3.4"},
+				protocol: corev1.ProtocolTCP,
+				inport:   80,
+				clusterEndpoints: util.LBEndpoints{
+					V4IPs: []string{"192.168.0.1", "192.168.0.2", "192.168.1.1"},
+					Port:  8080,
+				},
+				nodeEndpoints: util.PortToLBEndpoints{
+					nodeA: {
+						V4IPs: []string{"192.168.0.1"},
+						Port:  8080,
+					},
+				},
+				zoneEndpoints: util.PortToLBEndpoints{
+					"zone-a": {
+						V4IPs: []string{"192.168.0.1", "192.168.0.2"},
+						Port:  8080,
+					},
+				},
+				externalTrafficLocal: true,
+			},
+			node:                &nodeInfo{name: nodeA, topologyZone: "zone-a"},
+			expectedTargetIPsV4: []string{"192.168.0.1"},
+			expectedV4Changed:   true,
+			expectedV6Changed:   false,
+		},
 	}
 	for i, tt := range tc {
 		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
@@ -4574,3 +4681,196 @@ func Test_makeNodeSwitchTargetIPs(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildLBsTrafficDistributionPreferClose(t *testing.T) {
+	oldClusterSubnet := globalconfig.Default.ClusterSubnets
+	oldGwMode := globalconfig.Gateway.Mode
+	defer func() {
+		globalconfig.Gateway.Mode = oldGwMode
+		globalconfig.Default.ClusterSubnets = oldClusterSubnet
+	}()
+	_, cidr4, _ := net.ParseCIDR("10.128.0.0/16")
+	globalconfig.Default.ClusterSubnets = []globalconfig.CIDRNetworkEntry{{CIDR: cidr4, HostSubnetLength: 24}}
+	globalconfig.Gateway.Mode = globalconfig.GatewayModeShared
+
+	name := "foo"
+	namespace := "testns"
+	portName := "port80"
+	inport := int32(80)
+	outport := int32(8080)
+	nodeC := "node-c"
+
+	nodes := []nodeInfo{
+		{
+			name:               nodeA,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.1")},
+			gatewayRouterName:  "gr-node-a",
+			switchName:         "switch-node-a",
+			topologyZone:       "zone-a",
+		},
+		{
+			name:               nodeB,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.2")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.2")},
+			gatewayRouterName:  "gr-node-b",
+			switchName:         "switch-node-b",
+			topologyZone:       "zone-b",
+		},
+		{
+			name:               nodeC,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.3")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.3")},
+			gatewayRouterName:  "gr-node-c",
+			switchName:         "switch-node-c",
+			topologyZone:       "zone-c",
+		},
+	}
+
+	makeService := func(trafficDistribution *string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Type:       corev1.ServiceTypeClusterIP,
+				ClusterIP:  "192.168.1.1",
+				ClusterIPs: []string{"192.168.1.1"},
+				Ports: []corev1.ServicePort{{
+					Name:       portName,
+					Port:       inport,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(int(outport)),
+				}},
+				TrafficDistribution: trafficDistribution,
+			},
+		}
+	}
+
+	makeEndpoint := func(node, address string, zones ...string) discovery.Endpoint {
+		ep := kubetest.MakeReadyEndpoint(node, address)
+		if len(zones) > 0 {
+			ep.Hints = &discovery.EndpointHints{}
+			for _, zone := range zones {
+				ep.Hints.ForZones = append(ep.Hints.ForZones, discovery.ForZone{Name: zone})
+			}
+		}
+		return ep
+	}
+
+	makeSlices := func(endpoints ...discovery.Endpoint) []*discovery.EndpointSlice {
+		proto := corev1.ProtocolTCP
+		return []*discovery.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "ab1",
+				Namespace: namespace,
+				Labels:    map[string]string{discovery.LabelServiceName: name},
+			},
+			Ports: []discovery.EndpointPort{{
+				Protocol: &proto,
+				Port:     &outport,
+				Name:     &portName,
+			}},
+			AddressType: discovery.AddressTypeIPv4,
+			Endpoints:   endpoints,
+		}}
+	}
+
+	// switchTargets returns, for each node switch, the targets of the ClusterIP rule
+	switchTargets := func(lbs []LB) map[string][]Addr {
+		out := map[string][]Addr{}
+		for _, lb := range lbs {
+			for _, sw := range lb.Switches {
+				for _, rule := range lb.Rules {
+					if rule.Source.IP == "192.168.1.1" {
+						out[sw] = rule.Targets
+					}
+				}
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name                string
+		service             *corev1.Service
+		slices              []*discovery.EndpointSlice
+		expectPerNode       bool
+		expectedZoneConfigs map[string]util.LBEndpoints
+		expectedTargets     map[string][]Addr
+	}{
+		{
+			name:    "PreferClose, endpoints hinted for every zone but zone-c",
+			service: makeService(ptr.To(corev1.ServiceTrafficDistributionPreferClose)),
+			slices: makeSlices(
+				makeEndpoint(nodeA, "10.128.0.2", "zone-a"),
+				makeEndpoint(nodeB, "10.128.1.2", "zone-b"),
+			),
+			expectPerNode: true,
+			expectedZoneConfigs: map[string]util.LBEndpoints{
+				"zone-a": {V4IPs: []string{"10.128.0.2"}, Port: outport},
+				"zone-b": {V4IPs: []string{"10.128.1.2"}, Port: outport},
+			},
+			expectedTargets: map[string][]Addr{
+				"switch-node-a": {{IP: "10.128.0.2", Port: outport}},
+				"switch-node-b": {{IP: "10.128.1.2", Port: outport}},
+				// no endpoints hinted for zone-c: fall back to all endpoints
+				"switch-node-c": {{IP: "10.128.0.2", Port: outport}, {IP: "10.128.1.2", Port: outport}},
+			},
+		},
+		{
+			name:    "PreferClose, endpoint hinted for several zones",
+			service: makeService(ptr.To(corev1.ServiceTrafficDistributionPreferClose)),
+			slices: makeSlices(
+				makeEndpoint(nodeA, "10.128.0.2", "zone-a", "zone-c"),
+				makeEndpoint(nodeB, "10.128.1.2", "zone-b"),
+			),
+			expectPerNode: true,
+			expectedZoneConfigs: map[string]util.LBEndpoints{
+				"zone-a": {V4IPs: []string{"10.128.0.2"}, Port: outport},
+				"zone-b": {V4IPs: []string{"10.128.1.2"}, Port: outport},
+				"zone-c": {V4IPs: []string{"10.128.0.2"}, Port: outport},
+			},
+			expectedTargets: map[string][]Addr{
+				"switch-node-a": {{IP: "10.128.0.2", Port: outport}},
+				"switch-node-b": {{IP: "10.128.1.2", Port: outport}},
+				"switch-node-c": {{IP: "10.128.0.2", Port: outport}},
+			},
+		},
+		{
+			name:    "PreferClose, one endpoint without hints disables topology",
+			service: makeService(ptr.To(corev1.ServiceTrafficDistributionPreferClose)),
+			slices: makeSlices(
+				makeEndpoint(nodeA, "10.128.0.2", "zone-a"),
+				makeEndpoint(nodeB, "10.128.1.2"),
+			),
+			expectPerNode: false,
+		},
+		{
+			name:    "no trafficDistribution, hints are ignored",
+			service: makeService(nil),
+			slices: makeSlices(
+				makeEndpoint(nodeA, "10.128.0.2", "zone-a"),
+				makeEndpoint(nodeB, "10.128.1.2", "zone-b"),
+			),
+			expectPerNode: false,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			perNode, template, clusterWide := buildServiceLBConfigs(tt.service, tt.slices, nodes, true, true)
+			assert.Empty(t, template)
+			if !tt.expectPerNode {
+				assert.Empty(t, perNode)
+				require.Len(t, clusterWide, 1)
+				assert.Empty(t, clusterWide[0].zoneEndpoints)
+				return
+			}
+			assert.Empty(t, clusterWide)
+			require.Len(t, perNode, 1)
+			assert.Equal(t, tt.expectedZoneConfigs, perNode[0].zoneEndpoints)
+
+			lbs := buildPerNodeLBs(tt.service, perNode, nodes, &util.DefaultNetInfo{})
+			assert.Equal(t, tt.expectedTargets, switchTargets(lbs))
+		})
+	}
+}
@@ -5,38 +5,14 @@ import (
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
-const (
-	// Default Meters created on GRs.
-	OVNARPRateLimiter              = "arp"
-	OVNARPResolveRateLimiter       = "arp-resolve"
-	OVNBFDRateLimiter              = "bfd"
-	OVNControllerEventsRateLimiter = "event-elb"
-	OVNICMPV4ErrorsRateLimiter     = "icmp4-error"
-	OVNICMPV6ErrorsRateLimiter     = "icmp6-error"
-	OVNRejectRateLimiter           = "reject"
-	OVNTCPRSTRateLimiter           = "tcp-reset"
-	OVNServiceMonitorLimiter       = "svc-monitor"
-
-	// Default COPP object name
-	defaultCOPPName = "ovnkube-default"
-)
-
-var defaultProtocolNames = [...]string{
-	OVNARPRateLimiter,
-	OVNARPResolveRateLimiter,
-	OVNBFDRateLimiter,
-	OVNControllerEventsRateLimiter,
-	OVNICMPV4ErrorsRateLimiter,
-	OVNICMPV6ErrorsRateLimiter,
-	OVNRejectRateLimiter,
-	OVNTCPRSTRateLimiter,
-	OVNServiceMonitorLimiter,
-}
+// Default COPP object name
+const defaultCOPPName = "ovnkube-default"
 
 func getMeterNameForProtocol(protocol string) string {
 	// format: <OVNSupportedProtocolName>-rate-limiter
@@ -44,7 +20,9 @@ func getMeterNameForProtocol(protocol string) string {
 }
 
 // EnsureDefaultCOPP creates the default COPP that needs to be added to each GR
-// if not already present. Also cleans up old COPP entries if required.
+// if not already present, with the meter of each protocol rate limited as
+// configured in the [copp] config section. Also cleans up old COPP entries if
+// required.
 func EnsureDefaultCOPP(nbClient libovsdbclient.Client) (string, error) {
 	p := func(item *nbdb.Copp) bool {
 		return item.Name == ""
@@ -54,18 +32,27 @@ func EnsureDefaultCOPP(nbClient libovsdbclient.Client) (string, error) {
 		return "", fmt.Errorf("failed to delete duplicate COPPs: %w", err)
 	}
 
-	band := &nbdb.MeterBand{
-		Action: types.MeterAction,
-		Rate:   int(25), // hard-coding for now. TODO(tssurya): make this configurable if needed
-	}
-	ops, err = libovsdbops.CreateMeterBandOps(nbClient, ops, band)
-	if err != nil {
-		return "", fmt.Errorf("can't create meter band %v: %v", band, err)
-	}
-
-	meterNames := make(map[string]string, len(defaultProtocolNames))
+	// protocols with the same rate limit share the same band, bands that are not
+	// referenced anymore by a meter are removed by the db
+	bands := map[config.CoPPRateLimit]*nbdb.MeterBand{}
+	meterNames := make(map[string]string, len(types.CoPPProtocols))
 	meterFairness := true
-	for _, protocol := range defaultProtocolNames {
+	for _, protocol := range types.CoPPProtocols {
+		rateLimit := config.GetCoPPRateLimit(protocol)
+		band, ok := bands[rateLimit]
+		if !ok {
+			band = &nbdb.MeterBand{
+				Action:    types.MeterAction,
+				Rate:      int(rateLimit.Rate),
+				BurstSize: int(rateLimit.Burst),
+			}
+			ops, err = libovsdbops.CreateMeterBandOps(nbClient, ops, band)
+			if err != nil {
+				return "", fmt.Errorf("can't create meter band %v: %v", band, err)
+			}
+			bands[rateLimit] = band
+		}
+
 		// format: <OVNSupportedProtocolName>-rate-limiter
 		meterName := getMeterNameForProtocol(protocol)
 		meterNames[protocol] = meterName
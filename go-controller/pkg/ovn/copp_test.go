@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func TestEnsureDefaultCOPP(t *testing.T) {
-	meterMap := make(map[string]string, len(defaultProtocolNames))
-	for _, protocol := range defaultProtocolNames {
+	meterMap := make(map[string]string, len(types.CoPPProtocols))
+	for _, protocol := range types.CoPPProtocols {
 		// format: <OVNSupportedProtocolName>-rate-limiter
 		meterMap[protocol] = getMeterNameForProtocol(protocol)
 	}
@@ -24,10 +25,10 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 
 	var meters []*nbdb.Meter
 	meterFairness := true
-	for i := 0; i < len(defaultProtocolNames); i++ {
+	for i := 0; i < len(types.CoPPProtocols); i++ {
 		meters = append(meters, &nbdb.Meter{
 			UUID:  fmt.Sprintf("meter-%d-UUID", i),
-			Name:  getMeterNameForProtocol(defaultProtocolNames[i]),
+			Name:  getMeterNameForProtocol(types.CoPPProtocols[i]),
 			Fair:  &meterFairness,
 			Unit:  types.PacketsPerSecond,
 			Bands: []string{meterBand.UUID},
@@ -101,9 +102,33 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 		multipleEmptyAndNamedCOPPNBData = append(multipleEmptyAndNamedCOPPNBData, m)
 	}
 
+	bfdMeterBand := &nbdb.MeterBand{
+		UUID:      "bfd-meter-band-UUID",
+		Action:    types.MeterAction,
+		Rate:      500,
+		BurstSize: 1000,
+	}
+	rateLimitedNBData := []libovsdbtest.TestData{
+		&nbdb.Copp{
+			Name:   "ovnkube-default",
+			UUID:   "copp-UUID",
+			Meters: meterMap,
+		},
+		meterBand,
+		bfdMeterBand,
+	}
+	for _, m := range meters {
+		m = m.DeepCopy()
+		if m.Name == getMeterNameForProtocol(types.OVNBFDRateLimiter) {
+			m.Bands = []string{bfdMeterBand.UUID}
+		}
+		rateLimitedNBData = append(rateLimitedNBData, m)
+	}
+
 	tests := []struct {
 		desc         string
 		expectErr    bool
+		rateLimits   string
 		initialNbdb  libovsdbtest.TestSetup
 		expectedNbdb libovsdbtest.TestSetup
 	}{
@@ -124,6 +149,17 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 				NBData: expectedNBData,
 			},
 		},
+		{
+			desc:       "updates the meters of existing named COPP with the configured rate limits",
+			expectErr:  false,
+			rateLimits: "bfd=500:1000",
+			initialNbdb: libovsdbtest.TestSetup{
+				NBData: existingNamedCOPPNBData,
+			},
+			expectedNbdb: libovsdbtest.TestSetup{
+				NBData: rateLimitedNBData,
+			},
+		},
 		{
 			desc:      "cleans multiple empty-name default COPPs and adds named COPP",
 			expectErr: false,
@@ -149,6 +185,15 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
+			if err := config.PrepareTestConfig(); err != nil {
+				t.Fatalf("test: \"%s\" failed to prepare test config: %v", tt.desc, err)
+			}
+			rateLimits, err := config.ParseCoPPRateLimits(tt.rateLimits)
+			if err != nil {
+				t.Fatalf("test: \"%s\" failed to parse CoPP rate limits: %v", tt.desc, err)
+			}
+			config.CoPP.RateLimits = rateLimits
+
 			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(tt.initialNbdb, nil)
 			if err != nil {
 				t.Fatalf("test: \"%s\" failed to set up test harness: %v", tt.desc, err)
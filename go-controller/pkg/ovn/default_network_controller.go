@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+
 	corev1 "k8s.io/api/core/v1"
 	knet "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -188,6 +190,10 @@ func newDefaultNetworkControllerCommon(
 		zoneICHandler = zoneic.NewZoneInterconnectHandler(defaultNetInfo, cnci.nbClient, cnci.sbClient, cnci.watchFactory)
 		zoneChassisHandler = zoneic.NewZoneChassisHandler(cnci.sbClient)
 	}
+	var nadLister nadlisters.NetworkAttachmentDefinitionLister
+	if config.OVNKubernetesFeature.EnableMultiNetwork {
+		nadLister = cnci.watchFactory.NADInformer().Lister()
+	}
 	apbExternalRouteController, err := apbroutecontroller.NewExternalMasterController(
 		cnci.kube.APBRouteClient,
 		defaultStopChan,
@@ -195,6 +201,8 @@ func newDefaultNetworkControllerCommon(
 		cnci.watchFactory.NamespaceInformer(),
 		cnci.watchFactory.APBRouteInformer(),
 		cnci.watchFactory.NodeCoreInformer().Lister(),
+		nadLister,
+		networkManager,
 		cnci.nbClient,
 		addressSetFactory,
 		DefaultNetworkControllerName,
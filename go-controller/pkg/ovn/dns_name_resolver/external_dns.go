@@ -10,6 +10,7 @@ import (
 	ocpnetworklisterv1alpha1 "github.com/openshift/client-go/network/listers/network/v1alpha1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -19,6 +20,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
 	egressfirewalllister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
 // ExternalEgressDNS keeps track of DNS names and the corresponding IP addresses.
@@ -152,15 +154,25 @@ func (extEgDNS *ExternalEgressDNS) reconcileDNSNameResolver(key string) error {
 	extEgDNS.dnsNameToResolver[dnsName] = name
 
 	// Get the addresses corresponding to the DNS name and add them to
-	// the address set corresponding to the DNS name.
-	addresses := []string{}
-	for _, resolvedName := range obj.Status.ResolvedNames {
-		for _, resolvedAddress := range resolvedName.ResolvedAddresses {
-			addresses = append(addresses, resolvedAddress.IP)
-		}
+	// the address set corresponding to the DNS name. For a wildcard DNS
+	// name, the address set holds the addresses of all the matched
+	// subdomains which have not expired yet.
+	resolvedAddresses, nextExpiry := util.GetDNSNameResolverAddresses(obj, time.Now())
+	addresses := sets.New[string]()
+	for _, ips := range resolvedAddresses {
+		addresses.Insert(ips...)
+	}
+	if err := extEgDNS.dnsTracker.addOrUpdateDNSName(dnsName, sets.List(addresses)); err != nil {
+		return err
+	}
+
+	// Reconcile the object again when the next subdomain address expires
+	// so that it gets removed from the address set, unless the resolver
+	// refreshes it in the meantime.
+	if !nextExpiry.IsZero() {
+		extEgDNS.controller.ReconcileAfter(key, time.Until(nextExpiry))
 	}
-	err = extEgDNS.dnsTracker.addOrUpdateDNSName(dnsName, addresses)
-	return err
+	return nil
 }
 
 // Add adds the namespace to the set of namespaces where the DNS name is used in the
@@ -250,6 +250,50 @@ var _ = ginkgo.Describe("Egress Firewall External DNS Operations", func() {
 
 			expectDNSNameWithAddresses(extEgDNS, dnsName, addresses)
 		})
+
+		ginkgo.It("Should remove the addresses of the expired subdomains of a wildcard dns name", func() {
+			start()
+
+			config.IPv4Mode = true
+			config.IPv6Mode = true
+
+			const wildcardDNSName = "*.example.com."
+			now := time.Now()
+			dnsNameResolver := newDNSNameResolverObject("dns-wildcard", config.Kubernetes.OVNConfigNamespace, wildcardDNSName, nil)
+			dnsNameResolver.Status.ResolvedNames = []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+				{
+					DNSName: "www.example.com.",
+					ResolvedAddresses: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{
+						{IP: "1.1.1.1", TTLSeconds: 300, LastLookupTime: &metav1.Time{Time: now}},
+					},
+				},
+				{
+					// expires in 2 seconds
+					DNSName: "api.example.com.",
+					ResolvedAddresses: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{
+						{IP: "2.2.2.2", TTLSeconds: 1, LastLookupTime: &metav1.Time{Time: now.Add(-util.DNSResolvedAddressGracePeriod + time.Second)}},
+						{IP: "1.1.1.1", TTLSeconds: 1, LastLookupTime: &metav1.Time{Time: now.Add(-util.DNSResolvedAddressGracePeriod + time.Second)}},
+					},
+				},
+			}
+
+			_, err := fakeClient.OCPNetworkClient.NetworkV1alpha1().DNSNameResolvers(dnsNameResolver.Namespace).
+				Create(context.TODO(), dnsNameResolver, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			expectDNSNameWithAddresses(extEgDNS, wildcardDNSName, []string{"1.1.1.1", "2.2.2.2"})
+
+			// the addresses of the expired subdomain are removed, unless they
+			// are still used by another subdomain
+			gomega.Eventually(func() []string {
+				resolvedName, exists := extEgDNS.getResolvedName(wildcardDNSName)
+				if !exists {
+					return []string{}
+				}
+				v4, v6 := resolvedName.dnsAddressSet.GetAddresses()
+				return append(v4, v6...)
+			}).WithTimeout(5 * time.Second).Should(gomega.ConsistOf("1.1.1.1"))
+		})
 	})
 
 	ginkgo.It("Should not delete added addresses if DNS name is still used in a namespace", func() {
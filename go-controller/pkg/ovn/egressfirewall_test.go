@@ -1180,6 +1180,59 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 				err := app.Run([]string{app.Name})
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
+			ginkgo.It(fmt.Sprintf("correctly overrides an egressfirewall's ACL logging with the egress firewall annotation, gateway mode %s", gwMode), func() {
+				config.Gateway.Mode = gwMode
+				app.Action = func(*cli.Context) error {
+					namespace1 := *newNamespace("namespace1")
+					egressFirewall := newEgressFirewallObject("default", namespace1.Name, []egressfirewallapi.EgressFirewallRule{
+						{
+							Type: "Allow",
+							To: egressfirewallapi.EgressFirewallDestination{
+								CIDRSelector: "1.2.3.4/23",
+							},
+						},
+					})
+
+					startOvn(dbSetup, []corev1.Namespace{namespace1}, []egressfirewallapi.EgressFirewall{*egressFirewall}, true)
+
+					expectedDatabaseState := getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
+						"(ip4.dst == 1.2.3.4/23)", "", nbdb.ACLActionAllow)
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
+					acl := expectedDatabaseState[len(expectedDatabaseState)-2].(*nbdb.ACL)
+
+					namespace, err := fakeOVN.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					// enable ACL logging for the namespace and only deny logging for its egress firewall
+					namespace.Annotations[util.AclLoggingAnnotation] = `{ "deny": "alert", "allow": "alert" }`
+					namespace.Annotations[util.EgressFirewallAclLoggingAnnotation] = `{ "deny": "notice" }`
+					namespace, err = fakeOVN.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Consistently(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
+
+					// enable allow logging for the egress firewall
+					namespace.Annotations[util.EgressFirewallAclLoggingAnnotation] = `{ "deny": "notice", "allow": "info" }`
+					namespace, err = fakeOVN.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					allowSeverity := nbdb.ACLSeverityInfo
+					acl.Log = true
+					acl.Severity = &allowSeverity
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
+
+					// without the egress firewall annotation, the namespace ACL logging applies
+					delete(namespace.Annotations, util.EgressFirewallAclLoggingAnnotation)
+					_, err = fakeOVN.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					namespaceSeverity := nbdb.ACLSeverityAlert
+					acl.Severity = &namespaceSeverity
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
+
+					return nil
+				}
+
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
 			for _, ipMode := range []string{"IPv4", "IPv6"} {
 				ginkgo.It(fmt.Sprintf("configures egress firewall correctly with node selector, gateway mode: %s, IP mode: %s", gwMode, ipMode), func() {
 					nodeIP4CIDR := "10.10.10.1/24"
@@ -22,6 +22,7 @@ import (
 	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
@@ -1411,8 +1412,11 @@ var _ = ginkgo.Describe("EgressIP Operations for user defined network with topol
 						UUID:      "stor-" + networkName1_ + node1Name + "-UUID",
 						Name:      "stor-" + networkName1_ + node1Name,
 						Addresses: []string{"router"},
-						Options:   map[string]string{libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name},
-						Type:      "router",
+						Options: map[string]string{
+							libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name,
+							"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(netInfo.Subnets()),
+						},
+						Type: "router",
 					},
 					&nbdb.ACL{
 						UUID:      netInfo.GetNetworkScopedSwitchName(node1.Name) + "-NetpolNode-UUID",
@@ -1660,8 +1664,11 @@ var _ = ginkgo.Describe("EgressIP Operations for user defined network with topol
 						UUID:      "stor-" + networkName1_ + node1Name + "-UUID",
 						Name:      "stor-" + networkName1_ + node1Name,
 						Addresses: []string{"router"},
-						Options:   map[string]string{libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name},
-						Type:      "router",
+						Options: map[string]string{
+							libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name,
+							"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(netInfo.Subnets()),
+						},
+						Type: "router",
 					},
 					&nbdb.ACL{
 						UUID:      netInfo.GetNetworkScopedSwitchName(node1.Name) + "-NetpolNode-UUID",
@@ -2882,8 +2889,11 @@ var _ = ginkgo.Describe("EgressIP Operations for user defined network with topol
 						UUID:      "stor-" + networkName1_ + node1Name + "-UUID",
 						Name:      "stor-" + networkName1_ + node1Name,
 						Addresses: []string{"router"},
-						Options:   map[string]string{libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name},
-						Type:      "router",
+						Options: map[string]string{
+							libovsdbops.RouterPort: "rtos-" + networkName1_ + node1Name,
+							"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(netInfo.Subnets()),
+						},
+						Type: "router",
 					},
 					&nbdb.ACL{
 						UUID:      netInfo.GetNetworkScopedSwitchName(node1.Name) + "-NetpolNode-UUID",
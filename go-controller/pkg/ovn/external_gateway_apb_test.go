@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
@@ -2362,6 +2363,139 @@ var _ = ginkgo.Describe("OVN for APB External Route Operations", func() {
 				return nil
 			}
 
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+		ginkgo.It("should program the hop BFD timers and report the BFD session state", func() {
+			app.Action = func(*cli.Context) error {
+				namespaceT := *newNamespace(namespaceName)
+
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+				p := getStaticPolicy(true)
+				p.Spec.NextHops.StaticHops[0].BFD = &adminpolicybasedrouteapi.BFDConfig{
+					MinTx:      ptr.To[int32](100),
+					MinRx:      ptr.To[int32](200),
+					DetectMult: ptr.To[int32](5),
+				}
+				fakeOvn.startWithDBSetup(
+					libovsdbtest.TestSetup{
+						NBData: []libovsdbtest.TestData{
+							&nbdb.LogicalSwitch{
+								UUID: "node1",
+								Name: "node1",
+							},
+							&nbdb.LogicalRouter{
+								UUID: "GR_node1-UUID",
+								Name: "GR_node1",
+							},
+						},
+					},
+					&corev1.NamespaceList{
+						Items: []corev1.Namespace{
+							namespaceT,
+						},
+					},
+					&corev1.PodList{
+						Items: []corev1.Pod{
+							*newPod(t.namespace, t.podName, t.nodeName, t.podIP),
+						},
+					},
+					&adminpolicybasedrouteapi.AdminPolicyBasedExternalRouteList{
+						Items: []adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute{p},
+					},
+				)
+				t.populateLogicalSwitchCache(fakeOvn)
+
+				injectNode(fakeOvn)
+				err := fakeOvn.controller.WatchNamespaces()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeOvn.controller.WatchPods()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				fakeOvn.RunAPBExternalPolicyController()
+
+				bfd := &nbdb.BFD{
+					UUID:        bfd1NamedUUID,
+					DstIP:       "9.0.0.1",
+					LogicalPort: "rtoe-GR_node1",
+					MinTx:       ptr.To(100),
+					MinRx:       ptr.To(200),
+					DetectMult:  ptr.To(5),
+				}
+				expectedNB := []libovsdbtest.TestData{
+					&nbdb.LogicalSwitchPort{
+						UUID:      "lsp1",
+						Addresses: []string{"0a:58:0a:80:01:03 10.128.1.3"},
+						ExternalIDs: map[string]string{
+							"pod":       "true",
+							"namespace": namespaceName,
+						},
+						Name: "namespace1_myPod",
+						Options: map[string]string{
+							"iface-id-ver":               "myPod",
+							libovsdbops.RequestedChassis: "node1",
+						},
+						PortSecurity: []string{"0a:58:0a:80:01:03 10.128.1.3"},
+					},
+					&nbdb.LogicalSwitch{
+						UUID:  "node1",
+						Name:  "node1",
+						Ports: []string{"lsp1"},
+					},
+					bfd,
+					&nbdb.LogicalRouterStaticRoute{
+						UUID:       "static-route-1-UUID",
+						IPPrefix:   "10.128.1.3/32",
+						Nexthop:    "9.0.0.1",
+						BFD:        &bfd1NamedUUID,
+						Policy:     &nbdb.LogicalRouterStaticRoutePolicySrcIP,
+						OutputPort: &logicalRouterPort,
+						Options: map[string]string{
+							"ecmp_symmetric_reply": "true",
+						},
+					},
+					&nbdb.LogicalRouter{
+						UUID:         "GR_node1-UUID",
+						Name:         "GR_node1",
+						StaticRoutes: []string{"static-route-1-UUID"},
+					},
+				}
+				gomega.Eventually(fakeOvn.nbClient, 5).Should(libovsdbtest.HaveData(expectedNB))
+				checkAPBRouteStatus(fakeOvn, policyName, false)
+
+				ginkgo.By("Reporting the BFD session state in the policy status")
+				getBFDSessions := func() []adminpolicybasedrouteapi.BFDSessionStatus {
+					policy, err := fakeOvn.fakeClient.AdminPolicyRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.Background(), policyName, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					return policy.Status.BFDSessions
+				}
+				gomega.Eventually(getBFDSessions).Should(gomega.Equal([]adminpolicybasedrouteapi.BFDSessionStatus{
+					{Node: "node1", NextHop: "9.0.0.1", State: adminpolicybasedrouteapi.BFDSessionStateDown},
+				}))
+				ops, err := libovsdbops.CreateOrUpdateBFDOps(fakeOvn.nbClient, nil, &nbdb.BFD{
+					DstIP:       "9.0.0.1",
+					LogicalPort: "rtoe-GR_node1",
+					Status:      &nbdb.BFDStatusUp,
+				})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = libovsdbops.TransactAndCheck(fakeOvn.nbClient, ops)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getBFDSessions).Should(gomega.Equal([]adminpolicybasedrouteapi.BFDSessionStatus{
+					{Node: "node1", NextHop: "9.0.0.1", State: adminpolicybasedrouteapi.BFDSessionStateUp},
+				}))
+				bfd.Status = &nbdb.BFDStatusUp
+				gomega.Consistently(fakeOvn.nbClient, 2).Should(libovsdbtest.HaveData(expectedNB))
+				return nil
+			}
+
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
@@ -0,0 +1,323 @@
+package healthcheck
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// This file implements the asynchronous mode of BFD (RFC 5880) without authentication and without echo,
+// over UDP (RFC 5881, RFC 5883). Both ends of the sessions are ovnkube processes, so the sessions use the
+// configured intervals in all the states instead of starting with the 1 second interval required by
+// RFC 5880 for the sessions that are not up.
+
+type bfdState uint8
+
+const (
+	bfdStateAdminDown bfdState = 0
+	bfdStateDown      bfdState = 1
+	bfdStateInit      bfdState = 2
+	bfdStateUp        bfdState = 3
+)
+
+func (s bfdState) String() string {
+	switch s {
+	case bfdStateAdminDown:
+		return "AdminDown"
+	case bfdStateDown:
+		return "Down"
+	case bfdStateInit:
+		return "Init"
+	case bfdStateUp:
+		return "Up"
+	}
+	return fmt.Sprintf("Unknown(%d)", uint8(s))
+}
+
+const (
+	bfdVersion = 1
+	// bfdControlPacketLength is the length of a control packet without authentication
+	bfdControlPacketLength = 24
+
+	bfdDiagNone                 = 0
+	bfdDiagControlDetectExpire  = 1
+	bfdDiagNeighborSignaledDown = 3
+
+	bfdFlagPoll  = 1 << 5
+	bfdFlagFinal = 1 << 4
+	bfdFlagAuth  = 1 << 2
+	bfdFlagMulti = 1 << 0
+)
+
+// bfdControlPacket is a BFD control packet, the intervals are in microseconds.
+type bfdControlPacket struct {
+	diag                  uint8
+	state                 bfdState
+	poll                  bool
+	final                 bool
+	detectMult            uint8
+	myDiscriminator       uint32
+	yourDiscriminator     uint32
+	desiredMinTxInterval  uint32
+	requiredMinRxInterval uint32
+}
+
+func (p *bfdControlPacket) marshal() []byte {
+	b := make([]byte, bfdControlPacketLength)
+	b[0] = bfdVersion<<5 | p.diag&0x1f
+	b[1] = uint8(p.state) << 6
+	if p.poll {
+		b[1] |= bfdFlagPoll
+	}
+	if p.final {
+		b[1] |= bfdFlagFinal
+	}
+	b[2] = p.detectMult
+	b[3] = bfdControlPacketLength
+	binary.BigEndian.PutUint32(b[4:], p.myDiscriminator)
+	binary.BigEndian.PutUint32(b[8:], p.yourDiscriminator)
+	binary.BigEndian.PutUint32(b[12:], p.desiredMinTxInterval)
+	binary.BigEndian.PutUint32(b[16:], p.requiredMinRxInterval)
+	// required min echo RX interval is 0, echo is not supported
+	return b
+}
+
+// unmarshalBFDControlPacket parses and validates a control packet as per RFC 5880 section 6.8.6.
+func unmarshalBFDControlPacket(b []byte) (*bfdControlPacket, error) {
+	if len(b) < bfdControlPacketLength {
+		return nil, fmt.Errorf("packet too short: %d bytes", len(b))
+	}
+	if version := b[0] >> 5; version != bfdVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+	if length := int(b[3]); length < bfdControlPacketLength || length > len(b) {
+		return nil, fmt.Errorf("invalid length %d", length)
+	}
+	if b[1]&bfdFlagAuth != 0 {
+		return nil, fmt.Errorf("authentication is not supported")
+	}
+	if b[1]&bfdFlagMulti != 0 {
+		return nil, fmt.Errorf("multipoint is not supported")
+	}
+	p := &bfdControlPacket{
+		diag:                  b[0] & 0x1f,
+		state:                 bfdState(b[1] >> 6),
+		poll:                  b[1]&bfdFlagPoll != 0,
+		final:                 b[1]&bfdFlagFinal != 0,
+		detectMult:            b[2],
+		myDiscriminator:       binary.BigEndian.Uint32(b[4:]),
+		yourDiscriminator:     binary.BigEndian.Uint32(b[8:]),
+		desiredMinTxInterval:  binary.BigEndian.Uint32(b[12:]),
+		requiredMinRxInterval: binary.BigEndian.Uint32(b[16:]),
+	}
+	if p.poll && p.final {
+		return nil, fmt.Errorf("both poll and final are set")
+	}
+	if p.detectMult == 0 {
+		return nil, fmt.Errorf("detect multiplier is 0")
+	}
+	if p.myDiscriminator == 0 {
+		return nil, fmt.Errorf("my discriminator is 0")
+	}
+	if p.yourDiscriminator == 0 && p.state != bfdStateDown && p.state != bfdStateAdminDown {
+		return nil, fmt.Errorf("your discriminator is 0 in state %s", p.state)
+	}
+	return p, nil
+}
+
+// bfdSession is a BFD session with a remote system. The session state is owned by the run goroutine,
+// the received packets are passed to it with receive.
+type bfdSession struct {
+	// name of the session for the logs
+	name string
+	// passive sessions don't transmit until they receive a packet from the remote system
+	passive bool
+	// interval is both the desired min TX interval and the required min RX interval
+	interval   time.Duration
+	detectMult uint8
+	send       func([]byte) error
+	// onStateChange is called from the run goroutine when the session state changes
+	onStateChange func(oldState, newState bfdState)
+	// onDownTimeout, when set, is called from the run goroutine when the session is still down after the
+	// detection time without receiving a packet
+	onDownTimeout      func()
+	localDiscriminator uint32
+
+	rxCh chan *bfdControlPacket
+
+	// sync.Mutex protects state, which is read outside of the run goroutine
+	sync.Mutex
+	state bfdState
+
+	// only accessed from the run goroutine
+	diag                uint8
+	remoteState         bfdState
+	remoteDiscriminator uint32
+	remoteMinRxInterval time.Duration
+	remoteMinTxInterval time.Duration
+	remoteDetectMult    uint8
+}
+
+func newBFDSession(name string, passive bool, interval time.Duration, detectMult int, send func([]byte) error,
+	onStateChange func(oldState, newState bfdState)) *bfdSession {
+	localDiscriminator := rand.Uint32()
+	for localDiscriminator == 0 {
+		localDiscriminator = rand.Uint32()
+	}
+	return &bfdSession{
+		name:               name,
+		passive:            passive,
+		interval:           interval,
+		detectMult:         uint8(detectMult),
+		send:               send,
+		onStateChange:      onStateChange,
+		rxCh:               make(chan *bfdControlPacket, 16),
+		state:              bfdStateDown,
+		localDiscriminator: localDiscriminator,
+		remoteState:        bfdStateDown,
+		// RFC 5880 section 6.8.1, the remote system accepts packets until it tells otherwise
+		remoteMinRxInterval: time.Microsecond,
+	}
+}
+
+func (s *bfdSession) getState() bfdState {
+	s.Lock()
+	defer s.Unlock()
+	return s.state
+}
+
+// receive passes a packet received for the session to the run goroutine, the packet is dropped if the
+// session can't keep up, as if it was lost.
+func (s *bfdSession) receive(p *bfdControlPacket) {
+	select {
+	case s.rxCh <- p:
+	default:
+	}
+}
+
+// run runs the session until stopCh is closed.
+func (s *bfdSession) run(stopCh <-chan struct{}) {
+	transmitting := !s.passive
+	txTimer := time.NewTimer(0)
+	defer txTimer.Stop()
+	if !transmitting {
+		txTimer.Stop()
+	}
+	detectTimer := time.NewTimer(0)
+	defer detectTimer.Stop()
+	detectTimer.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-txTimer.C:
+			s.transmit(false)
+			txTimer.Reset(s.txInterval())
+		case p := <-s.rxCh:
+			s.process(p)
+			detectTimer.Reset(s.detectionTime())
+			if p.poll {
+				s.transmit(true)
+			}
+			if !transmitting {
+				transmitting = true
+				txTimer.Reset(0)
+			}
+		case <-detectTimer.C:
+			if s.state == bfdStateInit || s.state == bfdStateUp {
+				s.remoteDiscriminator = 0
+				s.remoteState = bfdStateDown
+				s.setState(bfdStateDown, bfdDiagControlDetectExpire)
+			} else if s.onDownTimeout != nil {
+				s.onDownTimeout()
+			}
+		}
+	}
+}
+
+// process updates the session with a received packet as per RFC 5880 section 6.8.6.
+func (s *bfdSession) process(p *bfdControlPacket) {
+	s.remoteDiscriminator = p.myDiscriminator
+	s.remoteState = p.state
+	s.remoteDetectMult = p.detectMult
+	s.remoteMinRxInterval = time.Duration(p.requiredMinRxInterval) * time.Microsecond
+	s.remoteMinTxInterval = time.Duration(p.desiredMinTxInterval) * time.Microsecond
+
+	if p.state == bfdStateAdminDown {
+		if s.state != bfdStateDown {
+			s.setState(bfdStateDown, bfdDiagNeighborSignaledDown)
+		}
+		return
+	}
+	switch s.state {
+	case bfdStateDown:
+		switch p.state {
+		case bfdStateDown:
+			s.setState(bfdStateInit, bfdDiagNone)
+		case bfdStateInit:
+			s.setState(bfdStateUp, bfdDiagNone)
+		}
+	case bfdStateInit:
+		if p.state == bfdStateInit || p.state == bfdStateUp {
+			s.setState(bfdStateUp, bfdDiagNone)
+		}
+	case bfdStateUp:
+		if p.state == bfdStateDown {
+			s.setState(bfdStateDown, bfdDiagNeighborSignaledDown)
+		}
+	}
+}
+
+func (s *bfdSession) setState(state bfdState, diag uint8) {
+	s.Lock()
+	oldState := s.state
+	s.state = state
+	s.Unlock()
+	s.diag = diag
+	if oldState == state {
+		return
+	}
+	klog.V(5).Infof("BFD session %s changed from %s to %s, diagnostic %d", s.name, oldState, state, diag)
+	if s.onStateChange != nil {
+		s.onStateChange(oldState, state)
+	}
+}
+
+func (s *bfdSession) transmit(final bool) {
+	if s.remoteMinRxInterval == 0 && !final {
+		// the remote system doesn't want to receive periodic packets
+		return
+	}
+	p := &bfdControlPacket{
+		diag:                  s.diag,
+		state:                 s.state,
+		final:                 final,
+		detectMult:            s.detectMult,
+		myDiscriminator:       s.localDiscriminator,
+		yourDiscriminator:     s.remoteDiscriminator,
+		desiredMinTxInterval:  uint32(s.interval.Microseconds()),
+		requiredMinRxInterval: uint32(s.interval.Microseconds()),
+	}
+	// errors are handled as lost packets, the detection time takes care of them
+	_ = s.send(p.marshal())
+}
+
+// txInterval returns the interval until the next packet is sent, with the jitter of RFC 5880 section 6.8.7.
+func (s *bfdSession) txInterval() time.Duration {
+	interval := max(s.interval, s.remoteMinRxInterval)
+	maxPercent := 100
+	if s.detectMult == 1 {
+		maxPercent = 90
+	}
+	return interval * time.Duration(75+rand.IntN(maxPercent-75)) / 100
+}
+
+// detectionTime returns the time after which the session goes down without receiving a packet,
+// as per RFC 5880 section 6.8.4.
+func (s *bfdSession) detectionTime() time.Duration {
+	return time.Duration(s.remoteDetectMult) * max(s.interval, s.remoteMinTxInterval)
+}
@@ -0,0 +1,312 @@
+package healthcheck
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/klog/v2"
+)
+
+// bfdMaxPacketLength is large enough for the control packets with authentication, which are discarded.
+const bfdMaxPacketLength = 128
+
+type egressIPBFDServer struct {
+	// Management IPs bound by server
+	nodeMgmtIPs []net.IP
+	// EgressIP Node reachability BFD UDP port
+	healthCheckPort int
+	interval        time.Duration
+	detectMult      int
+
+	sessionsLock sync.Mutex
+	// sessions are the passive sessions keyed by the remote address
+	sessions map[string]*bfdServerSession
+}
+
+type bfdServerSession struct {
+	session *bfdSession
+	stopCh  chan struct{}
+}
+
+// NewEgressIPBFDServer allocates an Egress IP health server answering the BFD sessions of the cluster manager
+// on the node management IPs.
+func NewEgressIPBFDServer(nodeMgmtIPs []net.IP, healthCheckPort int, interval time.Duration, detectMult int) (EgressIPHealthServer, error) {
+	return &egressIPBFDServer{
+		nodeMgmtIPs:     nodeMgmtIPs,
+		healthCheckPort: healthCheckPort,
+		interval:        interval,
+		detectMult:      detectMult,
+		sessions:        map[string]*bfdServerSession{},
+	}, nil
+}
+
+// Run answers the BFD sessions until stopCh is closed.
+func (ebs *egressIPBFDServer) Run(stopCh <-chan struct{}) {
+	wg := &sync.WaitGroup{}
+	var conns []*net.UDPConn
+	for _, nodeMgmtIP := range ebs.nodeMgmtIPs {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: nodeMgmtIP, Port: ebs.healthCheckPort})
+		if err != nil {
+			klog.Fatalf("BFD health checking listen failed: %v", err)
+		}
+		conns = append(conns, conn)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			klog.Infof("Starting Egress IP BFD Health Server on %s", conn.LocalAddr())
+			ebs.serve(conn)
+			klog.Infof("Stopped Egress IP BFD Health Server on %s", conn.LocalAddr())
+		}()
+	}
+
+	<-stopCh
+
+	klog.Info("Shutting down Egress IP BFD Health Server")
+	for _, conn := range conns {
+		conn.Close()
+	}
+	wg.Wait()
+	ebs.sessionsLock.Lock()
+	for remoteAddr, serverSession := range ebs.sessions {
+		close(serverSession.stopCh)
+		delete(ebs.sessions, remoteAddr)
+	}
+	ebs.sessionsLock.Unlock()
+	klog.Info("Egress IP BFD Health Server is shutdown")
+}
+
+// serve dispatches the packets received on conn to their sessions until conn is closed.
+func (ebs *egressIPBFDServer) serve(conn *net.UDPConn) {
+	buf := make([]byte, bfdMaxPacketLength)
+	for {
+		n, remoteAddr, err := conn.ReadFromUDP(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		p, err := unmarshalBFDControlPacket(buf[:n])
+		if err != nil {
+			klog.V(5).Infof("Discarding BFD packet from %s: %v", remoteAddr, err)
+			continue
+		}
+		session := ebs.getOrCreateSession(conn, remoteAddr, p)
+		if session != nil {
+			session.receive(p)
+		}
+	}
+}
+
+// getOrCreateSession returns the session of a received packet, a new passive session is created for the first
+// packet of a remote system. It returns nil when the packet doesn't belong to a session.
+func (ebs *egressIPBFDServer) getOrCreateSession(conn *net.UDPConn, remoteAddr *net.UDPAddr, p *bfdControlPacket) *bfdSession {
+	ebs.sessionsLock.Lock()
+	defer ebs.sessionsLock.Unlock()
+	key := remoteAddr.String()
+	if serverSession, ok := ebs.sessions[key]; ok {
+		if p.yourDiscriminator != 0 && p.yourDiscriminator != serverSession.session.localDiscriminator {
+			return nil
+		}
+		return serverSession.session
+	}
+	if p.yourDiscriminator != 0 {
+		// the packet is for a session that was removed, the remote system will start a new session
+		// once its detection time expires
+		return nil
+	}
+	serverSession := &bfdServerSession{stopCh: make(chan struct{})}
+	serverSession.session = newBFDSession(key, true, ebs.interval, ebs.detectMult,
+		func(b []byte) error {
+			_, err := conn.WriteToUDP(b, remoteAddr)
+			return err
+		},
+		func(_, newState bfdState) {
+			// the remote system stopped the session or can't be reached anymore, forget about it
+			if newState == bfdStateDown {
+				ebs.removeSession(key, serverSession)
+			}
+		})
+	// the session never came up and the remote system stopped sending packets, e.g. it was
+	// created by an AdminDown packet, forget about it as well
+	serverSession.session.onDownTimeout = func() {
+		ebs.removeSession(key, serverSession)
+	}
+	ebs.sessions[key] = serverSession
+	go serverSession.session.run(serverSession.stopCh)
+	return serverSession.session
+}
+
+func (ebs *egressIPBFDServer) removeSession(key string, serverSession *bfdServerSession) {
+	ebs.sessionsLock.Lock()
+	defer ebs.sessionsLock.Unlock()
+	if ebs.sessions[key] == serverSession {
+		close(serverSession.stopCh)
+		delete(ebs.sessions, key)
+	}
+}
+
+type egressIPBFDClient struct {
+	nodeName   string
+	interval   time.Duration
+	detectMult int
+	// notify is called when the node becomes reachable or unreachable according to the BFD sessions
+	notify func()
+	conn   *bfdClientConnection
+}
+
+// bfdClientConnection holds the BFD sessions with the management IPs of a node, the node is reachable when
+// at least one of them is up.
+type bfdClientConnection struct {
+	nodeAddrs []string
+	sessions  []*bfdSession
+	conns     []*net.UDPConn
+	stopCh    chan struct{}
+	upCount   atomic.Int32
+}
+
+// NewEgressIPBFDClient allocates an Egress IP health client probing the node with BFD sessions.
+// notify is called when the node is detected as reachable or unreachable, within the BFD detection time.
+func NewEgressIPBFDClient(nodeName string, interval time.Duration, detectMult int, notify func()) EgressIPHealthClient {
+	return &egressIPBFDClient{
+		nodeName:   nodeName,
+		interval:   interval,
+		detectMult: detectMult,
+		notify:     notify,
+	}
+}
+
+// IsConnected returns whether the BFD sessions are started or not.
+func (ebc *egressIPBFDClient) IsConnected() bool {
+	return ebc.conn != nil
+}
+
+// Connect starts the BFD sessions with the node management IPs and waits for one of them to be up.
+// The sessions are kept when they don't come up in time, Probe reports their state afterwards.
+func (ebc *egressIPBFDClient) Connect(dialCtx context.Context, mgmtIPs []net.IP, healthCheckPort int) bool {
+	ebc.Disconnect()
+	conn := &bfdClientConnection{stopCh: make(chan struct{})}
+	for _, nodeMgmtIP := range mgmtIPs {
+		nodeAddr := net.JoinHostPort(nodeMgmtIP.String(), strconv.Itoa(healthCheckPort))
+		udpConn, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: nodeMgmtIP, Port: healthCheckPort})
+		if err != nil {
+			klog.Warningf("Could not start BFD session with %s (%s): %v", ebc.nodeName, nodeAddr, err)
+			continue
+		}
+		session := newBFDSession(ebc.nodeName+"/"+nodeAddr, false, ebc.interval, ebc.detectMult,
+			func(b []byte) error {
+				_, err := udpConn.Write(b)
+				return err
+			},
+			func(oldState, newState bfdState) {
+				ebc.sessionStateChanged(conn, oldState, newState)
+			})
+		conn.nodeAddrs = append(conn.nodeAddrs, nodeAddr)
+		conn.sessions = append(conn.sessions, session)
+		conn.conns = append(conn.conns, udpConn)
+		go session.run(conn.stopCh)
+		go receiveBFDPackets(udpConn, session)
+	}
+	if len(conn.sessions) == 0 {
+		return false
+	}
+	ebc.conn = conn
+	klog.Infof("Started BFD sessions with %s (%v)", ebc.nodeName, conn.nodeAddrs)
+
+	ticker := time.NewTicker(ebc.interval)
+	defer ticker.Stop()
+	for conn.upCount.Load() == 0 {
+		select {
+		case <-dialCtx.Done():
+			klog.Warningf("BFD sessions with %s (%v) are not up yet", ebc.nodeName, conn.nodeAddrs)
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+func (ebc *egressIPBFDClient) sessionStateChanged(conn *bfdClientConnection, oldState, newState bfdState) {
+	var upCount int32
+	switch {
+	case newState == bfdStateUp:
+		upCount = conn.upCount.Add(1)
+		if upCount != 1 {
+			return
+		}
+		klog.Infof("BFD session with %s is up", ebc.nodeName)
+	case oldState == bfdStateUp:
+		upCount = conn.upCount.Add(-1)
+		if upCount != 0 {
+			return
+		}
+		klog.Warningf("BFD sessions with %s are down", ebc.nodeName)
+	default:
+		return
+	}
+	if ebc.notify != nil {
+		ebc.notify()
+	}
+}
+
+// receiveBFDPackets passes the packets received on conn to the session until conn is closed.
+func receiveBFDPackets(conn *net.UDPConn, session *bfdSession) {
+	buf := make([]byte, bfdMaxPacketLength)
+	for {
+		n, err := conn.Read(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			// e.g. connection refused while the node is not serving yet, the packet is lost
+			continue
+		}
+		p, err := unmarshalBFDControlPacket(buf[:n])
+		if err != nil {
+			klog.V(5).Infof("Discarding BFD packet from %s: %v", conn.RemoteAddr(), err)
+			continue
+		}
+		if p.yourDiscriminator != 0 && p.yourDiscriminator != session.localDiscriminator {
+			continue
+		}
+		session.receive(p)
+	}
+}
+
+// Disconnect stops the BFD sessions, the node is notified with an AdminDown packet.
+func (ebc *egressIPBFDClient) Disconnect() {
+	if ebc.conn == nil {
+		return
+	}
+	klog.Infof("Stopping BFD sessions with %s (%v)", ebc.nodeName, ebc.conn.nodeAddrs)
+	close(ebc.conn.stopCh)
+	for i, udpConn := range ebc.conn.conns {
+		adminDown := &bfdControlPacket{
+			state:                 bfdStateAdminDown,
+			detectMult:            uint8(ebc.detectMult),
+			myDiscriminator:       ebc.conn.sessions[i].localDiscriminator,
+			desiredMinTxInterval:  uint32(ebc.interval.Microseconds()),
+			requiredMinRxInterval: uint32(ebc.interval.Microseconds()),
+		}
+		_, _ = udpConn.Write(adminDown.marshal())
+		udpConn.Close()
+	}
+	ebc.conn = nil
+}
+
+// Probe returns whether one of the BFD sessions with the node is up. It doesn't wait, the BFD sessions
+// detect the failures on their own.
+func (ebc *egressIPBFDClient) Probe(context.Context) bool {
+	if ebc.conn == nil {
+		// should never happen
+		klog.Warningf("Unexpected probing before connecting %s", ebc.nodeName)
+		return false
+	}
+	return ebc.conn.upCount.Load() > 0
+}
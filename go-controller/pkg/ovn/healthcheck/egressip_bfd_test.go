@@ -0,0 +1,165 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestBFDControlPacket(t *testing.T) {
+	g := gomega.NewWithT(t)
+	p := &bfdControlPacket{
+		diag:                  bfdDiagControlDetectExpire,
+		state:                 bfdStateInit,
+		poll:                  true,
+		detectMult:            3,
+		myDiscriminator:       1,
+		yourDiscriminator:     2,
+		desiredMinTxInterval:  100000,
+		requiredMinRxInterval: 200000,
+	}
+	b := p.marshal()
+	g.Expect(b).To(gomega.HaveLen(bfdControlPacketLength))
+	g.Expect(b[0]).To(gomega.Equal(uint8(0x21)))
+	g.Expect(b[1]).To(gomega.Equal(uint8(0xa0)))
+	parsed, err := unmarshalBFDControlPacket(b)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(parsed).To(gomega.Equal(p))
+
+	for name, invalid := range map[string]func(b []byte){
+		"version":             func(b []byte) { b[0] = 0 },
+		"length":              func(b []byte) { b[3] = 20 },
+		"authentication":      func(b []byte) { b[1] |= bfdFlagAuth },
+		"detect multiplier":   func(b []byte) { b[2] = 0 },
+		"my discriminator":    func(b []byte) { copy(b[4:8], []byte{0, 0, 0, 0}) },
+		"your discriminator":  func(b []byte) { copy(b[8:12], []byte{0, 0, 0, 0}) },
+		"both poll and final": func(b []byte) { b[1] |= bfdFlagFinal },
+	} {
+		b := p.marshal()
+		invalid(b)
+		_, err := unmarshalBFDControlPacket(b)
+		g.Expect(err).To(gomega.HaveOccurred(), name)
+	}
+}
+
+func TestEgressIPBFD(t *testing.T) {
+	g := gomega.NewWithT(t)
+	const interval = 20 * time.Millisecond
+
+	// reserve a free UDP port for the server
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	g.Expect(conn.Close()).To(gomega.Succeed())
+	mgmtIPs := []net.IP{net.IPv4(127, 0, 0, 1)}
+
+	startServer := func() chan struct{} {
+		server, err := NewEgressIPBFDServer(mgmtIPs, port, interval, 3)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		stopCh := make(chan struct{})
+		go server.Run(stopCh)
+		return stopCh
+	}
+
+	var notified atomic.Int32
+	client := NewEgressIPBFDClient("node1", interval, 3, func() { notified.Add(1) })
+	defer client.Disconnect()
+
+	// the node is not reachable until the server is started
+	ctx, cancel := context.WithTimeout(context.Background(), 5*interval)
+	defer cancel()
+	g.Expect(client.Connect(ctx, mgmtIPs, port)).To(gomega.BeFalse())
+	g.Expect(client.IsConnected()).To(gomega.BeTrue())
+	g.Expect(client.Probe(ctx)).To(gomega.BeFalse())
+
+	serverStopCh := startServer()
+	g.Eventually(func() bool { return client.Probe(context.Background()) }).Should(gomega.BeTrue())
+	g.Expect(notified.Load()).To(gomega.Equal(int32(1)))
+
+	// the failure of the node is detected within the detection time
+	close(serverStopCh)
+	start := time.Now()
+	g.Eventually(func() bool { return client.Probe(context.Background()) }, time.Second, time.Millisecond).Should(gomega.BeFalse())
+	g.Expect(time.Since(start)).To(gomega.BeNumerically("<", 10*interval))
+	g.Expect(notified.Load()).To(gomega.Equal(int32(2)))
+
+	// the session comes back up with the node
+	serverStopCh = startServer()
+	defer close(serverStopCh)
+	g.Eventually(func() bool { return client.Probe(context.Background()) }).Should(gomega.BeTrue())
+	g.Expect(notified.Load()).To(gomega.Equal(int32(3)))
+
+	client.Disconnect()
+	g.Expect(client.IsConnected()).To(gomega.BeFalse())
+}
+
+func TestEgressIPBFDServerRemovesSessions(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	tests := []struct {
+		desc  string
+		state bfdState
+	}{
+		{
+			desc:  "session that never comes up",
+			state: bfdStateDown,
+		},
+		{
+			desc:  "session created by an AdminDown packet",
+			state: bfdStateAdminDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			// reserve a free UDP port for the server
+			conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			serverAddr := conn.LocalAddr().(*net.UDPAddr)
+			g.Expect(conn.Close()).To(gomega.Succeed())
+
+			server, err := NewEgressIPBFDServer([]net.IP{serverAddr.IP}, serverAddr.Port, interval, 3)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			go server.Run(stopCh)
+			ebs := server.(*egressIPBFDServer)
+			sessionCount := func() int {
+				ebs.sessionsLock.Lock()
+				defer ebs.sessionsLock.Unlock()
+				return len(ebs.sessions)
+			}
+
+			// the remote system sends a single packet and never answers
+			remote, err := net.DialUDP("udp", nil, serverAddr)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			defer remote.Close()
+			p := &bfdControlPacket{
+				state:                 tc.state,
+				detectMult:            3,
+				myDiscriminator:       1,
+				desiredMinTxInterval:  uint32(interval.Microseconds()),
+				requiredMinRxInterval: uint32(interval.Microseconds()),
+			}
+			buf := make([]byte, bfdMaxPacketLength)
+			g.Eventually(func() error {
+				if _, err := remote.Write(p.marshal()); err != nil {
+					return err
+				}
+				g.Expect(remote.SetReadDeadline(time.Now().Add(5 * interval))).To(gomega.Succeed())
+				_, err := remote.Read(buf)
+				return err
+			}).Should(gomega.Succeed())
+
+			// the session is removed once the detection time expires
+			g.Eventually(sessionCount, time.Second, time.Millisecond).Should(gomega.BeZero())
+			g.Consistently(sessionCount, 5*interval, interval).Should(gomega.BeZero())
+		})
+	}
+}
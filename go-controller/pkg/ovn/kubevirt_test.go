@@ -623,7 +623,7 @@ var _ = Describe("OVN Kubevirt Operations", func() {
 				Type: "router",
 				Options: map[string]string{
 					libovsdbops.RouterPort: logicalRouterPort.Name,
-					"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(),
+					"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 				},
 			}
 			logicalSwitch = &nbdb.LogicalSwitch{
@@ -683,7 +683,7 @@ var _ = Describe("OVN Kubevirt Operations", func() {
 					Type: "router",
 					Options: map[string]string{
 						libovsdbops.RouterPort: migrationTargetLRP.Name,
-						"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(),
+						"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 					},
 				}
 				migrationTargetLS = &nbdb.LogicalSwitch{
@@ -2,6 +2,7 @@ package ovn
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/generator/udn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -144,6 +146,15 @@ func (h *Layer3UserDefinedNetworkControllerEventHandler) AddResource(obj interfa
 				return err
 			}
 		}
+	case factory.PodType:
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *knet.Pod", obj)
+		}
+		if err := h.oc.ensurePodForUserDefinedNetwork(pod, true); err != nil {
+			return err
+		}
+		return h.oc.ensureLiveMigratablePodRouting(pod)
 	default:
 		return h.oc.AddUserDefinedNetworkResourceCommon(h.objType, obj)
 	}
@@ -222,6 +233,13 @@ func (h *Layer3UserDefinedNetworkControllerEventHandler) UpdateResource(oldObj,
 			}
 			return h.oc.addUpdateRemoteNodeEvent(newNode, syncZoneIC)
 		}
+	case factory.PodType:
+		newPod := newObj.(*corev1.Pod)
+		oldPod := oldObj.(*corev1.Pod)
+		if err := h.oc.ensurePodForUserDefinedNetwork(newPod, shouldAddPort(oldPod, newPod, inRetryCache)); err != nil {
+			return err
+		}
+		return h.oc.ensureLiveMigratablePodRouting(newPod)
 	default:
 		return h.oc.UpdateUserDefinedNetworkResourceCommon(h.objType, oldObj, newObj, inRetryCache)
 	}
@@ -239,6 +257,18 @@ func (h *Layer3UserDefinedNetworkControllerEventHandler) DeleteResource(obj, cac
 		}
 		return h.oc.deleteNodeEvent(node)
 
+	case factory.PodType:
+		var portInfoMap map[string]*lpInfo
+		pod := obj.(*corev1.Pod)
+
+		if cachedObj != nil {
+			portInfoMap = cachedObj.(map[string]*lpInfo)
+		}
+		if err := h.oc.removePodForUserDefinedNetwork(pod, portInfoMap); err != nil {
+			return err
+		}
+		return h.oc.removeLiveMigratablePod(pod)
+
 	default:
 		return h.oc.DeleteUserDefinedNetworkResourceCommon(h.objType, obj, cachedObj)
 	}
@@ -963,6 +993,64 @@ func (oc *Layer3UserDefinedNetworkController) deleteNode(nodeName string) error
 	return nil
 }
 
+// ensureLiveMigratablePodRouting configures the point to point routes needed
+// to reach a live migrated VM running on a node other than the one owning the
+// subnet its IPs were allocated from.
+func (oc *Layer3UserDefinedNetworkController) ensureLiveMigratablePodRouting(pod *corev1.Pod) error {
+	if util.PodWantsHostNetwork(pod) || !util.PodScheduled(pod) || !kubevirt.IsPodAllowedForMigration(pod, oc.GetNetInfo()) {
+		return nil
+	}
+	nadNames, err := util.PodNadNames(pod, oc.GetNetInfo())
+	if err != nil {
+		return err
+	}
+	isLocalPod := oc.isPodScheduledinLocalZone(pod)
+	for _, nadName := range nadNames {
+		if isLocalPod {
+			err = kubevirt.EnsureLocalZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, oc.lsManager, oc.GetNetInfo(), pod, nadName, oc.Subnets())
+		} else {
+			err = kubevirt.EnsureRemoteZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, oc.GetNetInfo(), pod, nadName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed configuring live migration routes for pod %s/%s on network %s: %w",
+				pod.Namespace, pod.Name, oc.GetNetworkName(), err)
+		}
+	}
+	return nil
+}
+
+// removeLiveMigratablePod removes the point to point routes of a live migrated
+// VM once all its pods are completed. For a VM last running on a remote node
+// it also releases its IPs if they were allocated from a subnet of this zone.
+func (oc *Layer3UserDefinedNetworkController) removeLiveMigratablePod(pod *corev1.Pod) error {
+	if util.PodWantsHostNetwork(pod) || !util.PodScheduled(pod) || !kubevirt.IsPodAllowedForMigration(pod, oc.GetNetInfo()) {
+		return nil
+	}
+	if err := kubevirt.CleanUpLiveMigratablePod(oc.nbClient, oc.watchFactory, oc.GetNetInfo(), pod); err != nil {
+		return err
+	}
+	// IPs of local pods are released as part of the logical port removal
+	if oc.isPodScheduledinLocalZone(pod) {
+		return nil
+	}
+	allVMPodsAreCompleted, err := kubevirt.AllVMPodsAreCompleted(oc.watchFactory.PodCoreInformer().Lister(), pod)
+	if err != nil {
+		return err
+	}
+	if !allVMPodsAreCompleted {
+		return nil
+	}
+	ips, err := util.GetPodCIDRsWithFullMask(pod, oc.GetNetInfo())
+	if err != nil && !errors.Is(err, util.ErrNoPodIPFound) {
+		return fmt.Errorf("failed to get pod ips for the pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	switchName, zoneContainsPodSubnet := kubevirt.ZoneContainsPodSubnet(oc.lsManager, ips)
+	if !zoneContainsPodSubnet {
+		return nil
+	}
+	return oc.lsManager.ReleaseIPs(switchName, ips)
+}
+
 // We only deal with cleaning up nodes that shouldn't exist here, since
 // watchNodes() will be called for all existing nodes at startup anyway.
 // Note that this list will include the 'join' cluster switch, which we
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -284,21 +285,8 @@ func (oc *LocalnetUserDefinedNetworkController) init() error {
 		return err
 	}
 
-	// Add external interface as a logical port to external_switch.
-	// This is a learning switch port with "unknown" address. The external
-	// world is accessed via this port.
-	logicalSwitchPort := nbdb.LogicalSwitchPort{
-		Name:      oc.GetNetworkScopedName(types.OVNLocalnetPort),
-		Addresses: []string{"unknown"},
-		Type:      "localnet",
-		Options:   oc.localnetPortNetworkNameOptions(),
-	}
-	intVlanID := int(oc.Vlan())
-	if intVlanID != 0 {
-		logicalSwitchPort.TagRequest = &intVlanID
-	}
-
-	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, logicalSwitch, &logicalSwitchPort)
+	logicalSwitchPort := oc.newLocalnetPort()
+	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, logicalSwitch, logicalSwitchPort)
 	if err != nil {
 		klog.Errorf("Failed to add logical port %+v to switch %s: %v", logicalSwitchPort, switchName, err)
 		return err
@@ -359,6 +347,33 @@ func (oc *LocalnetUserDefinedNetworkController) newRetryFramework(
 	)
 }
 
+// newLocalnetPort returns the logical port of the external interface on the network switch.
+// This is a learning switch port with "unknown" address. The external
+// world is accessed via this port.
+func (oc *LocalnetUserDefinedNetworkController) newLocalnetPort() *nbdb.LogicalSwitchPort {
+	logicalSwitchPort := &nbdb.LogicalSwitchPort{
+		Name:      oc.GetNetworkScopedName(types.OVNLocalnetPort),
+		Addresses: []string{"unknown"},
+		Type:      "localnet",
+		Options:   oc.localnetPortNetworkNameOptions(),
+	}
+	intVlanID := int(oc.Vlan())
+	if len(oc.AllowedVLANs()) > 0 {
+		// in trunk mode, the port carries the allowed VLANs tagged and the
+		// untagged traffic in the native VLAN, if any
+		trunks := make([]string, 0, len(oc.AllowedVLANs()))
+		for _, vlans := range oc.AllowedVLANs() {
+			trunks = append(trunks, vlans.String())
+		}
+		logicalSwitchPort.Options["trunks"] = strings.Join(trunks, ",")
+		intVlanID = int(oc.NativeVLAN())
+	}
+	if intVlanID != 0 {
+		logicalSwitchPort.TagRequest = &intVlanID
+	}
+	return logicalSwitchPort
+}
+
 func (oc *LocalnetUserDefinedNetworkController) localnetPortNetworkNameOptions() map[string]string {
 	localnetLSPOptions := map[string]string{
 		"network_name": oc.GetNetworkName(),
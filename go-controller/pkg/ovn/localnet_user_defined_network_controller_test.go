@@ -0,0 +1,69 @@
+package ovn
+
+import (
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Localnet port", func() {
+	const portName = "localnet_ovn_localnet_port"
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	tag := func(vlan int) *int {
+		return &vlan
+	}
+
+	DescribeTable("programs the VLAN configuration of the network",
+		func(vlanID int, allowedVLANs string, nativeVLAN int, expectedPort *nbdb.LogicalSwitchPort) {
+			netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+				NetConf:      cnitypes.NetConf{Name: "localnet"},
+				Topology:     types.LocalnetTopology,
+				NADName:      "ns1/localnet",
+				VLANID:       vlanID,
+				AllowedVLANs: allowedVLANs,
+				NativeVLAN:   nativeVLAN,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			oc := &LocalnetUserDefinedNetworkController{}
+			oc.ReconcilableNetInfo = util.NewReconcilableNetInfo(netInfo)
+			Expect(oc.newLocalnetPort()).To(Equal(expectedPort))
+		},
+		Entry("without VLAN", 0, "", 0, &nbdb.LogicalSwitchPort{
+			Name:      portName,
+			Addresses: []string{"unknown"},
+			Type:      "localnet",
+			Options:   map[string]string{"network_name": "localnet"},
+		}),
+		Entry("in access mode", 10, "", 0, &nbdb.LogicalSwitchPort{
+			Name:       portName,
+			Addresses:  []string{"unknown"},
+			Type:       "localnet",
+			Options:    map[string]string{"network_name": "localnet"},
+			TagRequest: tag(10),
+		}),
+		Entry("in trunk mode with a native VLAN", 0, "100,200-300", 10, &nbdb.LogicalSwitchPort{
+			Name:       portName,
+			Addresses:  []string{"unknown"},
+			Type:       "localnet",
+			Options:    map[string]string{"network_name": "localnet", "trunks": "100,200-300"},
+			TagRequest: tag(10),
+		}),
+		Entry("in trunk mode without a native VLAN", 0, "100", 0, &nbdb.LogicalSwitchPort{
+			Name:      portName,
+			Addresses: []string{"unknown"},
+			Type:      "localnet",
+			Options:   map[string]string{"network_name": "localnet", "trunks": "100"},
+		}),
+	)
+})
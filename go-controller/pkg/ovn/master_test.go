@@ -356,7 +356,7 @@ func addNodeLogicalFlowsHelper(testData []libovsdbtest.TestData, expectedOVNClus
 		Type: "router",
 		Options: map[string]string{
 			libovsdbops.RouterPort: types.RouterToSwitchPrefix + node.Name,
-			"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(),
+			"arp_proxy":            kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 		},
 		Addresses: []string{"router"},
 	})
@@ -149,12 +149,17 @@ func getMulticastStaleData(netInfo util.NetInfo, clusterPortGroup, clusterRtrPor
 func getMulticastPolicyExpectedData(netInfo util.NetInfo, ns string, ports []string) []libovsdb.TestData {
 	netControllerName := getNetworkControllerName(netInfo.GetNetworkName())
 	fakeController := getFakeController(netControllerName)
-	pg_hash := fakeController.getNamespacePortGroupName(ns)
-	egressMatch := libovsdbutil.GetACLMatch(pg_hash, fakeController.getMulticastACLEgrMatch(), libovsdbutil.ACLEgress)
-
 	ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(netControllerName, ns)
 	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4(ip4AddressSet), getMulticastACLIgrMatchV6(ip6AddressSet), config.IPv4Mode, config.IPv6Mode)
-	ingressMatch := libovsdbutil.GetACLMatch(pg_hash, mcastMatch, libovsdbutil.ACLIngress)
+	return getMulticastPolicyExpectedDataWithMatches(netInfo, ns, ports, fakeController.getMulticastACLEgrMatch(), mcastMatch)
+}
+
+func getMulticastPolicyExpectedDataWithMatches(netInfo util.NetInfo, ns string, ports []string, egressMcastMatch, ingressMcastMatch string) []libovsdb.TestData {
+	netControllerName := getNetworkControllerName(netInfo.GetNetworkName())
+	fakeController := getFakeController(netControllerName)
+	pg_hash := fakeController.getNamespacePortGroupName(ns)
+	egressMatch := libovsdbutil.GetACLMatch(pg_hash, egressMcastMatch, libovsdbutil.ACLEgress)
+	ingressMatch := libovsdbutil.GetACLMatch(pg_hash, ingressMcastMatch, libovsdbutil.ACLIngress)
 
 	aclIDs := getNamespaceMcastACLDbIDs(ns, libovsdbutil.ACLEgress, netControllerName)
 	aclName := libovsdbutil.GetACLName(aclIDs)
@@ -702,6 +707,93 @@ var _ = Describe("OVN Multicast with IP Address Family", func() {
 			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
 		)
 
+		DescribeTable("tests restricting the multicast groups allowed in a namespace", func(useIPv4, useIPv6 bool, nad *nadapi.NetworkAttachmentDefinition) {
+			app.Action = func(*cli.Context) error {
+				config.IPv4Mode = useIPv4
+				config.IPv6Mode = useIPv6
+
+				netInfo := getNetInfoFromNAD(nad)
+				namespace1 := *newNamespace(namespaceName1)
+
+				objs := []runtime.Object{&corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						namespace1,
+					},
+				}}
+				if nad != nil {
+					objs = append(objs, &nadapi.NetworkAttachmentDefinitionList{
+						Items: []nadapi.NetworkAttachmentDefinition{*nad},
+					})
+				}
+
+				fakeOvn.startWithDBSetup(libovsdb.TestSetup{}, objs...)
+
+				if nad != nil {
+					Expect(fakeOvn.networkManager.Start()).To(Succeed())
+					defer fakeOvn.networkManager.Stop()
+				}
+
+				bnc, _ := startBaseNetworkController(fakeOvn, nad)
+				Expect(bnc.WatchNamespaces()).To(Succeed())
+
+				ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(ns).NotTo(BeNil())
+
+				// Enable multicast in the namespace for a few groups, some of them restricted to the feed pods.
+				ns.Annotations[util.NsMulticastAllowedGroupsAnnotation] = `[
+					{"groups": ["239.1.1.0/24", "ff3e::1234/128"]},
+					{"podSelector": {"matchLabels": {"app": "feed"}}, "groups": ["232.1.2.3/32", "ff3e::8000:1/128"]}
+				]`
+				updateMulticast(fakeOvn, ns, true)
+
+				nsAddrSetV4, nsAddrSetV6 := getNsAddrSetHashNames(bnc.controllerName, namespace1.Name)
+				feedAddrSetKey := getPodSelectorKey(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "feed"}}, nil, namespace1.Name)
+				feedAddrSetV4, feedAddrSetV6 := addressset.GetHashNamesForAS(getPodSelectorAddrSetDbIDs(feedAddrSetKey, bnc.controllerName))
+				egressMatchV4 := "(igmp || (ip4.mcast && ((ip4.src == $" + nsAddrSetV4 + " && ip4.dst == {239.1.1.0/24}) || " +
+					"(ip4.src == $" + feedAddrSetV4 + " && ip4.dst == {232.1.2.3/32}))))"
+				egressMatchV6 := "(mldv1 || mldv2 || (" + ipv6DynamicMulticastMatch + " && ((ip6.src == $" + nsAddrSetV6 + " && ip6.dst == {ff3e::1234/128}) || " +
+					"(ip6.src == $" + feedAddrSetV6 + " && ip6.dst == {ff3e::8000:1/128}))))"
+				ingressMatchV4 := "(igmp || (ip4.src == $" + nsAddrSetV4 + " && ip4.mcast && ip4.dst == {232.1.2.3/32, 239.1.1.0/24}))"
+				ingressMatchV6 := "(mldv1 || mldv2 || (ip6.src == $" + nsAddrSetV6 + " && " + ipv6DynamicMulticastMatch +
+					" && ip6.dst == {ff3e::1234/128, ff3e::8000:1/128}))"
+				expectedData := getMulticastPolicyExpectedDataWithMatches(netInfo, namespace1.Name, nil,
+					getACLMatchAF(egressMatchV4, egressMatchV6, useIPv4, useIPv6),
+					getACLMatchAF(ingressMatchV4, ingressMatchV6, useIPv4, useIPv6))
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+				_, found := bnc.podSelectorAddressSets.Load(feedAddrSetKey)
+				Expect(found).To(BeTrue())
+
+				// Allow all groups in the namespace, the feed pods address set is released.
+				delete(ns.Annotations, util.NsMulticastAllowedGroupsAnnotation)
+				updateMulticast(fakeOvn, ns, true)
+				expectedData = getMulticastPolicyExpectedData(netInfo, namespace1.Name, nil)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+				Eventually(func() bool {
+					_, found := bnc.podSelectorAddressSets.Load(feedAddrSetKey)
+					return found
+				}).Should(BeFalse())
+
+				// An invalid annotation doesn't allow any group.
+				ns.Annotations[util.NsMulticastAllowedGroupsAnnotation] = `[{"groups": ["10.0.0.0/8"]}]`
+				updateMulticast(fakeOvn, ns, true)
+				expectedData = getMulticastPolicyExpectedDataWithMatches(netInfo, namespace1.Name, nil,
+					getACLMatchAF("igmp", "(mldv1 || mldv2)", useIPv4, useIPv6),
+					getACLMatchAF("igmp", "(mldv1 || mldv2)", useIPv4, useIPv6))
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		},
+			Entry("IPv4", true, false, nil),
+			Entry("IPv6", false, true, nil),
+			Entry("[Network Segmentation] IPv4", true, false, nadFromIPMode(namespaceName1, true, false)),
+			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
+		)
+
 		DescribeTable("tests enabling multicast in a namespace with a pod", func(useIPv4, useIPv6 bool, nad *nadapi.NetworkAttachmentDefinition) {
 			app.Action = func(*cli.Context) error {
 				config.IPv4Mode = useIPv4
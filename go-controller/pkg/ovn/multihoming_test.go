@@ -16,6 +16,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -344,6 +345,9 @@ func newExpectedSwitchToRouterPort(lspUUID string, portName string, pod testPod,
 	lrp.Options = map[string]string{
 		libovsdbops.RouterPort: "rtos-isolatednet_test-node",
 	}
+	if netInfo.IsPrimaryNetwork() {
+		lrp.Options["arp_proxy"] = kubevirt.ComposeARPProxyLSPOption(netInfo.Subnets())
+	}
 	lrp.PortSecurity = nil
 	lrp.Type = "router"
 	return lrp
@@ -287,6 +287,10 @@ func (oc *DefaultNetworkController) updateNamespace(old, newer *corev1.Namespace
 		if err := oc.updateNamespaceAclLogging(old.Name, aclAnnotation, nsInfo); err != nil {
 			errors = append(errors, err)
 		}
+	}
+	efACLAnnotation := newer.Annotations[util.EgressFirewallAclLoggingAnnotation]
+	oldEFACLAnnotation := old.Annotations[util.EgressFirewallAclLoggingAnnotation]
+	if aclAnnotation != oldACLAnnotation || efACLAnnotation != oldEFACLAnnotation {
 		if oc.efController != nil {
 			// Trigger an egress fw logging update - this will only happen if an egress firewall exists for the NS, otherwise
 			// this will not do anything.
@@ -300,8 +304,7 @@ func (oc *DefaultNetworkController) updateNamespace(old, newer *corev1.Namespace
 					klog.Errorf("Failed to get key for EgressFirewall %s/%s, will not update ACL logging: %v", old.Name, fwKey, err)
 					continue
 				}
-				klog.Infof("Namespace %s: EgressFirewall ACL logging setting updating to deny=%s allow=%s",
-					old.Name, nsInfo.aclLogging.Deny, nsInfo.aclLogging.Allow)
+				klog.Infof("Namespace %s: EgressFirewall ACL logging setting updating", old.Name)
 				oc.efController.Reconcile(fwKey)
 			}
 		}
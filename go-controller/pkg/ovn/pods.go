@@ -161,7 +161,7 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 			}
 		}
 	}
-	if err := kubevirt.SyncVirtualMachines(oc.nbClient, vms); err != nil {
+	if err := kubevirt.SyncVirtualMachines(oc.nbClient, oc.GetNetInfo(), vms); err != nil {
 		return fmt.Errorf("failed syncing running virtual machines: %v", err)
 	}
 
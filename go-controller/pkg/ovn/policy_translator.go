@@ -0,0 +1,163 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// PolicyTranslator runs the default network controller's NetworkPolicy, AdminNetworkPolicy and
+// BaselineAdminNetworkPolicy translation against a static snapshot of the cluster, without any
+// ovnkube-node, IPAM or OVN daemons. Pod logical switch ports are created straight from the pod IPs
+// found in the snapshot, so that the resulting port groups, address sets and ACLs in the northbound
+// database are the ones ovnkube-controller would program for the same objects.
+type PolicyTranslator struct {
+	oc *DefaultNetworkController
+}
+
+// NewPolicyTranslator returns a PolicyTranslator for the objects served by the given watch factory.
+// The northbound database must already contain its NB_Global row.
+func NewPolicyTranslator(ovnClient *util.OVNMasterClientset, wf *factory.WatchFactory,
+	nbClient, sbClient libovsdbclient.Client, recorder record.EventRecorder) (*PolicyTranslator, error) {
+	podRecorder := metrics.NewPodRecorder()
+	cnci, err := NewCommonNetworkControllerInfo(
+		ovnClient.KubeClient,
+		&kube.KubeOVN{
+			Kube:      kube.Kube{KClient: ovnClient.KubeClient},
+			ANPClient: ovnClient.ANPClient,
+		},
+		wf,
+		recorder,
+		nbClient,
+		sbClient,
+		&podRecorder,
+		false,
+		false,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	stopChan := make(chan struct{})
+	oc, err := newDefaultNetworkControllerCommon(cnci, stopChan, &sync.WaitGroup{}, nil,
+		networkmanager.Default().Interface(), nil, nil, nil, NewPortCache(stopChan))
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyTranslator{oc: oc}, nil
+}
+
+// Translate programs the northbound database for every node, pod, namespace and policy in the
+// snapshot. Policies that could not be translated are reported in the returned error.
+func (t *PolicyTranslator) Translate() error {
+	oc := t.oc
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if err := oc.setupClusterPortGroups(); err != nil {
+		return err
+	}
+	clusterPortGroupName := oc.getClusterPortGroupName(types.ClusterPortGroupNameBase)
+	for _, node := range nodes {
+		oc.localZoneNodes.Store(node.Name, true)
+		// the management port binds the cluster port group, and with it the ACLs shared by
+		// all the pods, to the node switch
+		mgmtPort := &nbdb.LogicalSwitchPort{Name: oc.GetNetworkScopedK8sMgmtIntfName(node.Name)}
+		sw := &nbdb.LogicalSwitch{Name: node.Name}
+		if err := libovsdbops.CreateOrUpdateLogicalSwitchPortsAndSwitch(oc.nbClient, sw, mgmtPort); err != nil {
+			return fmt.Errorf("failed to create logical switch for node %s: %w", node.Name, err)
+		}
+		if err := libovsdbops.AddPortsToPortGroup(oc.nbClient, clusterPortGroupName, mgmtPort.UUID); err != nil {
+			return fmt.Errorf("failed to add management port of node %s to the cluster port group: %w", node.Name, err)
+		}
+	}
+
+	pods, err := oc.watchFactory.GetAllPods()
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods {
+		if !util.PodScheduled(pod) || !oc.podExpectedInLogicalCache(pod) {
+			continue
+		}
+		ips, err := util.GetPodCIDRsWithFullMask(pod, oc.GetNetInfo())
+		if err != nil || len(ips) == 0 {
+			klog.Warningf("Skipping pod %s/%s without IPs: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		var mac net.HardwareAddr
+		if podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, types.DefaultNetworkName); err == nil {
+			mac = podAnnotation.MAC
+		} else {
+			mac = util.IPAddrToHWAddr(ips[0].IP)
+		}
+		addresses := []string{mac.String()}
+		for _, ip := range ips {
+			addresses = append(addresses, ip.IP.String())
+		}
+		lsp := &nbdb.LogicalSwitchPort{
+			Name:        oc.GetLogicalPortName(pod, types.DefaultNetworkName),
+			Addresses:   []string{strings.Join(addresses, " ")},
+			ExternalIDs: map[string]string{"namespace": pod.Namespace, "pod": "true"},
+		}
+		sw := &nbdb.LogicalSwitch{Name: pod.Spec.NodeName}
+		if err := libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, sw, lsp); err != nil {
+			return fmt.Errorf("failed to create logical switch port for pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		oc.logicalPortCache.add(pod, sw.Name, types.DefaultNetworkName, lsp.UUID, mac, ips)
+	}
+
+	if err := oc.WatchNamespaces(); err != nil {
+		return err
+	}
+	if err := oc.WatchNetworkPolicy(); err != nil {
+		return err
+	}
+	policies, err := oc.watchFactory.GetAllNetworkPolicies()
+	if err != nil {
+		return fmt.Errorf("failed to list network policies: %w", err)
+	}
+	var untranslated []string
+	for _, policy := range policies {
+		if _, ok := oc.networkPolicies.Load(getPolicyKey(policy)); !ok {
+			untranslated = append(untranslated, getPolicyKey(policy))
+		}
+	}
+	if len(untranslated) > 0 {
+		return fmt.Errorf("failed to translate network policies %s", strings.Join(untranslated, ", "))
+	}
+
+	if config.OVNKubernetesFeature.EnableAdminNetworkPolicy {
+		if err := oc.newANPController(); err != nil {
+			return fmt.Errorf("unable to create admin network policy controller: %w", err)
+		}
+		if err := oc.anpController.SyncOnce(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the handlers started by Translate.
+func (t *PolicyTranslator) Stop() {
+	close(t.oc.stopChan)
+	t.oc.cancelableCtx.Cancel()
+	t.oc.wg.Wait()
+}
@@ -0,0 +1,506 @@
+package policysim
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// packet is the flow being evaluated against an ACL match. Unset fields (empty port names, nil
+// IPs, empty protocol) make every relation on them false, the same way OVN's prerequisites do.
+type packet struct {
+	inport  string
+	outport string
+	src     net.IP
+	dst     net.IP
+	// protocol is the lower case OVN name of the L4 protocol: tcp, udp or sctp
+	protocol string
+	srcPort  int
+	dstPort  int
+}
+
+func (p *packet) isIPv4() bool {
+	return p.src != nil && p.src.To4() != nil
+}
+
+func (p *packet) isIPv6() bool {
+	return p.src != nil && p.src.To4() == nil
+}
+
+// resolver looks up the named address sets and port groups referenced by a match.
+type resolver interface {
+	addressSet(name string) ([]string, error)
+	portGroupPorts(name string) ([]string, error)
+}
+
+// expr is a parsed OVN logical flow match expression.
+type expr interface {
+	eval(p *packet, r resolver) (bool, error)
+}
+
+type orExpr struct{ left, right expr }
+type andExpr struct{ left, right expr }
+type notExpr struct{ e expr }
+type boolField struct{ name string }
+
+// relation compares a field against a set of values. A range such as 80<=tcp.dst<=90 is parsed
+// into two relations joined by &&.
+type relation struct {
+	field  string
+	op     string
+	values []value
+}
+
+type valueKind int
+
+const (
+	valueConst valueKind = iota
+	valueAddressSet
+	valuePortGroup
+)
+
+type value struct {
+	kind valueKind
+	text string
+}
+
+func (e *orExpr) eval(p *packet, r resolver) (bool, error) {
+	l, err := e.left.eval(p, r)
+	if err != nil || l {
+		return l, err
+	}
+	return e.right.eval(p, r)
+}
+
+func (e *andExpr) eval(p *packet, r resolver) (bool, error) {
+	l, err := e.left.eval(p, r)
+	if err != nil || !l {
+		return l, err
+	}
+	return e.right.eval(p, r)
+}
+
+func (e *notExpr) eval(p *packet, r resolver) (bool, error) {
+	v, err := e.e.eval(p, r)
+	return !v, err
+}
+
+func (e *boolField) eval(p *packet, _ resolver) (bool, error) {
+	switch e.name {
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	case "ip":
+		return p.src != nil, nil
+	case "ip4":
+		return p.isIPv4(), nil
+	case "ip6":
+		return p.isIPv6(), nil
+	case "tcp", "udp", "sctp":
+		return p.protocol == e.name, nil
+	case "ip4.mcast":
+		return p.isIPv4() && p.dst.IsMulticast(), nil
+	case "ip6.mcast":
+		return p.isIPv6() && p.dst.IsMulticast(), nil
+	case "icmp", "icmp4", "icmp6", "arp", "nd", "nd_ns", "nd_na", "nd_rs", "nd_ra", "igmp", "mldv1", "mldv2", "eth.mcast":
+		// only unicast TCP, UDP and SCTP flows are simulated
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported field %q", e.name)
+}
+
+func (e *relation) eval(p *packet, r resolver) (bool, error) {
+	switch e.field {
+	case "inport", "outport":
+		port := p.inport
+		if e.field == "outport" {
+			port = p.outport
+		}
+		if port == "" {
+			return false, nil
+		}
+		return e.compare(r, func(v value) ([]bool, error) {
+			if v.kind == valuePortGroup {
+				ports, err := r.portGroupPorts(v.text)
+				if err != nil {
+					return nil, err
+				}
+				for _, name := range ports {
+					if name == port {
+						return []bool{true}, nil
+					}
+				}
+				return []bool{false}, nil
+			}
+			return []bool{v.text == port}, nil
+		})
+	case "ip4.src", "ip4.dst", "ip6.src", "ip6.dst":
+		if (strings.HasPrefix(e.field, "ip4") && !p.isIPv4()) || (strings.HasPrefix(e.field, "ip6") && !p.isIPv6()) {
+			return false, nil
+		}
+		ip := p.src
+		if strings.HasSuffix(e.field, ".dst") {
+			ip = p.dst
+		}
+		return e.compare(r, func(v value) ([]bool, error) {
+			addresses := []string{v.text}
+			if v.kind == valueAddressSet {
+				var err error
+				if addresses, err = r.addressSet(v.text); err != nil {
+					return nil, err
+				}
+			} else if v.kind == valuePortGroup {
+				return nil, fmt.Errorf("port group %s compared to %s", v.text, e.field)
+			}
+			results := make([]bool, 0, len(addresses))
+			for _, address := range addresses {
+				matches, err := ipMatches(ip, address)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, matches)
+			}
+			return results, nil
+		})
+	case "tcp.src", "tcp.dst", "udp.src", "udp.dst", "sctp.src", "sctp.dst":
+		proto, dir, _ := strings.Cut(e.field, ".")
+		if p.protocol != proto {
+			return false, nil
+		}
+		port := p.srcPort
+		if dir == "dst" {
+			port = p.dstPort
+		}
+		if e.op != "==" && e.op != "!=" {
+			if len(e.values) != 1 || e.values[0].kind != valueConst {
+				return false, fmt.Errorf("%s %s requires a single integer", e.field, e.op)
+			}
+			bound, err := strconv.Atoi(e.values[0].text)
+			if err != nil {
+				return false, fmt.Errorf("invalid integer %q: %w", e.values[0].text, err)
+			}
+			switch e.op {
+			case "<":
+				return port < bound, nil
+			case "<=":
+				return port <= bound, nil
+			case ">":
+				return port > bound, nil
+			default:
+				return port >= bound, nil
+			}
+		}
+		return e.compare(r, func(v value) ([]bool, error) {
+			if v.kind != valueConst {
+				return nil, fmt.Errorf("%s compared to a set reference", e.field)
+			}
+			n, err := strconv.Atoi(v.text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer %q: %w", v.text, err)
+			}
+			return []bool{port == n}, nil
+		})
+	}
+	return false, fmt.Errorf("unsupported field %q", e.field)
+}
+
+// compare implements OVN set semantics: "field == {a, b}" is true when the field equals any
+// element of the set and "field != {a, b}" when it equals none of them.
+func (e *relation) compare(r resolver, equal func(value) ([]bool, error)) (bool, error) {
+	if e.op != "==" && e.op != "!=" {
+		return false, fmt.Errorf("operator %s not supported for %s", e.op, e.field)
+	}
+	for _, v := range e.values {
+		results, err := equal(v)
+		if err != nil {
+			return false, err
+		}
+		for _, matches := range results {
+			if matches {
+				return e.op == "==", nil
+			}
+		}
+	}
+	return e.op == "!=", nil
+}
+
+func ipMatches(ip net.IP, address string) (bool, error) {
+	if strings.Contains(address, "/") {
+		_, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return false, fmt.Errorf("invalid CIDR %q: %w", address, err)
+		}
+		return ipNet.Contains(ip), nil
+	}
+	other := net.ParseIP(address)
+	if other == nil {
+		return false, fmt.Errorf("invalid IP %q", address)
+	}
+	return other.Equal(ip), nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"),
+			strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="),
+			strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="):
+			tokens = append(tokens, token{kind: tokenOp, text: s[i : i+2]})
+			i += 2
+		case strings.IndexByte("()!{},<>", c) >= 0:
+			tokens = append(tokens, token{kind: tokenOp, text: s[i : i+1]})
+			i++
+		case c == '$' || c == '@' || isWordChar(rune(c)):
+			start := i
+			i++
+			for i < len(s) && isWordChar(rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func isWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.' || c == ':' || c == '/' || c == '-'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseMatch parses the subset of the OVN match language that ovn-kubernetes generates for
+// network policy ACLs.
+func parseMatch(match string) (expr, error) {
+	tokens, err := tokenize(match)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q after expression", p.peek().text)
+	}
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(text string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && t.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if !p.isOp(text) {
+		return fmt.Errorf("expected %q, got %q", text, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.isOp("!") {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{e: e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func isRelationalOp(t token) bool {
+	if t.kind != tokenOp {
+		return false
+	}
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	if p.isOp("(") {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+	if !isRelationalOp(p.peek()) {
+		return &boolField{name: t.text}, nil
+	}
+	op := p.next().text
+	if isConstantWord(t.text) {
+		// range form: <constant> <op> <field> <op> <constant>
+		field := p.next()
+		if field.kind != tokenWord || !isRelationalOp(p.peek()) {
+			return nil, fmt.Errorf("invalid range expression starting at %q", t.text)
+		}
+		op2 := p.next().text
+		bound, err := p.parseValues()
+		if err != nil {
+			return nil, err
+		}
+		return &andExpr{
+			left:  &relation{field: field.text, op: flipOp(op), values: []value{{kind: valueConst, text: t.text}}},
+			right: &relation{field: field.text, op: op2, values: bound},
+		}, nil
+	}
+	values, err := p.parseValues()
+	if err != nil {
+		return nil, err
+	}
+	return &relation{field: t.text, op: op, values: values}, nil
+}
+
+// flipOp turns "c <= field" into "field >= c".
+func flipOp(op string) string {
+	switch op {
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	}
+	return op
+}
+
+func isConstantWord(s string) bool {
+	if _, err := strconv.Atoi(s); err == nil {
+		return true
+	}
+	return net.ParseIP(s) != nil
+}
+
+func (p *parser) parseValues() ([]value, error) {
+	if !p.isOp("{") {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return []value{v}, nil
+	}
+	p.next()
+	var values []value
+	for !p.isOp("}") {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		if p.isOp(",") {
+			p.next()
+		} else if !p.isOp("}") {
+			return nil, fmt.Errorf("expected \",\" or \"}\", got %q", p.peek().text)
+		}
+	}
+	p.next()
+	return values, nil
+}
+
+func (p *parser) parseValue() (value, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return value{kind: valueConst, text: t.text}, nil
+	case t.kind == tokenWord && strings.HasPrefix(t.text, "$"):
+		return value{kind: valueAddressSet, text: t.text[1:]}, nil
+	case t.kind == tokenWord && strings.HasPrefix(t.text, "@"):
+		return value{kind: valuePortGroup, text: t.text[1:]}, nil
+	case t.kind == tokenWord:
+		return value{kind: valueConst, text: t.text}, nil
+	}
+	return value{}, fmt.Errorf("expected a value, got %q", t.text)
+}
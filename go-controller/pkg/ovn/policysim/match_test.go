@@ -0,0 +1,93 @@
+package policysim
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+type fakeResolver struct {
+	addressSets map[string][]string
+	portGroups  map[string][]string
+}
+
+func (r *fakeResolver) addressSet(name string) ([]string, error) {
+	addresses, ok := r.addressSets[name]
+	if !ok {
+		return nil, fmt.Errorf("address set %s not found", name)
+	}
+	return addresses, nil
+}
+
+func (r *fakeResolver) portGroupPorts(name string) ([]string, error) {
+	return r.portGroups[name], nil
+}
+
+func TestMatch(t *testing.T) {
+	r := &fakeResolver{
+		addressSets: map[string][]string{
+			"a1": {"10.128.1.10", "10.128.1.11"},
+			"a2": {},
+			"a6": {"fd00::10"},
+		},
+		portGroups: map[string][]string{
+			"pg": {"ns_client"},
+		},
+	}
+	tcpPacket := &packet{
+		inport:   "ns_client",
+		src:      net.ParseIP("10.128.1.10"),
+		dst:      net.ParseIP("10.128.2.10"),
+		protocol: "tcp",
+		srcPort:  40000,
+		dstPort:  8080,
+	}
+	tests := []struct {
+		match   string
+		p       *packet
+		matches bool
+		err     bool
+	}{
+		{match: "inport == @pg", p: tcpPacket, matches: true},
+		{match: "outport == @pg", p: tcpPacket, matches: false},
+		{match: `inport == "ns_client" && ip`, p: tcpPacket, matches: true},
+		{match: "ip4.src == {$a1, $a2}", p: tcpPacket, matches: true},
+		{match: "(ip4.dst == $a1 || ip6.dst == $a6)", p: tcpPacket, matches: false},
+		{match: "ip6.src == $a6", p: tcpPacket, matches: false},
+		{match: "ip6.src != $a6", p: tcpPacket, matches: false},
+		{match: "ip4.dst == 10.128.0.0/14 && ip4.dst != {10.128.2.0/24}", p: tcpPacket, matches: false},
+		{match: "ip4.dst == 10.128.0.0/14 && ip4.dst != {10.128.3.0/24, 10.128.4.0/24}", p: tcpPacket, matches: true},
+		{match: "tcp && tcp.dst==8080", p: tcpPacket, matches: true},
+		{match: "udp && udp.dst==8080", p: tcpPacket, matches: false},
+		{match: "tcp && tcp.dst=={80,8080}", p: tcpPacket, matches: true},
+		{match: "tcp && (tcp.dst==80 || 8000<=tcp.dst<=8100)", p: tcpPacket, matches: true},
+		{match: "tcp && 8081<=tcp.dst<=8100", p: tcpPacket, matches: false},
+		{match: "inport == @pg && (arp || nd)", p: tcpPacket, matches: false},
+		{match: "!(ip4.src == 10.128.1.10)", p: tcpPacket, matches: false},
+		{match: "(ip4.src == 10.128.1.10 && tcp.dst == 8080)", p: tcpPacket, matches: true},
+		{match: "ip4.src == $missing", p: tcpPacket, err: true},
+		{match: "eth.src == 0a:58:0a:80:01:0a", p: tcpPacket, err: true},
+		{match: "ip4.src == ", p: tcpPacket, err: true},
+		{match: "(ip4.src == 10.128.1.10", p: tcpPacket, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.match, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			e, err := parseMatch(tt.match)
+			if err == nil {
+				var matches bool
+				matches, err = e.eval(tt.p, r)
+				if !tt.err {
+					g.Expect(matches).To(gomega.Equal(tt.matches))
+				}
+			}
+			if tt.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,608 @@
+package policysim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+	anpfake "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/fake"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	adminpolicybasedroutefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
+	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
+	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	egressqosfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/fake"
+	egressservicefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// defaultNodeName is the node unscheduled pods of a snapshot are placed on
+	defaultNodeName = "policysim-node"
+
+	stageEgress  = "egress"
+	stageIngress = "ingress"
+
+	aclDirectionFromLport = "from-lport"
+	aclDirectionToLport   = "to-lport"
+	aclApplyAfterLB       = "apply-after-lb"
+)
+
+// Options tunes a Simulator.
+type Options struct {
+	// LogLevel is the klog verbosity used while the snapshot is translated.
+	LogLevel int
+}
+
+// Flow is the connection whose fate is simulated.
+type Flow struct {
+	// Source and Destination are either a pod as "namespace/name" or an IP address.
+	Source      string
+	Destination string
+	// Protocol is tcp, udp or sctp; it defaults to tcp.
+	Protocol        string
+	SourcePort      int
+	DestinationPort int
+	// IPFamily is 4 or 6. When unset, IPv4 is used if both endpoints have an IPv4 address.
+	IPFamily int
+}
+
+// Endpoint is one end of a simulated flow.
+type Endpoint struct {
+	Pod         string `json:"pod,omitempty"`
+	IP          string `json:"ip"`
+	LogicalPort string `json:"logicalPort,omitempty"`
+}
+
+// ACL is an ACL of the northbound database that took part in a decision.
+type ACL struct {
+	Name      string `json:"name,omitempty"`
+	Action    string `json:"action"`
+	Direction string `json:"direction"`
+	Tier      int    `json:"tier"`
+	Priority  int    `json:"priority"`
+	Match     string `json:"match"`
+	OwnerType string `json:"ownerType,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+}
+
+// StageVerdict is the outcome of one ACL pipeline stage the flow goes through.
+type StageVerdict struct {
+	// Stage is egress (on the source pod's port) or ingress (on the destination pod's port)
+	Stage       string `json:"stage"`
+	AfterLB     bool   `json:"afterLoadBalancing"`
+	LogicalPort string `json:"logicalPort"`
+	Allowed     bool   `json:"allowed"`
+	// ACL is the ACL that decided the stage, nil when no ACL matched and the flow was allowed by default
+	ACL *ACL `json:"acl,omitempty"`
+	// Passed are the pass ACLs that matched in lower tiers before the decision was taken
+	Passed []*ACL `json:"passed,omitempty"`
+}
+
+// Verdict is the result of a simulation.
+type Verdict struct {
+	Protocol        string          `json:"protocol"`
+	Source          Endpoint        `json:"source"`
+	SourcePort      int             `json:"sourcePort,omitempty"`
+	Destination     Endpoint        `json:"destination"`
+	DestinationPort int             `json:"destinationPort,omitempty"`
+	Allowed         bool            `json:"allowed"`
+	Stages          []*StageVerdict `json:"stages"`
+	// Warnings lists the ACLs that could not be evaluated and were considered as not matching
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Deciding returns the stage that decided the verdict: the first stage that denied the flow, or the
+// last stage in which an ACL allowed it. It returns nil when no ACL matched at all.
+func (v *Verdict) Deciding() *StageVerdict {
+	var deciding *StageVerdict
+	for _, stage := range v.Stages {
+		if !stage.Allowed {
+			return stage
+		}
+		if stage.ACL != nil {
+			deciding = stage
+		}
+	}
+	return deciding
+}
+
+// TierName returns the kind of policy the ACLs of a tier are generated for.
+func TierName(tier int) string {
+	switch tier {
+	case types.PrimaryACLTier:
+		return "Primary"
+	case types.DefaultANPACLTier:
+		return "AdminNetworkPolicy"
+	case types.DefaultACLTier:
+		return "NetworkPolicy"
+	case types.DefaultBANPACLTier:
+		return "BaselineAdminNetworkPolicy"
+	}
+	return "Unknown"
+}
+
+type aclEntry struct {
+	acl     *ACL
+	afterLB bool
+	match   expr
+	err     error
+}
+
+type logicalPort struct {
+	name     string
+	switchID string
+	pod      string
+	ips      []net.IP
+}
+
+// Simulator evaluates flows against the northbound ACLs ovnkube-controller programs for a snapshot
+// of NetworkPolicies, AdminNetworkPolicies and BaselineAdminNetworkPolicies.
+type Simulator struct {
+	podPorts    map[string]*logicalPort
+	ipPorts     map[string]*logicalPort
+	switchACLs  map[string][]*aclEntry
+	addressSets map[string][]string
+	portGroups  map[string][]string
+}
+
+// New translates the snapshot with the ovnkube-controller policy code into an in-memory northbound
+// database and loads the resulting ACLs. It overwrites the global configuration.
+func New(snapshot *Snapshot, opts Options) (*Simulator, error) {
+	if err := prepareConfig(opts.LogLevel); err != nil {
+		return nil, err
+	}
+	objects, anpObjects, err := completeSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	ovnClient := &util.OVNMasterClientset{
+		KubeClient:             fake.NewSimpleClientset(objects...),
+		ANPClient:              anpfake.NewSimpleClientset(anpObjects...),
+		EgressIPClient:         egressipfake.NewSimpleClientset(),
+		EgressFirewallClient:   egressfirewallfake.NewSimpleClientset(),
+		EgressQoSClient:        egressqosfake.NewSimpleClientset(),
+		EgressServiceClient:    egressservicefake.NewSimpleClientset(),
+		AdminPolicyRouteClient: adminpolicybasedroutefake.NewSimpleClientset(),
+	}
+	wf, err := factory.NewMasterWatchFactory(ovnClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watch factory: %w", err)
+	}
+	defer wf.Shutdown()
+	if err := wf.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start watch factory: %w", err)
+	}
+
+	nbClient, sbClient, dbCleanup, err := libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
+		NBData: []libovsdbtest.TestData{&nbdb.NBGlobal{Name: types.OvnDefaultZone}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start in-memory OVN databases: %w", err)
+	}
+	defer dbCleanup.Cleanup()
+
+	translator, err := ovn.NewPolicyTranslator(ovnClient, wf, nbClient, sbClient, &record.FakeRecorder{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy translator: %w", err)
+	}
+	defer translator.Stop()
+	if err := translator.Translate(); err != nil {
+		return nil, err
+	}
+	return load(nbClient)
+}
+
+// completeSnapshot fills in what a hand written snapshot usually lacks and the controllers rely
+// on: namespaces and nodes referenced by pods, the namespace name label set by the API server,
+// scheduling and pod IPs. The IP families of the configuration are set from the pod IPs.
+func completeSnapshot(snapshot *Snapshot) (objects, anpObjects []runtime.Object, err error) {
+	namespaces := map[string]bool{}
+	addNamespace := func(namespace *corev1.Namespace) {
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		namespace.Labels[corev1.LabelMetadataName] = namespace.Name
+		namespaces[namespace.Name] = true
+		objects = append(objects, namespace)
+	}
+	for _, namespace := range snapshot.Namespaces {
+		addNamespace(namespace.DeepCopy())
+	}
+	nodes := map[string]bool{}
+	for _, node := range snapshot.Nodes {
+		nodes[node.Name] = true
+		objects = append(objects, node.DeepCopy())
+	}
+
+	usedIPs := sets.New[string]()
+	for _, pod := range snapshot.Pods {
+		ips, _ := util.DefaultNetworkPodIPs(pod)
+		for _, ip := range ips {
+			usedIPs.Insert(ip.String())
+		}
+	}
+	clusterSubnet := config.Default.ClusterSubnets[0].CIDR
+	offset := int64(0)
+
+	var ipv4, ipv6 bool
+	for _, pod := range snapshot.Pods {
+		pod = pod.DeepCopy()
+		if !namespaces[pod.Namespace] {
+			addNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: pod.Namespace}})
+		}
+		if pod.Spec.NodeName == "" {
+			pod.Spec.NodeName = defaultNodeName
+		}
+		if !nodes[pod.Spec.NodeName] {
+			nodes[pod.Spec.NodeName] = true
+			objects = append(objects, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: pod.Spec.NodeName}})
+		}
+		ips, _ := util.DefaultNetworkPodIPs(pod)
+		if len(ips) == 0 && !util.PodWantsHostNetwork(pod) {
+			ip := nextFreeIP(clusterSubnet, &offset, usedIPs)
+			if ip == nil {
+				return nil, nil, fmt.Errorf("no IP left in %s for pod %s/%s", clusterSubnet, pod.Namespace, pod.Name)
+			}
+			pod.Status.PodIP = ip.String()
+			pod.Status.PodIPs = []corev1.PodIP{{IP: ip.String()}}
+			ips = []net.IP{ip}
+		}
+		for _, ip := range ips {
+			if utilnet.IsIPv6(ip) {
+				ipv6 = true
+			} else {
+				ipv4 = true
+			}
+		}
+		objects = append(objects, pod)
+	}
+	config.IPv4Mode = ipv4 || !ipv6
+	config.IPv6Mode = ipv6
+
+	for _, policy := range snapshot.NetworkPolicies {
+		objects = append(objects, policy.DeepCopy())
+	}
+	for _, anp := range snapshot.AdminNetworkPolicies {
+		anpObjects = append(anpObjects, anp.DeepCopy())
+	}
+	for _, banp := range snapshot.BaselineAdminNetworkPolicies {
+		anpObjects = append(anpObjects, banp.DeepCopy())
+	}
+	return objects, anpObjects, nil
+}
+
+// nextFreeIP returns the next host IP of the subnet that is not in use yet.
+func nextFreeIP(subnet *net.IPNet, offset *int64, used sets.Set[string]) net.IP {
+	for {
+		*offset++
+		ip := utilnet.AddIPOffset(utilnet.BigForIP(subnet.IP), int(*offset))
+		if !subnet.Contains(ip) {
+			return nil
+		}
+		if !used.Has(ip.String()) {
+			used.Insert(ip.String())
+			return ip
+		}
+	}
+}
+
+func prepareConfig(logLevel int) error {
+	if err := config.PrepareTestConfig(); err != nil {
+		return err
+	}
+	// PrepareTestConfig raises the verbosity for unit tests, restore the requested one
+	config.Logging.Level = logLevel
+	var level klog.Level
+	if err := level.Set(strconv.Itoa(logLevel)); err != nil {
+		return fmt.Errorf("failed to set klog log level %v", err)
+	}
+	config.OVNKubernetesFeature.EnableAdminNetworkPolicy = true
+	return nil
+}
+
+// load reads the translated ACLs, port groups and address sets from the northbound database.
+func load(nbClient libovsdbclient.Client) (*Simulator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+
+	var switches []nbdb.LogicalSwitch
+	var lsps []nbdb.LogicalSwitchPort
+	var portGroups []nbdb.PortGroup
+	var acls []nbdb.ACL
+	var addressSets []nbdb.AddressSet
+	for _, table := range []interface{}{&switches, &lsps, &portGroups, &acls, &addressSets} {
+		if err := nbClient.List(ctx, table); err != nil {
+			return nil, fmt.Errorf("failed to read northbound database: %w", err)
+		}
+	}
+
+	s := &Simulator{
+		podPorts:    map[string]*logicalPort{},
+		ipPorts:     map[string]*logicalPort{},
+		switchACLs:  map[string][]*aclEntry{},
+		addressSets: map[string][]string{},
+		portGroups:  map[string][]string{},
+	}
+	aclsByUUID := map[string]*aclEntry{}
+	for i := range acls {
+		aclsByUUID[acls[i].UUID] = newACLEntry(&acls[i])
+	}
+	for _, as := range addressSets {
+		s.addressSets[as.Name] = as.Addresses
+	}
+
+	portsByUUID := map[string]*logicalPort{}
+	for _, lsp := range lsps {
+		port := &logicalPort{name: lsp.Name}
+		if len(lsp.Addresses) > 0 {
+			// "<mac> <ip> [<ip>]"
+			for _, address := range strings.Fields(lsp.Addresses[0])[1:] {
+				port.ips = append(port.ips, net.ParseIP(address))
+			}
+		}
+		if lsp.ExternalIDs["pod"] == "true" {
+			port.pod = strings.Replace(lsp.Name, "_", "/", 1)
+			s.podPorts[port.pod] = port
+			for _, ip := range port.ips {
+				s.ipPorts[ip.String()] = port
+			}
+		}
+		portsByUUID[lsp.UUID] = port
+	}
+	for _, sw := range switches {
+		for _, uuid := range sw.Ports {
+			if port, ok := portsByUUID[uuid]; ok {
+				port.switchID = sw.Name
+			}
+		}
+		for _, uuid := range sw.ACLs {
+			if acl, ok := aclsByUUID[uuid]; ok {
+				s.switchACLs[sw.Name] = append(s.switchACLs[sw.Name], acl)
+			}
+		}
+	}
+	// ACLs of a port group are programmed on every switch one of its ports is bound to
+	for _, pg := range portGroups {
+		pgSwitches := map[string]bool{}
+		for _, uuid := range pg.Ports {
+			if port, ok := portsByUUID[uuid]; ok {
+				s.portGroups[pg.Name] = append(s.portGroups[pg.Name], port.name)
+				pgSwitches[port.switchID] = true
+			}
+		}
+		for sw := range pgSwitches {
+			for _, uuid := range pg.ACLs {
+				if acl, ok := aclsByUUID[uuid]; ok {
+					s.switchACLs[sw] = append(s.switchACLs[sw], acl)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func newACLEntry(acl *nbdb.ACL) *aclEntry {
+	entry := &aclEntry{
+		acl: &ACL{
+			Action:    acl.Action,
+			Direction: acl.Direction,
+			Tier:      acl.Tier,
+			Priority:  acl.Priority,
+			Match:     acl.Match,
+			OwnerType: acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()],
+			Owner:     acl.ExternalIDs[libovsdbops.ObjectNameKey.String()],
+		},
+		afterLB: acl.Options[aclApplyAfterLB] == "true",
+	}
+	if acl.Name != nil {
+		entry.acl.Name = *acl.Name
+	}
+	entry.match, entry.err = parseMatch(acl.Match)
+	return entry
+}
+
+func (s *Simulator) addressSet(name string) ([]string, error) {
+	addresses, ok := s.addressSets[name]
+	if !ok {
+		return nil, fmt.Errorf("address set %s not found", name)
+	}
+	return addresses, nil
+}
+
+func (s *Simulator) portGroupPorts(name string) ([]string, error) {
+	// port groups without ports are not an error, they are just empty
+	return s.portGroups[name], nil
+}
+
+// resolve returns the endpoint for a pod reference or an IP address, and the pod's logical switch
+// port if it is backed by one.
+func (s *Simulator) resolve(ref string) (*logicalPort, net.IP, error) {
+	if strings.Contains(ref, "/") {
+		port, ok := s.podPorts[ref]
+		if !ok {
+			return nil, nil, fmt.Errorf("pod %s not found or not attached to the pod network", ref)
+		}
+		return port, nil, nil
+	}
+	ip := net.ParseIP(ref)
+	if ip == nil {
+		return nil, nil, fmt.Errorf("%q is neither a namespace/pod reference nor an IP address", ref)
+	}
+	return s.ipPorts[ip.String()], ip, nil
+}
+
+func pickIP(port *logicalPort, ip net.IP, ipv6 bool) net.IP {
+	if ip != nil {
+		return ip
+	}
+	for _, portIP := range port.ips {
+		if utilnet.IsIPv6(portIP) == ipv6 {
+			return portIP
+		}
+	}
+	return nil
+}
+
+func hasFamily(port *logicalPort, ip net.IP, ipv6 bool) bool {
+	return pickIP(port, ip, ipv6) != nil
+}
+
+// Evaluate simulates the flow through the egress ACLs of its source pod and the ingress ACLs of
+// its destination pod. IP endpoints that do not belong to a pod only go through the ACLs of the
+// other side.
+func (s *Simulator) Evaluate(flow Flow) (*Verdict, error) {
+	protocol := strings.ToLower(flow.Protocol)
+	switch protocol {
+	case "":
+		protocol = "tcp"
+	case "tcp", "udp", "sctp":
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", flow.Protocol)
+	}
+	srcPort, srcIP, err := s.resolve(flow.Source)
+	if err != nil {
+		return nil, err
+	}
+	dstPort, dstIP, err := s.resolve(flow.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipv6 bool
+	switch flow.IPFamily {
+	case 4:
+	case 6:
+		ipv6 = true
+	case 0:
+		ipv6 = !hasFamily(srcPort, srcIP, false) || !hasFamily(dstPort, dstIP, false)
+	default:
+		return nil, fmt.Errorf("invalid IP family %d", flow.IPFamily)
+	}
+	p := &packet{
+		src:      pickIP(srcPort, srcIP, ipv6),
+		dst:      pickIP(dstPort, dstIP, ipv6),
+		protocol: protocol,
+		srcPort:  flow.SourcePort,
+		dstPort:  flow.DestinationPort,
+	}
+	if p.src == nil || p.dst == nil || utilnet.IsIPv6(p.src) != utilnet.IsIPv6(p.dst) {
+		return nil, fmt.Errorf("source %s and destination %s have no address of the same IP family", flow.Source, flow.Destination)
+	}
+
+	verdict := &Verdict{
+		Protocol:        protocol,
+		Source:          endpoint(srcPort, p.src),
+		SourcePort:      flow.SourcePort,
+		Destination:     endpoint(dstPort, p.dst),
+		DestinationPort: flow.DestinationPort,
+		Allowed:         true,
+	}
+	if srcPort != nil {
+		p.inport = srcPort.name
+		for _, afterLB := range []bool{false, true} {
+			verdict.addStage(s.evaluateStage(stageEgress, srcPort, aclDirectionFromLport, afterLB, p, verdict))
+		}
+	}
+	if dstPort != nil {
+		p.outport = dstPort.name
+		if srcPort == nil || srcPort.switchID != dstPort.switchID {
+			// traffic from another switch enters through the cluster router
+			p.inport = types.RouterToSwitchPrefix + dstPort.switchID
+		}
+		verdict.addStage(s.evaluateStage(stageIngress, dstPort, aclDirectionToLport, false, p, verdict))
+	}
+	return verdict, nil
+}
+
+func endpoint(port *logicalPort, ip net.IP) Endpoint {
+	e := Endpoint{IP: ip.String()}
+	if port != nil {
+		e.Pod = port.pod
+		e.LogicalPort = port.name
+	}
+	return e
+}
+
+func (v *Verdict) addStage(stage *StageVerdict) {
+	if stage == nil {
+		return
+	}
+	v.Stages = append(v.Stages, stage)
+	v.Allowed = v.Allowed && stage.Allowed
+}
+
+// evaluateStage runs one ACL stage the way OVN does: tiers are evaluated in increasing order and in
+// each tier the highest priority matching ACL wins. A pass ACL moves the evaluation to the next
+// tier and a flow that does not match any ACL is allowed. Stages without any ACL are omitted.
+func (s *Simulator) evaluateStage(stage string, port *logicalPort, direction string, afterLB bool, p *packet, verdict *Verdict) *StageVerdict {
+	var candidates []*aclEntry
+	for _, entry := range s.switchACLs[port.switchID] {
+		if entry.acl.Direction == direction && entry.afterLB == afterLB {
+			candidates = append(candidates, entry)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].acl.Tier != candidates[j].acl.Tier {
+			return candidates[i].acl.Tier < candidates[j].acl.Tier
+		}
+		return candidates[i].acl.Priority > candidates[j].acl.Priority
+	})
+
+	result := &StageVerdict{Stage: stage, AfterLB: afterLB, LogicalPort: port.name, Allowed: true}
+	passedTier := -1
+	for _, entry := range candidates {
+		if entry.acl.Tier <= passedTier {
+			continue
+		}
+		matches, err := s.matches(entry, p)
+		if err != nil {
+			verdict.Warnings = append(verdict.Warnings, fmt.Sprintf("ignoring ACL %q with match %q: %v", entry.acl.Name, entry.acl.Match, err))
+			continue
+		}
+		if !matches {
+			continue
+		}
+		switch entry.acl.Action {
+		case nbdb.ACLActionPass:
+			result.Passed = append(result.Passed, entry.acl)
+			passedTier = entry.acl.Tier
+			continue
+		case nbdb.ACLActionDrop, nbdb.ACLActionReject:
+			result.Allowed = false
+		}
+		result.ACL = entry.acl
+		return result
+	}
+	return result
+}
+
+func (s *Simulator) matches(entry *aclEntry, p *packet) (bool, error) {
+	if entry.err != nil {
+		return false, entry.err
+	}
+	return entry.match.eval(p, s)
+}
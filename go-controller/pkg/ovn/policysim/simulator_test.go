@@ -0,0 +1,219 @@
+package policysim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+const testSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: client
+    namespace: frontend
+    labels:
+      app: client
+  spec:
+    nodeName: node1
+  status:
+    podIPs:
+    - ip: 10.128.1.10
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: server
+    namespace: backend
+    labels:
+      app: server
+  spec:
+    nodeName: node2
+  status:
+    podIPs:
+    - ip: 10.128.2.10
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: intruder
+  namespace: untrusted
+spec:
+  nodeName: node1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: monitoring
+  namespace: monitoring
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-frontend
+  namespace: backend
+spec:
+  podSelector:
+    matchLabels:
+      app: server
+  policyTypes:
+  - Ingress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: frontend
+    ports:
+    - protocol: TCP
+      port: 8080
+---
+apiVersion: policy.networking.k8s.io/v1alpha1
+kind: AdminNetworkPolicy
+metadata:
+  name: cluster-guardrails
+spec:
+  priority: 10
+  subject:
+    namespaces: {}
+  ingress:
+  - name: deny-untrusted
+    action: Deny
+    from:
+    - namespaces:
+        matchLabels:
+          kubernetes.io/metadata.name: untrusted
+  - name: delegate-monitoring
+    action: Pass
+    from:
+    - namespaces:
+        matchLabels:
+          kubernetes.io/metadata.name: monitoring
+---
+apiVersion: policy.networking.k8s.io/v1alpha1
+kind: BaselineAdminNetworkPolicy
+metadata:
+  name: default
+spec:
+  subject:
+    namespaces: {}
+  ingress:
+  - name: allow-monitoring
+    action: Allow
+    from:
+    - namespaces:
+        matchLabels:
+          kubernetes.io/metadata.name: monitoring
+  egress:
+  - name: deny-external
+    action: Deny
+    to:
+    - networks:
+      - 192.0.2.0/24
+`
+
+func TestSimulator(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	snapshot := &Snapshot{}
+	g.Expect(snapshot.Load(strings.NewReader(testSnapshot))).To(gomega.Succeed())
+	g.Expect(snapshot.Pods).To(gomega.HaveLen(4))
+	g.Expect(snapshot.NetworkPolicies).To(gomega.HaveLen(1))
+	g.Expect(snapshot.AdminNetworkPolicies).To(gomega.HaveLen(1))
+	g.Expect(snapshot.BaselineAdminNetworkPolicies).To(gomega.HaveLen(1))
+
+	sim, err := New(snapshot, Options{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	tests := []struct {
+		name      string
+		flow      Flow
+		allowed   bool
+		tier      int
+		action    string
+		ownerType string
+		owner     string
+	}{
+		{
+			name:      "network policy allows the selected port",
+			flow:      Flow{Source: "frontend/client", Destination: "backend/server", DestinationPort: 8080},
+			allowed:   true,
+			tier:      types.DefaultACLTier,
+			action:    "allow-related",
+			ownerType: "NetworkPolicy",
+			owner:     "backend:allow-frontend",
+		},
+		{
+			name:      "network policy isolates other ports",
+			flow:      Flow{Source: "frontend/client", Destination: "backend/server", DestinationPort: 9090},
+			allowed:   false,
+			tier:      types.DefaultACLTier,
+			action:    "drop",
+			ownerType: "NetpolNamespace",
+			owner:     "backend",
+		},
+		{
+			name:      "admin network policy denies before network policies",
+			flow:      Flow{Source: "untrusted/intruder", Destination: "frontend/client", DestinationPort: 80},
+			allowed:   false,
+			tier:      types.DefaultANPACLTier,
+			action:    "drop",
+			ownerType: "AdminNetworkPolicy",
+			owner:     "cluster-guardrails",
+		},
+		{
+			name:      "pass delegates to the network policy of the namespace",
+			flow:      Flow{Source: "monitoring/monitoring", Destination: "backend/server", DestinationPort: 8080},
+			allowed:   false,
+			tier:      types.DefaultACLTier,
+			action:    "drop",
+			ownerType: "NetpolNamespace",
+			owner:     "backend",
+		},
+		{
+			name:      "pass delegates to the baseline admin network policy",
+			flow:      Flow{Source: "monitoring/monitoring", Destination: "frontend/client", Protocol: "udp", DestinationPort: 53},
+			allowed:   true,
+			tier:      types.DefaultBANPACLTier,
+			action:    "allow-related",
+			ownerType: "BaselineAdminNetworkPolicy",
+			owner:     "default",
+		},
+		{
+			name:      "baseline admin network policy denies egress by pod IP",
+			flow:      Flow{Source: "10.128.1.10", Destination: "192.0.2.1", DestinationPort: 443},
+			allowed:   false,
+			tier:      types.DefaultBANPACLTier,
+			action:    "drop",
+			ownerType: "BaselineAdminNetworkPolicy",
+			owner:     "default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			verdict, err := sim.Evaluate(tt.flow)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(verdict.Warnings).To(gomega.BeEmpty())
+			g.Expect(verdict.Allowed).To(gomega.Equal(tt.allowed))
+			deciding := verdict.Deciding()
+			g.Expect(deciding).NotTo(gomega.BeNil())
+			g.Expect(deciding.ACL.Tier).To(gomega.Equal(tt.tier))
+			g.Expect(deciding.ACL.Action).To(gomega.Equal(tt.action))
+			g.Expect(deciding.ACL.OwnerType).To(gomega.Equal(tt.ownerType))
+			g.Expect(deciding.ACL.Owner).To(gomega.Equal(tt.owner))
+		})
+	}
+
+	verdict, err := sim.Evaluate(Flow{Source: "backend/server", Destination: "frontend/client", DestinationPort: 80})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(verdict.Allowed).To(gomega.BeTrue())
+	g.Expect(verdict.Deciding()).To(gomega.BeNil(), "no ACL is expected to match")
+
+	_, err = sim.Evaluate(Flow{Source: "frontend/missing", Destination: "backend/server"})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,116 @@
+package policysim
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	knet "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+)
+
+// Snapshot holds the cluster objects a simulation is run against.
+type Snapshot struct {
+	Nodes                        []*corev1.Node
+	Namespaces                   []*corev1.Namespace
+	Pods                         []*corev1.Pod
+	NetworkPolicies              []*knet.NetworkPolicy
+	AdminNetworkPolicies         []*anpapi.AdminNetworkPolicy
+	BaselineAdminNetworkPolicies []*anpapi.BaselineAdminNetworkPolicy
+}
+
+// LoadSnapshotFiles reads the given YAML or JSON manifests, e.g. the output of
+// "kubectl get pods,namespaces,networkpolicies -A -o yaml", into a single Snapshot.
+func LoadSnapshotFiles(paths ...string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = snapshot.Load(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// Load adds the objects of a stream of YAML or JSON documents to the snapshot. List kinds are
+// flattened and objects of unrelated kinds are ignored.
+func (s *Snapshot) Load(r io.Reader) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if err := s.add(obj); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Snapshot) add(obj *unstructured.Unstructured) error {
+	if obj.IsList() {
+		return obj.EachListItem(func(item runtime.Object) error {
+			return s.add(item.(*unstructured.Unstructured))
+		})
+	}
+	var err error
+	switch obj.GetKind() {
+	case "Node":
+		node := &corev1.Node{}
+		if err = fromUnstructured(obj, node); err == nil {
+			s.Nodes = append(s.Nodes, node)
+		}
+	case "Namespace":
+		namespace := &corev1.Namespace{}
+		if err = fromUnstructured(obj, namespace); err == nil {
+			s.Namespaces = append(s.Namespaces, namespace)
+		}
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err = fromUnstructured(obj, pod); err == nil {
+			s.Pods = append(s.Pods, pod)
+		}
+	case "NetworkPolicy":
+		policy := &knet.NetworkPolicy{}
+		if err = fromUnstructured(obj, policy); err == nil {
+			s.NetworkPolicies = append(s.NetworkPolicies, policy)
+		}
+	case "AdminNetworkPolicy":
+		anp := &anpapi.AdminNetworkPolicy{}
+		if err = fromUnstructured(obj, anp); err == nil {
+			s.AdminNetworkPolicies = append(s.AdminNetworkPolicies, anp)
+		}
+	case "BaselineAdminNetworkPolicy":
+		banp := &anpapi.BaselineAdminNetworkPolicy{}
+		if err = fromUnstructured(obj, banp); err == nil {
+			s.BaselineAdminNetworkPolicies = append(s.BaselineAdminNetworkPolicies, banp)
+		}
+	default:
+		klog.V(5).Infof("Ignoring %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, into)
+}
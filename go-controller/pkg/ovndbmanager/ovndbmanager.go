@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
@@ -37,7 +38,8 @@ const (
 	sbdbServerSock = "unix:/var/run/ovn/ovnsb_db.sock"
 )
 
-func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
+func RunDBChecker(kclient kube.Interface, recorder record.EventRecorder, recoveryConfig RaftRecoveryConfig,
+	stopCh <-chan struct{}) {
 	defer utilruntime.HandleCrash()
 	klog.Info("Starting DB Checker to ensure cluster membership and DB consistency")
 	wg := &sync.WaitGroup{}
@@ -47,7 +49,7 @@ func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
 		if err := convertNBDBSchema(); err != nil {
 			klog.Fatalf("NBDB conversion failed: %v", err)
 		}
-		ensureOvnDBState(config.OvnNorth.DbLocation, kclient, stopCh)
+		ensureOvnDBState(config.OvnNorth.DbLocation, kclient, recorder, recoveryConfig, stopCh)
 	}()
 
 	wg.Add(1)
@@ -56,7 +58,7 @@ func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
 		if err := convertSBDBSchema(); err != nil {
 			klog.Fatalf("SBDB conversion failed: %v", err)
 		}
-		ensureOvnDBState(config.OvnSouth.DbLocation, kclient, stopCh)
+		ensureOvnDBState(config.OvnSouth.DbLocation, kclient, recorder, recoveryConfig, stopCh)
 	}()
 	<-stopCh
 	klog.Info("Shutting down db checker")
@@ -64,7 +66,8 @@ func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
 	klog.Info("Shut down db checker")
 }
 
-func ensureOvnDBState(db string, kclient kube.Interface, stopCh <-chan struct{}) {
+func ensureOvnDBState(db string, kclient kube.Interface, recorder record.EventRecorder, recoveryConfig RaftRecoveryConfig,
+	stopCh <-chan struct{}) {
 	ticker := time.NewTicker(60 * time.Second)
 	klog.Infof("Starting ensure routine for Raft db: %s", db)
 	_, _, err := util.RunOVSDBTool("db-is-standalone", db)
@@ -89,6 +92,10 @@ func ensureOvnDBState(db string, kclient kube.Interface, stopCh <-chan struct{})
 	}
 
 	var dbRetry int32
+	var recovery *raftRecovery
+	if recoveryConfig.QuorumLossThreshold > 0 {
+		recovery = newRaftRecovery(recoveryConfig, kclient, recorder, dbProperties)
+	}
 
 	for {
 		select {
@@ -102,6 +109,11 @@ func ensureOvnDBState(db string, kclient kube.Interface, stopCh <-chan struct{})
 			} else {
 				dbRetry = 0
 			}
+			if recovery != nil {
+				if err := recovery.ensureRaftQuorum(); err != nil {
+					klog.Error(err)
+				}
+			}
 			if err := ensureClusterRaftMembership(dbProperties, kclient); err != nil {
 				klog.Error(err)
 				if errors.Is(err, errDB) {
@@ -319,13 +331,9 @@ func ensureElectionTimeout(db *util.OvsDbProperties) error {
 // resetRaftDB backs up the db by renaming it and then stops the nb/sb ovsdb process.
 // Returns an error if anything goes wrong.
 func resetRaftDB(db *util.OvsDbProperties) error {
-	dbFile := filepath.Base(db.DbAlias)
-	backupFile := strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) +
-		time.Now().UTC().Format("2006-01-02_150405") + "db_bak"
-	backupDB := filepath.Join(filepath.Dir(db.DbAlias), backupFile)
-	err := os.Rename(db.DbAlias, backupDB)
+	backupFile, err := backupRaftDB(db)
 	if err != nil {
-		return fmt.Errorf("failed to back up the db to backupFile: %s, error: %s", backupDB, err)
+		return err
 	}
 
 	klog.Infof("Backed up the db to backupFile: %s", backupFile)
@@ -339,6 +347,19 @@ func resetRaftDB(db *util.OvsDbProperties) error {
 	return nil
 }
 
+// backupRaftDB backs up the db by renaming it, and returns the name of the backup file
+func backupRaftDB(db *util.OvsDbProperties) (string, error) {
+	dbFile := filepath.Base(db.DbAlias)
+	backupFile := strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) +
+		time.Now().UTC().Format("2006-01-02_150405") + "db_bak"
+	backupDB := filepath.Join(filepath.Dir(db.DbAlias), backupFile)
+	err := os.Rename(db.DbAlias, backupDB)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up the db to backupFile: %s, error: %s", backupDB, err)
+	}
+	return backupFile, nil
+}
+
 func convertNBDBSchema() error {
 	return convertDBSchemaWithRetries(nbdbSchema, nbdbServerSock, "OVN_Northbound")
 }
@@ -0,0 +1,368 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	v1pod "k8s.io/kubernetes/pkg/api/v1/pod"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// RaftRecoveryConfig configures the recovery of the Raft clusters which permanently lost their quorum
+type RaftRecoveryConfig struct {
+	// QuorumLossThreshold is how long the majority of the cluster members must have been unreachable
+	// before the cluster is recovered from a surviving member. The recovery is disabled when zero.
+	QuorumLossThreshold time.Duration
+	// DryRun only reports the recovery steps, without changing the databases
+	DryRun bool
+}
+
+// Reasons of the events recorded on the db pods during a recovery
+const (
+	raftQuorumLostReason        = "RaftQuorumLost"
+	raftClusterRecoveringReason = "RaftClusterRecovering"
+	raftClusterRecoveredReason  = "RaftClusterRecovered"
+	raftMemberRejoiningReason   = "RaftMemberRejoining"
+)
+
+// These variables are meant to be used in unit tests
+var runOVSDBTool = util.RunOVSDBTool
+var timeNow = time.Now
+
+// raftServer is a member of a Raft cluster, as seen by the local member
+type raftServer struct {
+	sid     string
+	address string
+	self    bool
+	// lastMsg is how long ago the local member received a message from the server, if it ever did
+	lastMsg *time.Duration
+}
+
+// raftClusterStatus holds the parts of the cluster/status of the local member used to recover the cluster
+type raftClusterStatus struct {
+	clusterID     string
+	serverID      string
+	address       string
+	leader        string
+	electionTimer time.Duration
+	servers       []raftServer
+}
+
+var (
+	clusterIDRegexp     = regexp.MustCompile(`Cluster ID: *[a-z0-9]+ \(([a-z0-9\-]+)\)`)
+	serverIDRegexp      = regexp.MustCompile(`Server ID: *([a-z0-9]{4})`)
+	addressRegexp       = regexp.MustCompile(`Address: *((ssl|tcp):\[?[a-z0-9\-.:]+\]?)`)
+	leaderRegexp        = regexp.MustCompile(`Leader: *(\S+)`)
+	electionTimerRegexp = regexp.MustCompile(`Election timer: *(\d+)`)
+	raftServerRegexp    = regexp.MustCompile(`([a-z0-9]{4}) \([a-z0-9]{4} at ((ssl|tcp):[^)\s]+)\)( \(self\))?( next_index=\d+ match_index=\d+)?( last msg (\d+) ms ago)?`)
+	// IPv4 example: tcp:172.18.0.2:6641
+	// IPv6 example: tcp:[fc00:f853:ccd:e793::3]:6642
+	raftAddressRegexp = regexp.MustCompile(`(ssl|tcp):\[?([a-z0-9\-.:]+?)\]?:\d+$`)
+)
+
+// getRaftClusterStatus returns the status of the Raft cluster of the db, as seen by the local member
+func getRaftClusterStatus(db *util.OvsDbProperties) (*raftClusterStatus, error) {
+	out, stderr, err := db.AppCtl(5, "cluster/status", db.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to get cluster status for: %s, stderr: %v, err: %v", errDB, db.DbAlias, stderr, err)
+	}
+
+	status := &raftClusterStatus{}
+	for _, field := range []struct {
+		regexp *regexp.Regexp
+		value  *string
+		name   string
+	}{
+		{clusterIDRegexp, &status.clusterID, "Cluster ID"},
+		{serverIDRegexp, &status.serverID, "Server ID"},
+		{addressRegexp, &status.address, "Address"},
+		{leaderRegexp, &status.leader, "Leader"},
+	} {
+		match := field.regexp.FindStringSubmatch(out)
+		if len(match) < 2 {
+			return nil, fmt.Errorf("unable to parse %s for db: %s, output: %s", field.name, db.DbAlias, out)
+		}
+		*field.value = match[1]
+	}
+
+	match := electionTimerRegexp.FindStringSubmatch(out)
+	if len(match) < 2 {
+		return nil, fmt.Errorf("unable to parse Election timer for db: %s, output: %s", db.DbAlias, out)
+	}
+	electionTimer, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert election timer %v for %s", match[1], db.DbAlias)
+	}
+	status.electionTimer = time.Duration(electionTimer) * time.Millisecond
+
+	for _, member := range raftServerRegexp.FindAllStringSubmatch(out, -1) {
+		server := raftServer{
+			sid:     member[1],
+			address: member[2],
+			self:    member[4] != "",
+		}
+		if member[7] != "" {
+			lastMsg, err := strconv.Atoi(member[7])
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert last message time %v of server %s for %s", member[7], member[1], db.DbAlias)
+			}
+			lastMsgDuration := time.Duration(lastMsg) * time.Millisecond
+			server.lastMsg = &lastMsgDuration
+		}
+		status.servers = append(status.servers, server)
+	}
+	if len(status.servers) == 0 {
+		return nil, fmt.Errorf("unable to parse Servers for db: %s, output: %s", db.DbAlias, out)
+	}
+
+	return status, nil
+}
+
+// reachableServers returns the IDs of the cluster members the local member recently received a message from,
+// including the local member, and whether the majority of the members is unreachable
+func (s *raftClusterStatus) reachableServers() ([]string, bool) {
+	var reachable []string
+	for _, server := range s.servers {
+		if server.self || (server.lastMsg != nil && *server.lastMsg <= s.electionTimer) {
+			reachable = append(reachable, server.sid)
+		}
+	}
+	// a leader is only known as long as the cluster has its quorum
+	quorumLost := s.leader == "unknown" && len(reachable) < len(s.servers)/2+1
+	return reachable, quorumLost
+}
+
+// liveUnreachableServer returns the address of a cluster member unreachable from the local member whose db
+// pod is still ready, if any. The local member can't tell a dead member from a network partition: such a
+// member might be part of a majority which still holds the quorum, and recovering the cluster from the
+// local member would then split it in two.
+func (s *raftClusterStatus) liveUnreachableServer(reachable []string, dbPods []*corev1.Pod) string {
+	for _, server := range s.servers {
+		if slices.Contains(reachable, server.sid) {
+			continue
+		}
+		dbPod := getPodWithRaftAddress(dbPods, server.address)
+		if dbPod != nil && !util.PodTerminating(dbPod) && v1pod.IsPodReadyConditionTrue(dbPod.Status) {
+			return server.address
+		}
+	}
+	return ""
+}
+
+// raftRecovery recovers the Raft cluster of a db which permanently lost its quorum: once the majority of
+// its members has been unreachable for longer than the configured threshold, a surviving member is
+// converted into a new single member cluster from its latest snapshot, and the other members reset
+// their db to join it.
+type raftRecovery struct {
+	config          RaftRecoveryConfig
+	kclient         kube.Interface
+	recorder        record.EventRecorder
+	db              *util.OvsDbProperties
+	quorumLostSince time.Time
+}
+
+func newRaftRecovery(config RaftRecoveryConfig, kclient kube.Interface, recorder record.EventRecorder,
+	db *util.OvsDbProperties) *raftRecovery {
+	return &raftRecovery{
+		config:   config,
+		kclient:  kclient,
+		recorder: recorder,
+		db:       db,
+	}
+}
+
+// recoveredClusterAnnotation is the annotation set on the db pod which recovered the Raft cluster of the db,
+// holding the ID of the new cluster
+func recoveredClusterAnnotation(db *util.OvsDbProperties) string {
+	return fmt.Sprintf("k8s.ovn.org/%s-recovered-cluster-id", strings.ToLower(db.DbName))
+}
+
+// ensureRaftQuorum checks if the Raft cluster of the db lost its quorum, and either recovers the cluster
+// from the local member or resets the local member to join a cluster recovered by another member.
+func (r *raftRecovery) ensureRaftQuorum() error {
+	status, err := getRaftClusterStatus(r.db)
+	if err != nil {
+		return err
+	}
+
+	reachable, quorumLost := status.reachableServers()
+	if !quorumLost {
+		if !r.quorumLostSince.IsZero() {
+			klog.Infof("Raft cluster of %s regained its quorum", r.db.DbName)
+			r.quorumLostSince = time.Time{}
+		}
+		return nil
+	}
+
+	dbPods, err := r.kclient.GetPodsForDBChecker(config.Kubernetes.OVNConfigNamespace, metav1.ListOptions{
+		LabelSelector: labels.Set(map[string]string{"ovn-db-pod": "true"}).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get db pod list from kubeclient: %v", err)
+	}
+	localPod := getPodWithRaftAddress(dbPods, status.address)
+
+	// another member recovered the cluster: the local member is part of the previous cluster and
+	// must join the new one
+	annotation := recoveredClusterAnnotation(r.db)
+	for _, dbPod := range dbPods {
+		clusterID := dbPod.Annotations[annotation]
+		if dbPod == localPod || clusterID == "" || clusterID == status.clusterID {
+			continue
+		}
+		return r.rejoinCluster(status, localPod, dbPod, clusterID)
+	}
+
+	if r.quorumLostSince.IsZero() {
+		r.quorumLostSince = timeNow()
+		r.recordEvent(localPod, corev1.EventTypeWarning, raftQuorumLostReason,
+			"Raft cluster %s of %s lost its quorum: only %d of its %d members are reachable",
+			status.clusterID, r.db.DbName, len(reachable), len(status.servers))
+		return nil
+	}
+	if timeNow().Sub(r.quorumLostSince) < r.config.QuorumLossThreshold {
+		return nil
+	}
+
+	// only recover the cluster once the unreachable members are known to be down
+	if address := status.liveUnreachableServer(reachable, dbPods); address != "" {
+		klog.Warningf("Raft cluster of %s lost its quorum but the db pod of the unreachable member %s is still ready, "+
+			"not recovering the cluster", r.db.DbName, address)
+		return nil
+	}
+
+	// only one of the surviving members recovers the cluster, the others join it
+	survivor := reachable[0]
+	for _, sid := range reachable {
+		if sid < survivor {
+			survivor = sid
+		}
+	}
+	if survivor != status.serverID {
+		klog.Infof("Raft cluster of %s lost its quorum, waiting for member %s to recover it", r.db.DbName, survivor)
+		return nil
+	}
+	if localPod == nil {
+		return fmt.Errorf("can't recover Raft cluster of %s: unable to find the db pod with address %s",
+			r.db.DbName, status.address)
+	}
+	return r.recoverCluster(status, localPod)
+}
+
+// recoverCluster converts the local member into a new single member cluster from its latest snapshot
+func (r *raftRecovery) recoverCluster(status *raftClusterStatus, localPod *corev1.Pod) error {
+	r.recordEvent(localPod, corev1.EventTypeWarning, raftClusterRecoveringReason,
+		"%sRaft cluster %s of %s lost its quorum for more than %s, converting member %s into a new single member cluster",
+		r.dryRunPrefix(), status.clusterID, r.db.DbName, r.config.QuorumLossThreshold, status.serverID)
+	if r.config.DryRun {
+		// report the recovery again once the threshold is exceeded again
+		r.quorumLostSince = timeNow()
+		return nil
+	}
+
+	standaloneDB := r.db.DbAlias + ".standalone"
+	recoveredDB := r.db.DbAlias + ".recovered"
+	defer os.Remove(standaloneDB)
+	_, stderr, err := runOVSDBTool("cluster-to-standalone", standaloneDB, r.db.DbAlias)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to a standalone db, stderr: %q, error: %v", r.db.DbAlias, stderr, err)
+	}
+	_, stderr, err = runOVSDBTool("create-cluster", recoveredDB, standaloneDB, status.address)
+	if err != nil {
+		os.Remove(recoveredDB)
+		return fmt.Errorf("failed to create a new cluster for %s, stderr: %q, error: %v", r.db.DbAlias, stderr, err)
+	}
+	clusterID, stderr, err := runOVSDBTool("db-cid", recoveredDB)
+	if err != nil {
+		os.Remove(recoveredDB)
+		return fmt.Errorf("failed to get the ID of the new cluster of %s, stderr: %q, error: %v", r.db.DbAlias, stderr, err)
+	}
+
+	backupFile, err := backupRaftDB(r.db)
+	if err != nil {
+		os.Remove(recoveredDB)
+		return err
+	}
+	klog.Infof("Backed up the db to backupFile: %s", backupFile)
+	if err = os.Rename(recoveredDB, r.db.DbAlias); err != nil {
+		return fmt.Errorf("failed to replace %s with the recovered db: %v", r.db.DbAlias, err)
+	}
+
+	// let the other members know they have to join the new cluster
+	err = r.kclient.SetAnnotationsOnPod(localPod.Namespace, localPod.Name, map[string]interface{}{
+		recoveredClusterAnnotation(r.db): clusterID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to annotate db pod %s/%s with the recovered cluster of %s: %v",
+			localPod.Namespace, localPod.Name, r.db.DbName, err)
+	}
+
+	_, stderr, err = r.db.AppCtl(5, "exit")
+	if err != nil {
+		return fmt.Errorf("unable to restart the ovn db: %s, stderr: %v, err: %v", r.db.DbName, stderr, err)
+	}
+	r.quorumLostSince = time.Time{}
+	r.recordEvent(localPod, corev1.EventTypeNormal, raftClusterRecoveredReason,
+		"Converted member %s of %s into the new cluster %s, the other members will join it",
+		status.serverID, r.db.DbName, clusterID)
+	return nil
+}
+
+// rejoinCluster resets the db of the local member, so that it joins the cluster recovered by another member
+// once restarted
+func (r *raftRecovery) rejoinCluster(status *raftClusterStatus, localPod, recoveredPod *corev1.Pod, clusterID string) error {
+	r.recordEvent(localPod, corev1.EventTypeNormal, raftMemberRejoiningReason,
+		"%sRaft cluster of %s was recovered by %s as cluster %s, resetting member %s of cluster %s to join it",
+		r.dryRunPrefix(), r.db.DbName, recoveredPod.Name, clusterID, status.serverID, status.clusterID)
+	if r.config.DryRun {
+		return nil
+	}
+	r.quorumLostSince = time.Time{}
+	return resetRaftDB(r.db)
+}
+
+func (r *raftRecovery) dryRunPrefix() string {
+	if r.config.DryRun {
+		return "[dry-run] "
+	}
+	return ""
+}
+
+func (r *raftRecovery) recordEvent(pod *corev1.Pod, eventType, reason, messageFmt string, args ...interface{}) {
+	klog.Infof(reason+": "+messageFmt, args...)
+	if pod == nil {
+		return
+	}
+	r.recorder.Eventf(pod, eventType, reason, messageFmt, args...)
+}
+
+// getPodWithRaftAddress returns the db pod with the IP address of the given Raft address
+func getPodWithRaftAddress(dbPods []*corev1.Pod, raftAddress string) *corev1.Pod {
+	match := raftAddressRegexp.FindStringSubmatch(raftAddress)
+	if len(match) < 3 {
+		return nil
+	}
+	server := match[2]
+	for _, dbPod := range dbPods {
+		for _, ip := range dbPod.Status.PodIPs {
+			if ip.IP == server || utilnet.ParseIPSloppy(ip.IP).String() == server {
+				return dbPod
+			}
+		}
+	}
+	return nil
+}
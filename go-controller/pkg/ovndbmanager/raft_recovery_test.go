@@ -0,0 +1,376 @@
+package ovndbmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	raftStatusTemplate = `87f0
+Name: OVN_Northbound
+Cluster ID: f832 (f832bbff-e28c-4656-83f0-075e91a7ab8f)
+Server ID: 87f0 (87f0d686-8a8d-4585-9513-45efac449101)
+Address: ssl:10.1.1.185:9643
+Status: cluster member
+Role: %s
+Term: 4
+Leader: %s
+Vote: self
+
+Election timer: 1000
+Log: [19418, 26772]
+Entries not yet committed: 0
+Entries not yet applied: 0
+Connections: ->bbf6 ->ad31 <-bbf6 <-ad31
+Disconnections: 1
+%s`
+
+	healthyRaftServers = `Servers:
+    87f0 (87f0 at ssl:10.1.1.185:9643) (self) next_index=26772 match_index=26771
+    bbf6 (bbf6 at ssl:10.1.1.218:9643) next_index=26772 match_index=26771 last msg 257 ms ago
+    ad31 (ad31 at ssl:10.1.1.211:9643) next_index=26772 match_index=26771 last msg 153 ms ago`
+
+	quorumLostRaftServers = `Servers:
+    87f0 (87f0 at ssl:10.1.1.185:9643) (self)
+    bbf6 (bbf6 at ssl:10.1.1.218:9643) last msg 153868958 ms ago
+    ad31 (ad31 at ssl:10.1.1.211:9643)`
+
+	twoSurvivorsRaftServers = `Servers:
+    87f0 (87f0 at ssl:10.1.1.185:9643) (self)
+    1cd2 (1cd2 at ssl:10.1.1.186:9643) last msg 120 ms ago
+    bbf6 (bbf6 at ssl:10.1.1.218:9643) last msg 153868958 ms ago
+    ad31 (ad31 at ssl:10.1.1.211:9643) last msg 153868958 ms ago
+    c10c (c10c at ssl:10.1.1.219:9643) last msg 153868958 ms ago`
+
+	recoveredClusterID = "0b6c41c9-3a8e-4a55-9d69-b4fa7c1fc0e5"
+)
+
+func newReadyDBPod(name, ip string) *corev1.Pod {
+	pod := newDBPod(name, ip, nil)
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	return pod
+}
+
+func newDBPod(name, ip string, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   config.Kubernetes.OVNConfigNamespace,
+			Labels:      map[string]string{"ovn-db-pod": "true"},
+			Annotations: annotations,
+		},
+		Status: corev1.PodStatus{
+			PodIPs: []corev1.PodIP{{IP: ip}},
+		},
+	}
+}
+
+func TestGetRaftClusterStatus(t *testing.T) {
+	tests := []struct {
+		desc               string
+		role               string
+		leader             string
+		servers            string
+		expectedReachable  []string
+		expectedQuorumLost bool
+	}{
+		{
+			desc:              "healthy cluster",
+			role:              "leader",
+			leader:            "self",
+			servers:           healthyRaftServers,
+			expectedReachable: []string{"87f0", "bbf6", "ad31"},
+		},
+		{
+			desc:               "majority of the members unreachable",
+			role:               "candidate",
+			leader:             "unknown",
+			servers:            quorumLostRaftServers,
+			expectedReachable:  []string{"87f0"},
+			expectedQuorumLost: true,
+		},
+		{
+			desc:              "members unreachable during an election",
+			role:              "candidate",
+			leader:            "unknown",
+			servers:           healthyRaftServers,
+			expectedReachable: []string{"87f0", "bbf6", "ad31"},
+		},
+		{
+			desc:               "minority of the members reachable",
+			role:               "follower",
+			leader:             "unknown",
+			servers:            twoSurvivorsRaftServers,
+			expectedReachable:  []string{"87f0", "1cd2"},
+			expectedQuorumLost: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			db := &util.OvsDbProperties{
+				DbName: "OVN_Northbound",
+				AppCtl: func(_ int, _ ...string) (string, string, error) {
+					return fmt.Sprintf(raftStatusTemplate, tc.role, tc.leader, tc.servers), "", nil
+				},
+			}
+			status, err := getRaftClusterStatus(db)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if status.clusterID != "f832bbff-e28c-4656-83f0-075e91a7ab8f" || status.serverID != "87f0" ||
+				status.address != "ssl:10.1.1.185:9643" || status.electionTimer != time.Second {
+				t.Errorf("Unexpected cluster status %+v", status)
+			}
+			reachable, quorumLost := status.reachableServers()
+			if strings.Join(reachable, ",") != strings.Join(tc.expectedReachable, ",") {
+				t.Errorf("Expected reachable servers %v, got %v", tc.expectedReachable, reachable)
+			}
+			if quorumLost != tc.expectedQuorumLost {
+				t.Errorf("Expected quorum lost %v, got %v", tc.expectedQuorumLost, quorumLost)
+			}
+		})
+	}
+}
+
+func TestEnsureRaftQuorum(t *testing.T) {
+	startTime := time.Now()
+	defer func() {
+		timeNow = time.Now
+		runOVSDBTool = util.RunOVSDBTool
+	}()
+
+	tests := []struct {
+		desc string
+		// quorumLostFor is how long the quorum has been lost, it was never lost if zero
+		quorumLostFor   time.Duration
+		dryRun          bool
+		servers         string
+		pods            []*corev1.Pod
+		expectedEvents  []string
+		expectRecovery  bool
+		expectReset     bool
+		expectLostSince bool
+		errorString     string
+	}{
+		{
+			desc:    "healthy cluster, no action needed",
+			servers: healthyRaftServers,
+			pods:    []*corev1.Pod{newDBPod("ovnkube-db-0", "10.1.1.185", nil)},
+		},
+		{
+			desc:            "quorum loss is reported",
+			servers:         quorumLostRaftServers,
+			pods:            []*corev1.Pod{newDBPod("ovnkube-db-0", "10.1.1.185", nil)},
+			expectedEvents:  []string{"Warning RaftQuorumLost Raft cluster f832bbff-e28c-4656-83f0-075e91a7ab8f of OVN_Northbound lost its quorum: only 1 of its 3 members are reachable"},
+			expectLostSince: true,
+		},
+		{
+			desc:            "quorum lost for less than the threshold, no action needed",
+			quorumLostFor:   5 * time.Minute,
+			servers:         quorumLostRaftServers,
+			pods:            []*corev1.Pod{newDBPod("ovnkube-db-0", "10.1.1.185", nil)},
+			expectLostSince: true,
+		},
+		{
+			desc:          "quorum lost for more than the threshold, the cluster is recovered",
+			quorumLostFor: 15 * time.Minute,
+			servers:       quorumLostRaftServers,
+			pods:          []*corev1.Pod{newDBPod("ovnkube-db-0", "10.1.1.185", nil)},
+			expectedEvents: []string{
+				"Warning RaftClusterRecovering Raft cluster f832bbff-e28c-4656-83f0-075e91a7ab8f of OVN_Northbound lost its quorum for more than 10m0s, converting member 87f0 into a new single member cluster",
+				"Normal RaftClusterRecovered Converted member 87f0 of OVN_Northbound into the new cluster " + recoveredClusterID + ", the other members will join it",
+			},
+			expectRecovery: true,
+		},
+		{
+			desc:          "quorum lost for more than the threshold, the unreachable members are not ready, the cluster is recovered",
+			quorumLostFor: 15 * time.Minute,
+			servers:       quorumLostRaftServers,
+			pods: []*corev1.Pod{
+				newDBPod("ovnkube-db-0", "10.1.1.185", nil),
+				newDBPod("ovnkube-db-1", "10.1.1.218", nil),
+			},
+			expectedEvents: []string{
+				"Warning RaftClusterRecovering Raft cluster f832bbff-e28c-4656-83f0-075e91a7ab8f of OVN_Northbound lost its quorum for more than 10m0s, converting member 87f0 into a new single member cluster",
+				"Normal RaftClusterRecovered Converted member 87f0 of OVN_Northbound into the new cluster " + recoveredClusterID + ", the other members will join it",
+			},
+			expectRecovery: true,
+		},
+		{
+			desc:          "quorum lost for more than the threshold, an unreachable member is still ready, the cluster is not recovered",
+			quorumLostFor: 15 * time.Minute,
+			servers:       quorumLostRaftServers,
+			pods: []*corev1.Pod{
+				newDBPod("ovnkube-db-0", "10.1.1.185", nil),
+				newReadyDBPod("ovnkube-db-1", "10.1.1.218"),
+			},
+			expectLostSince: true,
+		},
+		{
+			desc:          "dry run doesn't recover the cluster",
+			quorumLostFor: 15 * time.Minute,
+			dryRun:        true,
+			servers:       quorumLostRaftServers,
+			pods:          []*corev1.Pod{newDBPod("ovnkube-db-0", "10.1.1.185", nil)},
+			expectedEvents: []string{
+				"Warning RaftClusterRecovering [dry-run] Raft cluster f832bbff-e28c-4656-83f0-075e91a7ab8f of OVN_Northbound lost its quorum for more than 10m0s, converting member 87f0 into a new single member cluster",
+			},
+			expectLostSince: true,
+		},
+		{
+			desc:          "another surviving member recovers the cluster",
+			quorumLostFor: 15 * time.Minute,
+			servers:       twoSurvivorsRaftServers,
+			pods: []*corev1.Pod{
+				newDBPod("ovnkube-db-0", "10.1.1.185", nil),
+				newDBPod("ovnkube-db-1", "10.1.1.186", nil),
+			},
+			expectLostSince: true,
+		},
+		{
+			desc:            "the db pod can't be found, the cluster is not recovered",
+			quorumLostFor:   15 * time.Minute,
+			servers:         quorumLostRaftServers,
+			pods:            []*corev1.Pod{newDBPod("ovnkube-db-1", "10.1.1.186", nil)},
+			expectLostSince: true,
+			errorString:     "unable to find the db pod with address ssl:10.1.1.185:9643",
+		},
+		{
+			desc:    "the cluster was recovered by another member, the member rejoins it",
+			servers: quorumLostRaftServers,
+			pods: []*corev1.Pod{
+				newDBPod("ovnkube-db-0", "10.1.1.185", nil),
+				newDBPod("ovnkube-db-1", "10.1.1.186", map[string]string{
+					"k8s.ovn.org/ovn_northbound-recovered-cluster-id": recoveredClusterID,
+				}),
+			},
+			expectedEvents: []string{
+				"Normal RaftMemberRejoining Raft cluster of OVN_Northbound was recovered by ovnkube-db-1 as cluster " + recoveredClusterID + ", resetting member 87f0 of cluster f832bbff-e28c-4656-83f0-075e91a7ab8f to join it",
+			},
+			expectReset: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			role, leader := "candidate", "unknown"
+			if tc.servers == healthyRaftServers {
+				role, leader = "leader", "self"
+			}
+			exitCalled := false
+			db := &util.OvsDbProperties{
+				DbName:  "OVN_Northbound",
+				DbAlias: filepath.Join(t.TempDir(), "ovnnb_db.db"),
+				AppCtl: func(_ int, args ...string) (string, string, error) {
+					switch keyForArgs(args...) {
+					case keyForArgs("cluster/status", "OVN_Northbound"):
+						return fmt.Sprintf(raftStatusTemplate, role, leader, tc.servers), "", nil
+					case keyForArgs("exit"):
+						exitCalled = true
+						return "", "", nil
+					}
+					return "", "key not found", fmt.Errorf("key not found")
+				},
+			}
+			if err := os.WriteFile(db.DbAlias, []byte("clustered"), 0o644); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var ovsdbToolCalls []string
+			runOVSDBTool = func(args ...string) (string, string, error) {
+				ovsdbToolCalls = append(ovsdbToolCalls, strings.Join(args, " "))
+				switch args[0] {
+				case "cluster-to-standalone", "create-cluster":
+					return "", "", os.WriteFile(args[1], []byte(args[0]), 0o644)
+				case "db-cid":
+					return recoveredClusterID, "", nil
+				}
+				return "", "unexpected command", fmt.Errorf("unexpected command")
+			}
+			timeNow = func() time.Time { return startTime }
+
+			objects := []runtime.Object{}
+			for _, pod := range tc.pods {
+				objects = append(objects, pod)
+			}
+			kclient := &kube.Kube{KClient: fake.NewSimpleClientset(objects...)}
+			recorder := record.NewFakeRecorder(10)
+			recovery := newRaftRecovery(RaftRecoveryConfig{QuorumLossThreshold: 10 * time.Minute, DryRun: tc.dryRun},
+				kclient, recorder, db)
+			if tc.quorumLostFor > 0 {
+				recovery.quorumLostSince = startTime.Add(-tc.quorumLostFor)
+			}
+
+			err := recovery.ensureRaftQuorum()
+			failOnErrorMismatch(t, err, tc.errorString)
+
+			var events []string
+			close(recorder.Events)
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if strings.Join(events, "\n") != strings.Join(tc.expectedEvents, "\n") {
+				t.Errorf("Expected events %q, got %q", tc.expectedEvents, events)
+			}
+
+			if tc.expectLostSince == recovery.quorumLostSince.IsZero() {
+				t.Errorf("Unexpected quorum loss time %v", recovery.quorumLostSince)
+			}
+
+			data, err := os.ReadFile(db.DbAlias)
+			switch {
+			case tc.expectRecovery:
+				expectedCalls := []string{
+					"cluster-to-standalone " + db.DbAlias + ".standalone " + db.DbAlias,
+					"create-cluster " + db.DbAlias + ".recovered " + db.DbAlias + ".standalone ssl:10.1.1.185:9643",
+					"db-cid " + db.DbAlias + ".recovered",
+				}
+				if strings.Join(ovsdbToolCalls, "\n") != strings.Join(expectedCalls, "\n") {
+					t.Errorf("Expected ovsdb-tool calls %q, got %q", expectedCalls, ovsdbToolCalls)
+				}
+				if err != nil || string(data) != "create-cluster" {
+					t.Errorf("Expected the db to be replaced by the recovered db, got %q, %v", data, err)
+				}
+				if _, err = os.Stat(db.DbAlias + ".standalone"); !os.IsNotExist(err) {
+					t.Errorf("Expected the standalone db to be removed, got %v", err)
+				}
+				pod, err := kclient.KClient.CoreV1().Pods(config.Kubernetes.OVNConfigNamespace).Get(
+					context.TODO(), "ovnkube-db-0", metav1.GetOptions{})
+				if err != nil || pod.Annotations["k8s.ovn.org/ovn_northbound-recovered-cluster-id"] != recoveredClusterID {
+					t.Errorf("Expected the db pod to be annotated with the recovered cluster, got %v, %v", pod, err)
+				}
+				if !exitCalled {
+					t.Errorf("Expected the db to be restarted")
+				}
+			case tc.expectReset:
+				if !os.IsNotExist(err) {
+					t.Errorf("Expected the db to be backed up, got %v", err)
+				}
+				if !exitCalled {
+					t.Errorf("Expected the db to be restarted")
+				}
+			default:
+				if len(ovsdbToolCalls) > 0 || exitCalled {
+					t.Errorf("Expected no change to the db, got ovsdb-tool calls %q and exit %v", ovsdbToolCalls, exitCalled)
+				}
+				if err != nil || string(data) != "clustered" {
+					t.Errorf("Expected the db to be kept, got %q, %v", data, err)
+				}
+			}
+		})
+	}
+}
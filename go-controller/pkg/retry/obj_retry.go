@@ -27,6 +27,10 @@ const initialBackoff = 1 * time.Second
 const noBackoff = 0
 const maxBackoff = 60 * time.Second
 
+// backoffJitterFactor is the maximum fraction of the backoff randomly added to it,
+// so that the retries of objects which failed at the same time are spread out.
+const backoffJitterFactor = 0.1
+
 // retryObjEntry is a generic object caching with retry mechanism
 // that resources can use to eventually complete their intended operations.
 type retryObjEntry struct {
@@ -39,8 +43,23 @@ type retryObjEntry struct {
 	config    interface{}
 	timeStamp time.Time
 	backoff   time.Duration
+	// jitter is randomly added to backoff to compute the time of the next attempt
+	jitter time.Duration
 	// number of times this object has been unsuccessfully added/updated/deleted
 	failedAttempts uint8
+	// lastErr is the error of the last failed attempt
+	lastErr error
+}
+
+// setBackoff sets the backoff of the entry, along with a new random jitter.
+func (e *retryObjEntry) setBackoff(backoff time.Duration) {
+	e.backoff = backoff
+	e.jitter = time.Duration(rand.Float64() * backoffJitterFactor * float64(backoff))
+}
+
+// nextAttempt returns the earliest time the entry will be retried at.
+func (e *retryObjEntry) nextAttempt() time.Time {
+	return e.timeStamp.Add(e.backoff + e.jitter)
 }
 
 type EventHandler interface {
@@ -141,13 +160,25 @@ func (r *RetryFramework) DoWithLock(key string, f func(key string)) {
 	f(key)
 }
 
-func (r *RetryFramework) initRetryObjWithAddBackoff(obj interface{}, lockedKey string, backoff time.Duration) *retryObjEntry {
+// loadOrStoreRetryObj returns the retry entry of the key, creating it from newEntry
+// if it does not exist.
+func (r *RetryFramework) loadOrStoreRetryObj(lockedKey string, newEntry *retryObjEntry) *retryObjEntry {
 	// even if the object was loaded and changed before with the same lock, LoadOrStore will return reference to the same object
-	entry, _ := r.retryEntries.LoadOrStore(lockedKey, &retryObjEntry{backoff: backoff})
+	entry, loaded := r.retryEntries.LoadOrStore(lockedKey, newEntry)
+	if !loaded {
+		entry.setBackoff(entry.backoff)
+		metrics.MetricResourceRetryPendingCount.WithLabelValues(r.resourceType()).Inc()
+	}
+	return entry
+}
+
+func (r *RetryFramework) initRetryObjWithAddBackoff(obj interface{}, lockedKey string, backoff time.Duration) *retryObjEntry {
+	entry := r.loadOrStoreRetryObj(lockedKey, &retryObjEntry{backoff: backoff})
 	entry.timeStamp = time.Now()
 	entry.newObj = obj
 	entry.failedAttempts = 0
-	entry.backoff = backoff
+	entry.lastErr = nil
+	entry.setBackoff(backoff)
 	return entry
 }
 
@@ -159,12 +190,12 @@ func (r *RetryFramework) initRetryObjWithAdd(obj interface{}, lockedKey string)
 
 // initRetryObjWithUpdate tracks objects that failed to be updated to potentially retry later
 func (r *RetryFramework) initRetryObjWithUpdate(oldObj, newObj interface{}, lockedKey string) *retryObjEntry {
-	entry, _ := r.retryEntries.LoadOrStore(lockedKey, &retryObjEntry{config: oldObj, backoff: initialBackoff})
-	// even if the object was loaded and changed before with the same lock, LoadOrStore will return reference to the same object
+	entry := r.loadOrStoreRetryObj(lockedKey, &retryObjEntry{config: oldObj, backoff: initialBackoff})
 	entry.timeStamp = time.Now()
 	entry.newObj = newObj
 	entry.config = oldObj
 	entry.failedAttempts = 0
+	entry.lastErr = nil
 	return entry
 }
 
@@ -174,14 +205,14 @@ func (r *RetryFramework) initRetryObjWithUpdate(oldObj, newObj interface{}, lock
 // and the object is orphaned from the namespace.
 // The noRetryAdd boolean argument is to indicate whether to retry for addition
 func (r *RetryFramework) InitRetryObjWithDelete(obj interface{}, lockedKey string, config interface{}, noRetryAdd bool) *retryObjEntry {
-	// even if the object was loaded and changed before with the same lock, LoadOrStore will return reference to the same object
-	entry, _ := r.retryEntries.LoadOrStore(lockedKey, &retryObjEntry{config: config, backoff: initialBackoff})
+	entry := r.loadOrStoreRetryObj(lockedKey, &retryObjEntry{config: config, backoff: initialBackoff})
 	entry.timeStamp = time.Now()
 	entry.oldObj = obj
 	if entry.config == nil {
 		entry.config = config
 	}
 	entry.failedAttempts = 0
+	entry.lastErr = nil
 	if noRetryAdd {
 		// will not be retried for addition
 		entry.newObj = nil
@@ -207,14 +238,27 @@ func (r *RetryFramework) getRetryObj(lockedKey string) (value *retryObjEntry, fo
 }
 
 func (r *RetryFramework) DeleteRetryObj(lockedKey string) {
+	if _, loaded := r.retryEntries.Load(lockedKey); !loaded {
+		return
+	}
 	r.retryEntries.Delete(lockedKey)
+	metrics.MetricResourceRetryPendingCount.WithLabelValues(r.resourceType()).Dec()
+}
+
+// resourceType returns the name of the type of the resources handled by the framework.
+func (r *RetryFramework) resourceType() string {
+	objType := r.ResourceHandler.ObjType
+	if objType.Kind() == reflect.Pointer {
+		objType = objType.Elem()
+	}
+	return objType.Name()
 }
 
 // setRetryObjWithNoBackoff sets an object's backoff to be retried
 // immediately during the next retry iteration
 // Used only for testing right now
 func (r *RetryFramework) setRetryObjWithNoBackoff(entry *retryObjEntry) {
-	entry.backoff = noBackoff
+	entry.setBackoff(noBackoff)
 }
 
 // removeDeleteFromRetryObj removes any old object from a retry entry
@@ -224,9 +268,10 @@ func (r *RetryFramework) removeDeleteFromRetryObj(entry *retryObjEntry) {
 }
 
 // increaseFailedAttemptsCounter increases by one the counter of failed add/update/delete attempts
-// for the given key
-func (r *RetryFramework) increaseFailedAttemptsCounter(entry *retryObjEntry) {
+// for the given key, and records the error of the attempt
+func (r *RetryFramework) increaseFailedAttemptsCounter(entry *retryObjEntry, err error) {
 	entry.failedAttempts++
+	entry.lastErr = err
 }
 
 // RequestRetryFramework allows a caller to immediately request to iterate through all objects that
@@ -273,21 +318,17 @@ func (r *RetryFramework) resourceRetry(objKey string, now time.Time) {
 		forceRetry := false
 		// check if immediate retry is requested
 		if entry.backoff == noBackoff {
-			entry.backoff = initialBackoff
+			entry.setBackoff(initialBackoff)
 			forceRetry = true
 		}
-		backoff := entry.backoff + (time.Duration(rand.Intn(500)) * time.Millisecond)
-		objTimer := entry.timeStamp.Add(backoff)
+		objTimer := entry.nextAttempt()
 		if !forceRetry && now.Before(objTimer) {
 			klog.V(5).Infof("Attempting retry of %s %s before timer (time: %s): skip", r.ResourceHandler.ObjType, objKey, objTimer)
 			return
 		}
 
 		// update backoff for future attempts in case of failure
-		entry.backoff = entry.backoff * 2
-		if entry.backoff > maxBackoff {
-			entry.backoff = maxBackoff
-		}
+		entry.setBackoff(min(entry.backoff*2, maxBackoff))
 
 		// storing original obj for metrics
 		var initObj interface{}
@@ -324,7 +365,7 @@ func (r *RetryFramework) resourceRetry(objKey string, now time.Time) {
 				klog.Errorf("%v retry: cannot update object that is not scheduled: %s", r.ResourceHandler.ObjType, objKey)
 			} else if err := r.ResourceHandler.UpdateResource(entry.config, entry.newObj, true); err != nil {
 				entry.timeStamp = time.Now()
-				entry.failedAttempts++
+				r.increaseFailedAttemptsCounter(entry, err)
 				if entry.failedAttempts >= MaxFailedAttempts {
 					klog.Errorf("Retry update failed final attempt for %s %s: error: %v", r.ResourceHandler.ObjType, objKey, err)
 				} else {
@@ -345,7 +386,7 @@ func (r *RetryFramework) resourceRetry(objKey string, now time.Time) {
 					klog.Errorf("%v retry: cannot delete object that was not scheduled %s", r.ResourceHandler.ObjType, objKey)
 				} else if err := r.ResourceHandler.DeleteResource(entry.oldObj, entry.config); err != nil {
 					entry.timeStamp = time.Now()
-					entry.failedAttempts++
+					r.increaseFailedAttemptsCounter(entry, err)
 					if entry.failedAttempts >= MaxFailedAttempts {
 						klog.Errorf("Retry delete failed final attempt for %s %s: error: %v", r.ResourceHandler.ObjType, objKey, err)
 					} else {
@@ -368,7 +409,7 @@ func (r *RetryFramework) resourceRetry(objKey string, now time.Time) {
 					klog.Errorf("%v retry: cannot create object that is not scheduled %s", r.ResourceHandler.ObjType, objKey)
 				} else if err := r.ResourceHandler.AddResource(entry.newObj, true); err != nil {
 					entry.timeStamp = time.Now()
-					entry.failedAttempts++
+					r.increaseFailedAttemptsCounter(entry, err)
 					if entry.failedAttempts >= MaxFailedAttempts {
 						klog.Errorf("Retry add failed final attempt for %s %s: error: %v", r.ResourceHandler.ObjType, objKey, err)
 					} else {
@@ -423,6 +464,8 @@ func (r *RetryFramework) iterateRetryResources() {
 func (r *RetryFramework) periodicallyRetryResources() {
 	timer := time.NewTicker(RetryObjInterval)
 	defer timer.Stop()
+	registerRetryFramework(r)
+	defer unregisterRetryFramework(r)
 	for {
 		select {
 		case <-timer.C:
@@ -435,6 +478,10 @@ func (r *RetryFramework) periodicallyRetryResources() {
 
 		case <-r.stopChan:
 			klog.V(5).Infof("Stop channel got triggered: will stop retrying failed objects of type %s", r.ResourceHandler.ObjType)
+			// the remaining entries won't be retried anymore
+			for _, key := range r.retryEntries.GetKeys() {
+				r.DoWithLock(key, r.DeleteRetryObj)
+			}
 			return
 		}
 	}
@@ -469,7 +516,7 @@ func (r *RetryFramework) processObjectInTerminalState(obj interface{}, lockedKey
 		klog.Errorf("Failed to delete object %s of type %s in terminal state, during %s event: %v",
 			lockedKey, r.ResourceHandler.ObjType, event, err)
 		r.ResourceHandler.RecordErrorEvent(obj, "ErrorDeletingResource", err)
-		r.increaseFailedAttemptsCounter(retryEntry)
+		r.increaseFailedAttemptsCounter(retryEntry, err)
 		return
 	}
 	r.DeleteRetryObj(lockedKey)
@@ -530,7 +577,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 							klog.Errorf("Failed to delete old object %s of type %s,"+
 								" during add event: %v", key, r.ResourceHandler.ObjType, err)
 							r.ResourceHandler.RecordErrorEvent(obj, "ErrorDeletingResource", err)
-							r.increaseFailedAttemptsCounter(retryObj)
+							r.increaseFailedAttemptsCounter(retryObj, err)
 							return
 						}
 						r.removeDeleteFromRetryObj(retryObj)
@@ -543,7 +590,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 						} else {
 							klog.Infof("Failed to create %s %s, error: %v", r.ResourceHandler.ObjType, key, err)
 						}
-						r.increaseFailedAttemptsCounter(retryObj)
+						r.increaseFailedAttemptsCounter(retryObj, err)
 						return
 					}
 					klog.V(5).Infof("Creating %s %s took: %v", r.ResourceHandler.ObjType, key, time.Since(start))
@@ -636,7 +683,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 							klog.Errorf("Failed to delete stale object %s, during update: %v", oldKey, err)
 							r.ResourceHandler.RecordErrorEvent(retryEntryOrNil.oldObj, "ErrorDeletingResource", err)
 							retryEntry := r.initRetryObjWithAdd(latest, key)
-							r.increaseFailedAttemptsCounter(retryEntry)
+							r.increaseFailedAttemptsCounter(retryEntry, err)
 							return
 						}
 						// remove the old object from retry entry since it was correctly deleted
@@ -664,7 +711,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 							r.ResourceHandler.RecordErrorEvent(old, "ErrorDeletingResource", err)
 							retryEntry := r.InitRetryObjWithDelete(old, key, nil, false)
 							r.initRetryObjWithAdd(latest, key)
-							r.increaseFailedAttemptsCounter(retryEntry)
+							r.increaseFailedAttemptsCounter(retryEntry, err)
 							return
 						}
 						// remove the old object from retry entry since it was correctly deleted
@@ -692,13 +739,13 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 							} else {
 								retryEntry = r.initRetryObjWithAdd(latest, key)
 							}
-							r.increaseFailedAttemptsCounter(retryEntry)
+							r.increaseFailedAttemptsCounter(retryEntry, err)
 							return
 						}
 					} else { // we previously deleted old object, now let's add the new one
 						if err := r.ResourceHandler.AddResource(latest, false); err != nil {
 							retryEntry := r.initRetryObjWithAdd(latest, key)
-							r.increaseFailedAttemptsCounter(retryEntry)
+							r.increaseFailedAttemptsCounter(retryEntry, err)
 							if !ovntypes.IsSuppressedError(err) {
 								klog.Errorf("Failed to add %s %s, during update: %v",
 									r.ResourceHandler.ObjType, newKey, err)
@@ -743,7 +790,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 					internalCacheEntry := r.ResourceHandler.GetInternalCacheEntry(obj)
 					retryEntry := r.InitRetryObjWithDelete(obj, key, internalCacheEntry, false) // set up the retry obj for deletion
 					if err = r.ResourceHandler.DeleteResource(obj, internalCacheEntry); err != nil {
-						retryEntry.failedAttempts++
+						r.increaseFailedAttemptsCounter(retryEntry, err)
 						klog.Errorf("Failed to delete %s %s, error: %v", r.ResourceHandler.ObjType, key, err)
 						return
 					}
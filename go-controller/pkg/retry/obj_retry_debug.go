@@ -0,0 +1,103 @@
+package retry
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PendingRetriesPath is the path of the metrics server endpoint listing the pending retries
+const PendingRetriesPath = "/debug/retries"
+
+// runningFrameworks holds the retry frameworks which are retrying their objects
+var runningFrameworks = struct {
+	sync.Mutex
+	frameworks map[*RetryFramework]struct{}
+}{frameworks: map[*RetryFramework]struct{}{}}
+
+func registerRetryFramework(r *RetryFramework) {
+	runningFrameworks.Lock()
+	defer runningFrameworks.Unlock()
+	runningFrameworks.frameworks[r] = struct{}{}
+}
+
+func unregisterRetryFramework(r *RetryFramework) {
+	runningFrameworks.Lock()
+	defer runningFrameworks.Unlock()
+	delete(runningFrameworks.frameworks, r)
+}
+
+// PendingRetry describes an object waiting to be retried
+type PendingRetry struct {
+	ResourceType   string    `json:"resourceType"`
+	Key            string    `json:"key"`
+	FailedAttempts uint8     `json:"failedAttempts"`
+	LastError      string    `json:"lastError,omitempty"`
+	NextAttempt    time.Time `json:"nextAttempt"`
+}
+
+// getPendingRetries returns the objects waiting to be retried by the framework
+func (r *RetryFramework) getPendingRetries() []PendingRetry {
+	var pending []PendingRetry
+	for _, key := range r.retryEntries.GetKeys() {
+		r.DoWithLock(key, func(key string) {
+			entry, found := r.getRetryObj(key)
+			if !found {
+				return
+			}
+			retry := PendingRetry{
+				ResourceType:   r.resourceType(),
+				Key:            key,
+				FailedAttempts: entry.failedAttempts,
+				NextAttempt:    entry.nextAttempt(),
+			}
+			if entry.lastErr != nil {
+				retry.LastError = entry.lastErr.Error()
+			}
+			pending = append(pending, retry)
+		})
+	}
+	return pending
+}
+
+// GetPendingRetries returns the objects waiting to be retried by all the running
+// retry frameworks, sorted by resource type and key.
+func GetPendingRetries() []PendingRetry {
+	runningFrameworks.Lock()
+	frameworks := make([]*RetryFramework, 0, len(runningFrameworks.frameworks))
+	for r := range runningFrameworks.frameworks {
+		frameworks = append(frameworks, r)
+	}
+	runningFrameworks.Unlock()
+
+	pending := []PendingRetry{}
+	for _, r := range frameworks {
+		pending = append(pending, r.getPendingRetries()...)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].ResourceType != pending[j].ResourceType {
+			return pending[i].ResourceType < pending[j].ResourceType
+		}
+		return pending[i].Key < pending[j].Key
+	})
+	return pending
+}
+
+// PendingRetriesHandler returns an HTTP handler listing the objects waiting to be
+// retried by all the running retry frameworks, as JSON.
+func PendingRetriesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "unsupported http method", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(GetPendingRetries()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+type failingEventHandler struct {
+	DefaultEventHandler
+	obj interface{}
+	err error
+}
+
+func (h *failingEventHandler) AddResource(interface{}, bool) error { return h.err }
+
+func (h *failingEventHandler) UpdateResource(interface{}, interface{}, bool) error { return h.err }
+
+func (h *failingEventHandler) DeleteResource(interface{}, interface{}) error { return h.err }
+
+func (h *failingEventHandler) GetResourceFromInformerCache(string) (interface{}, error) {
+	return h.obj, nil
+}
+
+func (h *failingEventHandler) FilterOutResource(interface{}) bool { return false }
+
+func getPendingRetriesGauge(g *gomega.WithT, resourceType string) float64 {
+	metric := &dto.Metric{}
+	g.Expect(metrics.MetricResourceRetryPendingCount.WithLabelValues(resourceType).Write(metric)).To(gomega.Succeed())
+	return metric.GetGauge().GetValue()
+}
+
+func TestRetryBackoffAndPendingRetries(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace1", Name: "pod1"}}
+	handler := &failingEventHandler{obj: pod, err: errors.New("failed to add pod")}
+	r := NewRetryFramework(make(chan struct{}), &sync.WaitGroup{}, nil, &ResourceHandler{
+		ObjType:      factory.PodType,
+		EventHandler: handler,
+	})
+	registerRetryFramework(r)
+	defer unregisterRetryFramework(r)
+
+	pendingBefore := getPendingRetriesGauge(g, "Pod")
+	key := "namespace1/pod1"
+	r.DoWithLock(key, func(key string) {
+		r.initRetryObjWithAdd(pod, key)
+	})
+	g.Expect(getPendingRetriesGauge(g, "Pod")).To(gomega.Equal(pendingBefore + 1))
+
+	entry, found := GetRetryObj(key, r)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(entry.backoff).To(gomega.Equal(initialBackoff))
+	g.Expect(entry.jitter).To(gomega.BeNumerically("<=", time.Duration(backoffJitterFactor*float64(initialBackoff))))
+
+	// the entry is not retried before its next attempt
+	r.resourceRetry(key, entry.timeStamp)
+	g.Expect(entry.failedAttempts).To(gomega.BeZero())
+
+	// every failed attempt doubles the backoff, up to maxBackoff
+	expectedBackoff := initialBackoff
+	for i := 1; i <= 8; i++ {
+		r.resourceRetry(key, entry.nextAttempt())
+		expectedBackoff = min(expectedBackoff*2, maxBackoff)
+		g.Expect(entry.failedAttempts).To(gomega.BeEquivalentTo(i))
+		g.Expect(entry.backoff).To(gomega.Equal(expectedBackoff))
+		g.Expect(entry.jitter).To(gomega.BeNumerically("<=", time.Duration(backoffJitterFactor*float64(expectedBackoff))))
+		g.Expect(entry.lastErr).To(gomega.MatchError("failed to add pod"))
+	}
+	g.Expect(entry.backoff).To(gomega.Equal(maxBackoff))
+
+	pending := GetPendingRetries()
+	g.Expect(pending).To(gomega.ContainElement(PendingRetry{
+		ResourceType:   "Pod",
+		Key:            key,
+		FailedAttempts: 8,
+		LastError:      "failed to add pod",
+		NextAttempt:    entry.nextAttempt(),
+	}))
+
+	recorder := httptest.NewRecorder()
+	PendingRetriesHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PendingRetriesPath, nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	var served []PendingRetry
+	g.Expect(json.Unmarshal(recorder.Body.Bytes(), &served)).To(gomega.Succeed())
+	g.Expect(served).To(gomega.ContainElement(gomega.And(
+		gomega.HaveField("ResourceType", "Pod"),
+		gomega.HaveField("Key", key),
+		gomega.HaveField("FailedAttempts", uint8(8)),
+		gomega.HaveField("LastError", "failed to add pod"),
+	)))
+
+	// a successful attempt removes the entry
+	handler.err = nil
+	r.resourceRetry(key, entry.nextAttempt())
+	g.Expect(CheckRetryObj(key, r)).To(gomega.BeFalse())
+	g.Expect(getPendingRetriesGauge(g, "Pod")).To(gomega.Equal(pendingBefore))
+	g.Expect(GetPendingRetries()).NotTo(gomega.ContainElement(gomega.HaveField("Key", key)))
+}
@@ -155,6 +155,17 @@ const (
 	PacketsPerSecond     = "pktps"
 	MeterAction          = "drop"
 
+	// Protocols rate limited by the meters of the default CoPP of the gateway routers
+	OVNARPRateLimiter              = "arp"
+	OVNARPResolveRateLimiter       = "arp-resolve"
+	OVNBFDRateLimiter              = "bfd"
+	OVNControllerEventsRateLimiter = "event-elb"
+	OVNICMPV4ErrorsRateLimiter     = "icmp4-error"
+	OVNICMPV6ErrorsRateLimiter     = "icmp6-error"
+	OVNRejectRateLimiter           = "reject"
+	OVNTCPRSTRateLimiter           = "tcp-reset"
+	OVNServiceMonitorLimiter       = "svc-monitor"
+
 	// OVN-K8S annotation & taint constants
 	OvnK8sPrefix = "k8s.ovn.org"
 
@@ -324,6 +335,7 @@ const (
 	MetricOvnkubeSubsystemController     = "controller"
 	MetricOvnkubeSubsystemClusterManager = "clustermanager"
 	MetricOvnkubeSubsystemNode           = "node"
+	MetricOvnkubeSubsystemObserv         = "observ"
 	MetricOvnNamespace                   = "ovn"
 	MetricOvnSubsystemDB                 = "db"
 	MetricOvnSubsystemNorthd             = "northd"
@@ -338,3 +350,16 @@ const (
 	NFTMgmtPortNoSNATSubnetsV4 = "mgmtport-no-snat-subnets-v4"
 	NFTMgmtPortNoSNATSubnetsV6 = "mgmtport-no-snat-subnets-v6"
 )
+
+// CoPPProtocols are the protocols rate limited by the default CoPP of the gateway routers
+var CoPPProtocols = []string{
+	OVNARPRateLimiter,
+	OVNARPResolveRateLimiter,
+	OVNBFDRateLimiter,
+	OVNControllerEventsRateLimiter,
+	OVNICMPV4ErrorsRateLimiter,
+	OVNICMPV6ErrorsRateLimiter,
+	OVNRejectRateLimiter,
+	OVNTCPRSTRateLimiter,
+	OVNServiceMonitorLimiter,
+}
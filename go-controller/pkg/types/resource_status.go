@@ -10,6 +10,7 @@ const (
 	EgressFirewallErrorMsg = "EgressFirewall Rules not correctly applied"
 	EgressQoSErrorMsg      = "EgressQoS Rules not correctly applied"
 	NetworkQoSErrorMsg     = "NetworkQoS Destinations not correctly applied"
+	ObservabilityErrorMsg  = "ObservabilityConfig not correctly applied"
 )
 
 func GetZoneStatus(zoneID, message string) string {
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/miekg/dns"
+	ocpnetworkapiv1alpha1 "github.com/openshift/api/network/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/klog/v2"
 
@@ -17,6 +21,9 @@ import (
 const (
 	// dnsRegex gives the regular expression for DNS names when DNSNameResolver is enabled.
 	dnsRegex = `^(\*\.)?([a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?\.?$`
+	// DNSResolvedAddressGracePeriod is the time during which the IP address of a subdomain matched by
+	// a wildcard DNS name is kept after its TTL expired, leaving time to the DNS resolver to refresh it.
+	DNSResolvedAddressGracePeriod = 10 * time.Second
 )
 
 // ValidateAndGetEgressFirewallDNSDestination validates an egress firewall rule destination and returns
@@ -84,3 +91,37 @@ func GetDNSNames(ef *egressfirewallv1.EgressFirewall) []string {
 
 	return dnsNameSlice
 }
+
+// GetDNSNameResolverAddresses returns, for each name resolved by the DNSNameResolver, the IP addresses
+// the name currently resolves to. For a wildcard DNS name, the IP addresses of a matched subdomain
+// expire once their TTL and DNSResolvedAddressGracePeriod elapsed since their last lookup. The
+// subdomains without IP addresses left are not returned. The time at which the next of the returned
+// IP addresses expires is returned as well, or the zero time if none of them expires.
+func GetDNSNameResolverAddresses(resolver *ocpnetworkapiv1alpha1.DNSNameResolver, now time.Time) (map[string][]string, time.Time) {
+	var nextExpiry time.Time
+	addresses := map[string][]string{}
+	for _, resolvedName := range resolver.Status.ResolvedNames {
+		// only the subdomains matched by a wildcard DNS name expire, the resolver keeps refreshing
+		// the IP addresses of the DNS name itself.
+		isSubdomain := IsWildcard(string(resolver.Spec.Name)) && resolvedName.DNSName != resolver.Spec.Name
+		ips := sets.New[string]()
+		for _, resolvedAddress := range resolvedName.ResolvedAddresses {
+			if isSubdomain && resolvedAddress.LastLookupTime != nil {
+				expiry := resolvedAddress.LastLookupTime.Add(time.Duration(resolvedAddress.TTLSeconds)*time.Second +
+					DNSResolvedAddressGracePeriod)
+				if !expiry.After(now) {
+					continue
+				}
+				if nextExpiry.IsZero() || expiry.Before(nextExpiry) {
+					nextExpiry = expiry
+				}
+			}
+			ips.Insert(resolvedAddress.IP)
+		}
+		if ips.Len() > 0 {
+			name := LowerCaseFQDN(string(resolvedName.DNSName))
+			addresses[name] = sets.List(ips.Insert(addresses[name]...))
+		}
+	}
+	return addresses, nextExpiry
+}
@@ -2,10 +2,13 @@ package util
 
 import (
 	"testing"
+	"time"
 
+	ocpnetworkapiv1alpha1 "github.com/openshift/api/network/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -244,3 +247,85 @@ func TestGetNames(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDNSNameResolverAddresses(t *testing.T) {
+	now := time.Now()
+	lookup := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-ago)}
+	}
+	resolvedName := func(name string, addresses ...ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress) ocpnetworkapiv1alpha1.DNSNameResolverResolvedName {
+		return ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+			DNSName:           ocpnetworkapiv1alpha1.DNSName(name),
+			ResolvedAddresses: addresses,
+		}
+	}
+	tests := []struct {
+		name               string
+		dnsName            string
+		resolvedNames      []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName
+		expectedAddresses  map[string][]string
+		expectedNextExpiry time.Time
+	}{
+		{
+			name:    "regular DNS name addresses do not expire",
+			dnsName: "www.example.com.",
+			resolvedNames: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+				resolvedName("www.example.com.",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "1.1.1.1", TTLSeconds: 30, LastLookupTime: lookup(time.Hour)},
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "2.2.2.2", TTLSeconds: 30, LastLookupTime: lookup(time.Hour)},
+				),
+			},
+			expectedAddresses: map[string][]string{"www.example.com.": {"1.1.1.1", "2.2.2.2"}},
+		},
+		{
+			name:    "wildcard DNS name subdomain addresses expire after their TTL and the grace period",
+			dnsName: "*.example.com.",
+			resolvedNames: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+				resolvedName("*.example.com.",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "1.1.1.1", TTLSeconds: 30, LastLookupTime: lookup(time.Hour)},
+				),
+				resolvedName("a.example.com.",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "2.2.2.2", TTLSeconds: 30, LastLookupTime: lookup(10 * time.Second)},
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "3.3.3.3", TTLSeconds: 30, LastLookupTime: lookup(time.Minute)},
+				),
+				resolvedName("b.example.com.",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "4.4.4.4", TTLSeconds: 30, LastLookupTime: lookup(time.Minute)},
+				),
+				resolvedName("C.example.com",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "5.5.5.5", TTLSeconds: 60, LastLookupTime: lookup(0)},
+				),
+			},
+			expectedAddresses: map[string][]string{
+				"*.example.com.": {"1.1.1.1"},
+				"a.example.com.": {"2.2.2.2"},
+				"c.example.com.": {"5.5.5.5"},
+			},
+			expectedNextExpiry: now.Add(20*time.Second + DNSResolvedAddressGracePeriod),
+		},
+		{
+			name:    "wildcard DNS name subdomain addresses without lookup time do not expire",
+			dnsName: "*.example.com.",
+			resolvedNames: []ocpnetworkapiv1alpha1.DNSNameResolverResolvedName{
+				resolvedName("a.example.com.",
+					ocpnetworkapiv1alpha1.DNSNameResolverResolvedAddress{IP: "2.2.2.2", TTLSeconds: 30},
+				),
+			},
+			expectedAddresses: map[string][]string{"a.example.com.": {"2.2.2.2"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &ocpnetworkapiv1alpha1.DNSNameResolver{
+				Spec: ocpnetworkapiv1alpha1.DNSNameResolverSpec{
+					Name: ocpnetworkapiv1alpha1.DNSName(tc.dnsName),
+				},
+				Status: ocpnetworkapiv1alpha1.DNSNameResolverStatus{
+					ResolvedNames: tc.resolvedNames,
+				},
+			}
+			addresses, nextExpiry := GetDNSNameResolverAddresses(resolver, now)
+			assert.Equal(t, tc.expectedAddresses, addresses)
+			assert.True(t, tc.expectedNextExpiry.Equal(nextExpiry), "unexpected next expiry %v", nextExpiry)
+		})
+	}
+}
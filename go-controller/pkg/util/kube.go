@@ -52,6 +52,7 @@ import (
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
 	networkqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/clientset/versioned"
+	observabilityconfigclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/observabilityconfig/v1/apis/clientset/versioned"
 	routeadvertisementsclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned"
 	userdefinednetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/clientset/versioned"
 )
@@ -75,6 +76,7 @@ type OVNClientset struct {
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
 	FRRClient                 frrclientset.Interface
 	NetworkQoSClient          networkqosclientset.Interface
+	ObservabilityConfigClient observabilityconfigclientset.Interface
 }
 
 // OVNMasterClientset
@@ -95,6 +97,7 @@ type OVNMasterClientset struct {
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
 	FRRClient                 frrclientset.Interface
 	NetworkQoSClient          networkqosclientset.Interface
+	ObservabilityConfigClient observabilityconfigclientset.Interface
 }
 
 // OVNKubeControllerClientset
@@ -113,6 +116,7 @@ type OVNKubeControllerClientset struct {
 	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
 	NetworkQoSClient          networkqosclientset.Interface
+	ObservabilityConfigClient observabilityconfigclientset.Interface
 }
 
 type OVNNodeClientset struct {
@@ -142,6 +146,7 @@ type OVNClusterManagerClientset struct {
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
 	FRRClient                 frrclientset.Interface
 	NetworkQoSClient          networkqosclientset.Interface
+	ObservabilityConfigClient observabilityconfigclientset.Interface
 }
 
 const (
@@ -172,6 +177,7 @@ func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
 		FRRClient:                 cs.FRRClient,
 		NetworkQoSClient:          cs.NetworkQoSClient,
+		ObservabilityConfigClient: cs.ObservabilityConfigClient,
 	}
 }
 
@@ -191,6 +197,7 @@ func (cs *OVNMasterClientset) GetOVNKubeControllerClientset() *OVNKubeController
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
 		NetworkQoSClient:          cs.NetworkQoSClient,
+		ObservabilityConfigClient: cs.ObservabilityConfigClient,
 	}
 }
 
@@ -210,6 +217,7 @@ func (cs *OVNClientset) GetOVNKubeControllerClientset() *OVNKubeControllerClient
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
 		NetworkQoSClient:          cs.NetworkQoSClient,
+		ObservabilityConfigClient: cs.ObservabilityConfigClient,
 	}
 }
 
@@ -231,6 +239,7 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
 		FRRClient:                 cs.FRRClient,
 		NetworkQoSClient:          cs.NetworkQoSClient,
+		ObservabilityConfigClient: cs.ObservabilityConfigClient,
 	}
 }
 
@@ -545,6 +554,11 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		return nil, err
 	}
 
+	observabilityConfigClientset, err := observabilityconfigclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &OVNClientset{
 		KubeClient:                kclientset,
 		ANPClient:                 anpClientset,
@@ -563,6 +577,7 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		RouteAdvertisementsClient: routeAdvertisementsClientset,
 		FRRClient:                 frrClientset,
 		NetworkQoSClient:          networkqosClientset,
+		ObservabilityConfigClient: observabilityConfigClientset,
 	}, nil
 }
 
@@ -654,6 +669,19 @@ func ServiceInternalTrafficPolicyLocal(service *corev1.Service) bool {
 	return service.Spec.InternalTrafficPolicy != nil && *service.Spec.InternalTrafficPolicy == corev1.ServiceInternalTrafficPolicyLocal
 }
 
+// ServiceTrafficDistributionPreferClose returns true if the service asks for its traffic to be preferentially
+// routed to topologically close endpoints, either through spec.trafficDistribution (PreferClose or PreferSameZone)
+// or through the topology-mode annotation used by Topology Aware Routing.
+func ServiceTrafficDistributionPreferClose(service *corev1.Service) bool {
+	if service.Spec.TrafficDistribution != nil {
+		switch *service.Spec.TrafficDistribution {
+		case corev1.ServiceTrafficDistributionPreferClose, corev1.ServiceTrafficDistributionPreferSameZone:
+			return true
+		}
+	}
+	return strings.EqualFold(service.Annotations[corev1.AnnotationTopologyMode], "auto")
+}
+
 // GetClusterSubnetsWithHostPrefix returns the v4 and v6 cluster subnets, along with their host prefix,
 // in two separate slices
 func GetClusterSubnetsWithHostPrefix() ([]config.CIDRNetworkEntry, []config.CIDRNetworkEntry) {
@@ -16,6 +16,26 @@ type NetInfo struct {
 	mock.Mock
 }
 
+// AllowedVLANs provides a mock function with no fields
+func (_m *NetInfo) AllowedVLANs() []util.VLANIDRange {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllowedVLANs")
+	}
+
+	var r0 []util.VLANIDRange
+	if rf, ok := ret.Get(0).(func() []util.VLANIDRange); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]util.VLANIDRange)
+		}
+	}
+
+	return r0
+}
+
 // AllowsPersistentIPs provides a mock function with no fields
 func (_m *NetInfo) AllowsPersistentIPs() bool {
 	ret := _m.Called()
@@ -730,6 +750,24 @@ func (_m *NetInfo) MTU() int {
 	return r0
 }
 
+// NativeVLAN provides a mock function with no fields
+func (_m *NetInfo) NativeVLAN() uint {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NativeVLAN")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
 // PhysicalNetworkName provides a mock function with no fields
 func (_m *NetInfo) PhysicalNetworkName() string {
 	ret := _m.Called()
@@ -54,6 +54,8 @@ type NetInfo interface {
 	JoinSubnets() []*net.IPNet
 	TransitSubnets() []*net.IPNet
 	Vlan() uint
+	AllowedVLANs() []VLANIDRange
+	NativeVLAN() uint
 	AllowsPersistentIPs() bool
 	PhysicalNetworkName() string
 	GetNodeGatewayIP(hostSubnet *net.IPNet) *net.IPNet
@@ -663,6 +665,16 @@ func (nInfo *DefaultNetInfo) Vlan() uint {
 	return config.Gateway.VLANID
 }
 
+// AllowedVLANs has no impact on defaultNetConfInfo (localnet feature)
+func (nInfo *DefaultNetInfo) AllowedVLANs() []VLANIDRange {
+	return nil
+}
+
+// NativeVLAN has no impact on defaultNetConfInfo (localnet feature)
+func (nInfo *DefaultNetInfo) NativeVLAN() uint {
+	return 0
+}
+
 // AllowsPersistentIPs returns the defaultNetConfInfo's AllowPersistentIPs value
 func (nInfo *DefaultNetInfo) AllowsPersistentIPs() bool {
 	return false
@@ -692,6 +704,8 @@ type userDefinedNetInfo struct {
 	topology           string
 	mtu                int
 	vlan               uint
+	allowedVLANs       []VLANIDRange
+	nativeVLAN         uint
 	allowPersistentIPs bool
 
 	ipv4mode, ipv6mode    bool
@@ -813,6 +827,16 @@ func (nInfo *userDefinedNetInfo) Vlan() uint {
 	return nInfo.vlan
 }
 
+// AllowedVLANs returns the VLAN IDs carried tagged by a network in trunk mode
+func (nInfo *userDefinedNetInfo) AllowedVLANs() []VLANIDRange {
+	return nInfo.allowedVLANs
+}
+
+// NativeVLAN returns the VLAN ID of the untagged traffic of a network in trunk mode
+func (nInfo *userDefinedNetInfo) NativeVLAN() uint {
+	return nInfo.nativeVLAN
+}
+
 // AllowsPersistentIPs returns the defaultNetConfInfo's AllowPersistentIPs value
 func (nInfo *userDefinedNetInfo) AllowsPersistentIPs() bool {
 	return nInfo.allowPersistentIPs
@@ -927,6 +951,12 @@ func (nInfo *userDefinedNetInfo) canReconcile(other NetInfo) bool {
 	if nInfo.vlan != other.Vlan() {
 		return false
 	}
+	if !slices.Equal(nInfo.allowedVLANs, other.AllowedVLANs()) {
+		return false
+	}
+	if nInfo.nativeVLAN != other.NativeVLAN() {
+		return false
+	}
 	if nInfo.allowPersistentIPs != other.AllowsPersistentIPs() {
 		return false
 	}
@@ -966,6 +996,8 @@ func (nInfo *userDefinedNetInfo) copy() *userDefinedNetInfo {
 		topology:              nInfo.topology,
 		mtu:                   nInfo.mtu,
 		vlan:                  nInfo.vlan,
+		allowedVLANs:          nInfo.allowedVLANs,
+		nativeVLAN:            nInfo.nativeVLAN,
 		allowPersistentIPs:    nInfo.allowPersistentIPs,
 		ipv4mode:              nInfo.ipv4mode,
 		ipv6mode:              nInfo.ipv6mode,
@@ -1100,6 +1132,11 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error
 		return nil, err
 	}
 
+	allowedVLANs, err := ParseVLANIDRanges(netconf.AllowedVLANs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
+	}
+
 	ni := &userDefinedNetInfo{
 		netName:             netconf.Name,
 		topology:            types.LocalnetTopology,
@@ -1107,6 +1144,8 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error
 		excludeSubnets:      excludes,
 		mtu:                 netconf.MTU,
 		vlan:                uint(netconf.VLANID),
+		allowedVLANs:        allowedVLANs,
+		nativeVLAN:          uint(netconf.NativeVLAN),
 		allowPersistentIPs:  netconf.AllowPersistentIPs,
 		physicalNetworkName: netconf.PhysicalNetworkName,
 		mutableNetInfo: mutableNetInfo{
@@ -1155,6 +1194,55 @@ func parseSubnetList(subnets string) ([]*net.IPNet, error) {
 	return nets, nil
 }
 
+// VLANIDRange is an inclusive range of VLAN IDs
+type VLANIDRange struct {
+	Start uint
+	End   uint
+}
+
+func (r VLANIDRange) String() string {
+	if r.Start == r.End {
+		return strconv.FormatUint(uint64(r.Start), 10)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// ParseVLANIDRanges parses a comma-separated list of VLAN IDs and VLAN ID ranges, e.g.: "100,200-300",
+// returns nil if vlans is an empty string
+func ParseVLANIDRanges(vlans string) ([]VLANIDRange, error) {
+	if strings.TrimSpace(vlans) == "" {
+		return nil, nil
+	}
+
+	parseVLANID := func(vlan string) (uint, error) {
+		id, err := strconv.ParseUint(strings.TrimSpace(vlan), 10, 16)
+		if err != nil || id < 1 || id > 4094 {
+			return 0, fmt.Errorf("invalid VLAN ID %q, must be between 1 and 4094", vlan)
+		}
+		return uint(id), nil
+	}
+
+	var ranges []VLANIDRange
+	for _, item := range strings.Split(vlans, ",") {
+		start, end, isRange := strings.Cut(item, "-")
+		startID, err := parseVLANID(start)
+		if err != nil {
+			return nil, err
+		}
+		endID := startID
+		if isRange {
+			if endID, err = parseVLANID(end); err != nil {
+				return nil, err
+			}
+			if endID < startID {
+				return nil, fmt.Errorf("invalid VLAN ID range %q, start is higher than end", item)
+			}
+		}
+		ranges = append(ranges, VLANIDRange{Start: startID, End: endID})
+	}
+	return ranges, nil
+}
+
 // validateSubnetContainment checks if every subnet in subnets is contained in containerSubnets
 // and returns a typed error using the provided error constructor function
 func validateSubnetContainment(subnets []*net.IPNet, containerSubnets []config.CIDRNetworkEntry,
@@ -1397,6 +1485,22 @@ func ValidateNetConf(nadName string, netconf *ovncnitypes.NetConf) error {
 		return fmt.Errorf("defaultGatewayIPs is only supported for layer2 topology")
 	}
 
+	if (netconf.AllowedVLANs != "" || netconf.NativeVLAN != 0) && netconf.Topology != types.LocalnetTopology {
+		return fmt.Errorf("allowedVLANs and nativeVLAN are only supported for localnet topology")
+	}
+
+	if netconf.AllowedVLANs != "" && netconf.VLANID != 0 {
+		return fmt.Errorf("allowedVLANs and vlanID are mutually exclusive")
+	}
+
+	if netconf.NativeVLAN != 0 && netconf.AllowedVLANs == "" {
+		return fmt.Errorf("nativeVLAN requires allowedVLANs to be set")
+	}
+
+	if netconf.NativeVLAN < 0 || netconf.NativeVLAN > 4094 {
+		return fmt.Errorf("invalid nativeVLAN %d, must be between 1 and 4094", netconf.NativeVLAN)
+	}
+
 	if netconf.TransitSubnet == "" && netconf.Role == types.NetworkRolePrimary && netconf.Topology == types.Layer2Topology {
 		klog.Warningf("transitSubnet is not specified for layer2 primary NAD %s, dynamic transit subnet will be used", netconf.Name)
 		if err := SetTransitSubnets(netconf); err != nil {
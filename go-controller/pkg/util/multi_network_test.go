@@ -141,6 +141,58 @@ func TestParseSubnetList(t *testing.T) {
 	}
 }
 
+func TestParseVLANIDRanges(t *testing.T) {
+	tests := []struct {
+		desc           string
+		vlans          string
+		expectedRanges []VLANIDRange
+		expectError    bool
+	}{
+		{
+			desc:           "VLAN IDs and ranges",
+			vlans:          "100, 200-300,4094",
+			expectedRanges: []VLANIDRange{{Start: 100, End: 100}, {Start: 200, End: 300}, {Start: 4094, End: 4094}},
+		},
+		{
+			desc: "empty VLANs",
+		},
+		{
+			desc:        "VLAN ID out of range",
+			vlans:       "100,4095",
+			expectError: true,
+		},
+		{
+			desc:        "reserved VLAN ID",
+			vlans:       "0-10",
+			expectError: true,
+		},
+		{
+			desc:        "inverted range",
+			vlans:       "300-200",
+			expectError: true,
+		},
+		{
+			desc:        "invalid formatted VLANs",
+			vlans:       "100-200-300",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			ranges, err := ParseVLANIDRanges(tc.vlans)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(ranges).To(gomega.Equal(tc.expectedRanges))
+		})
+	}
+}
+
 func TestValidateSubnetContainment(t *testing.T) {
 	tests := []struct {
 		desc             string
@@ -296,6 +348,54 @@ func TestParseNetconf(t *testing.T) {
 				NetConf:  cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
 			},
 		},
+		{
+			desc: "valid attachment definition for a localnet topology in trunk VLAN mode",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "allowedVLANs": "100,200-300",
+            "nativeVLAN": 10,
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedNetConf: &ovncnitypes.NetConf{
+				Topology:     "localnet",
+				NADName:      "ns1/nad1",
+				MTU:          1400,
+				AllowedVLANs: "100,200-300",
+				NativeVLAN:   10,
+				NetConf:      cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
+			},
+		},
+		{
+			desc: "invalid attachment definition for a localnet topology with a VLAN and allowed VLANs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "vlanID": 10,
+            "allowedVLANs": "100,200-300",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("allowedVLANs and vlanID are mutually exclusive"),
+		},
+		{
+			desc: "invalid attachment definition for a localnet topology with a native VLAN without allowed VLANs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "nativeVLAN": 10,
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("nativeVLAN requires allowedVLANs to be set"),
+		},
 		{
 			desc: "valid attachment definition for the default network",
 			inputNetAttachDefConfigSpec: `
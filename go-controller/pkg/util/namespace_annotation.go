@@ -1,10 +1,12 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
@@ -14,6 +16,8 @@ import (
 const (
 	// Annotation used to enable/disable multicast in the namespace
 	NsMulticastAnnotation = "k8s.ovn.org/multicast-enabled"
+	// Annotation used to restrict the multicast groups allowed in a namespace with multicast enabled
+	NsMulticastAllowedGroupsAnnotation = "k8s.ovn.org/multicast-allowed-groups"
 	// Annotations used by multiple external gateways feature
 	RoutingExternalGWsAnnotation    = "k8s.ovn.org/routing-external-gws"
 	RoutingNamespaceAnnotation      = "k8s.ovn.org/routing-namespaces"
@@ -22,6 +26,9 @@ const (
 	ExternalGatewayPodIPsAnnotation = "k8s.ovn.org/external-gw-pod-ips"
 	// Annotation for enabling ACL logging to controller's log file
 	AclLoggingAnnotation = "k8s.ovn.org/acl-logging"
+	// Annotation for enabling ACL logging of the egress firewall rules of the namespace, it overrides
+	// AclLoggingAnnotation for egress firewall ACLs
+	EgressFirewallAclLoggingAnnotation = "k8s.ovn.org/egress-firewall-acl-logging"
 )
 
 func UpdateExternalGatewayPodIPsAnnotation(k kube.Interface, namespace string, exgwIPs []string) error {
@@ -33,6 +40,71 @@ func UpdateExternalGatewayPodIPsAnnotation(k kube.Interface, namespace string, e
 	return nil
 }
 
+// MulticastGroupsRule is a rule of the NsMulticastAllowedGroupsAnnotation annotation,
+// allowing the pods selected by PodSelector to send multicast traffic to Groups.
+type MulticastGroupsRule struct {
+	// PodSelector selects the pods of the namespace the rule applies to, all the pods
+	// of the namespace when nil.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Groups are the allowed IPv4 and IPv6 multicast group ranges, in CIDR notation.
+	Groups []string `json:"groups"`
+}
+
+var (
+	ipv4MulticastRange = mustParseCIDR("224.0.0.0/4")
+	ipv6MulticastRange = mustParseCIDR("ff00::/8")
+)
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+// ParseMulticastAllowedGroupsAnnotation parses the NsMulticastAllowedGroupsAnnotation
+// annotation of a namespace. It returns nil when the annotation is not set, meaning
+// that all the multicast groups are allowed.
+func ParseMulticastAllowedGroupsAnnotation(annotations map[string]string) ([]MulticastGroupsRule, error) {
+	annotation, ok := annotations[NsMulticastAllowedGroupsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	rules := []MulticastGroupsRule{}
+	if err := json.Unmarshal([]byte(annotation), &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal multicast allowed groups annotation %q: %v", annotation, err)
+	}
+	for i, rule := range rules {
+		if len(rule.Groups) == 0 {
+			return nil, fmt.Errorf("multicast allowed groups rule %d has no groups", i)
+		}
+		if rule.PodSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(rule.PodSelector); err != nil {
+				return nil, fmt.Errorf("multicast allowed groups rule %d has an invalid pod selector: %v", i, err)
+			}
+		}
+		for j, group := range rule.Groups {
+			ip, ipNet, err := net.ParseCIDR(group)
+			if err != nil {
+				return nil, fmt.Errorf("multicast allowed groups rule %d has an invalid group %q: %v", i, group, err)
+			}
+			mcastRange := ipv4MulticastRange
+			if ip.To4() == nil {
+				mcastRange = ipv6MulticastRange
+			}
+			ones, _ := ipNet.Mask.Size()
+			mcastOnes, _ := mcastRange.Mask.Size()
+			if !mcastRange.Contains(ip) || ones < mcastOnes {
+				return nil, fmt.Errorf("multicast allowed groups rule %d has group %q outside of the multicast range %s",
+					i, group, mcastRange)
+			}
+			rules[i].Groups[j] = ipNet.String()
+		}
+	}
+	return rules, nil
+}
+
 func ParseRoutingExternalGWAnnotation(annotation string) (sets.Set[string], error) {
 	ipTracker := sets.New[string]()
 	if annotation == "" {
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseMulticastAllowedGroupsAnnotation(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedRules []MulticastGroupsRule
+		expectError   bool
+	}{
+		{
+			name:          "annotation not set",
+			annotations:   map[string]string{NsMulticastAnnotation: "true"},
+			expectedRules: nil,
+		},
+		{
+			name:          "empty list denies all groups",
+			annotations:   map[string]string{NsMulticastAllowedGroupsAnnotation: "[]"},
+			expectedRules: []MulticastGroupsRule{},
+		},
+		{
+			name: "rules with and without pod selector",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[
+				{"groups": ["239.1.1.0/24", "ff3e::8000:1/128"]},
+				{"podSelector": {"matchLabels": {"app": "feed"}}, "groups": ["232.1.2.3/32"]}
+			]`},
+			expectedRules: []MulticastGroupsRule{
+				{Groups: []string{"239.1.1.0/24", "ff3e::8000:1/128"}},
+				{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "feed"}},
+					Groups:      []string{"232.1.2.3/32"},
+				},
+			},
+		},
+		{
+			name:          "groups are normalized",
+			annotations:   map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"groups": ["239.1.1.5/24"]}]`},
+			expectedRules: []MulticastGroupsRule{{Groups: []string{"239.1.1.0/24"}}},
+		},
+		{
+			name:        "invalid json",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `{"groups": ["239.1.1.0/24"]}`},
+			expectError: true,
+		},
+		{
+			name:        "rule without groups",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"podSelector": {}}]`},
+			expectError: true,
+		},
+		{
+			name:        "invalid group",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"groups": ["239.1.1.1"]}]`},
+			expectError: true,
+		},
+		{
+			name:        "unicast IPv4 group",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"groups": ["10.0.0.0/24"]}]`},
+			expectError: true,
+		},
+		{
+			name:        "group larger than the IPv4 multicast range",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"groups": ["224.0.0.0/3"]}]`},
+			expectError: true,
+		},
+		{
+			name:        "unicast IPv6 group",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{"groups": ["fd00::/64"]}]`},
+			expectError: true,
+		},
+		{
+			name: "invalid pod selector",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: `[{
+				"podSelector": {"matchExpressions": [{"key": "app", "operator": "Foo"}]},
+				"groups": ["239.1.1.0/24"]
+			}]`},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := ParseMulticastAllowedGroupsAnnotation(tc.annotations)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
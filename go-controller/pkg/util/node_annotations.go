@@ -95,6 +95,10 @@ const (
 	// OvnNodeEgressLabel is a user assigned node label indicating to ovn-kubernetes that the node is to be used for egress IP assignment
 	ovnNodeEgressLabel = "k8s.ovn.org/egress-assignable"
 
+	// OVNNodeEgressIPPlacement is a user assigned node annotation tuning the egress IP placement
+	// strategy for the node, e.g: '{"maxEgressIPs": 4, "weight": 2}'
+	OVNNodeEgressIPPlacement = "k8s.ovn.org/egress-ip-placement"
+
 	// OVNNodeHostCIDRs is used to track the different host IP addresses and subnet masks on the node
 	OVNNodeHostCIDRs = "k8s.ovn.org/host-cidrs"
 
@@ -878,6 +882,41 @@ func GetNodeEgressLabel() string {
 	return ovnNodeEgressLabel
 }
 
+// EgressIPPlacement holds the per node settings of the egress IP placement strategies
+type EgressIPPlacement struct {
+	// MaxEgressIPs is the maximum number of egress IPs the node may host
+	MaxEgressIPs int `json:"maxEgressIPs,omitempty"`
+	// Weight is the share of egress IPs the node hosts, relative to the weight of the other egress nodes
+	Weight int `json:"weight,omitempty"`
+}
+
+// ParseNodeEgressIPPlacement returns the egress IP placement settings of the node. A node without the
+// k8s.ovn.org/egress-ip-placement annotation may host an unlimited number of egress IPs and has a weight of 1.
+func ParseNodeEgressIPPlacement(node *corev1.Node) (EgressIPPlacement, error) {
+	placement := EgressIPPlacement{}
+	if annotation, ok := node.Annotations[OVNNodeEgressIPPlacement]; ok {
+		if err := json.Unmarshal([]byte(annotation), &placement); err != nil {
+			return EgressIPPlacement{MaxEgressIPs: UnlimitedNodeCapacity, Weight: 1},
+				fmt.Errorf("failed to unmarshal %s annotation %q for node %s: %w", OVNNodeEgressIPPlacement, annotation, node.Name, err)
+		}
+		if placement.MaxEgressIPs < 0 || placement.Weight < 0 {
+			return EgressIPPlacement{MaxEgressIPs: UnlimitedNodeCapacity, Weight: 1},
+				fmt.Errorf("invalid %s annotation %q for node %s: values must not be negative", OVNNodeEgressIPPlacement, annotation, node.Name)
+		}
+	}
+	if placement.MaxEgressIPs == 0 {
+		placement.MaxEgressIPs = UnlimitedNodeCapacity
+	}
+	if placement.Weight == 0 {
+		placement.Weight = 1
+	}
+	return placement, nil
+}
+
+func NodeEgressIPPlacementAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OVNNodeEgressIPPlacement] != newNode.Annotations[OVNNodeEgressIPPlacement]
+}
+
 func SetNodeHostCIDRs(nodeAnnotator kube.Annotator, cidrs sets.Set[string]) error {
 	return nodeAnnotator.Set(OVNNodeHostCIDRs, sets.List(cidrs))
 }
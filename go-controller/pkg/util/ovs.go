@@ -279,6 +279,11 @@ func SetSpecificExec(exec kexec.Interface, commands ...string) error {
 			if err != nil {
 				return err
 			}
+		case ovsdbToolCommand:
+			runner.ovsdbToolPath, err = exec.LookPath(ovsdbToolCommand)
+			if err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unknown command: %q", command)
 		}
@@ -686,6 +686,12 @@ func TestSetSpecificExec(t *testing.T) {
 			fnArg:       "ovs-vsctl",
 			onRetArgs:   &ovntest.TestifyMockHelper{OnCallMethodName: "LookPath", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{"", fmt.Errorf(`exec: \"ovs-vsctl:\" executable file not found in $PATH`)}},
 		},
+		{
+			desc:        "positive: ovsdb-tool path found",
+			expectedErr: nil,
+			fnArg:       "ovsdb-tool",
+			onRetArgs:   &ovntest.TestifyMockHelper{OnCallMethodName: "LookPath", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{"ovsdb-tool", nil}},
+		},
 		{
 			desc:        "negative: unknown command",
 			expectedErr: fmt.Errorf(`unknown command: "ovs-appctl"`),
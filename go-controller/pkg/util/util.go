@@ -896,6 +896,100 @@ func GetEndpointsForService(endpointSlices []*discoveryv1.EndpointSlice, service
 	return globalEndpoints, localEndpoints, errors.Join(validationErrors...)
 }
 
+// PortToZoneToLBEndpoints maps service port keys to topology zones and the load balancer endpoints hinted for them.
+// e.g. map["TCP/http"]["zone-a"] = LBEndpoints{Port: 8080, V4IPs: []string{"192.168.1.10"}}.
+type PortToZoneToLBEndpoints map[string]map[string]LBEndpoints
+
+// GetZoneHintedEndpointsForService extracts, for each service port, the eligible endpoints grouped by the zones
+// listed in their EndpointSlice topology hints (endpoint.hints.forZones).
+//
+// Following the kube-proxy semantics, hints are only usable when every eligible endpoint of a port carries them:
+// if any eligible endpoint of a port has no zone hints, that port is left out of the result so that callers fall
+// back to the cluster-wide endpoints.
+//
+// Parameters:
+//   - slices: EndpointSlices associated with the service
+//   - service: The Kubernetes Service object
+//
+// Returns:
+//   - PortToZoneToLBEndpoints: Per-zone endpoint mapping by port (empty if no port has usable hints)
+//
+// Example output:
+//
+//	{"TCP/http": {"zone-a": {Port: 8080, V4IPs: ["192.168.1.10"]}, "zone-b": {Port: 8080, V4IPs: ["192.168.1.11"]}}}
+func GetZoneHintedEndpointsForService(endpointSlices []*discoveryv1.EndpointSlice, service *corev1.Service) PortToZoneToLBEndpoints {
+	zoneEndpoints := make(PortToZoneToLBEndpoints)
+	if service == nil {
+		return zoneEndpoints
+	}
+
+	validServicePortKeys := map[string]bool{}
+	for _, servicePort := range service.Spec.Ports {
+		validServicePortKeys[GetServicePortKey(servicePort.Protocol, servicePort.Name)] = true
+	}
+
+	for portName, protocolMap := range newTargetEndpoints(endpointSlices) {
+		for protocol, portNumberMap := range protocolMap {
+			slicePortKey := GetServicePortKey(protocol, portName)
+			if !validServicePortKeys[slicePortKey] || len(portNumberMap) == 0 {
+				continue
+			}
+			// Same as GetEndpointsForService, only the first target port number is considered.
+			portNumbers := maps.Keys(portNumberMap)
+			slices.Sort(portNumbers)
+			targetPortNumber := portNumbers[0]
+			lbe, err := buildZoneLBEndpoints(service, targetPortNumber, portNumberMap[targetPortNumber])
+			if err != nil {
+				klog.V(5).Infof("Not using topology hints for port %s of service %s/%s: %v",
+					slicePortKey, service.Namespace, service.Name, err)
+				continue
+			}
+			zoneEndpoints[slicePortKey] = lbe
+		}
+	}
+
+	klog.V(5).Infof("Zone hinted endpoints for %s/%s: %v", service.Namespace, service.Name, zoneEndpoints)
+	return zoneEndpoints
+}
+
+// buildZoneLBEndpoints creates a per-zone mapping of load balancer endpoints out of the zone hints of the
+// eligible endpoints. An endpoint hinted for several zones is included in all of them.
+// It returns an error if any eligible endpoint has no zone hints or if no endpoint could be mapped to a zone.
+//
+// Parameters:
+//   - service: The Kubernetes Service object (for endpoint filtering)
+//   - portNumber: The target port number for the endpoints
+//   - endpoints: List of endpoints to process
+//
+// Returns:
+//   - map[string]LBEndpoints: Zone name to LBEndpoints mapping
+func buildZoneLBEndpoints(service *corev1.Service, portNumber int32, endpoints []discoveryv1.Endpoint) (map[string]LBEndpoints, error) {
+	zoneToEndpoints := map[string][]discoveryv1.Endpoint{}
+	for _, endpoint := range getEligibleEndpoints(endpoints, service) {
+		if endpoint.Hints == nil || len(endpoint.Hints.ForZones) == 0 {
+			return nil, fmt.Errorf("endpoint %v has no zone hints", endpoint.Addresses)
+		}
+		for _, zone := range endpoint.Hints.ForZones {
+			zoneToEndpoints[zone.Name] = append(zoneToEndpoints[zone.Name], endpoint)
+		}
+	}
+
+	zoneLBEndpoints := map[string]LBEndpoints{}
+	for zone, zoneEndpoints := range zoneToEndpoints {
+		lbe, err := buildLBEndpoints(service, portNumber, zoneEndpoints)
+		if err != nil {
+			klog.Warningf("Failed to build zone endpoints for zone %s port %d: %v", zone, portNumber, err)
+			continue
+		}
+		zoneLBEndpoints[zone] = lbe
+	}
+
+	if len(zoneLBEndpoints) == 0 {
+		return zoneLBEndpoints, fmt.Errorf("empty zone lb endpoints")
+	}
+	return zoneLBEndpoints, nil
+}
+
 // FindServicePortForEndpointSlicePort returns the ServicePort that corresponds to an EndpointSlice port
 // by matching the port name and protocol. This is the canonical way to map EndpointSlice ports to
 // Service ports, as Kubernetes guarantees that ServicePort.Name matches EndpointPort.Name.
@@ -2269,6 +2269,111 @@ ip route add %[3]s via %[4]s
 
 		})
 	})
+	Context("with kubevirt VM using layer3 UDPN", Ordered, func() {
+		const arpProxyIPv4 = "169.254.1.1"
+		var (
+			cidrIPv4          = "172.31.0.0/16"
+			externalContainer infraapi.ExternalContainer
+			userData          = `
+#cloud-config
+password: fedora
+chpasswd: { expire: False }
+`
+			networkData = `version: 2
+ethernets:
+  eth0:
+    dhcp4: true
+`
+			checkDefaultGateway = func(vmi *kubevirtv1.VirtualMachineInstance, stage string) {
+				GinkgoHelper()
+				Eventually(func() (string, error) {
+					return virtClient.RunCommand(vmi, "ip -4 route show default", 2*time.Second)
+				}).
+					WithTimeout(10*time.Second).
+					WithPolling(time.Second).
+					Should(ContainSubstring("via "+arpProxyIPv4), stage+": should use the ARP proxy IP as default gateway")
+			}
+		)
+		AfterAll(func() {
+			Expect(removeImagesInNodes(kubevirt.FedoraWithTestToolingContainerDiskImage)).To(Succeed())
+		})
+		BeforeEach(func() {
+			if !isInterconnectEnabled() {
+				Skip("Live migration at layer3 primary UDN is only tested with interconnect")
+			}
+			if !isIPv4Supported(fr.ClientSet) {
+				Skip("Live migration at layer3 primary UDN is only supported with IPv4")
+			}
+			ns, err := fr.CreateNamespace(context.TODO(), fr.BaseName, map[string]string{
+				"e2e-framework":           fr.BaseName,
+				RequiredUDNNamespaceLabel: "",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			fr.Namespace = ns
+			namespace = fr.Namespace.Name
+
+			cudn, _ := kubevirt.GenerateCUDN(namespace, "net1", udnv1.NetworkTopologyLayer3, udnv1.NetworkRolePrimary, udnv1.DualStackCIDRs{udnv1.CIDR(cidrIPv4)})
+			createCUDN(cudn)
+
+			providerNetwork, err := infraprovider.Get().PrimaryNetwork()
+			Expect(err).NotTo(HaveOccurred(), "primary network must be available to attach containers")
+			externalContainer, err = providerCtx.CreateExternalContainer(infraapi.ExternalContainer{
+				Name:    namespace + "-iperf",
+				Image:   images.IPerf3(),
+				Network: providerNetwork,
+				CmdArgs: []string{"sleep infinity"},
+				ExtPort: infraprovider.Get().GetExternalContainerPort(),
+			})
+			Expect(err).NotTo(HaveOccurred(), "creation of external container is test dependency")
+			Expect(externalContainer.IsIPv4()).To(BeTrue())
+		})
+		It("should keep egress connectivity after live migration across nodes", func() {
+			vm := fedoraWithTestToolingVM(nil /*labels*/, map[string]string{
+				kubevirtv1.AllowPodBridgeNetworkLiveMigrationAnnotation: "",
+			}, nil /*nodeSelector*/, kubevirtv1.NetworkSource{
+				Pod: &kubevirtv1.PodNetwork{},
+			}, userData, networkData)
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].Bridge = nil
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].Binding = &kubevirtv1.PluginBinding{Name: "l2bridge"}
+			createVirtualMachine(vm)
+
+			vmi := &kubevirtv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      vm.Name,
+				},
+			}
+			waitVirtualMachineInstanceReadiness(vmi)
+			Expect(crClient.Get(context.TODO(), crclient.ObjectKeyFromObject(vmi), vmi)).To(Succeed())
+			sourceNode := vmi.Status.NodeName
+
+			step := by(vmi.Name, "Login to virtual machine for the first time")
+			Eventually(func() error {
+				return virtClient.LoginToFedora(vmi, "fedora", "fedora")
+			}).
+				WithTimeout(5*time.Second).
+				WithPolling(time.Second).
+				Should(Succeed(), step)
+			expectedAddresses := virtualMachineAddressesFromStatus(vmi, 1)
+
+			step = by(vmi.Name, "Check north/south egress traffic before live migration")
+			checkDefaultGateway(vmi, step)
+			checkNorthSouthEgressICMPTraffic(vmi, []string{externalContainer.IPv4}, step)
+
+			by(vmi.Name, "Live migrate virtual machine")
+			liveMigrateSucceed(vmi)
+			Expect(crClient.Get(context.TODO(), crclient.ObjectKeyFromObject(vmi), vmi)).To(Succeed())
+			Expect(vmi.Status.NodeName).NotTo(Equal(sourceNode), "should run at a node not owning the VM subnet")
+
+			step = by(vmi.Name, "Login to virtual machine after live migration")
+			Expect(virtClient.LoginToFedora(vmi, "fedora", "fedora")).To(Succeed(), step)
+			Expect(virtualMachineAddressesFromStatus(vmi, 1)).To(Equal(expectedAddresses))
+
+			step = by(vmi.Name, "Check north/south egress traffic after live migration")
+			checkDefaultGateway(vmi, step)
+			checkNorthSouthEgressICMPTraffic(vmi, []string{externalContainer.IPv4}, step)
+		})
+	})
 	Context("with user defined networks with ipamless localnet topology", Ordered, func() {
 		BeforeEach(func() {
 			ns, err := fr.CreateNamespace(context.TODO(), fr.BaseName, map[string]string{
@@ -55,6 +55,17 @@ func GenerateCUDN(namespace, name string, topology udnv1.NetworkTopology, role u
 			Subnets: subnets,
 			IPAM:    ipam,
 		}
+	} else if topology == udnv1.NetworkTopologyLayer3 {
+		// layer3 networks do not support persistent IPs, the VM IPs are kept
+		// across live migrations by the point to point routing
+		layer3Subnets := []udnv1.Layer3Subnet{}
+		for _, subnet := range subnets {
+			layer3Subnets = append(layer3Subnets, udnv1.Layer3Subnet{CIDR: subnet})
+		}
+		cudn.Spec.Network.Layer3 = &udnv1.Layer3Config{
+			Role:    role,
+			Subnets: layer3Subnets,
+		}
 	} else if topology == udnv1.NetworkTopologyLocalnet {
 		cudn.Spec.Network.Localnet = &udnv1.LocalnetConfig{
 			Role:                role,